@@ -3,15 +3,20 @@ package api
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
 	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
 )
 
 type createAccountRequest struct {
 	Owner    string `json:"owner" binding:"required"`	
 	Currency string `json:"currency" binding:"required,oneof=USD EUR UAH"`
+	// Type is checking by default, savings accounts need an interest plan
+	Type           string `json:"type" binding:"omitempty,oneof=checking savings"`
+	InterestPlanID int64  `json:"interest_plan_id" binding:"omitempty,min=1"`
 }
 
 func (server *Server) createAccount(ctx *gin.Context) {
@@ -33,10 +38,27 @@ func (server *Server) createAccount(ctx *gin.Context) {
 		return
 	}
 
+	if authPayload.OrganizationID != 0 {
+		server.createOrganizationAccount(ctx, req, authPayload.OrganizationID)
+		return
+	}
+
+	if req.Type == db.AccountTypeSavings {
+		server.createSavingsAccount(ctx, req)
+		return
+	}
+
+	if req.InterestPlanID != 0 {
+		err := errors.New("only savings accounts have an interest plan")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	arg := db.CreateAccountParams{
 		Owner: req.Owner,
 		Currency: req.Currency,
 		Balance: 0,
+		Type: db.AccountTypeChecking,
 	}
 
 	account, err := server.store.CreateAccount(ctx, arg)
@@ -48,36 +70,97 @@ func (server *Server) createAccount(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, account)
 }
 
-type getAccountRequest struct {
-	ID int64 `uri:"id" binding:"required,min=1"`
+// createOrganizationAccount opens a checking account held by the organization the user is acting for.
+// Only its owners can open accounts.
+func (server *Server) createOrganizationAccount(ctx *gin.Context, req createAccountRequest, organizationID int64) {
+	member, valid := server.organizationMember(ctx, organizationID)
+	if !valid {
+		return
+	}
+
+	if member.Role != db.OrganizationRoleOwner {
+		err := fmt.Errorf("only owners of organization [%d] can open accounts", organizationID)
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	if req.Type == db.AccountTypeSavings || req.InterestPlanID != 0 {
+		err := errors.New("organizations can only open checking accounts")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, err := server.store.CreateAccount(ctx, db.CreateAccountParams{
+		Owner:    req.Owner,
+		Currency: req.Currency,
+		Balance:  0,
+		Type:     db.AccountTypeChecking,
+		OrganizationID: sql.NullInt64{
+			Int64: organizationID,
+			Valid: true,
+		},
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, account)
 }
 
-func (server *Server) getAccount(ctx *gin.Context) {
-	var req getAccountRequest
-	if err := ctx.ShouldBindUri(&req); err != nil {
+// createSavingsAccount opens a savings account earning interest on the requested plan
+func (server *Server) createSavingsAccount(ctx *gin.Context, req createAccountRequest) {
+	if req.InterestPlanID == 0 {
+		err := errors.New("savings accounts need an interest plan")
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	account, err := server.store.GetAccount(ctx, req.ID)
+	result, err := server.store.CreateSavingsAccountTx(ctx, db.CreateSavingsAccountTxParams{
+		Owner:          req.Owner,
+		Currency:       req.Currency,
+		InterestPlanID: req.InterestPlanID,
+	})
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
+			err := fmt.Errorf("interest plan [%d] not found", req.InterestPlanID)
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	authPayload, err := getCurrentUser(ctx)
-	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+	ctx.JSON(http.StatusOK, result.Account)
+}
+
+type getAccountRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getAccount(ctx *gin.Context) {
+	var req getAccountRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	if account.Owner != authPayload.Username {
-		err = errors.New("account doesn't belong to the authenticated user")
-		ctx.JSON(http.StatusForbidden, errorResponse(err))
+	account, valid := server.authorizedAccount(ctx, req.ID, db.AccountPermissionView)
+	if !valid {
 		return
 	}
 
@@ -89,6 +172,14 @@ type listAccountsRequest struct {
 	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
 }
 
+// listAccountsResponse is an account with the money set aside in its pots, which aren't listed on their own
+type listAccountsResponse struct {
+	db.Account
+	Pots         int64 `json:"pots"`
+	PotsBalance  int64 `json:"pots_balance"`
+	TotalBalance int64 `json:"total_balance"`
+}
+
 func (server *Server) listAccounts(ctx *gin.Context) {
 	var req listAccountsRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
@@ -102,6 +193,11 @@ func (server *Server) listAccounts(ctx *gin.Context) {
 		return
 	}
 
+	if authPayload.OrganizationID != 0 {
+		server.listOrganizationAccounts(ctx, req, authPayload.OrganizationID)
+		return
+	}
+
 	arg := db.ListAccountsParams{
 		Owner:  authPayload.Username,
 		Limit:  req.PageSize,
@@ -114,68 +210,58 @@ func (server *Server) listAccounts(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, accounts)
-}
-
-type updateAccountRequest struct {
-	ID int64 `uri:"id" binding:"required,min=1"`
-}
-
-type updateAccountRequestBody struct {
-	Balance int64 `json:"balance" binding:"required"`
-}
-
-func (server *Server) updateAccount(ctx *gin.Context) {
-	var uriReq updateAccountRequest
-	if err := ctx.ShouldBindUri(&uriReq); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
-	}
-	
-	var bodyReq updateAccountRequestBody
-	if err := ctx.ShouldBindJSON(&bodyReq); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
-	}
-
-	authPayload, err := getCurrentUser(ctx)
+	potBalances, err := server.store.ListPotBalances(ctx, authPayload.Username)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	account, err := server.store.GetAccount(ctx, uriReq.ID)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+	pots := make(map[int64]db.ListPotBalancesRow, len(potBalances))
+	for _, row := range potBalances {
+		pots[row.ParentAccountID] = row
 	}
 
-	if account.Owner != authPayload.Username {
-		err := errors.New("account doesn't belong to the authenticated user")
-		ctx.JSON(http.StatusForbidden, errorResponse(err))
-		return
+	rsp := make([]listAccountsResponse, 0, len(accounts))
+	for _, account := range accounts {
+		rsp = append(rsp, listAccountsResponse{
+			Account:      account,
+			Pots:         pots[account.ID].Pots,
+			PotsBalance:  pots[account.ID].Balance,
+			TotalBalance: account.Balance + pots[account.ID].Balance,
+		})
 	}
 
-	arg := db.UpdateAccountParams{
-		ID:      uriReq.ID,
-		Balance: bodyReq.Balance,
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// listOrganizationAccounts lists the accounts of the organization the user is acting for, which have no pots
+func (server *Server) listOrganizationAccounts(ctx *gin.Context, req listAccountsRequest, organizationID int64) {
+	if _, valid := server.organizationMember(ctx, organizationID); !valid {
+		return
 	}
 
-	account, err = server.store.UpdateAccount(ctx, arg)
+	accounts, err := server.store.ListOrganizationAccounts(ctx, db.ListOrganizationAccountsParams{
+		OrganizationID: sql.NullInt64{
+			Int64: organizationID,
+			Valid: true,
+		},
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	})
 	if err != nil {
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
+	rsp := make([]listAccountsResponse, 0, len(accounts))
+	for _, account := range accounts {
+		rsp = append(rsp, listAccountsResponse{
+			Account:      account,
+			TotalBalance: account.Balance,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
 }
 
 type deleteAccountRequest struct {
@@ -189,29 +275,13 @@ func (server *Server) deleteAccount(ctx *gin.Context) {
 		return
 	}
 
-	authPayload, err := getCurrentUser(ctx)
-	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
-	account, err := server.store.GetAccount(ctx, req.ID)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-
-	if account.Owner != authPayload.Username {
-		err := errors.New("account doesn't belong to the authenticated user")
-		ctx.JSON(http.StatusForbidden, errorResponse(err))
+	account, valid := server.authorizedAccount(ctx, req.ID, db.AccountPermissionManage)
+	if !valid {
 		return
 	}
+	setAuditBefore(ctx, account)
 
-	err = server.store.DeleteAccount(ctx, req.ID)
+	err := server.store.DeleteAccount(ctx, req.ID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
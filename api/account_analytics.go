@@ -0,0 +1,316 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+// maxAnalyticsDays limits the period a single analytics request aggregates over
+const maxAnalyticsDays = 366
+
+// defaultAnalyticsLimit is the number of counterparties or transactions returned when no limit is given
+const defaultAnalyticsLimit = 10
+
+type accountAnalyticsURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// accountAnalyticsPeriod is the days from and to, both included, analytics aggregate over
+type accountAnalyticsPeriod struct {
+	From time.Time `form:"from" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	To   time.Time `form:"to" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+}
+
+// end checks the period and returns its exclusive end, the start of the day after to
+func (period accountAnalyticsPeriod) end() (time.Time, error) {
+	to := period.To.AddDate(0, 0, 1)
+	if !to.After(period.From) {
+		return to, errors.New("from must not be after to")
+	}
+
+	if to.Sub(period.From) > maxAnalyticsDays*24*time.Hour {
+		return to, fmt.Errorf("analytics can't cover more than %d days", maxAnalyticsDays)
+	}
+
+	return to, nil
+}
+
+type cashFlowRequest struct {
+	accountAnalyticsPeriod
+	Interval string `form:"interval" binding:"omitempty,oneof=day week month"`
+}
+
+type cashFlowPeriodResponse struct {
+	// Period is the day, or the first day of the week or month, weeks starting on Monday
+	Period  string `json:"period"`
+	Inflow  int64  `json:"inflow"`
+	Outflow int64  `json:"outflow"`
+	Net     int64  `json:"net"`
+	Entries int64  `json:"entries"`
+}
+
+type cashFlowResponse struct {
+	AccountID int64                    `json:"account_id"`
+	Currency  string                   `json:"currency"`
+	Interval  string                   `json:"interval"`
+	Inflow    int64                    `json:"inflow"`
+	Outflow   int64                    `json:"outflow"`
+	Periods   []cashFlowPeriodResponse `json:"periods"`
+}
+
+// getCashFlow returns the money that came in and went out of an account per day, week or month of a period.
+// Days, weeks and months without any entry are left out.
+func (server *Server) getCashFlow(ctx *gin.Context) {
+	var uriReq accountAnalyticsURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req cashFlowRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	to, err := req.end()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	interval := req.Interval
+	if interval == "" {
+		interval = "day"
+	}
+
+	account, valid := server.authorizedAccount(ctx, uriReq.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	rows, err := server.store.ListCashFlow(ctx, db.ListCashFlowParams{
+		Bucket:    interval,
+		AccountID: account.ID,
+		FromTime:  req.From,
+		ToTime:    to,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := cashFlowResponse{
+		AccountID: account.ID,
+		Currency:  account.Currency,
+		Interval:  interval,
+		Periods:   make([]cashFlowPeriodResponse, 0, len(rows)),
+	}
+	for _, row := range rows {
+		rsp.Inflow += row.Inflow
+		rsp.Outflow += row.Outflow
+		rsp.Periods = append(rsp.Periods, cashFlowPeriodResponse{
+			Period:  row.Period.Format("2006-01-02"),
+			Inflow:  row.Inflow,
+			Outflow: row.Outflow,
+			Net:     row.Inflow - row.Outflow,
+			Entries: row.EntryCount,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type accountAnalyticsTopRequest struct {
+	accountAnalyticsPeriod
+	Limit int32 `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type counterpartyResponse struct {
+	AccountID int64  `json:"account_id"`
+	Owner     string `json:"owner"`
+	Inflow    int64  `json:"inflow"`
+	Outflow   int64  `json:"outflow"`
+	Transfers int64  `json:"transfers"`
+}
+
+// listTopCounterparties returns the accounts an account exchanged the most money with over a period,
+// counting what was sent and received
+func (server *Server) listTopCounterparties(ctx *gin.Context) {
+	var uriReq accountAnalyticsURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req accountAnalyticsTopRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	to, err := req.end()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, uriReq.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	rows, err := server.store.ListTopCounterparties(ctx, db.ListTopCounterpartiesParams{
+		AccountID: account.ID,
+		FromTime:  req.From,
+		ToTime:    to,
+		RowLimit:  analyticsLimit(req.Limit),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]counterpartyResponse, 0, len(rows))
+	for _, row := range rows {
+		rsp = append(rsp, counterpartyResponse{
+			AccountID: row.CounterpartyAccountID,
+			Owner:     row.CounterpartyOwner,
+			Inflow:    row.Inflow,
+			Outflow:   row.Outflow,
+			Transfers: row.TransferCount,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type averageBalanceResponse struct {
+	AccountID      int64  `json:"account_id"`
+	Currency       string `json:"currency"`
+	Days           int    `json:"days"`
+	AverageBalance int64  `json:"average_balance"`
+	MinBalance     int64  `json:"min_balance"`
+	MaxBalance     int64  `json:"max_balance"`
+}
+
+// getAverageBalance returns the average of the closing balances of an account over the days of a period,
+// with the lowest and highest of them
+func (server *Server) getAverageBalance(ctx *gin.Context) {
+	var uriReq accountAnalyticsURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req accountAnalyticsPeriod
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	to, err := req.end()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, uriReq.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	stats, err := server.store.GetDailyBalanceStats(ctx, db.GetDailyBalanceStatsParams{
+		AccountID: account.ID,
+		FromTime:  req.From,
+		ToTime:    to,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, averageBalanceResponse{
+		AccountID:      account.ID,
+		Currency:       account.Currency,
+		Days:           int(to.Sub(req.From).Hours() / 24),
+		AverageBalance: stats.AverageBalance,
+		MinBalance:     stats.MinBalance,
+		MaxBalance:     stats.MaxBalance,
+	})
+}
+
+type largestTransactionResponse struct {
+	EntryID  int64     `json:"entry_id"`
+	Amount   int64     `json:"amount"`
+	PostedAt time.Time `json:"posted_at"`
+	// TransferID and the counterparty are left out for entries that aren't part of a transfer, e.g. fees
+	TransferID            int64  `json:"transfer_id,omitempty"`
+	CounterpartyAccountID int64  `json:"counterparty_account_id,omitempty"`
+	CounterpartyOwner     string `json:"counterparty_owner,omitempty"`
+	Description           string `json:"description"`
+}
+
+// listLargestTransactions returns the largest entries of an account over a period, in and out alike
+func (server *Server) listLargestTransactions(ctx *gin.Context) {
+	var uriReq accountAnalyticsURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req accountAnalyticsTopRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	to, err := req.end()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, uriReq.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	rows, err := server.store.ListLargestEntries(ctx, db.ListLargestEntriesParams{
+		AccountID: account.ID,
+		FromTime:  req.From,
+		ToTime:    to,
+		RowLimit:  analyticsLimit(req.Limit),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]largestTransactionResponse, 0, len(rows))
+	for _, row := range rows {
+		rsp = append(rsp, largestTransactionResponse{
+			EntryID:               row.ID,
+			Amount:                row.Amount,
+			PostedAt:              row.CreatedAt,
+			TransferID:            row.TransferID.Int64,
+			CounterpartyAccountID: row.CounterpartyAccountID,
+			CounterpartyOwner:     row.CounterpartyOwner,
+			Description:           row.Description,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+func analyticsLimit(limit int32) int32 {
+	if limit == 0 {
+		return defaultAnalyticsLimit
+	}
+	return limit
+}
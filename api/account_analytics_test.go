@@ -0,0 +1,353 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/volskyi-dmytro/st-bank/db/mock"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/token"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+func TestGetCashFlowAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount()
+	account.Owner = user.Username
+
+	from := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	rows := []db.ListCashFlowRow{
+		{Period: from, Inflow: 1000, Outflow: 250, EntryCount: 3},
+		{Period: from.AddDate(0, 0, 7), Outflow: 100, EntryCount: 1},
+	}
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: url.Values{"from": {"2026-06-01"}, "to": {"2026-06-30"}, "interval": {"week"}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListCashFlowParams{
+					Bucket:    "week",
+					AccountID: account.ID,
+					FromTime:  from,
+					ToTime:    to,
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListCashFlow(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got cashFlowResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, "week", got.Interval)
+				require.Equal(t, int64(1000), got.Inflow)
+				require.Equal(t, int64(350), got.Outflow)
+				require.Equal(t, []cashFlowPeriodResponse{
+					{Period: "2026-06-01", Inflow: 1000, Outflow: 250, Net: 750, Entries: 3},
+					{Period: "2026-06-08", Outflow: 100, Net: -100, Entries: 1},
+				}, got.Periods)
+			},
+		},
+		{
+			name:  "DefaultInterval",
+			query: url.Values{"from": {"2026-06-01"}, "to": {"2026-06-30"}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListCashFlowParams{
+					Bucket:    "day",
+					AccountID: account.ID,
+					FromTime:  from,
+					ToTime:    to,
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListCashFlow(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.ListCashFlowRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidInterval",
+			query: url.Values{"from": {"2026-06-01"}, "to": {"2026-06-30"}, "interval": {"hour"}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListCashFlow(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "PeriodTooLong",
+			query: url.Values{"from": {"2025-01-01"}, "to": {"2026-06-30"}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListCashFlow(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "UnauthorizedUser",
+			query: url.Values{"from": {"2026-06-01"}, "to": {"2026-06-30"}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized", util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().ListCashFlow(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/analytics/cash_flow?%s", account.ID, tc.query.Encode())
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListTopCounterpartiesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount()
+	account.Owner = user.Username
+
+	from := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+	arg := db.ListTopCounterpartiesParams{
+		AccountID: account.ID,
+		FromTime:  from,
+		ToTime:    from.AddDate(0, 1, 0),
+		RowLimit:  defaultAnalyticsLimit,
+	}
+	rows := []db.ListTopCounterpartiesRow{
+		{CounterpartyAccountID: 9, CounterpartyOwner: "landlord", Outflow: 900, TransferCount: 1},
+		{CounterpartyAccountID: 4, CounterpartyOwner: "employer", Inflow: 500, TransferCount: 1},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+	store.EXPECT().ListTopCounterparties(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rows, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/accounts/%d/analytics/counterparties?from=2026-06-01&to=2026-06-30", account.ID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got []counterpartyResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &got)
+	require.NoError(t, err)
+	require.Equal(t, []counterpartyResponse{
+		{AccountID: 9, Owner: "landlord", Outflow: 900, Transfers: 1},
+		{AccountID: 4, Owner: "employer", Inflow: 500, Transfers: 1},
+	}, got)
+}
+
+func TestGetAverageBalanceAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount()
+	account.Owner = user.Username
+
+	from := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+	arg := db.GetDailyBalanceStatsParams{
+		AccountID: account.ID,
+		FromTime:  from,
+		ToTime:    from.AddDate(0, 0, 30),
+	}
+	stats := db.GetDailyBalanceStatsRow{AverageBalance: 750, MinBalance: 100, MaxBalance: 1200}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+	store.EXPECT().GetDailyBalanceStats(gomock.Any(), gomock.Eq(arg)).Times(1).Return(stats, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/accounts/%d/analytics/average_balance?from=2026-06-01&to=2026-06-30", account.ID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got averageBalanceResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &got)
+	require.NoError(t, err)
+	require.Equal(t, averageBalanceResponse{
+		AccountID:      account.ID,
+		Currency:       account.Currency,
+		Days:           30,
+		AverageBalance: 750,
+		MinBalance:     100,
+		MaxBalance:     1200,
+	}, got)
+}
+
+func TestListLargestTransactionsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount()
+	account.Owner = user.Username
+
+	from := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+	rows := []db.ListLargestEntriesRow{
+		{
+			ID:                    7,
+			Amount:                -900,
+			CreatedAt:             from.Add(time.Hour),
+			TransferID:            sql.NullInt64{Int64: 3, Valid: true},
+			CounterpartyAccountID: 9,
+			CounterpartyOwner:     "landlord",
+			Description:           "rent",
+		},
+		{
+			ID:          8,
+			Amount:      -5,
+			CreatedAt:   from.Add(2 * time.Hour),
+			Description: "maintenance fee",
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "from=2026-06-01&to=2026-06-30&limit=2",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListLargestEntriesParams{
+					AccountID: account.ID,
+					FromTime:  from,
+					ToTime:    from.AddDate(0, 1, 0),
+					RowLimit:  2,
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListLargestEntries(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []largestTransactionResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Len(t, got, 2)
+				require.Equal(t, int64(3), got[0].TransferID)
+				require.Equal(t, "landlord", got[0].CounterpartyOwner)
+				require.Zero(t, got[1].TransferID)
+				require.Equal(t, "maintenance fee", got[1].Description)
+			},
+		},
+		{
+			name:  "LimitTooLarge",
+			query: "from=2026-06-01&to=2026-06-30&limit=500",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListLargestEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "FromAfterTo",
+			query: "from=2026-06-30&to=2026-06-01",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListLargestEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/analytics/largest_transactions?%s", account.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+type accountBalanceURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type accountBalanceRequest struct {
+	AsOf time.Time `form:"as_of" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type accountBalanceResponse struct {
+	AccountID int64     `json:"account_id"`
+	Currency  string    `json:"currency"`
+	Balance   int64     `json:"balance"`
+	AsOf      time.Time `json:"as_of"`
+}
+
+// getAccountBalance returns the balance of an account at a point in time, computed from its entries.
+// The latest balance snapshot before that time is used so that only recent entries have to be added up.
+func (server *Server) getAccountBalance(ctx *gin.Context) {
+	var uriReq accountBalanceURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req accountBalanceRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.AsOf.After(time.Now()) {
+		err := errors.New("as_of must not be in the future")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, uriReq.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	arg := db.GetBalanceAsOfParams{
+		AccountID: account.ID,
+		AsOf:      req.AsOf,
+	}
+
+	balance, err := server.store.GetBalanceAsOf(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, accountBalanceResponse{
+		AccountID: account.ID,
+		Currency:  account.Currency,
+		Balance:   balance,
+		AsOf:      req.AsOf,
+	})
+}
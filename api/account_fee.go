@@ -0,0 +1,89 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+type accountFeesURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type listAccountFeesRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+type accountFeeResponse struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// Period is the month of the fee, e.g. 2026-06
+	Period         string    `json:"period"`
+	Amount         int64     `json:"amount"`
+	Charged        int64     `json:"charged"`
+	Arrears        int64     `json:"arrears"`
+	Status         string    `json:"status"`
+	WaiverReason   string    `json:"waiver_reason,omitempty"`
+	JournalEntryID *int64    `json:"journal_entry_id,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func newAccountFeeResponse(fee db.AccountFee) accountFeeResponse {
+	rsp := accountFeeResponse{
+		ID:           fee.ID,
+		AccountID:    fee.AccountID,
+		Period:       fee.Period.Format("2006-01"),
+		Amount:       fee.Amount,
+		Charged:      fee.Charged,
+		Arrears:      fee.Arrears,
+		Status:       fee.Status,
+		WaiverReason: fee.WaiverReason,
+		CreatedAt:    fee.CreatedAt,
+	}
+	if fee.JournalEntryID.Valid {
+		rsp.JournalEntryID = &fee.JournalEntryID.Int64
+	}
+	return rsp
+}
+
+// listAccountFees returns the maintenance fees billed to an account, latest month first
+func (server *Server) listAccountFees(ctx *gin.Context) {
+	var uriReq accountFeesURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req listAccountFeesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, uriReq.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	arg := db.ListAccountFeesParams{
+		AccountID: account.ID,
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	}
+
+	fees, err := server.store.ListAccountFees(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]accountFeeResponse, 0, len(fees))
+	for _, fee := range fees {
+		rsp = append(rsp, newAccountFeeResponse(fee))
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
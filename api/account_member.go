@@ -0,0 +1,264 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+type accountMembersURI struct {
+	AccountID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type accountMemberURI struct {
+	AccountID int64  `uri:"id" binding:"required,min=1"`
+	Username  string `uri:"username" binding:"required,alphanum"`
+}
+
+type grantAccountMemberRequest struct {
+	// Permission is view for read-only access, transfer to also send money up to the transfer limit,
+	// or manage for a joint owner
+	Permission string `json:"permission" binding:"required,oneof=view transfer manage"`
+	// TransferLimit is the largest single transfer in cents with the transfer permission, 0 for no limit
+	TransferLimit int64 `json:"transfer_limit" binding:"min=0"`
+}
+
+// grantAccountMember shares an account with another user, or changes what an existing member can do
+func (server *Server) grantAccountMember(ctx *gin.Context) {
+	var uriReq accountMemberURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req grantAccountMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, uriReq.AccountID, db.AccountPermissionManage)
+	if !valid {
+		return
+	}
+
+	if account.Type != db.AccountTypeChecking && account.Type != db.AccountTypeSavings {
+		err := fmt.Errorf("account [%d] is a %s account, only checking and savings accounts can be shared", account.ID, account.Type)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if account.OrganizationID.Valid {
+		err := fmt.Errorf("account [%d] belongs to an organization, add members to the organization instead", account.ID)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if uriReq.Username == account.Owner {
+		err := errors.New("the owner of the account can't be added as a member")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err = server.store.GetUser(ctx, uriReq.Username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// granting access again changes the permission of the existing member
+	existing, err := server.store.GetAccountMember(ctx, db.GetAccountMemberParams{
+		AccountID: account.ID,
+		Username:  uriReq.Username,
+	})
+	if err == nil {
+		setAuditBefore(ctx, existing)
+	} else if err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	member, err := server.store.GrantAccountMember(ctx, db.GrantAccountMemberParams{
+		AccountID:     account.ID,
+		Username:      uriReq.Username,
+		Permission:    req.Permission,
+		TransferLimit: req.TransferLimit,
+		GrantedBy:     authPayload.Username,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, member)
+}
+
+// listAccountMembers returns the users an account is shared with
+func (server *Server) listAccountMembers(ctx *gin.Context) {
+	var req accountMembersURI
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, req.AccountID, db.AccountPermissionManage)
+	if !valid {
+		return
+	}
+
+	members, err := server.store.ListAccountMembers(ctx, account.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, members)
+}
+
+// revokeAccountMember stops sharing an account with a user. Members can also remove themselves.
+func (server *Server) revokeAccountMember(ctx *gin.Context) {
+	var uriReq accountMemberURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	permission := db.AccountPermissionManage
+	if uriReq.Username == authPayload.Username {
+		permission = db.AccountPermissionView
+	}
+
+	account, valid := server.authorizedAccount(ctx, uriReq.AccountID, permission)
+	if !valid {
+		return
+	}
+
+	arg := db.GetAccountMemberParams{
+		AccountID: account.ID,
+		Username:  uriReq.Username,
+	}
+
+	member, err := server.store.GetAccountMember(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	setAuditBefore(ctx, member)
+
+	member, err = server.store.RevokeAccountMember(ctx, db.RevokeAccountMemberParams(arg))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, member)
+}
+
+// authorizedAccount loads an account and checks that the authenticated user can act on it with the permission
+func (server *Server) authorizedAccount(ctx *gin.Context, id int64, permission string) (db.Account, bool) {
+	account, err := server.store.GetAccount(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return account, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return account, false
+	}
+
+	if !server.authorizeAccount(ctx, account, permission, 0) {
+		return account, false
+	}
+
+	return account, true
+}
+
+// authorizeAccount checks that the authenticated user can act on the account with the permission.
+// Owners can do anything, other users only what they were granted as members.
+// For transfers, amount is the amount sent, which has to be within the member's transfer limit.
+// Accounts of an organization are only reachable in its context, with what the member's role allows.
+func (server *Server) authorizeAccount(ctx *gin.Context, account db.Account, permission string, amount int64) bool {
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return false
+	}
+
+	if account.OrganizationID.Valid {
+		if authPayload.OrganizationID != account.OrganizationID.Int64 {
+			err := fmt.Errorf("account [%d] belongs to organization [%d], switch to its context to act on it", account.ID, account.OrganizationID.Int64)
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return false
+		}
+
+		member, valid := server.organizationMember(ctx, account.OrganizationID.Int64)
+		if !valid {
+			return false
+		}
+
+		if !db.AccountPermissionIncludes(member.AccountPermission(), permission) {
+			err := fmt.Errorf("%s of organization [%d] can't do this with account [%d]", member.Role, member.OrganizationID, account.ID)
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return false
+		}
+		return true
+	}
+
+	if account.Owner == authPayload.Username {
+		return true
+	}
+
+	member, err := server.store.GetAccountMember(ctx, db.GetAccountMemberParams{
+		AccountID: account.ID,
+		Username:  authPayload.Username,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err := errors.New("account doesn't belong to the authenticated user")
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if !member.Allows(permission, amount) {
+		err := fmt.Errorf("%s access to account [%d] doesn't allow this", member.Permission, account.ID)
+		if member.Permission == db.AccountPermissionTransfer && permission == db.AccountPermissionTransfer {
+			err = fmt.Errorf("transfers from account [%d] are limited to %d for the authenticated user", account.ID, member.TransferLimit)
+		}
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,417 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/volskyi-dmytro/st-bank/db/mock"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+func randomAccountMember(account db.Account, username string, permission string) db.AccountMember {
+	return db.AccountMember{
+		AccountID:  account.ID,
+		Username:   username,
+		Permission: permission,
+		GrantedBy:  account.Owner,
+	}
+}
+
+func TestGrantAccountMemberAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	member, _ := randomUser(t)
+	manager, _ := randomUser(t)
+	account := randomAccount()
+	account.Owner = owner.Username
+
+	managerMember := randomAccountMember(account, manager.Username, db.AccountPermissionManage)
+
+	testCases := []struct {
+		name          string
+		username      string
+		member        string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: owner.Username,
+			member:   member.Username,
+			body:     gin.H{"permission": "transfer", "transfer_limit": 5000},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.GrantAccountMemberParams{
+					AccountID:     account.ID,
+					Username:      member.Username,
+					Permission:    db.AccountPermissionTransfer,
+					TransferLimit: 5000,
+					GrantedBy:     owner.Username,
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(member.Username)).Times(1).Return(member, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Eq(db.GetAccountMemberParams{
+					AccountID: account.ID,
+					Username:  member.Username,
+				})).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().GrantAccountMember(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.AccountMember{
+					AccountID:     arg.AccountID,
+					Username:      arg.Username,
+					Permission:    arg.Permission,
+					TransferLimit: arg.TransferLimit,
+					GrantedBy:     arg.GrantedBy,
+				}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.AccountMember
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, member.Username, got.Username)
+				require.Equal(t, db.AccountPermissionTransfer, got.Permission)
+				require.Equal(t, int64(5000), got.TransferLimit)
+			},
+		},
+		{
+			name:     "GrantedByManager",
+			username: manager.Username,
+			member:   member.Username,
+			body:     gin.H{"permission": "view"},
+			buildStubs: func(store *mockdb.MockStore) {
+				existing := randomAccountMember(account, member.Username, db.AccountPermissionManage)
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Eq(db.GetAccountMemberParams{
+					AccountID: account.ID,
+					Username:  manager.Username,
+				})).Times(1).Return(managerMember, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(member.Username)).Times(1).Return(member, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Eq(db.GetAccountMemberParams{
+					AccountID: account.ID,
+					Username:  member.Username,
+				})).Times(1).Return(existing, nil)
+				store.EXPECT().GrantAccountMember(gomock.Any(), gomock.Eq(db.GrantAccountMemberParams{
+					AccountID:  account.ID,
+					Username:   member.Username,
+					Permission: db.AccountPermissionView,
+					GrantedBy:  manager.Username,
+				})).Times(1).Return(randomAccountMember(account, member.Username, db.AccountPermissionView), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "TransferMemberCantGrant",
+			username: manager.Username,
+			member:   member.Username,
+			body:     gin.H{"permission": "view"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).
+					Return(randomAccountMember(account, manager.Username, db.AccountPermissionTransfer), nil)
+				store.EXPECT().GrantAccountMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			username: "unauthorized",
+			member:   member.Username,
+			body:     gin.H{"permission": "view"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().GrantAccountMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "Owner",
+			username: owner.Username,
+			member:   owner.Username,
+			body:     gin.H{"permission": "view"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GrantAccountMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "LoanAccount",
+			username: owner.Username,
+			member:   member.Username,
+			body:     gin.H{"permission": "view"},
+			buildStubs: func(store *mockdb.MockStore) {
+				loanAccount := account
+				loanAccount.Type = db.AccountTypeLoan
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(loanAccount, nil)
+				store.EXPECT().GrantAccountMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "UserNotFound",
+			username: owner.Username,
+			member:   member.Username,
+			body:     gin.H{"permission": "view"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(member.Username)).Times(1).Return(db.User{}, sql.ErrNoRows)
+				store.EXPECT().GrantAccountMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidPermission",
+			username: owner.Username,
+			member:   member.Username,
+			body:     gin.H{"permission": "owner"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "InternalError",
+			username: owner.Username,
+			member:   member.Username,
+			body:     gin.H{"permission": "view"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(member.Username)).Times(1).Return(member, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().GrantAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/accounts/%d/members/%s", account.ID, tc.member)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListAccountMembersAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	account := randomAccount()
+	account.Owner = owner.Username
+
+	members := []db.AccountMember{
+		randomAccountMember(account, util.RandomOwner(), db.AccountPermissionView),
+		randomAccountMember(account, util.RandomOwner(), db.AccountPermissionManage),
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: owner.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListAccountMembers(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(members, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.AccountMember
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, members, got)
+			},
+		},
+		{
+			name:     "ViewMember",
+			username: members[0].Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(members[0], nil)
+				store.EXPECT().ListAccountMembers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "InternalError",
+			username: owner.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListAccountMembers(gomock.Any(), gomock.Any()).Times(1).Return([]db.AccountMember{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/members", account.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRevokeAccountMemberAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	viewer, _ := randomUser(t)
+	account := randomAccount()
+	account.Owner = owner.Username
+
+	member := randomAccountMember(account, viewer.Username, db.AccountPermissionView)
+	memberArg := db.GetAccountMemberParams{AccountID: account.ID, Username: viewer.Username}
+
+	testCases := []struct {
+		name          string
+		username      string
+		member        string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: owner.Username,
+			member:   viewer.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Eq(memberArg)).Times(1).Return(member, nil)
+				store.EXPECT().RevokeAccountMember(gomock.Any(), gomock.Eq(db.RevokeAccountMemberParams(memberArg))).Times(1).Return(member, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "LeaveAccount",
+			username: viewer.Username,
+			member:   viewer.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				// once to authorize the member, once to load the membership to revoke
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Eq(memberArg)).Times(2).Return(member, nil)
+				store.EXPECT().RevokeAccountMember(gomock.Any(), gomock.Eq(db.RevokeAccountMemberParams(memberArg))).Times(1).Return(member, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "ViewMemberRevokesOther",
+			username: viewer.Username,
+			member:   owner.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Eq(memberArg)).Times(1).Return(member, nil)
+				store.EXPECT().RevokeAccountMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "MemberNotFound",
+			username: owner.Username,
+			member:   viewer.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Eq(memberArg)).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().RevokeAccountMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/members/%s", account.ID, tc.member)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
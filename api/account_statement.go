@@ -0,0 +1,111 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/iso20022"
+	"github.com/volskyi-dmytro/st-bank/statement"
+)
+
+// maxStatementDays limits the period of a single statement
+const maxStatementDays = 366
+
+// camt053Format renders statements as ISO 20022 camt.053 documents
+const camt053Format = "camt053"
+
+type accountStatementURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type accountStatementRequest struct {
+	From   time.Time `form:"from" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	To     time.Time `form:"to" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	Format string    `form:"format" binding:"omitempty,oneof=csv pdf ofx mt940 camt053"`
+}
+
+// getAccountStatement renders the statement of an account for the days from and to, both included.
+// It lists the entries of the period with their counterparties between the opening and closing balance.
+func (server *Server) getAccountStatement(ctx *gin.Context) {
+	var uriReq accountStatementURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req accountStatementRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// the statement ends at the start of the day after to
+	to := req.To.AddDate(0, 0, 1)
+	if !to.After(req.From) {
+		err := errors.New("from must not be after to")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if to.Sub(req.From) > maxStatementDays*24*time.Hour {
+		err := fmt.Errorf("a statement can't cover more than %d days", maxStatementDays)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	format := statement.Format(req.Format)
+	if format == "" {
+		format = statement.CSV
+	}
+
+	contentType, extension := format.ContentType(), format.Extension()
+	if format == camt053Format {
+		contentType, extension = iso20022.ContentType, "xml"
+	}
+
+	account, valid := server.authorizedAccount(ctx, uriReq.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	openingBalance, err := server.store.GetBalanceAsOf(ctx, db.GetBalanceAsOfParams{
+		AccountID: account.ID,
+		AsOf:      req.From.Add(-time.Microsecond),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	entries, err := server.store.ListStatementEntries(ctx, db.ListStatementEntriesParams{
+		AccountID: account.ID,
+		FromTime:  req.From,
+		ToTime:    to,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var buf bytes.Buffer
+	accountStatement := statement.New(account, req.From, to, openingBalance, entries)
+	if format == camt053Format {
+		err = iso20022.WriteCamt053(&buf, accountStatement)
+	} else {
+		err = statement.Render(&buf, format, accountStatement)
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	filename := fmt.Sprintf("statement-%d-%s-%s.%s",
+		account.ID, req.From.Format("20060102"), req.To.Format("20060102"), extension)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Data(http.StatusOK, contentType, buf.Bytes())
+}
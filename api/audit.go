@@ -0,0 +1,145 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+const (
+	requestIDHeaderKey = "X-Request-ID"
+	requestIDKey       = "request_id"
+	auditBeforeKey     = "audit_before"
+	auditAfterKey      = "audit_after"
+)
+
+// unauditedRoutes change no state even though they aren't read-only requests
+var unauditedRoutes = map[string]bool{
+	"/users/login": true,
+}
+
+// auditedFailureStatuses are the failed responses that still change state, such as the declined card
+// authorizations, which are recorded before being answered with 402
+var auditedFailureStatuses = map[int]bool{
+	http.StatusPaymentRequired: true,
+}
+
+// requestIDMiddleware tags every request with the request ID sent by the client, or a new one
+func requestIDMiddleware() gin.HandlerFunc {
+	return gin.HandlerFunc(func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(requestIDHeaderKey)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+
+		ctx.Set(requestIDKey, requestID)
+		ctx.Header(requestIDHeaderKey, requestID)
+		ctx.Next()
+	})
+}
+
+// auditResponseWriter holds the response back until the call is recorded in the audit log
+type auditResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (writer *auditResponseWriter) Write(data []byte) (int, error) {
+	return writer.body.Write(data)
+}
+
+func (writer *auditResponseWriter) WriteString(data string) (int, error) {
+	return writer.body.WriteString(data)
+}
+
+// WriteHeaderNow keeps the status from being sent before the audit log entry is appended
+func (writer *auditResponseWriter) WriteHeaderNow() {}
+
+// flush sends the response held back so far
+func (writer *auditResponseWriter) flush() {
+	writer.ResponseWriter.WriteHeaderNow()
+	if writer.body.Len() > 0 {
+		writer.ResponseWriter.Write(writer.body.Bytes())
+	}
+}
+
+// auditMiddleware records every successful state-changing call in the hash-chained audit log,
+// along with the failed ones in auditedFailureStatuses.
+// The response body is recorded as the state after the call, unless the handler records another one with
+// setAuditAfter; handlers that change an existing resource record its previous state with setAuditBefore.
+// The response is held back until the entry is appended, and replaced with 500 when it can't be,
+// so that no caller is told a change succeeded without it being recorded. The entry is appended in its own
+// transaction once the handler committed its change, so a failed append leaves that change without an entry:
+// it is logged with everything the entry would have held, so that it can be recorded by hand.
+func auditMiddleware(store db.Store) gin.HandlerFunc {
+	return gin.HandlerFunc(func(ctx *gin.Context) {
+		if ctx.Request.Method == http.MethodGet || ctx.Request.Method == http.MethodHead ||
+			ctx.Request.Method == http.MethodOptions || unauditedRoutes[ctx.FullPath()] {
+			ctx.Next()
+			return
+		}
+
+		writer := &auditResponseWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+		ctx.Next()
+		ctx.Writer = writer.ResponseWriter
+
+		// failed calls change nothing, apart from the few recording why they failed
+		if writer.Status() >= http.StatusBadRequest && !auditedFailureStatuses[writer.Status()] {
+			writer.flush()
+			return
+		}
+
+		arg := db.AppendAuditLogParams{
+			Action:    ctx.Request.Method + " " + ctx.FullPath(),
+			Resource:  ctx.Request.URL.Path,
+			RequestID: ctx.GetString(requestIDKey),
+		}
+
+		if authPayload, err := getCurrentUser(ctx); err == nil {
+			arg.Actor = authPayload.Username
+		}
+
+		if before, exists := ctx.Get(auditBeforeKey); exists {
+			data, err := json.Marshal(before)
+			if err == nil {
+				arg.Before = data
+			}
+		}
+
+		if after, exists := ctx.Get(auditAfterKey); exists {
+			data, err := json.Marshal(after)
+			if err == nil {
+				arg.After = data
+			}
+		} else if json.Valid(writer.body.Bytes()) {
+			arg.After = writer.body.Bytes()
+		}
+
+		_, err := store.AppendAuditLogTx(ctx, arg)
+		if err != nil {
+			log.Printf("change committed without an audit log entry: request %s, actor %q, action %q, resource %q, before %s, after %s: %v",
+				arg.RequestID, arg.Actor, arg.Action, arg.Resource, arg.Before, arg.After, err)
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		writer.flush()
+	})
+}
+
+// setAuditBefore records the state of a resource before the handler changes it
+func setAuditBefore(ctx *gin.Context, before any) {
+	ctx.Set(auditBeforeKey, before)
+}
+
+// setAuditAfter records the state of a resource after the handler changed it, for responses holding secrets
+// that must not end up in the audit log
+func setAuditAfter(ctx *gin.Context, after any) {
+	ctx.Set(auditAfterKey, after)
+}
@@ -0,0 +1,289 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/volskyi-dmytro/st-bank/cards"
+	mockdb "github.com/volskyi-dmytro/st-bank/db/mock"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/token"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+func TestAuditMiddleware(t *testing.T) {
+	user, password := randomUser(t)
+	account := randomAccount()
+	beneficiary := randomBeneficiary(user.Username, account)
+
+	updated := beneficiary
+	updated.Nickname = "landlord"
+
+	organization := randomOrganization(user.Username)
+
+	account.Owner = user.Username
+	card, cvv := randomCard(t, account, user.Username)
+	declined := db.CardAuthorization{
+		ID:            util.RandomInt(1, 1000),
+		CardID:        card.ID,
+		Amount:        2500,
+		Currency:      account.Currency,
+		Merchant:      "Coffee Shop",
+		Status:        db.CardAuthorizationStatusDeclined,
+		DeclineReason: db.CardDeclineInsufficientFunds,
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          gin.H
+		requestID     string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "StateChangingCall",
+			method:    http.MethodPut,
+			url:       fmt.Sprintf("/beneficiaries/%d", beneficiary.ID),
+			body:      gin.H{"nickname": updated.Nickname},
+			requestID: "req-1",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBeneficiary(gomock.Any(), gomock.Eq(beneficiary.ID)).Times(1).Return(beneficiary, nil)
+				store.EXPECT().UpdateBeneficiary(gomock.Any(), gomock.Any()).Times(1).Return(updated, nil)
+				store.EXPECT().
+					AppendAuditLogTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.AppendAuditLogParams) (db.AuditLog, error) {
+						require.Equal(t, user.Username, arg.Actor)
+						require.Equal(t, "PUT /beneficiaries/:id", arg.Action)
+						require.Equal(t, fmt.Sprintf("/beneficiaries/%d", beneficiary.ID), arg.Resource)
+						require.Equal(t, "req-1", arg.RequestID)
+
+						var before, after db.Beneficiary
+						require.NoError(t, json.Unmarshal(arg.Before, &before))
+						require.Equal(t, beneficiary.Nickname, before.Nickname)
+						require.NoError(t, json.Unmarshal(arg.After, &after))
+						require.Equal(t, updated.Nickname, after.Nickname)
+
+						return db.AuditLog{}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "req-1", recorder.Header().Get(requestIDHeaderKey))
+			},
+		},
+		{
+			name:   "GeneratedRequestID",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/beneficiaries/%d", beneficiary.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBeneficiary(gomock.Any(), gomock.Eq(beneficiary.ID)).Times(1).Return(beneficiary, nil)
+				store.EXPECT().DeleteBeneficiary(gomock.Any(), gomock.Eq(beneficiary.ID)).Times(1).Return(nil)
+				store.EXPECT().
+					AppendAuditLogTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.AppendAuditLogParams) (db.AuditLog, error) {
+						require.NotEmpty(t, arg.RequestID)
+						return db.AuditLog{}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.NotEmpty(t, recorder.Header().Get(requestIDHeaderKey))
+			},
+		},
+		{
+			name:   "ReadOnlyCall",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/beneficiaries/%d", beneficiary.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBeneficiary(gomock.Any(), gomock.Eq(beneficiary.ID)).Times(1).Return(beneficiary, nil)
+				store.EXPECT().AppendAuditLogTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "FailedCall",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/beneficiaries/%d", beneficiary.ID),
+			body:   gin.H{"nickname": updated.Nickname},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBeneficiary(gomock.Any(), gomock.Eq(beneficiary.ID)).Times(1).Return(beneficiary, nil)
+				store.EXPECT().UpdateBeneficiary(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().AppendAuditLogTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "DeclinedCardAuthorization",
+			method: http.MethodPost,
+			url:    "/card_authorizations",
+			body: gin.H{
+				"pan":          card.Pan,
+				"expiry_month": card.ExpiryMonth,
+				"expiry_year":  card.ExpiryYear,
+				"cvv":          cvv,
+				"amount":       declined.Amount,
+				"currency":     declined.Currency,
+				"merchant":     declined.Merchant,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				request.Header.Set(cards.MerchantKeyHeader, "merchant-key")
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCardByPAN(gomock.Any(), gomock.Eq(card.Pan)).Times(1).Return(card, nil)
+				store.EXPECT().AuthorizeCardTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.AuthorizeCardTxResult{CardAuthorization: declined, Card: card}, nil)
+				store.EXPECT().
+					AppendAuditLogTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.AppendAuditLogParams) (db.AuditLog, error) {
+						// the declined authorization is recorded even though the call failed
+						require.Equal(t, "POST /card_authorizations", arg.Action)
+
+						var after cardAuthorizationResponse
+						require.NoError(t, json.Unmarshal(arg.After, &after))
+						require.Equal(t, declined.ID, after.ID)
+						require.Equal(t, db.CardAuthorizationStatusDeclined, after.Status)
+						return db.AuditLog{}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPaymentRequired, recorder.Code)
+			},
+		},
+		{
+			name:   "Login",
+			method: http.MethodPost,
+			url:    "/users/login",
+			body: gin.H{
+				"username": user.Username,
+				"password": password,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().AppendAuditLogTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "SwitchContext",
+			method: http.MethodPost,
+			url:    "/users/context",
+			body:   gin.H{"organization_id": organization.ID},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectOrganizationMember(store, randomOrganizationMember(organization, user.Username, db.OrganizationRoleViewer), 1)
+				store.EXPECT().
+					AppendAuditLogTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.AppendAuditLogParams) (db.AuditLog, error) {
+						require.Equal(t, "POST /users/context", arg.Action)
+						require.JSONEq(t, fmt.Sprintf(`{"organization_id": %d}`, organization.ID), string(arg.After))
+						require.NotContains(t, string(arg.After), "access_token")
+						return db.AuditLog{}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "access_token")
+			},
+		},
+		{
+			name:   "AuditLogError",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/beneficiaries/%d", beneficiary.ID),
+			body:   gin.H{"nickname": updated.Nickname},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBeneficiary(gomock.Any(), gomock.Eq(beneficiary.ID)).Times(1).Return(beneficiary, nil)
+				store.EXPECT().UpdateBeneficiary(gomock.Any(), gomock.Any()).Times(1).Return(updated, nil)
+				store.EXPECT().
+					AppendAuditLogTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AuditLog{}, errors.New("connection refused"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				// the change can't be confirmed to the caller without being recorded
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.NotContains(t, recorder.Body.String(), updated.Nickname)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			config := util.Config{
+				TokenSymmetricKey:   util.RandomString(32),
+				AccessTokenDuration: time.Minute,
+				AuditLogEnabled:     true,
+				CardMerchantKey:     "merchant-key",
+			}
+
+			server, err := NewServer(config, store)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				err = json.NewEncoder(&body).Encode(tc.body)
+				require.NoError(t, err)
+			}
+
+			request, err := http.NewRequest(tc.method, tc.url, &body)
+			require.NoError(t, err)
+			if tc.requestID != "" {
+				request.Header.Set(requestIDHeaderKey, tc.requestID)
+			}
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+type createBeneficiaryRequest struct {
+	Nickname  string `json:"nickname" binding:"required,max=64"`
+	AccountID int64  `json:"account_id" binding:"required,min=1"`
+}
+
+func (server *Server) createBeneficiary(ctx *gin.Context) {
+	var req createBeneficiaryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	_, err = server.store.GetAccount(ctx, req.AccountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateBeneficiaryParams{
+		Owner:     authPayload.Username,
+		Nickname:  req.Nickname,
+		AccountID: req.AccountID,
+	}
+
+	beneficiary, err := server.store.CreateBeneficiary(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, beneficiary)
+}
+
+type beneficiaryURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getBeneficiary(ctx *gin.Context) {
+	var req beneficiaryURI
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	beneficiary, valid := server.validBeneficiary(ctx, req.ID)
+	if !valid {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, beneficiary)
+}
+
+type listBeneficiariesRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listBeneficiaries(ctx *gin.Context) {
+	var req listBeneficiariesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	arg := db.ListBeneficiariesParams{
+		Owner:  authPayload.Username,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	beneficiaries, err := server.store.ListBeneficiaries(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, beneficiaries)
+}
+
+type updateBeneficiaryRequest struct {
+	Nickname string `json:"nickname" binding:"required,max=64"`
+}
+
+func (server *Server) updateBeneficiary(ctx *gin.Context) {
+	var uriReq beneficiaryURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req updateBeneficiaryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	beneficiary, valid := server.validBeneficiary(ctx, uriReq.ID)
+	if !valid {
+		return
+	}
+	setAuditBefore(ctx, beneficiary)
+
+	arg := db.UpdateBeneficiaryParams{
+		ID:       uriReq.ID,
+		Nickname: req.Nickname,
+	}
+
+	beneficiary, err := server.store.UpdateBeneficiary(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, beneficiary)
+}
+
+func (server *Server) deleteBeneficiary(ctx *gin.Context) {
+	var req beneficiaryURI
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	beneficiary, valid := server.validBeneficiary(ctx, req.ID)
+	if !valid {
+		return
+	}
+	setAuditBefore(ctx, beneficiary)
+
+	err := server.store.DeleteBeneficiary(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "beneficiary deleted successfully"})
+}
+
+// validBeneficiary loads a beneficiary and checks that it belongs to the authenticated user
+func (server *Server) validBeneficiary(ctx *gin.Context, id int64) (db.Beneficiary, bool) {
+	beneficiary, err := server.store.GetBeneficiary(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return beneficiary, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return beneficiary, false
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return beneficiary, false
+	}
+
+	if beneficiary.Owner != authPayload.Username {
+		err := errors.New("beneficiary doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return beneficiary, false
+	}
+
+	return beneficiary, true
+}
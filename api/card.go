@@ -0,0 +1,321 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/volskyi-dmytro/st-bank/cards"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+// cardResponse is a card without its verification value, and with its number masked
+type cardResponse struct {
+	ID            int64     `json:"id"`
+	AccountID     int64     `json:"account_id"`
+	Holder        string    `json:"holder"`
+	PAN           string    `json:"pan"`
+	ExpiryMonth   int32     `json:"expiry_month"`
+	ExpiryYear    int32     `json:"expiry_year"`
+	Status        string    `json:"status"`
+	SpendingLimit int64     `json:"spending_limit"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func newCardResponse(card db.Card) cardResponse {
+	return cardResponse{
+		ID:            card.ID,
+		AccountID:     card.AccountID,
+		Holder:        card.Holder,
+		PAN:           cards.MaskPAN(card.Pan),
+		ExpiryMonth:   card.ExpiryMonth,
+		ExpiryYear:    card.ExpiryYear,
+		Status:        card.Status,
+		SpendingLimit: card.SpendingLimit,
+		CreatedAt:     card.CreatedAt,
+	}
+}
+
+// issueCardResponse is the only time the full card number and the verification value are shown
+type issueCardResponse struct {
+	cardResponse
+	PAN string `json:"pan"`
+	CVV string `json:"cvv"`
+}
+
+type cardAccountURI struct {
+	AccountID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type issueCardRequest struct {
+	// SpendingLimit is the most the card can spend per UTC day in cents, 0 for no limit
+	SpendingLimit int64 `json:"spending_limit" binding:"min=0"`
+}
+
+// issueCard issues a virtual debit card on a checking account to the authenticated user
+func (server *Server) issueCard(ctx *gin.Context) {
+	var uriReq cardAccountURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req issueCardRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, uriReq.AccountID, db.AccountPermissionManage)
+	if !valid {
+		return
+	}
+
+	if account.Type != db.AccountTypeChecking {
+		err := fmt.Errorf("account [%d] is a %s account, cards are issued on checking accounts", account.ID, account.Type)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	pan, err := cards.GeneratePAN()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	cvv, err := cards.GenerateCVV()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	hashedCVV, err := util.HashPassword(cvv)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	expiryMonth, expiryYear := cards.Expiry(time.Now())
+
+	card, err := server.store.CreateCard(ctx, db.CreateCardParams{
+		AccountID:     account.ID,
+		Holder:        authPayload.Username,
+		Pan:           pan,
+		ExpiryMonth:   int32(expiryMonth),
+		ExpiryYear:    int32(expiryYear),
+		HashedCvv:     hashedCVV,
+		SpendingLimit: req.SpendingLimit,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newCardResponse(card)
+	setAuditAfter(ctx, rsp)
+
+	ctx.JSON(http.StatusOK, issueCardResponse{
+		cardResponse: rsp,
+		PAN:          card.Pan,
+		CVV:          cvv,
+	})
+}
+
+// listCards returns the cards issued on an account
+func (server *Server) listCards(ctx *gin.Context) {
+	var req cardAccountURI
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, req.AccountID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	issued, err := server.store.ListCards(ctx, account.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]cardResponse, 0, len(issued))
+	for _, card := range issued {
+		rsp = append(rsp, newCardResponse(card))
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type cardURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// freezeCard declines every payment with a card until it is unfrozen
+func (server *Server) freezeCard(ctx *gin.Context) {
+	server.updateCardStatus(ctx, db.CardStatusFrozen)
+}
+
+// unfreezeCard lets a frozen card, or one blocked after too many failed CVV attempts, pay again
+func (server *Server) unfreezeCard(ctx *gin.Context) {
+	server.updateCardStatus(ctx, db.CardStatusActive)
+}
+
+// updateCardStatus freezes or unfreezes a card. Its holder can do it, as can the users managing its account.
+func (server *Server) updateCardStatus(ctx *gin.Context, status string) {
+	var req cardURI
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	card, valid := server.authorizedCard(ctx, req.ID, db.AccountPermissionManage)
+	if !valid {
+		return
+	}
+	setAuditBefore(ctx, newCardResponse(card))
+
+	card, err := server.store.UpdateCardStatus(ctx, db.UpdateCardStatusParams{
+		ID:     card.ID,
+		Status: status,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newCardResponse(card))
+}
+
+type updateCardLimitRequest struct {
+	SpendingLimit int64 `json:"spending_limit" binding:"min=0"`
+}
+
+// updateCardLimit changes the daily spending limit of a card. Only the users managing its account can change it,
+// so that cards issued to members of a shared account stay within what its owner allowed.
+func (server *Server) updateCardLimit(ctx *gin.Context) {
+	var uriReq cardURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req updateCardLimitRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	card, valid := server.validCard(ctx, uriReq.ID)
+	if !valid {
+		return
+	}
+
+	if _, valid := server.authorizedAccount(ctx, card.AccountID, db.AccountPermissionManage); !valid {
+		return
+	}
+	setAuditBefore(ctx, newCardResponse(card))
+
+	card, err := server.store.UpdateCardSpendingLimit(ctx, db.UpdateCardSpendingLimitParams{
+		ID:            card.ID,
+		SpendingLimit: req.SpendingLimit,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newCardResponse(card))
+}
+
+type listCardAuthorizationsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listCardAuthorizations returns the payments made with a card, latest first
+func (server *Server) listCardAuthorizations(ctx *gin.Context) {
+	var uriReq cardURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req listCardAuthorizationsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	card, valid := server.authorizedCard(ctx, uriReq.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	authorizations, err := server.store.ListCardAuthorizations(ctx, db.ListCardAuthorizationsParams{
+		CardID: card.ID,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]cardAuthorizationResponse, 0, len(authorizations))
+	for _, authorization := range authorizations {
+		rsp = append(rsp, newCardAuthorizationResponse(authorization))
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// validCard loads a card, responding with 404 when it doesn't exist
+func (server *Server) validCard(ctx *gin.Context, id int64) (db.Card, bool) {
+	card, err := server.store.GetCard(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return card, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return card, false
+	}
+
+	return card, true
+}
+
+// authorizedCard loads a card and checks that the authenticated user is its holder,
+// or can act on its account with the permission
+func (server *Server) authorizedCard(ctx *gin.Context, id int64, permission string) (db.Card, bool) {
+	card, valid := server.validCard(ctx, id)
+	if !valid {
+		return card, false
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return card, false
+	}
+
+	if card.Holder == authPayload.Username {
+		return card, true
+	}
+
+	if _, valid := server.authorizedAccount(ctx, card.AccountID, permission); !valid {
+		return card, false
+	}
+
+	return card, true
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/volskyi-dmytro/st-bank/cards"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+// merchantMiddleware authenticates merchants with the key they share with the bank.
+// No merchant is let in when no key is configured.
+func merchantMiddleware(key string) gin.HandlerFunc {
+	return gin.HandlerFunc(func(ctx *gin.Context) {
+		merchantKey := ctx.GetHeader(cards.MerchantKeyHeader)
+		if key == "" || subtle.ConstantTimeCompare([]byte(merchantKey), []byte(key)) != 1 {
+			err := errors.New("invalid merchant key")
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+
+		ctx.Next()
+	})
+}
+
+// cardAuthorizationResponse matches cards.Authorization, which the merchant simulator decodes it into
+type cardAuthorizationResponse struct {
+	ID            int64      `json:"id"`
+	CardID        int64      `json:"card_id"`
+	Amount        int64      `json:"amount"`
+	Currency      string     `json:"currency"`
+	Merchant      string     `json:"merchant"`
+	Status        string     `json:"status"`
+	DeclineReason string     `json:"decline_reason,omitempty"`
+	ClearedAmount int64      `json:"cleared_amount"`
+	CreatedAt     time.Time  `json:"created_at"`
+	SettledAt     *time.Time `json:"settled_at,omitempty"`
+}
+
+func newCardAuthorizationResponse(authorization db.CardAuthorization) cardAuthorizationResponse {
+	rsp := cardAuthorizationResponse{
+		ID:            authorization.ID,
+		CardID:        authorization.CardID,
+		Amount:        authorization.Amount,
+		Currency:      authorization.Currency,
+		Merchant:      authorization.Merchant,
+		Status:        authorization.Status,
+		DeclineReason: authorization.DeclineReason,
+		ClearedAmount: authorization.ClearedAmount,
+		CreatedAt:     authorization.CreatedAt,
+	}
+	if authorization.SettledAt.Valid {
+		rsp.SettledAt = &authorization.SettledAt.Time
+	}
+	return rsp
+}
+
+// cardAuthorizationStatus is 402 Payment Required for declined authorizations, so that merchants tell them apart
+func cardAuthorizationStatus(authorization db.CardAuthorization) int {
+	if authorization.Status == db.CardAuthorizationStatusDeclined {
+		return http.StatusPaymentRequired
+	}
+	return http.StatusOK
+}
+
+// maxFailedCVVAttempts is how many authorizations in a row can be declined for invalid card details
+// before the card is blocked, which keeps a 3-digit CVV from being found by trying every value
+const maxFailedCVVAttempts = 3
+
+type authorizeCardRequest struct {
+	PAN         string `json:"pan" binding:"required,len=16"`
+	ExpiryMonth int32  `json:"expiry_month" binding:"required,min=1,max=12"`
+	ExpiryYear  int32  `json:"expiry_year" binding:"required,min=2000"`
+	CVV         string `json:"cvv" binding:"required,len=3"`
+	Amount      int64  `json:"amount" binding:"required,gt=0"`
+	Currency    string `json:"currency" binding:"required,currency"`
+	Merchant    string `json:"merchant" binding:"required,max=64"`
+}
+
+// authorizeCard decides on a card payment presented by a merchant. Approved payments are held on the account
+// of the card until the merchant clears or releases them; declined ones are recorded and answered with 402.
+// A card is blocked after maxFailedCVVAttempts declines for invalid details in a row, until it is unfrozen.
+func (server *Server) authorizeCard(ctx *gin.Context) {
+	var req authorizeCardRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !cards.ValidPAN(req.PAN) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(cards.ErrInvalidPAN))
+		return
+	}
+
+	card, err := server.store.GetCardByPAN(ctx, req.PAN)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	now := time.Now().UTC()
+
+	declineReason := ""
+	if card.Status == db.CardStatusBlocked {
+		// the details of blocked cards aren't checked, so that guessing the CVV can't go on
+		declineReason = db.CardDeclineBlocked
+	} else if req.ExpiryMonth != card.ExpiryMonth || req.ExpiryYear != card.ExpiryYear ||
+		util.CheckPassword(req.CVV, card.HashedCvv) != nil {
+		declineReason = db.CardDeclineInvalidDetails
+
+		_, err = server.store.IncrementCardFailedCVVAttempts(ctx, db.IncrementCardFailedCVVAttemptsParams{
+			ID:          card.ID,
+			MaxAttempts: maxFailedCVVAttempts,
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	} else {
+		if card.FailedCvvAttempts > 0 {
+			err = server.store.ResetCardFailedCVVAttempts(ctx, card.ID)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+		}
+
+		if cards.Expired(int(card.ExpiryMonth), int(card.ExpiryYear), now) {
+			declineReason = db.CardDeclineExpired
+		}
+	}
+
+	if declineReason != "" {
+		authorization, err := server.store.CreateCardAuthorization(ctx, db.CreateCardAuthorizationParams{
+			CardID:        card.ID,
+			Amount:        req.Amount,
+			Currency:      req.Currency,
+			Merchant:      req.Merchant,
+			Status:        db.CardAuthorizationStatusDeclined,
+			DeclineReason: declineReason,
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusPaymentRequired, newCardAuthorizationResponse(authorization))
+		return
+	}
+
+	result, err := server.store.AuthorizeCardTx(ctx, db.AuthorizeCardTxParams{
+		CardID:        card.ID,
+		Amount:        req.Amount,
+		Currency:      req.Currency,
+		Merchant:      req.Merchant,
+		SpendingSince: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(cardAuthorizationStatus(result.CardAuthorization), newCardAuthorizationResponse(result.CardAuthorization))
+}
+
+type cardAuthorizationURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type clearCardAuthorizationRequest struct {
+	// Amount is what the merchant settles, 0 for the whole amount held
+	Amount int64 `json:"amount" binding:"min=0"`
+}
+
+// clearCardAuthorization settles a held card payment into a journal entry debiting the account of the card
+func (server *Server) clearCardAuthorization(ctx *gin.Context) {
+	var uriReq cardAuthorizationURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req clearCardAuthorizationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.ClearCardAuthorizationTx(ctx, db.ClearCardAuthorizationTxParams{
+		ID:     uriReq.ID,
+		Amount: req.Amount,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrCardAuthorizationNotHeld) || errors.Is(err, db.ErrClearingExceedsHold) ||
+			errors.Is(err, db.ErrInsufficientFunds) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newCardAuthorizationResponse(result.CardAuthorization))
+}
+
+// releaseCardAuthorization drops a held card payment, so that its amount is available on the account again
+func (server *Server) releaseCardAuthorization(ctx *gin.Context) {
+	var req cardAuthorizationURI
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authorization, err := server.store.GetCardAuthorization(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	setAuditBefore(ctx, newCardAuthorizationResponse(authorization))
+
+	authorization, err = server.store.ReleaseCardAuthorization(ctx, authorization.ID)
+	if err != nil {
+		// only held authorizations are released
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusBadRequest, errorResponse(db.ErrCardAuthorizationNotHeld))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newCardAuthorizationResponse(authorization))
+}
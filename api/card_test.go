@@ -0,0 +1,651 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/volskyi-dmytro/st-bank/cards"
+	mockdb "github.com/volskyi-dmytro/st-bank/db/mock"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/token"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+// randomCard returns an active card on the account with the verification value it was issued with
+func randomCard(t *testing.T, account db.Account, holder string) (db.Card, string) {
+	pan, err := cards.GeneratePAN()
+	require.NoError(t, err)
+
+	cvv, err := cards.GenerateCVV()
+	require.NoError(t, err)
+
+	hashedCVV, err := util.HashPassword(cvv)
+	require.NoError(t, err)
+
+	month, year := cards.Expiry(time.Now())
+
+	return db.Card{
+		ID:          util.RandomInt(1, 1000),
+		AccountID:   account.ID,
+		Holder:      holder,
+		Pan:         pan,
+		ExpiryMonth: int32(month),
+		ExpiryYear:  int32(year),
+		HashedCvv:   hashedCVV,
+		Status:      db.CardStatusActive,
+	}, cvv
+}
+
+func TestIssueCardAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount()
+	account.Owner = user.Username
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"spending_limit": 5000},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CreateCard(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateCardParams) (db.Card, error) {
+						require.Equal(t, account.ID, arg.AccountID)
+						require.Equal(t, user.Username, arg.Holder)
+						require.True(t, cards.ValidPAN(arg.Pan))
+						require.NotEmpty(t, arg.HashedCvv)
+						require.Equal(t, int64(5000), arg.SpendingLimit)
+
+						return db.Card{
+							ID:            1,
+							AccountID:     arg.AccountID,
+							Holder:        arg.Holder,
+							Pan:           arg.Pan,
+							ExpiryMonth:   arg.ExpiryMonth,
+							ExpiryYear:    arg.ExpiryYear,
+							HashedCvv:     arg.HashedCvv,
+							Status:        db.CardStatusActive,
+							SpendingLimit: arg.SpendingLimit,
+						}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got struct {
+					PAN       string `json:"pan"`
+					CVV       string `json:"cvv"`
+					HashedCVV string `json:"hashed_cvv"`
+					Status    string `json:"status"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.True(t, cards.ValidPAN(got.PAN))
+				require.Len(t, got.CVV, cards.CVVLength)
+				require.Empty(t, got.HashedCVV)
+				require.Equal(t, db.CardStatusActive, got.Status)
+			},
+		},
+		{
+			name: "SavingsAccount",
+			body: gin.H{},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				savingsAccount := account
+				savingsAccount.Type = db.AccountTypeSavings
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(savingsAccount, nil)
+				store.EXPECT().CreateCard(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			body: gin.H{},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized", util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().CreateCard(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidSpendingLimit",
+			body: gin.H{"spending_limit": -1},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateCard(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/accounts/%d/cards", account.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestFreezeCardAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	holder, _ := randomUser(t)
+	account := randomAccount()
+	account.Owner = owner.Username
+	card, _ := randomCard(t, account, holder.Username)
+
+	frozen := card
+	frozen.Status = db.CardStatusFrozen
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Holder",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, holder.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateCardStatusParams{ID: card.ID, Status: db.CardStatusFrozen}
+
+				store.EXPECT().GetCard(gomock.Any(), gomock.Eq(card.ID)).Times(1).Return(card, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateCardStatus(gomock.Any(), gomock.Eq(arg)).Times(1).Return(frozen, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got cardResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, db.CardStatusFrozen, got.Status)
+				require.Equal(t, cards.MaskPAN(card.Pan), got.PAN)
+			},
+		},
+		{
+			name: "AccountOwner",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, owner.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCard(gomock.Any(), gomock.Eq(card.ID)).Times(1).Return(card, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().UpdateCardStatus(gomock.Any(), gomock.Any()).Times(1).Return(frozen, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized", util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCard(gomock.Any(), gomock.Eq(card.ID)).Times(1).Return(card, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().UpdateCardStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, holder.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCard(gomock.Any(), gomock.Eq(card.ID)).Times(1).Return(db.Card{}, sql.ErrNoRows)
+				store.EXPECT().UpdateCardStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/cards/%d/freeze", card.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateCardLimitAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	holder, _ := randomUser(t)
+	account := randomAccount()
+	account.Owner = owner.Username
+	card, _ := randomCard(t, account, holder.Username)
+
+	updated := card
+	updated.SpendingLimit = 10000
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"spending_limit": 10000},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, owner.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateCardSpendingLimitParams{ID: card.ID, SpendingLimit: 10000}
+
+				store.EXPECT().GetCard(gomock.Any(), gomock.Eq(card.ID)).Times(1).Return(card, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().UpdateCardSpendingLimit(gomock.Any(), gomock.Eq(arg)).Times(1).Return(updated, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got cardResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, int64(10000), got.SpendingLimit)
+			},
+		},
+		{
+			// holders who don't manage the account can't raise their own limit
+			name: "HolderWithoutManage",
+			body: gin.H{"spending_limit": 10000},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, holder.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				member := db.AccountMember{
+					AccountID:  account.ID,
+					Username:   holder.Username,
+					Permission: db.AccountPermissionTransfer,
+				}
+
+				store.EXPECT().GetCard(gomock.Any(), gomock.Eq(card.ID)).Times(1).Return(card, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(member, nil)
+				store.EXPECT().UpdateCardSpendingLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidSpendingLimit",
+			body: gin.H{"spending_limit": -5},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, owner.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCard(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateCardSpendingLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/cards/%d/limit", card.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+// TestCardMerchantSimulator drives the card authorization endpoints the way a merchant does, with the simulator
+// of the cards package calling a live test server
+func TestCardMerchantSimulator(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount()
+	account.Owner = user.Username
+	card, cvv := randomCard(t, account, user.Username)
+
+	details := cards.Details{
+		PAN:         card.Pan,
+		ExpiryMonth: int(card.ExpiryMonth),
+		ExpiryYear:  int(card.ExpiryYear),
+		CVV:         cvv,
+	}
+
+	held := db.CardAuthorization{
+		ID:       util.RandomInt(1, 1000),
+		CardID:   card.ID,
+		Amount:   2500,
+		Currency: account.Currency,
+		Merchant: "Coffee Shop",
+		Status:   db.CardAuthorizationStatusHeld,
+	}
+
+	newMerchant := func(t *testing.T, store *mockdb.MockStore, key string) *cards.Merchant {
+		server := newTestServer(t, store)
+		httpServer := httptest.NewServer(server.router)
+		t.Cleanup(httpServer.Close)
+
+		return cards.NewMerchant("Coffee Shop", httpServer.URL, key)
+	}
+
+	t.Run("AuthorizeAndClear", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		merchant := newMerchant(t, store, "merchant-key")
+
+		cleared := held
+		cleared.Status = db.CardAuthorizationStatusCleared
+		cleared.ClearedAmount = 2000
+		cleared.SettledAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+		store.EXPECT().GetCardByPAN(gomock.Any(), gomock.Eq(card.Pan)).Times(1).Return(card, nil)
+		store.EXPECT().AuthorizeCardTx(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, arg db.AuthorizeCardTxParams) (db.AuthorizeCardTxResult, error) {
+				require.Equal(t, card.ID, arg.CardID)
+				require.Equal(t, held.Amount, arg.Amount)
+				require.Equal(t, "Coffee Shop", arg.Merchant)
+
+				now := time.Now().UTC()
+				require.Equal(t, time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), arg.SpendingSince)
+
+				return db.AuthorizeCardTxResult{CardAuthorization: held, Card: card}, nil
+			})
+		store.EXPECT().ClearCardAuthorizationTx(gomock.Any(), gomock.Eq(db.ClearCardAuthorizationTxParams{
+			ID:     held.ID,
+			Amount: 2000,
+		})).Times(1).Return(db.ClearCardAuthorizationTxResult{CardAuthorization: cleared}, nil)
+
+		authorization, err := merchant.Authorize(context.Background(), details, held.Amount, account.Currency)
+		require.NoError(t, err)
+		require.True(t, authorization.Approved())
+		require.Equal(t, held.ID, authorization.ID)
+
+		authorization, err = merchant.Clear(context.Background(), authorization.ID, 2000)
+		require.NoError(t, err)
+		require.Equal(t, db.CardAuthorizationStatusCleared, authorization.Status)
+		require.Equal(t, int64(2000), authorization.ClearedAmount)
+		require.NotNil(t, authorization.SettledAt)
+	})
+
+	t.Run("AuthorizeAndRelease", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		merchant := newMerchant(t, store, "merchant-key")
+
+		released := held
+		released.Status = db.CardAuthorizationStatusReleased
+
+		store.EXPECT().GetCardByPAN(gomock.Any(), gomock.Eq(card.Pan)).Times(1).Return(card, nil)
+		store.EXPECT().AuthorizeCardTx(gomock.Any(), gomock.Any()).Times(1).
+			Return(db.AuthorizeCardTxResult{CardAuthorization: held, Card: card}, nil)
+		store.EXPECT().GetCardAuthorization(gomock.Any(), gomock.Eq(held.ID)).Times(1).Return(held, nil)
+		store.EXPECT().ReleaseCardAuthorization(gomock.Any(), gomock.Eq(held.ID)).Times(1).Return(released, nil)
+
+		authorization, err := merchant.Authorize(context.Background(), details, held.Amount, account.Currency)
+		require.NoError(t, err)
+		require.True(t, authorization.Approved())
+
+		authorization, err = merchant.Release(context.Background(), authorization.ID)
+		require.NoError(t, err)
+		require.Equal(t, db.CardAuthorizationStatusReleased, authorization.Status)
+	})
+
+	t.Run("DeclinedByBank", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		merchant := newMerchant(t, store, "merchant-key")
+
+		declined := held
+		declined.Status = db.CardAuthorizationStatusDeclined
+		declined.DeclineReason = db.CardDeclineInsufficientFunds
+
+		store.EXPECT().GetCardByPAN(gomock.Any(), gomock.Eq(card.Pan)).Times(1).Return(card, nil)
+		store.EXPECT().AuthorizeCardTx(gomock.Any(), gomock.Any()).Times(1).
+			Return(db.AuthorizeCardTxResult{CardAuthorization: declined, Card: card}, nil)
+
+		authorization, err := merchant.Authorize(context.Background(), details, held.Amount, account.Currency)
+		require.NoError(t, err)
+		require.False(t, authorization.Approved())
+		require.Equal(t, db.CardDeclineInsufficientFunds, authorization.DeclineReason)
+	})
+
+	t.Run("WrongCVV", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		merchant := newMerchant(t, store, "merchant-key")
+
+		wrongDetails := details
+		wrongDetails.CVV = string('0'+(cvv[0]-'0'+1)%10) + cvv[1:]
+
+		store.EXPECT().GetCardByPAN(gomock.Any(), gomock.Eq(card.Pan)).Times(1).Return(card, nil)
+		store.EXPECT().IncrementCardFailedCVVAttempts(gomock.Any(), gomock.Eq(db.IncrementCardFailedCVVAttemptsParams{
+			ID:          card.ID,
+			MaxAttempts: maxFailedCVVAttempts,
+		})).Times(1).Return(card, nil)
+		store.EXPECT().CreateCardAuthorization(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, arg db.CreateCardAuthorizationParams) (db.CardAuthorization, error) {
+				require.Equal(t, db.CardAuthorizationStatusDeclined, arg.Status)
+				require.Equal(t, db.CardDeclineInvalidDetails, arg.DeclineReason)
+
+				return db.CardAuthorization{
+					ID:            1,
+					CardID:        arg.CardID,
+					Amount:        arg.Amount,
+					Currency:      arg.Currency,
+					Merchant:      arg.Merchant,
+					Status:        arg.Status,
+					DeclineReason: arg.DeclineReason,
+				}, nil
+			})
+		store.EXPECT().AuthorizeCardTx(gomock.Any(), gomock.Any()).Times(0)
+
+		authorization, err := merchant.Authorize(context.Background(), wrongDetails, held.Amount, account.Currency)
+		require.NoError(t, err)
+		require.False(t, authorization.Approved())
+		require.Equal(t, db.CardDeclineInvalidDetails, authorization.DeclineReason)
+	})
+
+	t.Run("BlockedAfterFailedCVVAttempts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		merchant := newMerchant(t, store, "merchant-key")
+
+		wrongDetails := details
+		wrongDetails.CVV = string('0'+(cvv[0]-'0'+1)%10) + cvv[1:]
+
+		// the store keeps count of the failed attempts like the database does
+		current := card
+		store.EXPECT().GetCardByPAN(gomock.Any(), gomock.Eq(card.Pan)).Times(maxFailedCVVAttempts + 1).
+			DoAndReturn(func(_ context.Context, _ string) (db.Card, error) {
+				return current, nil
+			})
+		store.EXPECT().IncrementCardFailedCVVAttempts(gomock.Any(), gomock.Any()).Times(maxFailedCVVAttempts).
+			DoAndReturn(func(_ context.Context, arg db.IncrementCardFailedCVVAttemptsParams) (db.Card, error) {
+				current.FailedCvvAttempts++
+				if current.FailedCvvAttempts >= arg.MaxAttempts {
+					current.Status = db.CardStatusBlocked
+				}
+				return current, nil
+			})
+		store.EXPECT().CreateCardAuthorization(gomock.Any(), gomock.Any()).Times(maxFailedCVVAttempts + 1).
+			DoAndReturn(func(_ context.Context, arg db.CreateCardAuthorizationParams) (db.CardAuthorization, error) {
+				return db.CardAuthorization{
+					ID:            1,
+					CardID:        arg.CardID,
+					Amount:        arg.Amount,
+					Currency:      arg.Currency,
+					Merchant:      arg.Merchant,
+					Status:        arg.Status,
+					DeclineReason: arg.DeclineReason,
+				}, nil
+			})
+		store.EXPECT().ResetCardFailedCVVAttempts(gomock.Any(), gomock.Any()).Times(0)
+		store.EXPECT().AuthorizeCardTx(gomock.Any(), gomock.Any()).Times(0)
+
+		for i := 0; i < maxFailedCVVAttempts; i++ {
+			authorization, err := merchant.Authorize(context.Background(), wrongDetails, held.Amount, account.Currency)
+			require.NoError(t, err)
+			require.Equal(t, db.CardDeclineInvalidDetails, authorization.DeclineReason)
+		}
+		require.Equal(t, db.CardStatusBlocked, current.Status)
+
+		// once blocked, even the right CVV is declined
+		authorization, err := merchant.Authorize(context.Background(), details, held.Amount, account.Currency)
+		require.NoError(t, err)
+		require.False(t, authorization.Approved())
+		require.Equal(t, db.CardDeclineBlocked, authorization.DeclineReason)
+	})
+
+	t.Run("ValidCVVResetsFailedAttempts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		merchant := newMerchant(t, store, "merchant-key")
+
+		failed := card
+		failed.FailedCvvAttempts = maxFailedCVVAttempts - 1
+
+		store.EXPECT().GetCardByPAN(gomock.Any(), gomock.Eq(card.Pan)).Times(1).Return(failed, nil)
+		store.EXPECT().ResetCardFailedCVVAttempts(gomock.Any(), gomock.Eq(card.ID)).Times(1).Return(nil)
+		store.EXPECT().AuthorizeCardTx(gomock.Any(), gomock.Any()).Times(1).
+			Return(db.AuthorizeCardTxResult{CardAuthorization: held, Card: card}, nil)
+
+		authorization, err := merchant.Authorize(context.Background(), details, held.Amount, account.Currency)
+		require.NoError(t, err)
+		require.True(t, authorization.Approved())
+	})
+
+	t.Run("ClearTwice", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		merchant := newMerchant(t, store, "merchant-key")
+
+		store.EXPECT().ClearCardAuthorizationTx(gomock.Any(), gomock.Any()).Times(1).
+			Return(db.ClearCardAuthorizationTxResult{}, db.ErrCardAuthorizationNotHeld)
+
+		_, err := merchant.Clear(context.Background(), held.ID, 0)
+		require.ErrorContains(t, err, db.ErrCardAuthorizationNotHeld.Error())
+	})
+
+	t.Run("InvalidMerchantKey", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		merchant := newMerchant(t, store, "wrong-key")
+
+		store.EXPECT().GetCardByPAN(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := merchant.Authorize(context.Background(), details, held.Amount, account.Currency)
+		require.ErrorContains(t, err, "401")
+	})
+}
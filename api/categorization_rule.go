@@ -0,0 +1,144 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+type categorizationRuleResponse struct {
+	ID                 int64     `json:"id"`
+	CategoryID         int64     `json:"category_id"`
+	RecipientAccountID *int64    `json:"recipient_account_id,omitempty"`
+	MemoContains       string    `json:"memo_contains,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+func newCategorizationRuleResponse(rule db.CategorizationRule) categorizationRuleResponse {
+	rsp := categorizationRuleResponse{
+		ID:           rule.ID,
+		CategoryID:   rule.CategoryID,
+		MemoContains: rule.MemoContains,
+		CreatedAt:    rule.CreatedAt,
+	}
+	if rule.RecipientAccountID.Valid {
+		rsp.RecipientAccountID = &rule.RecipientAccountID.Int64
+	}
+	return rsp
+}
+
+type createCategorizationRuleRequest struct {
+	CategoryID int64 `json:"category_id" binding:"required,min=1"`
+	// RecipientAccountID or MemoContains, but not both, selects the outgoing transfers of the category
+	RecipientAccountID int64  `json:"recipient_account_id" binding:"min=0"`
+	MemoContains       string `json:"memo_contains" binding:"max=64"`
+}
+
+// createCategorizationRule sorts the outgoing transfers to an account, or with a text in their description,
+// into a category. Rules on the recipient win over rules on the description, then the oldest rule wins.
+func (server *Server) createCategorizationRule(ctx *gin.Context) {
+	var req createCategorizationRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if (req.RecipientAccountID == 0) == (req.MemoContains == "") {
+		err := errors.New("a rule needs either recipient_account_id or memo_contains")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	category, valid := server.validSpendingCategory(ctx, req.CategoryID)
+	if !valid {
+		return
+	}
+
+	arg := db.CreateCategorizationRuleParams{
+		CategoryID:   category.ID,
+		MemoContains: req.MemoContains,
+	}
+
+	if req.RecipientAccountID != 0 {
+		recipient, err := server.store.GetAccount(ctx, req.RecipientAccountID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		arg.RecipientAccountID = sql.NullInt64{Int64: recipient.ID, Valid: true}
+	}
+
+	rule, err := server.store.CreateCategorizationRule(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newCategorizationRuleResponse(rule))
+}
+
+// listCategorizationRules returns the rules of all the categories of the user, oldest first
+func (server *Server) listCategorizationRules(ctx *gin.Context) {
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	rules, err := server.store.ListCategorizationRules(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]categorizationRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		rsp = append(rsp, newCategorizationRuleResponse(rule))
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type categorizationRuleURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// deleteCategorizationRule stops a rule from categorizing transfers, including the ones it already matched
+func (server *Server) deleteCategorizationRule(ctx *gin.Context) {
+	var req categorizationRuleURI
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rule, err := server.store.GetCategorizationRule(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if _, valid := server.validSpendingCategory(ctx, rule.CategoryID); !valid {
+		return
+	}
+	setAuditBefore(ctx, newCategorizationRuleResponse(rule))
+
+	err = server.store.DeleteCategorizationRule(ctx, rule.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "categorization rule deleted successfully"})
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/volskyi-dmytro/st-bank/db/mock"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+func TestCreateCategorizationRuleAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	category := randomSpendingCategory(user.Username)
+	recipient := randomAccount()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "RecipientRule",
+			body: gin.H{
+				"category_id":          category.ID,
+				"recipient_account_id": recipient.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateCategorizationRuleParams{
+					CategoryID:         category.ID,
+					RecipientAccountID: sql.NullInt64{Int64: recipient.ID, Valid: true},
+				}
+				rule := db.CategorizationRule{
+					ID:                 util.RandomInt(1, 1000),
+					CategoryID:         category.ID,
+					RecipientAccountID: arg.RecipientAccountID,
+				}
+
+				store.EXPECT().GetSpendingCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(recipient.ID)).Times(1).Return(recipient, nil)
+				store.EXPECT().CreateCategorizationRule(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rule, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got categorizationRuleResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, category.ID, got.CategoryID)
+				require.NotNil(t, got.RecipientAccountID)
+				require.Equal(t, recipient.ID, *got.RecipientAccountID)
+				require.Empty(t, got.MemoContains)
+			},
+		},
+		{
+			name: "MemoRule",
+			body: gin.H{
+				"category_id":   category.ID,
+				"memo_contains": "coffee",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateCategorizationRuleParams{
+					CategoryID:   category.ID,
+					MemoContains: "coffee",
+				}
+				rule := db.CategorizationRule{
+					ID:           util.RandomInt(1, 1000),
+					CategoryID:   category.ID,
+					MemoContains: "coffee",
+				}
+
+				store.EXPECT().GetSpendingCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateCategorizationRule(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rule, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got categorizationRuleResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Nil(t, got.RecipientAccountID)
+				require.Equal(t, "coffee", got.MemoContains)
+			},
+		},
+		{
+			name: "BothRecipientAndMemo",
+			body: gin.H{
+				"category_id":          category.ID,
+				"recipient_account_id": recipient.ID,
+				"memo_contains":        "coffee",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSpendingCategory(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateCategorizationRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NeitherRecipientNorMemo",
+			body: gin.H{
+				"category_id": category.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSpendingCategory(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateCategorizationRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "RecipientNotFound",
+			body: gin.H{
+				"category_id":          category.ID,
+				"recipient_account_id": recipient.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSpendingCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(recipient.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().CreateCategorizationRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "CategoryOfAnotherUser",
+			body: gin.H{
+				"category_id":   category.ID,
+				"memo_contains": "coffee",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				otherCategory := category
+				otherCategory.Owner = "someone-else"
+
+				store.EXPECT().GetSpendingCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(otherCategory, nil)
+				store.EXPECT().CreateCategorizationRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/categorization_rules", bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/fraud"
+)
+
+type directDebitResponse struct {
+	ID            int64      `json:"id"`
+	MandateID     int64      `json:"mandate_id"`
+	TransferID    int64      `json:"transfer_id"`
+	Amount        int64      `json:"amount"`
+	Status        string     `json:"status"`
+	DisputeReason string     `json:"dispute_reason,omitempty"`
+	ReversalID    *int64     `json:"reversal_id,omitempty"`
+	CollectedAt   time.Time  `json:"collected_at"`
+	DisputedAt    *time.Time `json:"disputed_at,omitempty"`
+}
+
+func newDirectDebitResponse(directDebit db.DirectDebit) directDebitResponse {
+	rsp := directDebitResponse{
+		ID:            directDebit.ID,
+		MandateID:     directDebit.MandateID,
+		TransferID:    directDebit.TransferID,
+		Amount:        directDebit.Amount,
+		Status:        directDebit.Status,
+		DisputeReason: directDebit.DisputeReason,
+		CollectedAt:   directDebit.CollectedAt,
+	}
+	if directDebit.ReversalID.Valid {
+		rsp.ReversalID = &directDebit.ReversalID.Int64
+	}
+	if directDebit.DisputedAt.Valid {
+		rsp.DisputedAt = &directDebit.DisputedAt.Time
+	}
+	return rsp
+}
+
+type collectDirectDebitRequest struct {
+	Amount      int64  `json:"amount" binding:"required,gt=0"`
+	Description string `json:"description" binding:"max=140"`
+}
+
+// collectDirectDebit pulls an amount from the debtor account of a mandate, on behalf of its creditor.
+// The transfer isn't returned, as it holds the balance of the debtor account.
+func (server *Server) collectDirectDebit(ctx *gin.Context) {
+	var uriReq mandateURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req collectDirectDebitRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	mandate, valid := server.validMandate(ctx, uriReq.ID)
+	if !valid {
+		return
+	}
+
+	creditorAccount, valid := server.authorizedAccount(ctx, mandate.CreditorAccountID, db.AccountPermissionTransfer)
+	if !valid {
+		return
+	}
+
+	// collections can't be held for approval, so the ones a banker or the fraud checks would review are refused
+	if server.bankReviewRequired(req.Amount) {
+		err := errors.New("direct debit needs a banker's approval, ask the debtor for a transfer")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if server.fraudEngine != nil {
+		decision, valid := server.assessTransfer(ctx, transferRequest{
+			FromAccountID: mandate.DebtorAccountID,
+			ToAccountID:   mandate.CreditorAccountID,
+			Amount:        req.Amount,
+			Currency:      creditorAccount.Currency,
+		})
+		if !valid {
+			return
+		}
+
+		if decision == fraud.Review {
+			err := errors.New("direct debit needs a review, ask the debtor for a transfer")
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	result, err := server.store.CollectDirectDebitTx(ctx, db.CollectDirectDebitTxParams{
+		MandateID:   mandate.ID,
+		Amount:      req.Amount,
+		Description: req.Description,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrMandateNotActive) || errors.Is(err, db.ErrMandateAmountExceeded) ||
+			errors.Is(err, db.ErrMandateTooFrequent) || errors.Is(err, db.ErrInsufficientFunds) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newDirectDebitResponse(result.DirectDebit))
+}
+
+type listDirectDebitsRequest struct {
+	// AccountID is the debtor or the creditor account of the mandate, which the user needs to view
+	AccountID int64 `form:"account_id" binding:"required,min=1"`
+	PageID    int32 `form:"page_id" binding:"required,min=1"`
+	PageSize  int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listDirectDebits returns the direct debits collected under a mandate, latest first
+func (server *Server) listDirectDebits(ctx *gin.Context) {
+	var uriReq mandateURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req listDirectDebitsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	mandate, valid := server.validMandate(ctx, uriReq.ID)
+	if !valid {
+		return
+	}
+
+	if req.AccountID != mandate.DebtorAccountID && req.AccountID != mandate.CreditorAccountID {
+		err := fmt.Errorf("account [%d] is not a party to mandate [%d]", req.AccountID, mandate.ID)
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	if _, valid := server.authorizedAccount(ctx, req.AccountID, db.AccountPermissionView); !valid {
+		return
+	}
+
+	directDebits, err := server.store.ListDirectDebits(ctx, db.ListDirectDebitsParams{
+		MandateID: mandate.ID,
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]directDebitResponse, 0, len(directDebits))
+	for _, directDebit := range directDebits {
+		rsp = append(rsp, newDirectDebitResponse(directDebit))
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type disputeDirectDebitURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type disputeDirectDebitRequest struct {
+	Reason string `json:"reason" binding:"required,max=140"`
+}
+
+// disputeDirectDebit refunds a direct debit to the debtor, within DIRECT_DEBIT_DISPUTE_WINDOW of its collection
+func (server *Server) disputeDirectDebit(ctx *gin.Context) {
+	var uriReq disputeDirectDebitURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req disputeDirectDebitRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	directDebit, err := server.store.GetDirectDebit(ctx, uriReq.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	mandate, valid := server.validMandate(ctx, directDebit.MandateID)
+	if !valid {
+		return
+	}
+
+	if _, valid := server.authorizedAccount(ctx, mandate.DebtorAccountID, db.AccountPermissionManage); !valid {
+		return
+	}
+	setAuditBefore(ctx, newDirectDebitResponse(directDebit))
+
+	result, err := server.store.DisputeDirectDebitTx(ctx, db.DisputeDirectDebitTxParams{
+		DirectDebitID: directDebit.ID,
+		Reason:        req.Reason,
+		DisputedBy:    authPayload.Username,
+		Window:        server.config.DirectDebitDisputeWindow,
+	})
+	if err != nil {
+		// the creditor may not cover the refund, or a banker may have reversed the transfer already
+		if errors.Is(err, db.ErrDirectDebitAlreadyDisputed) || errors.Is(err, db.ErrDisputeWindowClosed) ||
+			errors.Is(err, db.ErrInsufficientFunds) || errors.Is(err, db.ErrTransferAlreadyReversed) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newDirectDebitResponse(result.DirectDebit))
+}
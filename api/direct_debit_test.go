@@ -0,0 +1,362 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/volskyi-dmytro/st-bank/db/mock"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/fraud"
+	"github.com/volskyi-dmytro/st-bank/token"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+func randomDirectDebit(mandate db.Mandate) db.DirectDebit {
+	return db.DirectDebit{
+		ID:          util.RandomInt(1, 1000),
+		MandateID:   mandate.ID,
+		TransferID:  util.RandomInt(1, 1000),
+		Amount:      mandate.MaxAmount,
+		Status:      db.DirectDebitStatusCollected,
+		CollectedAt: time.Now(),
+	}
+}
+
+func TestCollectDirectDebitAPI(t *testing.T) {
+	creditor, _ := randomUser(t)
+	debtorAccount := randomAccount()
+	creditorAccount := randomAccount()
+	creditorAccount.Owner = creditor.Username
+	mandate := randomMandate(debtorAccount, creditorAccount)
+	mandate.MaxAmount = util.RandomInt(1, 49)
+	directDebit := randomDirectDebit(mandate)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		fraudResult   *fraud.Result
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"amount": directDebit.Amount, "description": "June invoice"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, creditor.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CollectDirectDebitTxParams{
+					MandateID:   mandate.ID,
+					Amount:      directDebit.Amount,
+					Description: "June invoice",
+				}
+				result := db.CollectDirectDebitTxResult{
+					Mandate:     mandate,
+					DirectDebit: directDebit,
+					Transfer: db.TransferTxResult{
+						FromAccount: debtorAccount,
+					},
+				}
+
+				store.EXPECT().GetMandate(gomock.Any(), gomock.Eq(mandate.ID)).Times(1).Return(mandate, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(creditorAccount.ID)).Times(1).Return(creditorAccount, nil)
+				store.EXPECT().CollectDirectDebitTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				// the balance of the debtor account isn't disclosed to the creditor
+				require.NotContains(t, recorder.Body.String(), "balance")
+
+				var got directDebitResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, directDebit.ID, got.ID)
+				require.Equal(t, directDebit.TransferID, got.TransferID)
+				require.Equal(t, db.DirectDebitStatusCollected, got.Status)
+			},
+		},
+		{
+			name: "TooFrequent",
+			body: gin.H{"amount": directDebit.Amount},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, creditor.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMandate(gomock.Any(), gomock.Eq(mandate.ID)).Times(1).Return(mandate, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(creditorAccount.ID)).Times(1).Return(creditorAccount, nil)
+				store.EXPECT().CollectDirectDebitTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.CollectDirectDebitTxResult{}, db.ErrMandateTooFrequent)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NeedsApproval",
+			body: gin.H{"amount": 51},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, creditor.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMandate(gomock.Any(), gomock.Eq(mandate.ID)).Times(1).Return(mandate, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(creditorAccount.ID)).Times(1).Return(creditorAccount, nil)
+				store.EXPECT().CollectDirectDebitTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "needs a banker's approval")
+			},
+		},
+		{
+			name:        "FraudReview",
+			body:        gin.H{"amount": directDebit.Amount},
+			fraudResult: &fraud.Result{Score: fraud.NewRecipientScore, ReasonCode: fraud.ReasonNewRecipientLargeAmount},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, creditor.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateFraudDecisionParams{
+					Username:      creditor.Username,
+					FromAccountID: debtorAccount.ID,
+					ToAccountID:   creditorAccount.ID,
+					Amount:        directDebit.Amount,
+					Currency:      creditorAccount.Currency,
+					Score:         fraud.NewRecipientScore,
+					Decision:      string(fraud.Review),
+					ReasonCodes:   []string{fraud.ReasonNewRecipientLargeAmount},
+				}
+
+				store.EXPECT().GetMandate(gomock.Any(), gomock.Eq(mandate.ID)).Times(1).Return(mandate, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(creditorAccount.ID)).Times(1).Return(creditorAccount, nil)
+				store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.FraudDecision{}, nil)
+				store.EXPECT().CollectDirectDebitTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "needs a review")
+			},
+		},
+		{
+			name: "AmountExceeded",
+			body: gin.H{"amount": mandate.MaxAmount + 1},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, creditor.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMandate(gomock.Any(), gomock.Eq(mandate.ID)).Times(1).Return(mandate, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(creditorAccount.ID)).Times(1).Return(creditorAccount, nil)
+				store.EXPECT().CollectDirectDebitTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.CollectDirectDebitTxResult{}, db.ErrMandateAmountExceeded)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			// the debtor can't collect from their own account to another one
+			name: "NotCreditor",
+			body: gin.H{"amount": directDebit.Amount},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, debtorAccount.Owner, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMandate(gomock.Any(), gomock.Eq(mandate.ID)).Times(1).Return(mandate, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(creditorAccount.ID)).Times(1).Return(creditorAccount, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().CollectDirectDebitTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			if tc.fraudResult != nil {
+				engine, err := fraud.NewEngine(fraud.DefaultReviewScore, fraud.DefaultBlockScore, fraudStubRule{result: *tc.fraudResult})
+				require.NoError(t, err)
+				server.fraudEngine = engine
+			}
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/mandates/%d/direct_debits", mandate.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDisputeDirectDebitAPI(t *testing.T) {
+	debtor, _ := randomUser(t)
+	debtorAccount := randomAccount()
+	debtorAccount.Owner = debtor.Username
+	creditorAccount := randomAccount()
+	mandate := randomMandate(debtorAccount, creditorAccount)
+	directDebit := randomDirectDebit(mandate)
+
+	disputed := directDebit
+	disputed.Status = db.DirectDebitStatusDisputed
+	disputed.DisputeReason = "not authorized"
+	disputed.ReversalID = sql.NullInt64{Int64: directDebit.TransferID + 1, Valid: true}
+	disputed.DisputedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"reason": "not authorized"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, debtor.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.DisputeDirectDebitTxParams{
+					DirectDebitID: directDebit.ID,
+					Reason:        "not authorized",
+					DisputedBy:    debtor.Username,
+					Window:        time.Hour,
+				}
+
+				store.EXPECT().GetDirectDebit(gomock.Any(), gomock.Eq(directDebit.ID)).Times(1).Return(directDebit, nil)
+				store.EXPECT().GetMandate(gomock.Any(), gomock.Eq(mandate.ID)).Times(1).Return(mandate, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(debtorAccount.ID)).Times(1).Return(debtorAccount, nil)
+				store.EXPECT().DisputeDirectDebitTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.DisputeDirectDebitTxResult{DirectDebit: disputed}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got directDebitResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, db.DirectDebitStatusDisputed, got.Status)
+				require.Equal(t, "not authorized", got.DisputeReason)
+				require.NotNil(t, got.ReversalID)
+				require.Equal(t, disputed.ReversalID.Int64, *got.ReversalID)
+			},
+		},
+		{
+			name: "WindowClosed",
+			body: gin.H{"reason": "not authorized"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, debtor.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetDirectDebit(gomock.Any(), gomock.Eq(directDebit.ID)).Times(1).Return(directDebit, nil)
+				store.EXPECT().GetMandate(gomock.Any(), gomock.Eq(mandate.ID)).Times(1).Return(mandate, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(debtorAccount.ID)).Times(1).Return(debtorAccount, nil)
+				store.EXPECT().DisputeDirectDebitTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.DisputeDirectDebitTxResult{}, db.ErrDisputeWindowClosed)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			// creditors can't dispute the direct debits they collected
+			name: "Creditor",
+			body: gin.H{"reason": "not authorized"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, creditorAccount.Owner, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetDirectDebit(gomock.Any(), gomock.Eq(directDebit.ID)).Times(1).Return(directDebit, nil)
+				store.EXPECT().GetMandate(gomock.Any(), gomock.Eq(mandate.ID)).Times(1).Return(mandate, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(debtorAccount.ID)).Times(1).Return(debtorAccount, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().DisputeDirectDebitTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "MissingReason",
+			body: gin.H{},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, debtor.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetDirectDebit(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DisputeDirectDebitTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"reason": "not authorized"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, debtor.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetDirectDebit(gomock.Any(), gomock.Eq(directDebit.ID)).Times(1).Return(db.DirectDebit{}, sql.ErrNoRows)
+				store.EXPECT().DisputeDirectDebitTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/direct_debits/%d/dispute", directDebit.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,502 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/fraud"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+// maxEscrowDuration limits how far in the future the deadline of an escrow can be
+const maxEscrowDuration = 366 * 24 * time.Hour
+
+type escrowResponse struct {
+	ID                   int64      `json:"id"`
+	PayerAccountID       int64      `json:"payer_account_id"`
+	PayeeAccountID       int64      `json:"payee_account_id"`
+	Amount               int64      `json:"amount"`
+	Description          string     `json:"description"`
+	Status               string     `json:"status"`
+	Deadline             time.Time  `json:"deadline"`
+	CreatedBy            string     `json:"created_by"`
+	FundingTransferID    int64      `json:"funding_transfer_id"`
+	SettlementTransferID *int64     `json:"settlement_transfer_id,omitempty"`
+	DisputeReason        string     `json:"dispute_reason,omitempty"`
+	DisputedBy           string     `json:"disputed_by,omitempty"`
+	SettledBy            string     `json:"settled_by,omitempty"`
+	ResolutionNote       string     `json:"resolution_note,omitempty"`
+	CreatedAt            time.Time  `json:"created_at"`
+	DisputedAt           *time.Time `json:"disputed_at,omitempty"`
+	SettledAt            *time.Time `json:"settled_at,omitempty"`
+}
+
+func newEscrowResponse(escrow db.Escrow) escrowResponse {
+	rsp := escrowResponse{
+		ID:                escrow.ID,
+		PayerAccountID:    escrow.PayerAccountID,
+		PayeeAccountID:    escrow.PayeeAccountID,
+		Amount:            escrow.Amount,
+		Description:       escrow.Description,
+		Status:            escrow.Status,
+		Deadline:          escrow.Deadline,
+		CreatedBy:         escrow.CreatedBy,
+		FundingTransferID: escrow.FundingTransferID,
+		DisputeReason:     escrow.DisputeReason,
+		DisputedBy:        escrow.DisputedBy.String,
+		SettledBy:         escrow.SettledBy.String,
+		ResolutionNote:    escrow.ResolutionNote,
+		CreatedAt:         escrow.CreatedAt,
+	}
+	if escrow.SettlementTransferID.Valid {
+		rsp.SettlementTransferID = &escrow.SettlementTransferID.Int64
+	}
+	if escrow.DisputedAt.Valid {
+		rsp.DisputedAt = &escrow.DisputedAt.Time
+	}
+	if escrow.SettledAt.Valid {
+		rsp.SettledAt = &escrow.SettledAt.Time
+	}
+	return rsp
+}
+
+type createEscrowRequest struct {
+	PayerAccountID int64     `json:"payer_account_id" binding:"required,min=1"`
+	PayeeAccountID int64     `json:"payee_account_id" binding:"required,min=1"`
+	Amount         int64     `json:"amount" binding:"required,gt=0"`
+	Currency       string    `json:"currency" binding:"required,currency"`
+	Deadline       time.Time `json:"deadline" binding:"required"`
+	Description    string    `json:"description" binding:"max=140"`
+}
+
+// createEscrow moves an amount from the payer account to a new escrow account, where it is held
+// until the payer releases it, an arbiter resolves a dispute about it, or it is refunded at its deadline
+func (server *Server) createEscrow(ctx *gin.Context) {
+	var req createEscrowRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.PayerAccountID == req.PayeeAccountID {
+		err := errors.New("payer_account_id and payee_account_id cannot be the same")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !req.Deadline.After(time.Now()) {
+		err := errors.New("deadline must be in the future")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if time.Until(req.Deadline) > maxEscrowDuration {
+		err := fmt.Errorf("deadline can't be more than %d days away", maxEscrowDuration/(24*time.Hour))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	payerAccount, valid := server.validAccount(ctx, req.PayerAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	if !server.authorizeAccount(ctx, payerAccount, db.AccountPermissionTransfer, req.Amount) {
+		return
+	}
+
+	// the escrow would move money out of the account without the approvals of the organization
+	if payerAccount.OrganizationID.Valid {
+		err := errors.New("escrows can't be funded from organization accounts")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, valid = server.validAccount(ctx, req.PayeeAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	// releasing the escrow pays the payee without any further check, so it has to pass them when it is funded
+	if !server.savedBeneficiaryCoolingOff(ctx, req.PayeeAccountID, req.Amount) {
+		return
+	}
+
+	if server.bankReviewRequired(req.Amount) {
+		err := errors.New("escrow needs a banker's approval, pay the amount as a single transfer")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.sufficientFunds(ctx, payerAccount, req.Amount) {
+		return
+	}
+
+	if server.fraudEngine != nil {
+		decision, valid := server.assessTransfer(ctx, transferRequest{
+			FromAccountID: payerAccount.ID,
+			ToAccountID:   req.PayeeAccountID,
+			Amount:        req.Amount,
+			Currency:      req.Currency,
+		})
+		if !valid {
+			return
+		}
+
+		if decision == fraud.Review {
+			err := errors.New("escrow needs a review, pay the amount as a single transfer")
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	result, err := server.store.CreateEscrowTx(ctx, db.CreateEscrowTxParams{
+		PayerAccountID: payerAccount.ID,
+		PayeeAccountID: req.PayeeAccountID,
+		Amount:         req.Amount,
+		Description:    req.Description,
+		Deadline:       req.Deadline,
+		CreatedBy:      authPayload.Username,
+	})
+	if err != nil {
+		// the balance may have changed since it was checked
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newEscrowResponse(result.Escrow))
+}
+
+type listEscrowsURI struct {
+	AccountID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type listEscrowsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listEscrows returns the escrows an account pays into or is paid from, latest first
+func (server *Server) listEscrows(ctx *gin.Context) {
+	var uriReq listEscrowsURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req listEscrowsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, uriReq.AccountID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	escrows, err := server.store.ListEscrows(ctx, db.ListEscrowsParams{
+		PayerAccountID: account.ID,
+		PayeeAccountID: account.ID,
+		Limit:          req.PageSize,
+		Offset:         (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newEscrowResponses(escrows))
+}
+
+type listEscrowDisputesRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listEscrowDisputes returns the disputed escrows waiting for an arbiter, oldest dispute first
+func (server *Server) listEscrowDisputes(ctx *gin.Context) {
+	var req listEscrowDisputesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, valid := server.bankerUser(ctx); !valid {
+		return
+	}
+
+	escrows, err := server.store.ListDisputedEscrows(ctx, db.ListDisputedEscrowsParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newEscrowResponses(escrows))
+}
+
+func newEscrowResponses(escrows []db.Escrow) []escrowResponse {
+	rsp := make([]escrowResponse, 0, len(escrows))
+	for _, escrow := range escrows {
+		rsp = append(rsp, newEscrowResponse(escrow))
+	}
+	return rsp
+}
+
+type escrowURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type getEscrowRequest struct {
+	// AccountID is the payer or the payee account of the escrow, which the user needs to view.
+	// Bankers can leave it out.
+	AccountID int64 `form:"account_id" binding:"omitempty,min=1"`
+}
+
+// getEscrow returns an escrow to either party, or to a banker
+func (server *Server) getEscrow(ctx *gin.Context) {
+	var uriReq escrowURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req getEscrowRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	escrow, valid := server.validEscrow(ctx, uriReq.ID)
+	if !valid {
+		return
+	}
+
+	if req.AccountID == 0 && authPayload.Role != util.BankerRole {
+		err := errors.New("account_id is required")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.AccountID != 0 && !server.authorizeEscrowParty(ctx, escrow, req.AccountID, db.AccountPermissionView) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newEscrowResponse(escrow))
+}
+
+// releaseEscrow pays an escrow out to the payee, on behalf of the payer
+func (server *Server) releaseEscrow(ctx *gin.Context) {
+	var uriReq escrowURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	escrow, valid := server.validEscrow(ctx, uriReq.ID)
+	if !valid {
+		return
+	}
+
+	payerAccount, err := server.store.GetAccount(ctx, escrow.PayerAccountID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeAccount(ctx, payerAccount, db.AccountPermissionTransfer, escrow.Amount) {
+		return
+	}
+	setAuditBefore(ctx, newEscrowResponse(escrow))
+
+	result, err := server.store.ReleaseEscrowTx(ctx, db.ReleaseEscrowTxParams{
+		EscrowID:   escrow.ID,
+		ReleasedBy: authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrEscrowNotHeld) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newEscrowResponse(result.Escrow))
+}
+
+type disputeEscrowRequest struct {
+	// AccountID is the payer or the payee account of the escrow the user disputes it for
+	AccountID int64  `json:"account_id" binding:"required,min=1"`
+	Reason    string `json:"reason" binding:"required,max=140"`
+}
+
+// disputeEscrow stops an escrow from being released or refunded until an arbiter resolves the dispute.
+// Either party can dispute an escrow that is still held, before its deadline.
+func (server *Server) disputeEscrow(ctx *gin.Context) {
+	var uriReq escrowURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req disputeEscrowRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	escrow, valid := server.validEscrow(ctx, uriReq.ID)
+	if !valid {
+		return
+	}
+
+	if !server.authorizeEscrowParty(ctx, escrow, req.AccountID, db.AccountPermissionTransfer) {
+		return
+	}
+
+	// past its deadline, the escrow is owed back to the payer
+	if !time.Now().Before(escrow.Deadline) {
+		err := fmt.Errorf("escrow [%d] can't be disputed after its deadline", escrow.ID)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	setAuditBefore(ctx, newEscrowResponse(escrow))
+
+	escrow, err = server.store.DisputeEscrow(ctx, db.DisputeEscrowParams{
+		ID:            escrow.ID,
+		DisputeReason: req.Reason,
+		DisputedBy: sql.NullString{
+			String: authPayload.Username,
+			Valid:  true,
+		},
+	})
+	if err != nil {
+		// the escrow was already disputed or settled
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusBadRequest, errorResponse(db.ErrEscrowNotHeld))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newEscrowResponse(escrow))
+}
+
+type resolveEscrowRequest struct {
+	// Outcome is release to pay the payee, or refund to pay the payer back
+	Outcome string `json:"outcome" binding:"required,oneof=release refund"`
+	Note    string `json:"note" binding:"max=140"`
+}
+
+// resolveEscrow settles a disputed escrow as the arbiter decided
+func (server *Server) resolveEscrow(ctx *gin.Context) {
+	var uriReq escrowURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req resolveEscrowRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, valid := server.bankerUser(ctx)
+	if !valid {
+		return
+	}
+
+	escrow, valid := server.validEscrow(ctx, uriReq.ID)
+	if !valid {
+		return
+	}
+	setAuditBefore(ctx, newEscrowResponse(escrow))
+
+	status := db.EscrowStatusReleased
+	if req.Outcome == "refund" {
+		status = db.EscrowStatusRefunded
+	}
+
+	result, err := server.store.ResolveEscrowTx(ctx, db.ResolveEscrowTxParams{
+		EscrowID:   escrow.ID,
+		Status:     status,
+		Note:       req.Note,
+		ResolvedBy: authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrEscrowNotDisputed) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newEscrowResponse(result.Escrow))
+}
+
+func (server *Server) validEscrow(ctx *gin.Context, id int64) (db.Escrow, bool) {
+	escrow, err := server.store.GetEscrow(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return escrow, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return escrow, false
+	}
+
+	return escrow, true
+}
+
+// authorizeEscrowParty checks that the account is the payer or the payee account of the escrow,
+// and that the authenticated user can act on it with the permission
+func (server *Server) authorizeEscrowParty(ctx *gin.Context, escrow db.Escrow, accountID int64, permission string) bool {
+	if accountID != escrow.PayerAccountID && accountID != escrow.PayeeAccountID {
+		err := fmt.Errorf("account [%d] is not a party to escrow [%d]", accountID, escrow.ID)
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return false
+	}
+
+	_, valid := server.authorizedAccount(ctx, accountID, permission)
+	return valid
+}
@@ -0,0 +1,797 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/volskyi-dmytro/st-bank/db/mock"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/fraud"
+	"github.com/volskyi-dmytro/st-bank/token"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+func randomEscrow(payerAccount db.Account, payeeAccount db.Account) db.Escrow {
+	return db.Escrow{
+		ID:                util.RandomInt(1, 1000),
+		AccountID:         util.RandomInt(1001, 2000),
+		PayerAccountID:    payerAccount.ID,
+		PayeeAccountID:    payeeAccount.ID,
+		Amount:            util.RandomInt(1, 50),
+		Description:       "second-hand bike",
+		Status:            db.EscrowStatusHeld,
+		Deadline:          time.Now().Add(72 * time.Hour),
+		CreatedBy:         payerAccount.Owner,
+		FundingTransferID: util.RandomInt(1, 1000),
+		CreatedAt:         time.Now(),
+	}
+}
+
+func TestCreateEscrowAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	payerAccount := randomAccount()
+	payerAccount.Owner = user.Username
+	payerAccount.Currency = "EUR"
+	payerAccount.Balance = 1000
+	payeeAccount := randomAccount()
+	payeeAccount.ID = payerAccount.ID + 1
+	payeeAccount.Currency = "EUR"
+	escrow := randomEscrow(payerAccount, payeeAccount)
+	deadline := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second)
+
+	body := gin.H{
+		"payer_account_id": payerAccount.ID,
+		"payee_account_id": payeeAccount.ID,
+		"amount":           escrow.Amount,
+		"currency":         "EUR",
+		"deadline":         deadline,
+		"description":      escrow.Description,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		fraudResult   *fraud.Result
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateEscrowTxParams{
+					PayerAccountID: payerAccount.ID,
+					PayeeAccountID: payeeAccount.ID,
+					Amount:         escrow.Amount,
+					Description:    escrow.Description,
+					Deadline:       deadline,
+					CreatedBy:      user.Username,
+				}
+				result := db.CreateEscrowTxResult{
+					Escrow: escrow,
+					Transfer: db.TransferTxResult{
+						FromAccount: payerAccount,
+					},
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payerAccount.ID)).Times(1).Return(payerAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payeeAccount.ID)).Times(1).Return(payeeAccount, nil)
+				store.EXPECT().CreateEscrowTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				// the escrow is shared with the payee, who doesn't see the balance of the payer
+				require.NotContains(t, recorder.Body.String(), "balance")
+
+				var got escrowResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, escrow.ID, got.ID)
+				require.Equal(t, escrow.FundingTransferID, got.FundingTransferID)
+				require.Equal(t, db.EscrowStatusHeld, got.Status)
+				require.Nil(t, got.SettlementTransferID)
+			},
+		},
+		{
+			name: "SameAccounts",
+			body: gin.H{
+				"payer_account_id": payerAccount.ID,
+				"payee_account_id": payerAccount.ID,
+				"amount":           escrow.Amount,
+				"currency":         "EUR",
+				"deadline":         deadline,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateEscrowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DeadlineInThePast",
+			body: gin.H{
+				"payer_account_id": payerAccount.ID,
+				"payee_account_id": payeeAccount.ID,
+				"amount":           escrow.Amount,
+				"currency":         "EUR",
+				"deadline":         time.Now().Add(-time.Hour),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateEscrowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DeadlineTooFar",
+			body: gin.H{
+				"payer_account_id": payerAccount.ID,
+				"payee_account_id": payeeAccount.ID,
+				"amount":           escrow.Amount,
+				"currency":         "EUR",
+				"deadline":         time.Now().AddDate(2, 0, 0),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateEscrowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "SavedBeneficiaryCoolingOffLimit",
+			body: gin.H{
+				"payer_account_id": payerAccount.ID,
+				"payee_account_id": payeeAccount.ID,
+				"amount":           101, // above the cooling-off limit
+				"currency":         "EUR",
+				"deadline":         deadline,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				beneficiary := randomBeneficiary(user.Username, payeeAccount)
+				beneficiary.CreatedAt = time.Now()
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payerAccount.ID)).Times(1).Return(payerAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payeeAccount.ID)).Times(1).Return(payeeAccount, nil)
+				store.EXPECT().GetBeneficiaryByAccount(gomock.Any(), gomock.Eq(db.GetBeneficiaryByAccountParams{
+					Owner:     user.Username,
+					AccountID: payeeAccount.ID,
+				})).Times(1).Return(beneficiary, nil)
+				store.EXPECT().CreateEscrowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "cooling-off")
+			},
+		},
+		{
+			name: "NeedsApproval",
+			body: gin.H{
+				"payer_account_id": payerAccount.ID,
+				"payee_account_id": payeeAccount.ID,
+				"amount":           51,
+				"currency":         "EUR",
+				"deadline":         deadline,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payerAccount.ID)).Times(1).Return(payerAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payeeAccount.ID)).Times(1).Return(payeeAccount, nil)
+				store.EXPECT().CreateEscrowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "needs a banker's approval")
+			},
+		},
+		{
+			name:        "FraudReview",
+			body:        body,
+			fraudResult: &fraud.Result{Score: fraud.NewRecipientScore, ReasonCode: fraud.ReasonNewRecipientLargeAmount},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payerAccount.ID)).Times(1).Return(payerAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payeeAccount.ID)).Times(1).Return(payeeAccount, nil)
+				store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Any()).Times(1).Return(db.FraudDecision{}, nil)
+				store.EXPECT().CreateEscrowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "needs a review")
+			},
+		},
+		{
+			name: "NotPayer",
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, payeeAccount.Owner, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payerAccount.ID)).Times(1).Return(payerAccount, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().CreateEscrowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			// money can't be sent to the escrow account of another escrow
+			name: "PayeeEscrowAccount",
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				escrowAccount := payeeAccount
+				escrowAccount.Owner = db.SystemUsername
+				escrowAccount.Type = db.AccountTypeEscrow
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payerAccount.ID)).Times(1).Return(payerAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payeeAccount.ID)).Times(1).Return(escrowAccount, nil)
+				store.EXPECT().CreateEscrowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InsufficientFunds",
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payerAccount.ID)).Times(1).Return(payerAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payeeAccount.ID)).Times(1).Return(payeeAccount, nil)
+				store.EXPECT().CreateEscrowTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.CreateEscrowTxResult{}, db.ErrInsufficientFunds)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			// the payee isn't a saved beneficiary of the user unless the case says so
+			store.EXPECT().GetBeneficiaryByAccount(gomock.Any(), gomock.Any()).AnyTimes().Return(db.Beneficiary{}, sql.ErrNoRows)
+
+			server := newTestServer(t, store)
+			if tc.fraudResult != nil {
+				engine, err := fraud.NewEngine(fraud.DefaultReviewScore, fraud.DefaultBlockScore, fraudStubRule{result: *tc.fraudResult})
+				require.NoError(t, err)
+				server.fraudEngine = engine
+			}
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/escrows", bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetEscrowAPI(t *testing.T) {
+	payerAccount := randomAccount()
+	payeeAccount := randomAccount()
+	payeeAccount.ID = payerAccount.ID + 1
+	otherAccount := randomAccount()
+	otherAccount.ID = payeeAccount.ID + 1
+	escrow := randomEscrow(payerAccount, payeeAccount)
+
+	testCases := []struct {
+		name          string
+		accountID     int64
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "Payee",
+			accountID: payeeAccount.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, payeeAccount.Owner, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEscrow(gomock.Any(), gomock.Eq(escrow.ID)).Times(1).Return(escrow, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payeeAccount.ID)).Times(1).Return(payeeAccount, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got escrowResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, escrow.ID, got.ID)
+			},
+		},
+		{
+			name: "Banker",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "arbiter", util.BankerRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEscrow(gomock.Any(), gomock.Eq(escrow.ID)).Times(1).Return(escrow, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "MissingAccount",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, payeeAccount.Owner, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEscrow(gomock.Any(), gomock.Eq(escrow.ID)).Times(1).Return(escrow, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "NotParty",
+			accountID: otherAccount.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherAccount.Owner, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEscrow(gomock.Any(), gomock.Eq(escrow.ID)).Times(1).Return(escrow, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "NotFound",
+			accountID: payeeAccount.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, payeeAccount.Owner, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEscrow(gomock.Any(), gomock.Eq(escrow.ID)).Times(1).Return(db.Escrow{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/escrows/%d", escrow.ID)
+			if tc.accountID != 0 {
+				url = fmt.Sprintf("%s?account_id=%d", url, tc.accountID)
+			}
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestReleaseEscrowAPI(t *testing.T) {
+	payerAccount := randomAccount()
+	payeeAccount := randomAccount()
+	payeeAccount.ID = payerAccount.ID + 1
+	escrow := randomEscrow(payerAccount, payeeAccount)
+
+	released := escrow
+	released.Status = db.EscrowStatusReleased
+	released.SettlementTransferID = sql.NullInt64{Int64: util.RandomInt(1001, 2000), Valid: true}
+	released.SettledBy = sql.NullString{String: payerAccount.Owner, Valid: true}
+	released.SettledAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, payerAccount.Owner, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ReleaseEscrowTxParams{
+					EscrowID:   escrow.ID,
+					ReleasedBy: payerAccount.Owner,
+				}
+
+				store.EXPECT().GetEscrow(gomock.Any(), gomock.Eq(escrow.ID)).Times(1).Return(escrow, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payerAccount.ID)).Times(1).Return(payerAccount, nil)
+				store.EXPECT().ReleaseEscrowTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.EscrowTxResult{Escrow: released}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got escrowResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, db.EscrowStatusReleased, got.Status)
+				require.Equal(t, released.SettlementTransferID.Int64, *got.SettlementTransferID)
+				require.Equal(t, payerAccount.Owner, got.SettledBy)
+			},
+		},
+		{
+			// only the payer can release the escrow, the payee disputes it instead
+			name: "Payee",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, payeeAccount.Owner, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEscrow(gomock.Any(), gomock.Eq(escrow.ID)).Times(1).Return(escrow, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payerAccount.ID)).Times(1).Return(payerAccount, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().ReleaseEscrowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Disputed",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, payerAccount.Owner, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEscrow(gomock.Any(), gomock.Eq(escrow.ID)).Times(1).Return(escrow, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payerAccount.ID)).Times(1).Return(payerAccount, nil)
+				store.EXPECT().ReleaseEscrowTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.EscrowTxResult{}, db.ErrEscrowNotHeld)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/escrows/%d/release", escrow.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDisputeEscrowAPI(t *testing.T) {
+	payerAccount := randomAccount()
+	payeeAccount := randomAccount()
+	payeeAccount.ID = payerAccount.ID + 1
+	otherAccount := randomAccount()
+	otherAccount.ID = payeeAccount.ID + 1
+	escrow := randomEscrow(payerAccount, payeeAccount)
+
+	expired := escrow
+	expired.Deadline = time.Now().Add(-time.Minute)
+
+	disputed := escrow
+	disputed.Status = db.EscrowStatusDisputed
+	disputed.DisputeReason = "bike never delivered"
+	disputed.DisputedBy = sql.NullString{String: payeeAccount.Owner, Valid: true}
+	disputed.DisputedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"account_id": payeeAccount.ID, "reason": disputed.DisputeReason},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, payeeAccount.Owner, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.DisputeEscrowParams{
+					ID:            escrow.ID,
+					DisputeReason: disputed.DisputeReason,
+					DisputedBy:    sql.NullString{String: payeeAccount.Owner, Valid: true},
+				}
+
+				store.EXPECT().GetEscrow(gomock.Any(), gomock.Eq(escrow.ID)).Times(1).Return(escrow, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payeeAccount.ID)).Times(1).Return(payeeAccount, nil)
+				store.EXPECT().DisputeEscrow(gomock.Any(), gomock.Eq(arg)).Times(1).Return(disputed, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got escrowResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, db.EscrowStatusDisputed, got.Status)
+				require.Equal(t, disputed.DisputeReason, got.DisputeReason)
+				require.Equal(t, payeeAccount.Owner, got.DisputedBy)
+			},
+		},
+		{
+			name: "NotParty",
+			body: gin.H{"account_id": otherAccount.ID, "reason": "not mine"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherAccount.Owner, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEscrow(gomock.Any(), gomock.Eq(escrow.ID)).Times(1).Return(escrow, nil)
+				store.EXPECT().DisputeEscrow(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "AfterDeadline",
+			body: gin.H{"account_id": payeeAccount.ID, "reason": disputed.DisputeReason},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, payeeAccount.Owner, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEscrow(gomock.Any(), gomock.Eq(escrow.ID)).Times(1).Return(expired, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payeeAccount.ID)).Times(1).Return(payeeAccount, nil)
+				store.EXPECT().DisputeEscrow(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadySettled",
+			body: gin.H{"account_id": payerAccount.ID, "reason": "changed my mind"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, payerAccount.Owner, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEscrow(gomock.Any(), gomock.Eq(escrow.ID)).Times(1).Return(escrow, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payerAccount.ID)).Times(1).Return(payerAccount, nil)
+				store.EXPECT().DisputeEscrow(gomock.Any(), gomock.Any()).Times(1).Return(db.Escrow{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "MissingReason",
+			body: gin.H{"account_id": payeeAccount.ID},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, payeeAccount.Owner, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEscrow(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/escrows/%d/dispute", escrow.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestResolveEscrowAPI(t *testing.T) {
+	payerAccount := randomAccount()
+	payeeAccount := randomAccount()
+	payeeAccount.ID = payerAccount.ID + 1
+	escrow := randomEscrow(payerAccount, payeeAccount)
+	escrow.Status = db.EscrowStatusDisputed
+
+	refunded := escrow
+	refunded.Status = db.EscrowStatusRefunded
+	refunded.SettlementTransferID = sql.NullInt64{Int64: util.RandomInt(1001, 2000), Valid: true}
+	refunded.SettledBy = sql.NullString{String: "arbiter", Valid: true}
+	refunded.ResolutionNote = "no proof of delivery"
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"outcome": "refund", "note": refunded.ResolutionNote},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "arbiter", util.BankerRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ResolveEscrowTxParams{
+					EscrowID:   escrow.ID,
+					Status:     db.EscrowStatusRefunded,
+					Note:       refunded.ResolutionNote,
+					ResolvedBy: "arbiter",
+				}
+
+				store.EXPECT().GetEscrow(gomock.Any(), gomock.Eq(escrow.ID)).Times(1).Return(escrow, nil)
+				store.EXPECT().ResolveEscrowTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.EscrowTxResult{Escrow: refunded}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got escrowResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, db.EscrowStatusRefunded, got.Status)
+				require.Equal(t, "arbiter", got.SettledBy)
+				require.Equal(t, refunded.ResolutionNote, got.ResolutionNote)
+			},
+		},
+		{
+			name: "NotBanker",
+			body: gin.H{"outcome": "release"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, payeeAccount.Owner, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEscrow(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ResolveEscrowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotDisputed",
+			body: gin.H{"outcome": "release"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "arbiter", util.BankerRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEscrow(gomock.Any(), gomock.Eq(escrow.ID)).Times(1).Return(escrow, nil)
+				store.EXPECT().ResolveEscrowTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.EscrowTxResult{}, db.ErrEscrowNotDisputed)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidOutcome",
+			body: gin.H{"outcome": "split"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "arbiter", util.BankerRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ResolveEscrowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/escrows/%d/resolve", escrow.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/fraud"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+const (
+	fraudDeviationMultiplier  = 10
+	fraudDeviationMinHistory  = 5
+	fraudPasswordChangeWindow = 24 * time.Hour
+)
+
+// newFraudEngine creates the fraud engine with the built-in rules
+func newFraudEngine(config util.Config, store db.Store) (*fraud.Engine, error) {
+	// a zero limit or amount would flag every single transfer
+	if config.FraudVelocityLimit <= 0 || config.FraudVelocityWindow <= 0 {
+		return nil, fmt.Errorf("invalid fraud velocity limit: %d transfers within %s",
+			config.FraudVelocityLimit, config.FraudVelocityWindow)
+	}
+	if config.FraudLargeAmount <= 0 {
+		return nil, fmt.Errorf("invalid fraud large amount: %d", config.FraudLargeAmount)
+	}
+
+	return fraud.NewEngine(
+		fraud.DefaultReviewScore,
+		fraud.DefaultBlockScore,
+		fraud.NewVelocityRule(store, config.FraudVelocityLimit, config.FraudVelocityWindow),
+		fraud.NewNewRecipientRule(store, config.FraudLargeAmount),
+		fraud.NewDeviationRule(store, fraudDeviationMultiplier, fraudDeviationMinHistory),
+		fraud.NewPasswordChangeRule(store, fraudPasswordChangeWindow),
+	)
+}
+
+// assessTransfer runs the fraud checks on a transfer request and records the decision.
+// Blocked transfers are answered with 403 and the reason code of the main rule that was triggered.
+func (server *Server) assessTransfer(ctx *gin.Context, req transferRequest) (fraud.Decision, bool) {
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return "", false
+	}
+
+	assessment, err := server.fraudEngine.Assess(ctx, fraud.Transfer{
+		Username:      authPayload.Username,
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        req.Amount,
+		Currency:      req.Currency,
+		CreatedAt:     time.Now(),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return "", false
+	}
+
+	arg := db.CreateFraudDecisionParams{
+		Username:      authPayload.Username,
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        req.Amount,
+		Currency:      req.Currency,
+		Score:         int32(assessment.Score),
+		Decision:      string(assessment.Decision),
+		ReasonCodes:   assessment.ReasonCodes,
+	}
+
+	_, err = server.store.CreateFraudDecision(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return "", false
+	}
+
+	if assessment.Decision == fraud.Block {
+		ctx.JSON(http.StatusForbidden, gin.H{
+			"error":       "transfer blocked by fraud checks",
+			"reason_code": assessment.ReasonCode(),
+		})
+		return assessment.Decision, false
+	}
+
+	return assessment.Decision, true
+}
@@ -0,0 +1,236 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/volskyi-dmytro/st-bank/db/mock"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/fraud"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+type fraudStubRule struct {
+	result fraud.Result
+}
+
+func (rule fraudStubRule) Evaluate(ctx context.Context, transfer fraud.Transfer) (fraud.Result, error) {
+	return rule.result, nil
+}
+
+func TestTransferFraudAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	amount := int64(10)
+
+	account1 := randomAccount()
+	account1.Owner = user.Username
+	account1.Currency = "USD"
+	account1.Balance = 100
+	account2 := randomAccount()
+	account2.Currency = "USD"
+
+	body := gin.H{
+		"from_account_id": account1.ID,
+		"to_account_id":   account2.ID,
+		"amount":          amount,
+		"currency":        "USD",
+	}
+
+	testCases := []struct {
+		name          string
+		result        fraud.Result
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Allow",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateFraudDecisionParams{
+					Username:      user.Username,
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        amount,
+					Currency:      "USD",
+					Score:         0,
+					Decision:      string(fraud.Allow),
+					ReasonCodes:   []string{},
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.FraudDecision{}, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Review",
+			result: fraud.Result{Score: fraud.NewRecipientScore, ReasonCode: fraud.ReasonNewRecipientLargeAmount},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateTransferApprovalParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        amount,
+					Currency:      "USD",
+					Metadata:      json.RawMessage("{}"),
+					Initiator:     user.Username,
+					Status:        db.TransferApprovalStatusPending,
+					FraudReview:   true,
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Any()).Times(1).Return(db.FraudDecision{}, nil)
+				store.EXPECT().
+					CreateTransferApproval(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.TransferApproval{Status: db.TransferApprovalStatusPending}, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+			},
+		},
+		{
+			name:   "Block",
+			result: fraud.Result{Score: fraud.VelocityScore, ReasonCode: fraud.ReasonVelocity},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateFraudDecisionParams{
+					Username:      user.Username,
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        amount,
+					Currency:      "USD",
+					Score:         fraud.VelocityScore,
+					Decision:      string(fraud.Block),
+					ReasonCodes:   []string{fraud.ReasonVelocity},
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.FraudDecision{}, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+
+				var rsp gin.H
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, fraud.ReasonVelocity, rsp["reason_code"])
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Any()).Times(1).Return(db.FraudDecision{}, sql.ErrConnDone)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			// the destination isn't a saved beneficiary of the user unless the case says so
+			store.EXPECT().GetBeneficiaryByAccount(gomock.Any(), gomock.Any()).AnyTimes().Return(db.Beneficiary{}, sql.ErrNoRows)
+
+			server := newTestServer(t, store)
+			engine, err := fraud.NewEngine(fraud.DefaultReviewScore, fraud.DefaultBlockScore, fraudStubRule{result: tc.result})
+			require.NoError(t, err)
+			server.fraudEngine = engine
+
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestNewFraudEngine(t *testing.T) {
+	valid := util.Config{
+		FraudVelocityLimit:  5,
+		FraudVelocityWindow: 10 * time.Minute,
+		FraudLargeAmount:    100000,
+	}
+
+	testCases := []struct {
+		name    string
+		update  func(config *util.Config)
+		wantErr bool
+	}{
+		{
+			name:   "OK",
+			update: func(config *util.Config) {},
+		},
+		{
+			name:    "NoVelocityLimit",
+			update:  func(config *util.Config) { config.FraudVelocityLimit = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "NoVelocityWindow",
+			update:  func(config *util.Config) { config.FraudVelocityWindow = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "NegativeLargeAmount",
+			update:  func(config *util.Config) { config.FraudLargeAmount = -1 },
+			wantErr: true,
+		},
+		{
+			name:    "NoLargeAmount",
+			update:  func(config *util.Config) { config.FraudLargeAmount = 0 },
+			wantErr: true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			config := valid
+			tc.update(&config)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			_, err := newFraudEngine(config, mockdb.NewMockStore(ctrl))
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/interest"
+)
+
+type createInterestPlanRequest struct {
+	Name string `json:"name" binding:"required,max=64"`
+	// AnnualRateBps is the annual interest rate in basis points, 250 is 2.5%
+	AnnualRateBps int32  `json:"annual_rate_bps" binding:"min=0,max=10000"`
+	DayCount      string `json:"day_count" binding:"omitempty,oneof=ACT/365 ACT/360 30/360"`
+}
+
+// createInterestPlan adds an interest plan savings accounts can be opened on
+func (server *Server) createInterestPlan(ctx *gin.Context) {
+	var req createInterestPlanRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, valid := server.bankerUser(ctx); !valid {
+		return
+	}
+
+	arg := db.CreateInterestPlanParams{
+		Name:          req.Name,
+		AnnualRateBps: req.AnnualRateBps,
+		DayCount:      req.DayCount,
+	}
+	if arg.DayCount == "" {
+		arg.DayCount = string(interest.Actual365)
+	}
+
+	plan, err := server.store.CreateInterestPlan(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, plan)
+}
+
+type listInterestPlansRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listInterestPlans returns the interest plans savings accounts can be opened on
+func (server *Server) listInterestPlans(ctx *gin.Context) {
+	var req listInterestPlansRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListInterestPlansParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	plans, err := server.store.ListInterestPlans(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, plans)
+}
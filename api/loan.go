@@ -0,0 +1,223 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/loans"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+type createLoanRequest struct {
+	// AccountID is the checking account the loan is disbursed to and repaid from
+	AccountID int64 `json:"account_id" binding:"required,min=1"`
+	// Principal is the amount lent, in cents
+	Principal int64 `json:"principal" binding:"required,gt=0"`
+	// AnnualRateBps is the annual interest rate in basis points, 250 is 2.5%
+	AnnualRateBps int32  `json:"annual_rate_bps" binding:"min=0,max=10000"`
+	TermMonths    int32  `json:"term_months" binding:"required,min=1,max=360"`
+	Method        string `json:"method" binding:"required,oneof=annuity linear"`
+}
+
+// createLoan lends money to the owner of a checking account, repaid in monthly installments from the same account
+func (server *Server) createLoan(ctx *gin.Context) {
+	var req createLoanRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, valid := server.bankerUser(ctx)
+	if !valid {
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, req.AccountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if account.Type != db.AccountTypeChecking {
+		err := fmt.Errorf("account [%d] is a %s account, loans are repaid from checking accounts", account.ID, account.Type)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	schedule, err := loans.Schedule(req.Principal, req.AnnualRateBps, req.TermMonths, loans.Method(req.Method), time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.OriginateLoanTxParams{
+		RepaymentAccountID: account.ID,
+		Principal:          req.Principal,
+		AnnualRateBps:      req.AnnualRateBps,
+		TermMonths:         req.TermMonths,
+		Method:             req.Method,
+		OriginatedBy:       authPayload.Username,
+		Installments:       make([]db.LoanInstallmentParams, 0, len(schedule)),
+	}
+	for _, installment := range schedule {
+		arg.Installments = append(arg.Installments, db.LoanInstallmentParams{
+			DueDate:   installment.DueDate,
+			Principal: installment.Principal,
+			Interest:  installment.Interest,
+		})
+	}
+
+	result, err := server.store.OriginateLoanTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+type listLoansRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listLoans returns the loans of the authenticated user
+func (server *Server) listLoans(ctx *gin.Context) {
+	var req listLoansRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	userLoans, err := server.store.ListLoans(ctx, db.ListLoansParams{
+		Owner:      authPayload.Username,
+		PageLimit:  req.PageSize,
+		PageOffset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, userLoans)
+}
+
+type loanURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type loanResponse struct {
+	db.Loan
+	Installments []db.LoanInstallment `json:"installments"`
+	// Outstanding is the principal left to repay, in cents
+	Outstanding int64 `json:"outstanding"`
+	// Arrears is the principal and interest of the installments that couldn't be collected, in cents
+	Arrears int64 `json:"arrears"`
+}
+
+// getLoan returns a loan with its amortization schedule, to its borrower or a banker
+func (server *Server) getLoan(ctx *gin.Context) {
+	var req loanURI
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	loan, valid := server.borrowerLoan(ctx, req.ID, db.AccountPermissionView, true)
+	if !valid {
+		return
+	}
+
+	installments, err := server.store.ListLoanInstallments(ctx, loan.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := loanResponse{
+		Loan:         loan,
+		Installments: installments,
+	}
+	for _, installment := range installments {
+		rsp.Outstanding += installment.Principal - installment.PaidPrincipal
+		if installment.Status == db.LoanInstallmentStatusArrears {
+			rsp.Arrears += installment.Principal - installment.PaidPrincipal + installment.Interest - installment.PaidInterest
+		}
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// repayLoan repays the rest of a loan early from its repayment account, paying interest only up to today
+func (server *Server) repayLoan(ctx *gin.Context) {
+	var req loanURI
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	loan, valid := server.borrowerLoan(ctx, req.ID, db.AccountPermissionManage, false)
+	if !valid {
+		return
+	}
+
+	result, err := server.store.RepayLoanTx(ctx, db.RepayLoanTxParams{
+		LoanID: loan.ID,
+		AsOf:   time.Now(),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) || errors.Is(err, db.ErrLoanNotActive) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// borrowerLoan returns a loan whose repayment account the authenticated user can act on with the permission,
+// or anyone's loan when bankers are allowed
+func (server *Server) borrowerLoan(ctx *gin.Context, loanID int64, permission string, allowBanker bool) (db.Loan, bool) {
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return db.Loan{}, false
+	}
+
+	loan, err := server.store.GetLoan(ctx, loanID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return loan, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return loan, false
+	}
+
+	if allowBanker && authPayload.Role == util.BankerRole {
+		return loan, true
+	}
+
+	if _, valid := server.authorizedAccount(ctx, loan.AccountID, permission); !valid {
+		return loan, false
+	}
+
+	return loan, true
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+type mandateResponse struct {
+	ID                int64      `json:"id"`
+	DebtorAccountID   int64      `json:"debtor_account_id"`
+	CreditorAccountID int64      `json:"creditor_account_id"`
+	MaxAmount         int64      `json:"max_amount"`
+	Frequency         string     `json:"frequency"`
+	Reference         string     `json:"reference"`
+	Status            string     `json:"status"`
+	GrantedBy         string     `json:"granted_by"`
+	CreatedAt         time.Time  `json:"created_at"`
+	RevokedAt         *time.Time `json:"revoked_at,omitempty"`
+}
+
+func newMandateResponse(mandate db.Mandate) mandateResponse {
+	rsp := mandateResponse{
+		ID:                mandate.ID,
+		DebtorAccountID:   mandate.DebtorAccountID,
+		CreditorAccountID: mandate.CreditorAccountID,
+		MaxAmount:         mandate.MaxAmount,
+		Frequency:         mandate.Frequency,
+		Reference:         mandate.Reference,
+		Status:            mandate.Status,
+		GrantedBy:         mandate.GrantedBy,
+		CreatedAt:         mandate.CreatedAt,
+	}
+	if mandate.RevokedAt.Valid {
+		rsp.RevokedAt = &mandate.RevokedAt.Time
+	}
+	return rsp
+}
+
+type createMandateRequest struct {
+	DebtorAccountID   int64  `json:"debtor_account_id" binding:"required,min=1"`
+	CreditorAccountID int64  `json:"creditor_account_id" binding:"required,min=1"`
+	MaxAmount         int64  `json:"max_amount" binding:"required,gt=0"`
+	Currency          string `json:"currency" binding:"required,currency"`
+	Frequency         string `json:"frequency" binding:"required,oneof=daily weekly monthly yearly"`
+	Reference         string `json:"reference" binding:"max=35"`
+}
+
+// createMandate lets the creditor account collect direct debits from the debtor account,
+// up to the maximum amount and at most once per period of the frequency
+func (server *Server) createMandate(ctx *gin.Context) {
+	var req createMandateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.DebtorAccountID == req.CreditorAccountID {
+		err := errors.New("debtor_account_id and creditor_account_id cannot be the same")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	debtorAccount, valid := server.validAccount(ctx, req.DebtorAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	if !server.authorizeAccount(ctx, debtorAccount, db.AccountPermissionManage, 0) {
+		return
+	}
+
+	// direct debits would pull money from the account without the approvals of the organization
+	if debtorAccount.OrganizationID.Valid {
+		err := errors.New("mandates can't be granted on organization accounts")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, valid = server.validAccount(ctx, req.CreditorAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	mandate, err := server.store.CreateMandate(ctx, db.CreateMandateParams{
+		DebtorAccountID:   debtorAccount.ID,
+		CreditorAccountID: req.CreditorAccountID,
+		MaxAmount:         req.MaxAmount,
+		Frequency:         req.Frequency,
+		Reference:         req.Reference,
+		GrantedBy:         authPayload.Username,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newMandateResponse(mandate))
+}
+
+type listMandatesURI struct {
+	AccountID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type listMandatesRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listMandates returns the mandates an account granted, and those it can collect with, latest first
+func (server *Server) listMandates(ctx *gin.Context) {
+	var uriReq listMandatesURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req listMandatesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, uriReq.AccountID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	mandates, err := server.store.ListMandates(ctx, db.ListMandatesParams{
+		DebtorAccountID:   account.ID,
+		CreditorAccountID: account.ID,
+		Limit:             req.PageSize,
+		Offset:            (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]mandateResponse, 0, len(mandates))
+	for _, mandate := range mandates {
+		rsp = append(rsp, newMandateResponse(mandate))
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type mandateURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// revokeMandate stops any further direct debit under a mandate. Direct debits already collected can still be disputed.
+func (server *Server) revokeMandate(ctx *gin.Context) {
+	var req mandateURI
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	mandate, valid := server.validMandate(ctx, req.ID)
+	if !valid {
+		return
+	}
+
+	if _, valid := server.authorizedAccount(ctx, mandate.DebtorAccountID, db.AccountPermissionManage); !valid {
+		return
+	}
+	setAuditBefore(ctx, newMandateResponse(mandate))
+
+	mandate, err := server.store.RevokeMandate(ctx, mandate.ID)
+	if err != nil {
+		// the mandate was already revoked
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusBadRequest, errorResponse(db.ErrMandateNotActive))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newMandateResponse(mandate))
+}
+
+func (server *Server) validMandate(ctx *gin.Context, id int64) (db.Mandate, bool) {
+	mandate, err := server.store.GetMandate(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return mandate, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return mandate, false
+	}
+
+	return mandate, true
+}
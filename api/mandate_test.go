@@ -0,0 +1,316 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/volskyi-dmytro/st-bank/db/mock"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/token"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+func randomMandate(debtorAccount db.Account, creditorAccount db.Account) db.Mandate {
+	return db.Mandate{
+		ID:                util.RandomInt(1, 1000),
+		DebtorAccountID:   debtorAccount.ID,
+		CreditorAccountID: creditorAccount.ID,
+		MaxAmount:         util.RandomMoney(),
+		Frequency:         db.MandateFrequencyMonthly,
+		Reference:         util.RandomString(10),
+		Status:            db.MandateStatusActive,
+		GrantedBy:         debtorAccount.Owner,
+	}
+}
+
+func TestCreateMandateAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	debtorAccount := randomAccount()
+	debtorAccount.Owner = user.Username
+	debtorAccount.Currency = "USD"
+	creditorAccount := randomAccount()
+	creditorAccount.ID = debtorAccount.ID + 1
+	creditorAccount.Currency = "USD"
+	mandate := randomMandate(debtorAccount, creditorAccount)
+
+	body := gin.H{
+		"debtor_account_id":   debtorAccount.ID,
+		"creditor_account_id": creditorAccount.ID,
+		"max_amount":          mandate.MaxAmount,
+		"currency":            "USD",
+		"frequency":           mandate.Frequency,
+		"reference":           mandate.Reference,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateMandateParams{
+					DebtorAccountID:   debtorAccount.ID,
+					CreditorAccountID: creditorAccount.ID,
+					MaxAmount:         mandate.MaxAmount,
+					Frequency:         mandate.Frequency,
+					Reference:         mandate.Reference,
+					GrantedBy:         user.Username,
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(debtorAccount.ID)).Times(1).Return(debtorAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(creditorAccount.ID)).Times(1).Return(creditorAccount, nil)
+				store.EXPECT().CreateMandate(gomock.Any(), gomock.Eq(arg)).Times(1).Return(mandate, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got mandateResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, newMandateResponse(mandate), got)
+			},
+		},
+		{
+			name: "SameAccount",
+			body: gin.H{
+				"debtor_account_id":   debtorAccount.ID,
+				"creditor_account_id": debtorAccount.ID,
+				"max_amount":          mandate.MaxAmount,
+				"currency":            "USD",
+				"frequency":           mandate.Frequency,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateMandate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidFrequency",
+			body: gin.H{
+				"debtor_account_id":   debtorAccount.ID,
+				"creditor_account_id": creditorAccount.ID,
+				"max_amount":          mandate.MaxAmount,
+				"currency":            "USD",
+				"frequency":           "hourly",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateMandate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized", util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(debtorAccount.ID)).Times(1).Return(debtorAccount, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().CreateMandate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "CreditorCurrencyMismatch",
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				eurAccount := creditorAccount
+				eurAccount.Currency = "EUR"
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(debtorAccount.ID)).Times(1).Return(debtorAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(creditorAccount.ID)).Times(1).Return(eurAccount, nil)
+				store.EXPECT().CreateMandate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "OrganizationAccount",
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addOrganizationAuthorization(t, request, tokenMaker, user.Username, 7)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				organization := randomOrganization(user.Username)
+				organization.ID = 7
+				organizationAccount := randomOrganizationAccount(organization)
+				organizationAccount.ID = debtorAccount.ID
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(debtorAccount.ID)).Times(1).Return(organizationAccount, nil)
+				expectOrganizationMember(store, randomOrganizationMember(organization, user.Username, db.OrganizationRoleOwner), 1)
+				store.EXPECT().CreateMandate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/mandates", bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRevokeMandateAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	debtorAccount := randomAccount()
+	debtorAccount.Owner = user.Username
+	creditorAccount := randomAccount()
+	mandate := randomMandate(debtorAccount, creditorAccount)
+
+	revoked := mandate
+	revoked.Status = db.MandateStatusRevoked
+	revoked.RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMandate(gomock.Any(), gomock.Eq(mandate.ID)).Times(1).Return(mandate, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(debtorAccount.ID)).Times(1).Return(debtorAccount, nil)
+				store.EXPECT().RevokeMandate(gomock.Any(), gomock.Eq(mandate.ID)).Times(1).Return(revoked, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got mandateResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, db.MandateStatusRevoked, got.Status)
+				require.NotNil(t, got.RevokedAt)
+			},
+		},
+		{
+			// creditors can't revoke the mandates granted to them
+			name: "Creditor",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, creditorAccount.Owner, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMandate(gomock.Any(), gomock.Eq(mandate.ID)).Times(1).Return(mandate, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(debtorAccount.ID)).Times(1).Return(debtorAccount, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().RevokeMandate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyRevoked",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMandate(gomock.Any(), gomock.Eq(mandate.ID)).Times(1).Return(revoked, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(debtorAccount.ID)).Times(1).Return(debtorAccount, nil)
+				store.EXPECT().RevokeMandate(gomock.Any(), gomock.Eq(mandate.ID)).Times(1).Return(db.Mandate{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMandate(gomock.Any(), gomock.Eq(mandate.ID)).Times(1).Return(db.Mandate{}, sql.ErrNoRows)
+				store.EXPECT().RevokeMandate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/mandates/%d", mandate.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
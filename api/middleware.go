@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/volskyi-dmytro/st-bank/token"
+	"github.com/volskyi-dmytro/st-bank/util"
 )
 
 const (
@@ -63,4 +64,21 @@ func getCurrentUser(ctx *gin.Context) (*token.Payload, error) {
 	}
 
 	return payload, nil
-}
\ No newline at end of file
+}
+
+// bankerUser returns the authenticated user if they have the banker role
+func (server *Server) bankerUser(ctx *gin.Context) (*token.Payload, bool) {
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return nil, false
+	}
+
+	if authPayload.Role != util.BankerRole {
+		err := errors.New("only bankers can perform this action")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return nil, false
+	}
+
+	return authPayload, true
+}
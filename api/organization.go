@@ -0,0 +1,577 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+type createOrganizationRequest struct {
+	Name string `json:"name" binding:"required,max=128"`
+	// RequiredApprovals is the number of approvals transfers initiated by an initiator need, 0 to execute them directly
+	RequiredApprovals int32 `json:"required_approvals" binding:"min=0,max=10"`
+	// ApprovalThreshold is the largest transfer in cents an initiator can make without approvals
+	ApprovalThreshold int64 `json:"approval_threshold" binding:"min=0"`
+}
+
+// createOrganization sets up a business customer with the authenticated user as its first owner
+func (server *Server) createOrganization(ctx *gin.Context) {
+	var req createOrganizationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.CreateOrganizationTx(ctx, db.CreateOrganizationTxParams{
+		Name:              req.Name,
+		RequiredApprovals: req.RequiredApprovals,
+		ApprovalThreshold: req.ApprovalThreshold,
+		Owner:             authPayload.Username,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result.Organization)
+}
+
+// listOrganizations returns the organizations the authenticated user is a member of, with their role
+func (server *Server) listOrganizations(ctx *gin.Context) {
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	organizations, err := server.store.ListUserOrganizations(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, organizations)
+}
+
+type organizationURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type updateOrganizationPolicyRequest struct {
+	RequiredApprovals int32 `json:"required_approvals" binding:"min=0,max=10"`
+	ApprovalThreshold int64 `json:"approval_threshold" binding:"min=0"`
+}
+
+// updateOrganizationPolicy changes the approvals transfers initiated by initiators need.
+// Transfers already waiting for approvals are counted against the new policy at their next approval.
+func (server *Server) updateOrganizationPolicy(ctx *gin.Context) {
+	var uriReq organizationURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req updateOrganizationPolicyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.organizationOwner(ctx, uriReq.ID) {
+		return
+	}
+
+	organization, err := server.store.GetOrganization(ctx, uriReq.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	setAuditBefore(ctx, organization)
+
+	organization, err = server.store.UpdateOrganizationPolicy(ctx, db.UpdateOrganizationPolicyParams{
+		ID:                organization.ID,
+		RequiredApprovals: req.RequiredApprovals,
+		ApprovalThreshold: req.ApprovalThreshold,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, organization)
+}
+
+// listOrganizationMembers returns the members of an organization to any of its members
+func (server *Server) listOrganizationMembers(ctx *gin.Context) {
+	var req organizationURI
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, valid := server.organizationMember(ctx, req.ID); !valid {
+		return
+	}
+
+	members, err := server.store.ListOrganizationMembers(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, members)
+}
+
+type organizationMemberURI struct {
+	ID       int64  `uri:"id" binding:"required,min=1"`
+	Username string `uri:"username" binding:"required,alphanum"`
+}
+
+type addOrganizationMemberRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner approver initiator viewer"`
+}
+
+// addOrganizationMember adds a user to an organization, or changes the role of an existing member
+func (server *Server) addOrganizationMember(ctx *gin.Context) {
+	var uriReq organizationMemberURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req addOrganizationMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	if !server.organizationOwner(ctx, uriReq.ID) {
+		return
+	}
+
+	_, err = server.store.GetUser(ctx, uriReq.Username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	existing, err := server.store.GetOrganizationMember(ctx, db.GetOrganizationMemberParams{
+		OrganizationID: uriReq.ID,
+		Username:       uriReq.Username,
+	})
+	if err == nil {
+		setAuditBefore(ctx, existing)
+
+		if existing.Role == db.OrganizationRoleOwner && req.Role != db.OrganizationRoleOwner &&
+			!server.otherOrganizationOwners(ctx, uriReq.ID) {
+			return
+		}
+	} else if err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	member, err := server.store.AddOrganizationMember(ctx, db.AddOrganizationMemberParams{
+		OrganizationID: uriReq.ID,
+		Username:       uriReq.Username,
+		Role:           req.Role,
+		AddedBy:        authPayload.Username,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, member)
+}
+
+// removeOrganizationMember removes a user from an organization. Members can also leave on their own.
+func (server *Server) removeOrganizationMember(ctx *gin.Context) {
+	var uriReq organizationMemberURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	if uriReq.Username == authPayload.Username {
+		if _, valid := server.organizationMember(ctx, uriReq.ID); !valid {
+			return
+		}
+	} else if !server.organizationOwner(ctx, uriReq.ID) {
+		return
+	}
+
+	arg := db.GetOrganizationMemberParams{
+		OrganizationID: uriReq.ID,
+		Username:       uriReq.Username,
+	}
+
+	member, err := server.store.GetOrganizationMember(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	setAuditBefore(ctx, member)
+
+	if member.Role == db.OrganizationRoleOwner && !server.otherOrganizationOwners(ctx, uriReq.ID) {
+		return
+	}
+
+	member, err = server.store.RemoveOrganizationMember(ctx, db.RemoveOrganizationMemberParams(arg))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, member)
+}
+
+type switchContextRequest struct {
+	// OrganizationID is the organization to act for, 0 to act as a personal customer again
+	OrganizationID int64 `json:"organization_id" binding:"min=0"`
+}
+
+type switchContextResponse struct {
+	AccessToken    string `json:"access_token"`
+	OrganizationID int64  `json:"organization_id"`
+}
+
+// switchContext issues a new access token acting for one of the user's organizations, or for the user alone.
+// The new token expires with the one it was switched from, so that switching can't extend a session.
+func (server *Server) switchContext(ctx *gin.Context) {
+	var req switchContextRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	if req.OrganizationID != 0 {
+		if _, valid := server.organizationMember(ctx, req.OrganizationID); !valid {
+			return
+		}
+	}
+
+	accessToken, err := server.tokenMaker.CreateOrganizationToken(
+		authPayload.Username,
+		authPayload.Role,
+		req.OrganizationID,
+		authPayload.ExpiredAt,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the access token is a live credential and must not end up in the audit log
+	setAuditAfter(ctx, gin.H{"organization_id": req.OrganizationID})
+
+	ctx.JSON(http.StatusOK, switchContextResponse{
+		AccessToken:    accessToken,
+		OrganizationID: req.OrganizationID,
+	})
+}
+
+// listOrganizationTransferApprovals returns the transfers waiting for approvals of an organization's approvers
+func (server *Server) listOrganizationTransferApprovals(ctx *gin.Context) {
+	var uriReq organizationURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req listTransferApprovalsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.organizationApprover(ctx, uriReq.ID) {
+		return
+	}
+
+	approvals, err := server.store.ListOrganizationTransferApprovals(ctx, db.ListOrganizationTransferApprovalsParams{
+		OrganizationID: sql.NullInt64{
+			Int64: uriReq.ID,
+			Valid: true,
+		},
+		Status:     db.TransferApprovalStatusPendingOrganization,
+		PageLimit:  req.PageSize,
+		PageOffset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]transferApprovalResponse, 0, len(approvals))
+	for _, approval := range approvals {
+		rsp = append(rsp, newTransferApprovalResponse(approval))
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type organizationTransferApprovalURI struct {
+	ID         int64 `uri:"id" binding:"required,min=1"`
+	ApprovalID int64 `uri:"approval_id" binding:"required,min=1"`
+}
+
+// signOrganizationTransferApproval lets an approver or owner other than the initiator approve or reject a transfer
+// from an organization's account. The transfer is executed once it has the approvals the policy asks for,
+// unless it is large or suspicious enough to also need a banker's approval.
+func (server *Server) signOrganizationTransferApproval(ctx *gin.Context) {
+	var uriReq organizationTransferApprovalURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req reviewTransferApprovalRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	if !server.organizationApprover(ctx, uriReq.ID) {
+		return
+	}
+
+	approval, err := server.store.GetTransferApproval(ctx, uriReq.ApprovalID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	fromAccount, err := server.store.GetAccount(ctx, approval.FromAccountID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if fromAccount.OrganizationID.Int64 != uriReq.ID {
+		err := fmt.Errorf("transfer approval [%d] not found in organization [%d]", approval.ID, uriReq.ID)
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+
+	if approval.Initiator == authPayload.Username {
+		ctx.JSON(http.StatusForbidden, errorResponse(db.ErrSelfApproval))
+		return
+	}
+
+	if approval.Status != db.TransferApprovalStatusPendingOrganization {
+		ctx.JSON(http.StatusBadRequest, errorResponse(db.ErrTransferApprovalNotPending))
+		return
+	}
+	setAuditBefore(ctx, newTransferApprovalResponse(approval))
+
+	approve := req.Decision == "approve"
+	if approve {
+		// the balance may have changed while the transfer was waiting for approvals
+		fromAccount, valid := server.validAccount(ctx, approval.FromAccountID, approval.Currency)
+		if !valid {
+			return
+		}
+
+		if !server.sufficientFunds(ctx, fromAccount, approval.Amount) {
+			return
+		}
+	}
+
+	organization, err := server.store.GetOrganization(ctx, uriReq.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.SignTransferApprovalTx(ctx, db.SignTransferApprovalTxParams{
+		ID:                approval.ID,
+		Approver:          authPayload.Username,
+		Approve:           approve,
+		RequiredApprovals: organization.RequiredApprovals,
+		BankReview:        approval.FraudReview || server.bankReviewRequired(approval.Amount),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrTransferApprovalNotPending) || errors.Is(err, db.ErrInsufficientFunds) ||
+			errors.Is(err, db.ErrAlreadySigned) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrSelfApproval) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		// the reference may have been used by another transfer while this one was waiting for approvals
+		if referenceAlreadyUsed(err) {
+			err := fmt.Errorf("reference %q was already used for account [%d]", approval.Reference, approval.FromAccountID)
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"transfer_approval": newTransferApprovalResponse(result.TransferApproval),
+		"approvals":         result.Approvals,
+		"transfer":          result.Transfer,
+	})
+}
+
+// organizationMember checks that the authenticated user is a member of the organization and returns the membership
+func (server *Server) organizationMember(ctx *gin.Context, organizationID int64) (db.OrganizationMember, bool) {
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return db.OrganizationMember{}, false
+	}
+
+	member, err := server.store.GetOrganizationMember(ctx, db.GetOrganizationMemberParams{
+		OrganizationID: organizationID,
+		Username:       authPayload.Username,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err := fmt.Errorf("authenticated user isn't a member of organization [%d]", organizationID)
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return member, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return member, false
+	}
+
+	return member, true
+}
+
+// organizationOwner checks that the authenticated user is an owner of the organization
+func (server *Server) organizationOwner(ctx *gin.Context, organizationID int64) bool {
+	member, valid := server.organizationMember(ctx, organizationID)
+	if !valid {
+		return false
+	}
+
+	if member.Role != db.OrganizationRoleOwner {
+		err := fmt.Errorf("only owners of organization [%d] can do this", organizationID)
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return false
+	}
+
+	return true
+}
+
+// organizationApprover checks that the authenticated user can approve transfers of the organization
+func (server *Server) organizationApprover(ctx *gin.Context, organizationID int64) bool {
+	member, valid := server.organizationMember(ctx, organizationID)
+	if !valid {
+		return false
+	}
+
+	if !member.CanApprove() {
+		err := fmt.Errorf("only owners and approvers of organization [%d] can approve transfers", organizationID)
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return false
+	}
+
+	return true
+}
+
+// otherOrganizationOwners checks that an owner about to be removed or demoted isn't the last one of the organization
+func (server *Server) otherOrganizationOwners(ctx *gin.Context, organizationID int64) bool {
+	owners, err := server.store.CountOrganizationOwners(ctx, organizationID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if owners <= 1 {
+		err := fmt.Errorf("organization [%d] needs at least one owner", organizationID)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return false
+	}
+
+	return true
+}
+
+// organizationApprovalRequired reports whether a transfer of amount from an account of the organization,
+// initiated by the authenticated user, has to wait for approvals under the organization's policy.
+// Owners make transfers directly.
+func (server *Server) organizationApprovalRequired(ctx *gin.Context, organizationID int64, amount int64) (bool, bool) {
+	member, valid := server.organizationMember(ctx, organizationID)
+	if !valid {
+		return false, false
+	}
+
+	if member.Role != db.OrganizationRoleInitiator {
+		return false, true
+	}
+
+	organization, err := server.store.GetOrganization(ctx, organizationID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false, false
+	}
+
+	return organization.RequiresApproval(amount), true
+}
@@ -0,0 +1,757 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/volskyi-dmytro/st-bank/db/mock"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/token"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+func randomOrganization(owner string) db.Organization {
+	return db.Organization{
+		ID:                util.RandomInt(1, 1000),
+		Name:              util.RandomString(8),
+		RequiredApprovals: 2,
+		ApprovalThreshold: 20,
+		CreatedBy:         owner,
+	}
+}
+
+func randomOrganizationMember(organization db.Organization, username string, role string) db.OrganizationMember {
+	return db.OrganizationMember{
+		OrganizationID: organization.ID,
+		Username:       username,
+		Role:           role,
+		AddedBy:        organization.CreatedBy,
+	}
+}
+
+func randomOrganizationAccount(organization db.Organization) db.Account {
+	account := randomAccount()
+	account.Owner = organization.CreatedBy
+	account.Currency = "USD"
+	account.OrganizationID = sql.NullInt64{Int64: organization.ID, Valid: true}
+	return account
+}
+
+func addOrganizationAuthorization(
+	t *testing.T,
+	request *http.Request,
+	tokenMaker token.Maker,
+	username string,
+	organizationID int64,
+) {
+	token, err := tokenMaker.CreateOrganizationToken(username, util.DepositorRole, organizationID, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	authorizationHeader := fmt.Sprintf("%s %s", authorizationTypeBearer, token)
+	request.Header.Set(authorizationHeaderKey, authorizationHeader)
+}
+
+func expectOrganizationMember(store *mockdb.MockStore, member db.OrganizationMember, times int) {
+	store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Eq(db.GetOrganizationMemberParams{
+		OrganizationID: member.OrganizationID,
+		Username:       member.Username,
+	})).Times(times).Return(member, nil)
+}
+
+func TestCreateOrganizationAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	organization := randomOrganization(user.Username)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"name":               organization.Name,
+				"required_approvals": organization.RequiredApprovals,
+				"approval_threshold": organization.ApprovalThreshold,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateOrganizationTxParams{
+					Name:              organization.Name,
+					RequiredApprovals: organization.RequiredApprovals,
+					ApprovalThreshold: organization.ApprovalThreshold,
+					Owner:             user.Username,
+				}
+
+				store.EXPECT().CreateOrganizationTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.CreateOrganizationTxResult{
+					Organization: organization,
+					Owner:        randomOrganizationMember(organization, user.Username, db.OrganizationRoleOwner),
+				}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.Organization
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, organization, got)
+			},
+		},
+		{
+			name: "NegativeApprovals",
+			body: gin.H{
+				"name":               organization.Name,
+				"required_approvals": -1,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateOrganizationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{"name": organization.Name},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateOrganizationTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.CreateOrganizationTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/organizations", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestAddOrganizationMemberAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	user, _ := randomUser(t)
+	organization := randomOrganization(owner.Username)
+
+	ownerMember := randomOrganizationMember(organization, owner.Username, db.OrganizationRoleOwner)
+
+	testCases := []struct {
+		name          string
+		username      string
+		member        string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: owner.Username,
+			member:   user.Username,
+			body:     gin.H{"role": "initiator"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.AddOrganizationMemberParams{
+					OrganizationID: organization.ID,
+					Username:       user.Username,
+					Role:           db.OrganizationRoleInitiator,
+					AddedBy:        owner.Username,
+				}
+
+				expectOrganizationMember(store, ownerMember, 1)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Eq(db.GetOrganizationMemberParams{
+					OrganizationID: organization.ID,
+					Username:       user.Username,
+				})).Times(1).Return(db.OrganizationMember{}, sql.ErrNoRows)
+				store.EXPECT().AddOrganizationMember(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(randomOrganizationMember(organization, user.Username, db.OrganizationRoleInitiator), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.OrganizationMember
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, user.Username, got.Username)
+				require.Equal(t, db.OrganizationRoleInitiator, got.Role)
+			},
+		},
+		{
+			name:     "NotOwner",
+			username: user.Username,
+			member:   owner.Username,
+			body:     gin.H{"role": "viewer"},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectOrganizationMember(store, randomOrganizationMember(organization, user.Username, db.OrganizationRoleApprover), 1)
+				store.EXPECT().AddOrganizationMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "NotMember",
+			username: user.Username,
+			member:   owner.Username,
+			body:     gin.H{"role": "viewer"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(1).Return(db.OrganizationMember{}, sql.ErrNoRows)
+				store.EXPECT().AddOrganizationMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "LastOwner",
+			username: owner.Username,
+			member:   owner.Username,
+			body:     gin.H{"role": "approver"},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectOrganizationMember(store, ownerMember, 2)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(owner.Username)).Times(1).Return(owner, nil)
+				store.EXPECT().CountOrganizationOwners(gomock.Any(), gomock.Eq(organization.ID)).Times(1).Return(int64(1), nil)
+				store.EXPECT().AddOrganizationMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "UserNotFound",
+			username: owner.Username,
+			member:   user.Username,
+			body:     gin.H{"role": "viewer"},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectOrganizationMember(store, ownerMember, 1)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.User{}, sql.ErrNoRows)
+				store.EXPECT().AddOrganizationMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidRole",
+			username: owner.Username,
+			member:   user.Username,
+			body:     gin.H{"role": "manage"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/organizations/%d/members/%s", organization.ID, tc.member)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestSwitchContextAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	organization := randomOrganization(user.Username)
+
+	testCases := []struct {
+		name           string
+		organizationID int64
+		tokenDuration  time.Duration
+		buildStubs     func(store *mockdb.MockStore)
+		checkResponse  func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker)
+	}{
+		{
+			name:           "OK",
+			organizationID: organization.ID,
+			tokenDuration:  time.Minute,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectOrganizationMember(store, randomOrganizationMember(organization, user.Username, db.OrganizationRoleViewer), 1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got switchContextResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, organization.ID, got.OrganizationID)
+
+				payload, err := tokenMaker.VerifyToken(got.AccessToken)
+				require.NoError(t, err)
+				require.Equal(t, user.Username, payload.Username)
+				require.Equal(t, organization.ID, payload.OrganizationID)
+			},
+		},
+		{
+			name:           "KeepsExpiry",
+			organizationID: organization.ID,
+			tokenDuration:  10 * time.Second,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectOrganizationMember(store, randomOrganizationMember(organization, user.Username, db.OrganizationRoleViewer), 1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got switchContextResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+
+				// the switched token must not outlive the one it was switched from
+				payload, err := tokenMaker.VerifyToken(got.AccessToken)
+				require.NoError(t, err)
+				require.False(t, payload.ExpiredAt.After(time.Now().Add(10*time.Second)))
+			},
+		},
+		{
+			name:           "Personal",
+			organizationID: 0,
+			tokenDuration:  time.Minute,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got switchContextResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+
+				payload, err := tokenMaker.VerifyToken(got.AccessToken)
+				require.NoError(t, err)
+				require.Zero(t, payload.OrganizationID)
+			},
+		},
+		{
+			name:           "NotMember",
+			organizationID: organization.ID,
+			tokenDuration:  time.Minute,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(1).Return(db.OrganizationMember{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"organization_id": tc.organizationID})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/users/context", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, tc.tokenDuration)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder, server.tokenMaker)
+		})
+	}
+}
+
+func TestOrganizationTransferAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	initiator, _ := randomUser(t)
+	viewer, _ := randomUser(t)
+	organization := randomOrganization(owner.Username)
+	fromAccount := randomOrganizationAccount(organization)
+	fromAccount.Balance = 1000
+	toAccount := randomAccount()
+	toAccount.Currency = "USD"
+
+	testCases := []struct {
+		name           string
+		username       string
+		organizationID int64
+		amount         int64
+		buildStubs     func(store *mockdb.MockStore)
+		checkResponse  func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:           "InitiatorRequiresApproval",
+			username:       initiator.Username,
+			organizationID: organization.ID,
+			amount:         30,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				expectOrganizationMember(store, randomOrganizationMember(organization, initiator.Username, db.OrganizationRoleInitiator), 2)
+				store.EXPECT().GetOrganization(gomock.Any(), gomock.Eq(organization.ID)).Times(1).Return(organization, nil)
+				store.EXPECT().CreateTransferApproval(gomock.Any(), gomock.Eq(db.CreateTransferApprovalParams{
+					FromAccountID: fromAccount.ID,
+					ToAccountID:   toAccount.ID,
+					Amount:        30,
+					Currency:      "USD",
+					Metadata:      json.RawMessage("{}"),
+					Initiator:     initiator.Username,
+					Status:        db.TransferApprovalStatusPendingOrganization,
+				})).Times(1).Return(db.TransferApproval{
+					ID:     1,
+					Status: db.TransferApprovalStatusPendingOrganization,
+				}, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+			},
+		},
+		{
+			name:           "InitiatorBelowThreshold",
+			username:       initiator.Username,
+			organizationID: organization.ID,
+			amount:         20,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				expectOrganizationMember(store, randomOrganizationMember(organization, initiator.Username, db.OrganizationRoleInitiator), 2)
+				store.EXPECT().GetOrganization(gomock.Any(), gomock.Eq(organization.ID)).Times(1).Return(organization, nil)
+				store.EXPECT().CreateTransferApproval(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:           "OwnerTransfersDirectly",
+			username:       owner.Username,
+			organizationID: organization.ID,
+			amount:         30,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				expectOrganizationMember(store, randomOrganizationMember(organization, owner.Username, db.OrganizationRoleOwner), 2)
+				store.EXPECT().GetOrganization(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:           "Viewer",
+			username:       viewer.Username,
+			organizationID: organization.ID,
+			amount:         10,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				expectOrganizationMember(store, randomOrganizationMember(organization, viewer.Username, db.OrganizationRoleViewer), 1)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:           "PersonalContext",
+			username:       owner.Username,
+			organizationID: 0,
+			amount:         10,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			// the destination isn't a saved beneficiary of the user unless the case says so
+			store.EXPECT().GetBeneficiaryByAccount(gomock.Any(), gomock.Any()).AnyTimes().Return(db.Beneficiary{}, sql.ErrNoRows)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{
+				"from_account_id": fromAccount.ID,
+				"to_account_id":   toAccount.ID,
+				"amount":          tc.amount,
+				"currency":        "USD",
+			})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addOrganizationAuthorization(t, request, server.tokenMaker, tc.username, tc.organizationID)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestSignOrganizationTransferApprovalAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	initiator, _ := randomUser(t)
+	approver, _ := randomUser(t)
+	organization := randomOrganization(owner.Username)
+	fromAccount := randomOrganizationAccount(organization)
+	fromAccount.Balance = 1000
+
+	approval := db.TransferApproval{
+		ID:            util.RandomInt(1, 1000),
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   fromAccount.ID + 1,
+		Amount:        30,
+		Currency:      "USD",
+		Initiator:     initiator.Username,
+		Status:        db.TransferApprovalStatusPendingOrganization,
+	}
+
+	approverMember := randomOrganizationMember(organization, approver.Username, db.OrganizationRoleApprover)
+
+	testCases := []struct {
+		name          string
+		username      string
+		decision      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Approve",
+			username: approver.Username,
+			decision: "approve",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SignTransferApprovalTxParams{
+					ID:                approval.ID,
+					Approver:          approver.Username,
+					Approve:           true,
+					RequiredApprovals: organization.RequiredApprovals,
+				}
+
+				expectOrganizationMember(store, approverMember, 1)
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(2).Return(fromAccount, nil)
+				store.EXPECT().GetOrganization(gomock.Any(), gomock.Eq(organization.ID)).Times(1).Return(organization, nil)
+				store.EXPECT().SignTransferApprovalTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.SignTransferApprovalTxResult{
+					TransferApproval: approval,
+					Approvals:        1,
+				}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "ReferenceAlreadyUsed",
+			username: approver.Username,
+			decision: "approve",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectOrganizationMember(store, approverMember, 1)
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(2).Return(fromAccount, nil)
+				store.EXPECT().GetOrganization(gomock.Any(), gomock.Eq(organization.ID)).Times(1).Return(organization, nil)
+				store.EXPECT().SignTransferApprovalTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.SignTransferApprovalTxResult{}, &pq.Error{Code: "23505", Constraint: transferReferenceConstraint})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:     "FraudReview",
+			username: approver.Username,
+			decision: "approve",
+			buildStubs: func(store *mockdb.MockStore) {
+				flagged := approval
+				flagged.FraudReview = true
+
+				arg := db.SignTransferApprovalTxParams{
+					ID:                approval.ID,
+					Approver:          approver.Username,
+					Approve:           true,
+					RequiredApprovals: organization.RequiredApprovals,
+					BankReview:        true,
+				}
+
+				expectOrganizationMember(store, approverMember, 1)
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(flagged, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(2).Return(fromAccount, nil)
+				store.EXPECT().GetOrganization(gomock.Any(), gomock.Eq(organization.ID)).Times(1).Return(organization, nil)
+				store.EXPECT().SignTransferApprovalTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.SignTransferApprovalTxResult{
+					TransferApproval: flagged,
+					Approvals:        1,
+				}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "Reject",
+			username: approver.Username,
+			decision: "reject",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectOrganizationMember(store, approverMember, 1)
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetOrganization(gomock.Any(), gomock.Eq(organization.ID)).Times(1).Return(organization, nil)
+				store.EXPECT().SignTransferApprovalTx(gomock.Any(), gomock.Any()).Times(1).Return(db.SignTransferApprovalTxResult{
+					TransferApproval: approval,
+				}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "AlreadySigned",
+			username: approver.Username,
+			decision: "approve",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectOrganizationMember(store, approverMember, 1)
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(2).Return(fromAccount, nil)
+				store.EXPECT().GetOrganization(gomock.Any(), gomock.Eq(organization.ID)).Times(1).Return(organization, nil)
+				store.EXPECT().SignTransferApprovalTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.SignTransferApprovalTxResult{}, db.ErrAlreadySigned)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "Initiator",
+			username: initiator.Username,
+			decision: "approve",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectOrganizationMember(store, randomOrganizationMember(organization, initiator.Username, db.OrganizationRoleOwner), 1)
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().SignTransferApprovalTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "InitiatorRole",
+			username: initiator.Username,
+			decision: "approve",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectOrganizationMember(store, randomOrganizationMember(organization, initiator.Username, db.OrganizationRoleInitiator), 1)
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "OtherOrganization",
+			username: approver.Username,
+			decision: "approve",
+			buildStubs: func(store *mockdb.MockStore) {
+				personalAccount := fromAccount
+				personalAccount.OrganizationID = sql.NullInt64{}
+
+				expectOrganizationMember(store, approverMember, 1)
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(personalAccount, nil)
+				store.EXPECT().SignTransferApprovalTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"decision": tc.decision})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/organizations/%d/transfer_approvals/%d", organization.ID, approval.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addOrganizationAuthorization(t, request, server.tokenMaker, tc.username, organization.ID)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+type overdraftURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type grantOverdraftRequest struct {
+	// Limit is how far below zero the balance can go, in cents
+	Limit int64 `json:"limit" binding:"required,gt=0"`
+	// AnnualRateBps is the annual interest rate charged on the negative balance in basis points
+	AnnualRateBps int32 `json:"annual_rate_bps" binding:"min=0,max=10000"`
+}
+
+// grantOverdraft lets a checking account go below zero up to a limit, or changes the terms of its facility
+func (server *Server) grantOverdraft(ctx *gin.Context) {
+	var uriReq overdraftURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req grantOverdraftRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, valid := server.bankerUser(ctx)
+	if !valid {
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, uriReq.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if account.Type != db.AccountTypeChecking {
+		err := fmt.Errorf("account [%d] is a %s account, only checking accounts can be overdrawn", account.ID, account.Type)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// granting an overdraft again changes the terms of the existing facility
+	existing, err := server.store.GetOverdraftFacility(ctx, account.ID)
+	if err == nil {
+		setAuditBefore(ctx, existing)
+	} else if err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	facility, err := server.store.GrantOverdraftFacility(ctx, db.GrantOverdraftFacilityParams{
+		AccountID:      account.ID,
+		OverdraftLimit: req.Limit,
+		AnnualRateBps:  req.AnnualRateBps,
+		GrantedBy:      authPayload.Username,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, facility)
+}
+
+// revokeOverdraft sets the overdraft limit of an account to zero.
+// An overdrawn account can't be debited any further but keeps accruing interest until it is back above zero.
+func (server *Server) revokeOverdraft(ctx *gin.Context) {
+	var uriReq overdraftURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, valid := server.bankerUser(ctx)
+	if !valid {
+		return
+	}
+
+	facility, err := server.store.GetOverdraftFacility(ctx, uriReq.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	setAuditBefore(ctx, facility)
+
+	facility, err = server.store.RevokeOverdraftFacility(ctx, db.RevokeOverdraftFacilityParams{
+		AccountID: facility.AccountID,
+		GrantedBy: authPayload.Username,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, facility)
+}
+
+type listOverdraftNotificationsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listOverdraftNotifications returns the times an account went below zero, latest first
+func (server *Server) listOverdraftNotifications(ctx *gin.Context) {
+	var uriReq overdraftURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req listOverdraftNotificationsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, uriReq.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	notifications, err := server.store.ListOverdraftNotifications(ctx, db.ListOverdraftNotificationsParams{
+		AccountID: account.ID,
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, notifications)
+}
+
+// sufficientFunds checks that an account can be debited an amount, going below zero only within its overdraft limit.
+// The limit is only looked up when the balance doesn't cover the amount. TransferTx enforces the limit again
+// atomically, this check only avoids starting transfers that can't be made.
+func (server *Server) sufficientFunds(ctx *gin.Context, account db.Account, amount int64) bool {
+	if account.Balance >= amount {
+		return true
+	}
+
+	limit, err := server.store.GetOverdraftLimit(ctx, account.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if account.Balance+limit < amount {
+		err := fmt.Errorf("account [%d] has insufficient balance: %d", account.ID, account.Balance)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return false
+	}
+
+	return true
+}
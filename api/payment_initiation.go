@@ -0,0 +1,166 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/fraud"
+	"github.com/volskyi-dmytro/st-bank/iso20022"
+)
+
+// maxPaymentInitiationSize limits the size of uploaded pain.001 documents
+const maxPaymentInitiationSize = 1 << 20
+
+type paymentInitiationResponse struct {
+	MessageID string                `json:"message_id"`
+	Transfers []db.TransferTxResult `json:"transfers"`
+}
+
+// createPaymentInitiation makes the credit transfers of a pain.001 document sent as the request body.
+// Either all the transfers are made or none of them: the user must be allowed to transfer from every debtor
+// account, each account must cover its transfers, and transfers that would need a banker's approval have to be
+// submitted on their own.
+func (server *Server) createPaymentInitiation(ctx *gin.Context) {
+	document, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxPaymentInitiationSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	initiation, err := iso20022.ParsePain001(document)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	accounts := make(map[int64]db.Account)
+	debits := make(map[int64]int64)
+
+	for i, transfer := range initiation.Transfers {
+		if transfer.RequestedExecutionDate.UTC().Truncate(24 * time.Hour).After(today) {
+			err := fmt.Errorf("transfer %d: requested execution date %s is in the future, only immediate transfers are supported",
+				i+1, transfer.RequestedExecutionDate.Format(time.DateOnly))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		if transfer.FromAccountID == transfer.ToAccountID {
+			err := fmt.Errorf("transfer %d: debtor and creditor accounts cannot be the same", i+1)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		fromAccount, valid := server.batchAccount(ctx, accounts, transfer.FromAccountID, transfer.Currency)
+		if !valid {
+			return
+		}
+
+		if !server.authorizeAccount(ctx, fromAccount, db.AccountPermissionTransfer, transfer.Amount) {
+			return
+		}
+
+		// the approval policy of an organization applies to transfers one by one
+		if fromAccount.OrganizationID.Valid {
+			err := fmt.Errorf("transfer %d: account [%d] belongs to an organization, make it as a single transfer", i+1, fromAccount.ID)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		_, valid = server.batchAccount(ctx, accounts, transfer.ToAccountID, transfer.Currency)
+		if !valid {
+			return
+		}
+
+		if !server.savedBeneficiaryCoolingOff(ctx, transfer.ToAccountID, transfer.Amount) {
+			return
+		}
+
+		if server.bankReviewRequired(transfer.Amount) {
+			err := fmt.Errorf("transfer %d needs a banker's approval, make it as a single transfer", i+1)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		debits[fromAccount.ID] += transfer.Amount
+		if !server.sufficientFunds(ctx, fromAccount, debits[fromAccount.ID]) {
+			return
+		}
+	}
+
+	if server.fraudEngine != nil {
+		for i, transfer := range initiation.Transfers {
+			decision, valid := server.assessTransfer(ctx, transferRequest{
+				FromAccountID: transfer.FromAccountID,
+				ToAccountID:   transfer.ToAccountID,
+				Amount:        transfer.Amount,
+				Currency:      transfer.Currency,
+			})
+			if !valid {
+				return
+			}
+
+			if decision == fraud.Review {
+				err := fmt.Errorf("transfer %d needs a review, make it as a single transfer", i+1)
+				ctx.JSON(http.StatusBadRequest, errorResponse(err))
+				return
+			}
+		}
+	}
+
+	batch, err := initiation.Batch()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.TransferBatchTx(ctx, db.TransferBatchTxParams{Transfers: batch})
+	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if referenceAlreadyUsed(err) {
+			err := fmt.Errorf("an end-to-end id of message %q was already used as a transfer reference", initiation.MessageID)
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, paymentInitiationResponse{
+		MessageID: initiation.MessageID,
+		Transfers: result.Transfers,
+	})
+}
+
+// batchAccount returns an account of a batch of transfers, loading it only once, and checks its currency
+func (server *Server) batchAccount(ctx *gin.Context, accounts map[int64]db.Account, accountID int64, currency string) (db.Account, bool) {
+	account, ok := accounts[accountID]
+	if !ok {
+		account, valid := server.validAccount(ctx, accountID, currency)
+		if valid {
+			accounts[accountID] = account
+		}
+		return account, valid
+	}
+
+	if account.Currency != currency {
+		err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, currency)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return account, false
+	}
+
+	return account, true
+}
@@ -0,0 +1,380 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/fraud"
+)
+
+type paymentRequestResponse struct {
+	ID          int64     `json:"id"`
+	Requester   string    `json:"requester"`
+	Payer       string    `json:"payer"`
+	ToAccountID int64     `json:"to_account_id"`
+	Amount      int64     `json:"amount"`
+	Currency    string    `json:"currency"`
+	Status      string    `json:"status"`
+	TransferID  *int64    `json:"transfer_id,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func newPaymentRequestResponse(request db.PaymentRequest) paymentRequestResponse {
+	rsp := paymentRequestResponse{
+		ID:          request.ID,
+		Requester:   request.Requester,
+		Payer:       request.Payer,
+		ToAccountID: request.ToAccountID,
+		Amount:      request.Amount,
+		Currency:    request.Currency,
+		Status:      request.Status,
+		ExpiresAt:   request.ExpiresAt,
+		CreatedAt:   request.CreatedAt,
+	}
+	if request.IsExpired(time.Now()) {
+		rsp.Status = db.PaymentRequestStatusExpired
+	}
+	if request.TransferID.Valid {
+		rsp.TransferID = &request.TransferID.Int64
+	}
+	return rsp
+}
+
+type createPaymentRequestRequest struct {
+	Payer       string `json:"payer" binding:"required,alphanum"`
+	ToAccountID int64  `json:"to_account_id" binding:"required,min=1"`
+	Amount      int64  `json:"amount" binding:"required,gt=0"`
+	Currency    string `json:"currency" binding:"required,currency"`
+}
+
+func (server *Server) createPaymentRequest(ctx *gin.Context) {
+	var req createPaymentRequestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	if req.Payer == authPayload.Username {
+		err := errors.New("cannot request money from yourself")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	toAccount, valid := server.validAccount(ctx, req.ToAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	if !server.authorizeAccount(ctx, toAccount, db.AccountPermissionTransfer, 0) {
+		return
+	}
+
+	_, err = server.store.GetUser(ctx, req.Payer)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.CreatePaymentRequestParams{
+		Requester:   authPayload.Username,
+		Payer:       req.Payer,
+		ToAccountID: req.ToAccountID,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		ExpiresAt:   time.Now().Add(server.config.PaymentRequestDuration),
+	}
+
+	request, err := server.store.CreatePaymentRequest(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPaymentRequestResponse(request))
+}
+
+type paymentRequestURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getPaymentRequest(ctx *gin.Context) {
+	var req paymentRequestURI
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	request, valid := server.validPaymentRequest(ctx, req.ID)
+	if !valid {
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	if request.Requester != authPayload.Username && request.Payer != authPayload.Username {
+		err := errors.New("payment request doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPaymentRequestResponse(request))
+}
+
+type listPaymentRequestsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listPaymentRequests(ctx *gin.Context) {
+	var req listPaymentRequestsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	arg := db.ListPaymentRequestsParams{
+		Requester: authPayload.Username,
+		Payer:     authPayload.Username,
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	}
+
+	requests, err := server.store.ListPaymentRequests(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]paymentRequestResponse, 0, len(requests))
+	for _, request := range requests {
+		rsp = append(rsp, newPaymentRequestResponse(request))
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type acceptPaymentRequestRequest struct {
+	FromAccountID int64 `json:"from_account_id" binding:"required,min=1"`
+}
+
+func (server *Server) acceptPaymentRequest(ctx *gin.Context) {
+	var uriReq paymentRequestURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req acceptPaymentRequestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	request, valid := server.validPaymentRequest(ctx, uriReq.ID)
+	if !valid {
+		return
+	}
+
+	if request.Payer != authPayload.Username {
+		err := errors.New("only the payer can accept a payment request")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	if !server.pendingPaymentRequest(ctx, request) {
+		return
+	}
+	setAuditBefore(ctx, newPaymentRequestResponse(request))
+
+	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, request.Currency)
+	if !valid {
+		return
+	}
+
+	if !server.authorizeAccount(ctx, fromAccount, db.AccountPermissionTransfer, request.Amount) {
+		return
+	}
+
+	if fromAccount.OrganizationID.Valid {
+		err := errors.New("payment requests can't be paid from organization accounts")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.sufficientFunds(ctx, fromAccount, request.Amount) {
+		return
+	}
+
+	fraudReview := false
+	if server.fraudEngine != nil {
+		decision, valid := server.assessTransfer(ctx, transferRequest{
+			FromAccountID: fromAccount.ID,
+			ToAccountID:   request.ToAccountID,
+			Amount:        request.Amount,
+			Currency:      request.Currency,
+		})
+		if !valid {
+			return
+		}
+		fraudReview = decision == fraud.Review
+	}
+
+	// large and suspicious payments wait for a banker like any other transfer
+	if fraudReview || server.bankReviewRequired(request.Amount) {
+		server.requestPaymentApproval(ctx, request, fromAccount, fraudReview)
+		return
+	}
+
+	arg := db.AcceptPaymentRequestTxParams{
+		PaymentRequestID: request.ID,
+		FromAccountID:    fromAccount.ID,
+	}
+
+	result, err := server.store.AcceptPaymentRequestTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrPaymentRequestNotPending) || errors.Is(err, db.ErrPaymentRequestExpired) ||
+			errors.Is(err, db.ErrInsufficientFunds) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"payment_request": newPaymentRequestResponse(result.PaymentRequest),
+		"transfer":        result.Transfer,
+	})
+}
+
+// requestPaymentApproval accepts a payment request whose payment has to be approved by a banker first.
+// The request waits in the pending approval state until the banker's review accepts or declines it.
+func (server *Server) requestPaymentApproval(ctx *gin.Context, request db.PaymentRequest, fromAccount db.Account, fraudReview bool) {
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.RequestPaymentApprovalTx(ctx, db.RequestPaymentApprovalTxParams{
+		PaymentRequestID: request.ID,
+		FromAccountID:    fromAccount.ID,
+		Initiator:        authPayload.Username,
+		FraudReview:      fraudReview,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrPaymentRequestNotPending) || errors.Is(err, db.ErrPaymentRequestExpired) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{
+		"payment_request":   newPaymentRequestResponse(result.PaymentRequest),
+		"transfer_approval": newTransferApprovalResponse(result.TransferApproval),
+	})
+}
+
+func (server *Server) declinePaymentRequest(ctx *gin.Context) {
+	var req paymentRequestURI
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	request, valid := server.validPaymentRequest(ctx, req.ID)
+	if !valid {
+		return
+	}
+
+	if request.Payer != authPayload.Username {
+		err := errors.New("only the payer can decline a payment request")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	if !server.pendingPaymentRequest(ctx, request) {
+		return
+	}
+	setAuditBefore(ctx, newPaymentRequestResponse(request))
+
+	request, err = server.store.DeclinePaymentRequest(ctx, request.ID)
+	if err != nil {
+		// the request was accepted or declined concurrently
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusBadRequest, errorResponse(db.ErrPaymentRequestNotPending))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPaymentRequestResponse(request))
+}
+
+func (server *Server) validPaymentRequest(ctx *gin.Context, id int64) (db.PaymentRequest, bool) {
+	request, err := server.store.GetPaymentRequest(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return request, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return request, false
+	}
+
+	return request, true
+}
+
+func (server *Server) pendingPaymentRequest(ctx *gin.Context, request db.PaymentRequest) bool {
+	if request.Status != db.PaymentRequestStatusPending {
+		ctx.JSON(http.StatusBadRequest, errorResponse(db.ErrPaymentRequestNotPending))
+		return false
+	}
+
+	if request.IsExpired(time.Now()) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(db.ErrPaymentRequestExpired))
+		return false
+	}
+
+	return true
+}
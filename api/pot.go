@@ -0,0 +1,214 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+type potAccountURI struct {
+	AccountID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type potRequest struct {
+	Name string `json:"name" binding:"required,max=64"`
+	// TargetAmount is the amount saved up to in cents, 0 for none
+	TargetAmount int64 `json:"target_amount" binding:"min=0"`
+	// RoundUpTo rounds outgoing transfers of the parent account up to a multiple of it in cents, 0 to disable
+	RoundUpTo int64 `json:"round_up_to" binding:"min=0,max=10000"`
+}
+
+// createPot sets up a pot to put money aside from a checking or savings account of the authenticated user
+func (server *Server) createPot(ctx *gin.Context) {
+	var uriReq potAccountURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req potRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, uriReq.AccountID, db.AccountPermissionManage)
+	if !valid {
+		return
+	}
+
+	if account.Type != db.AccountTypeChecking && account.Type != db.AccountTypeSavings {
+		err := fmt.Errorf("account [%d] is a %s account, pots are set up under checking and savings accounts", account.ID, account.Type)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if account.OrganizationID.Valid {
+		err := fmt.Errorf("account [%d] belongs to an organization, which can't set up pots", account.ID)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.CreatePotTx(ctx, db.CreatePotTxParams{
+		ParentAccountID: account.ID,
+		Name:            req.Name,
+		TargetAmount:    req.TargetAmount,
+		RoundUpTo:       req.RoundUpTo,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				err := errors.New("the account already has a pot with this name, or a pot with round-ups")
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// listPots returns the pots of an account of the authenticated user with their balance
+func (server *Server) listPots(ctx *gin.Context) {
+	var req potAccountURI
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, req.AccountID, db.AccountPermissionManage)
+	if !valid {
+		return
+	}
+
+	pots, err := server.store.ListPots(ctx, account.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pots)
+}
+
+type potURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// updatePot renames a pot, or changes its target or round-up rule
+func (server *Server) updatePot(ctx *gin.Context) {
+	var uriReq potURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req potRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	pot, valid := server.validPot(ctx, uriReq.ID)
+	if !valid {
+		return
+	}
+	setAuditBefore(ctx, pot)
+
+	pot, err := server.store.UpdatePot(ctx, db.UpdatePotParams{
+		ID:           pot.ID,
+		Name:         req.Name,
+		TargetAmount: req.TargetAmount,
+		RoundUpTo:    req.RoundUpTo,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				err := errors.New("the account already has a pot with this name, or a pot with round-ups")
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pot)
+}
+
+type movePotRequest struct {
+	Amount int64 `json:"amount" binding:"required,gt=0"`
+}
+
+// depositPot moves money from the parent account into a pot
+func (server *Server) depositPot(ctx *gin.Context) {
+	server.movePot(ctx, 1)
+}
+
+// withdrawPot moves money from a pot back to its parent account
+func (server *Server) withdrawPot(ctx *gin.Context) {
+	server.movePot(ctx, -1)
+}
+
+// movePot moves the requested amount between a pot and its parent account, into the pot when sign is positive.
+// Moves are free and don't count as transfers.
+func (server *Server) movePot(ctx *gin.Context, sign int64) {
+	var uriReq potURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req movePotRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	pot, valid := server.validPot(ctx, uriReq.ID)
+	if !valid {
+		return
+	}
+
+	result, err := server.store.MovePotTx(ctx, db.MovePotTxParams{
+		PotID:  pot.ID,
+		Amount: sign * req.Amount,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// validPot loads a pot and checks that the authenticated user manages its parent account
+func (server *Server) validPot(ctx *gin.Context, id int64) (db.Pot, bool) {
+	pot, err := server.store.GetPot(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return pot, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return pot, false
+	}
+
+	if _, valid := server.authorizedAccount(ctx, pot.ParentAccountID, db.AccountPermissionManage); !valid {
+		return pot, false
+	}
+
+	return pot, true
+}
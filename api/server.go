@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/fraud"
 	"github.com/volskyi-dmytro/st-bank/token"
 	"github.com/volskyi-dmytro/st-bank/util"
 )
@@ -13,6 +14,8 @@ type Server struct {
 	store      db.Store
 	tokenMaker token.Maker
 	router     *gin.Engine
+	// fraudEngine is nil when fraud checks are disabled
+	fraudEngine *fraud.Engine
 }
 
 func NewServer(config util.Config, store db.Store) (*Server, error) {
@@ -37,11 +40,22 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 		store:      store,
 		tokenMaker: tokenMaker,
 	}
+
+	if config.FraudCheckEnabled {
+		server.fraudEngine, err = newFraudEngine(config, store)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create fraud engine: %w", err)
+		}
+	}
 	
 	// Register custom validators
 	RegisterValidators()
 	
 	router := gin.Default()
+	router.Use(requestIDMiddleware())
+	if config.AuditLogEnabled {
+		router.Use(auditMiddleware(store))
+	}
 
 	router.POST("/users", server.createUser)
 	router.POST("/users/login", server.loginUser)
@@ -49,11 +63,102 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
 	authRoutes.POST("/accounts", server.createAccount)
 	authRoutes.GET("/accounts/:id", server.getAccount)
+	authRoutes.GET("/accounts/:id/balance", server.getAccountBalance)
+	authRoutes.GET("/accounts/:id/statements", server.getAccountStatement)
+	authRoutes.GET("/accounts/:id/analytics/cash_flow", server.getCashFlow)
+	authRoutes.GET("/accounts/:id/analytics/counterparties", server.listTopCounterparties)
+	authRoutes.GET("/accounts/:id/analytics/average_balance", server.getAverageBalance)
+	authRoutes.GET("/accounts/:id/analytics/largest_transactions", server.listLargestTransactions)
+	authRoutes.GET("/accounts/:id/fees", server.listAccountFees)
+	authRoutes.PUT("/accounts/:id/overdraft", server.grantOverdraft)
+	authRoutes.DELETE("/accounts/:id/overdraft", server.revokeOverdraft)
+	authRoutes.GET("/accounts/:id/overdraft/notifications", server.listOverdraftNotifications)
+	authRoutes.POST("/accounts/:id/pots", server.createPot)
+	authRoutes.GET("/accounts/:id/pots", server.listPots)
+	authRoutes.GET("/accounts/:id/members", server.listAccountMembers)
+	authRoutes.PUT("/accounts/:id/members/:username", server.grantAccountMember)
+	authRoutes.DELETE("/accounts/:id/members/:username", server.revokeAccountMember)
 	authRoutes.GET("/accounts", server.listAccounts)
-	authRoutes.PUT("/accounts/:id", server.updateAccount)
 	authRoutes.DELETE("/accounts/:id", server.deleteAccount)
 	
 	authRoutes.POST("/transfers", server.createTransfer)
+	authRoutes.GET("/transfers", server.listTransfers)
+	authRoutes.POST("/transfers/:id/approve", server.reviewTransferApproval)
+	authRoutes.POST("/transfers/:id/reverse", server.reverseTransfer)
+	authRoutes.GET("/transfer_approvals", server.listTransferApprovals)
+	authRoutes.POST("/payment_initiations", server.createPaymentInitiation)
+
+	authRoutes.POST("/organizations", server.createOrganization)
+	authRoutes.GET("/organizations", server.listOrganizations)
+	authRoutes.PUT("/organizations/:id/policy", server.updateOrganizationPolicy)
+	authRoutes.GET("/organizations/:id/members", server.listOrganizationMembers)
+	authRoutes.PUT("/organizations/:id/members/:username", server.addOrganizationMember)
+	authRoutes.DELETE("/organizations/:id/members/:username", server.removeOrganizationMember)
+	authRoutes.GET("/organizations/:id/transfer_approvals", server.listOrganizationTransferApprovals)
+	authRoutes.POST("/organizations/:id/transfer_approvals/:approval_id", server.signOrganizationTransferApproval)
+	authRoutes.POST("/users/context", server.switchContext)
+
+	authRoutes.POST("/payment_requests", server.createPaymentRequest)
+	authRoutes.GET("/payment_requests/:id", server.getPaymentRequest)
+	authRoutes.GET("/payment_requests", server.listPaymentRequests)
+	authRoutes.POST("/payment_requests/:id/accept", server.acceptPaymentRequest)
+	authRoutes.POST("/payment_requests/:id/decline", server.declinePaymentRequest)
+
+	authRoutes.POST("/beneficiaries", server.createBeneficiary)
+	authRoutes.GET("/beneficiaries/:id", server.getBeneficiary)
+	authRoutes.GET("/beneficiaries", server.listBeneficiaries)
+	authRoutes.PUT("/beneficiaries/:id", server.updateBeneficiary)
+	authRoutes.DELETE("/beneficiaries/:id", server.deleteBeneficiary)
+
+	authRoutes.POST("/interest_plans", server.createInterestPlan)
+	authRoutes.GET("/interest_plans", server.listInterestPlans)
+
+	authRoutes.POST("/loans", server.createLoan)
+	authRoutes.GET("/loans", server.listLoans)
+	authRoutes.GET("/loans/:id", server.getLoan)
+	authRoutes.POST("/loans/:id/repayment", server.repayLoan)
+
+	authRoutes.PUT("/pots/:id", server.updatePot)
+	authRoutes.POST("/pots/:id/deposit", server.depositPot)
+	authRoutes.POST("/pots/:id/withdraw", server.withdrawPot)
+
+	authRoutes.POST("/accounts/:id/cards", server.issueCard)
+	authRoutes.GET("/accounts/:id/cards", server.listCards)
+	authRoutes.POST("/cards/:id/freeze", server.freezeCard)
+	authRoutes.POST("/cards/:id/unfreeze", server.unfreezeCard)
+	authRoutes.PUT("/cards/:id/limit", server.updateCardLimit)
+	authRoutes.GET("/cards/:id/authorizations", server.listCardAuthorizations)
+
+	authRoutes.POST("/mandates", server.createMandate)
+	authRoutes.GET("/accounts/:id/mandates", server.listMandates)
+	authRoutes.DELETE("/mandates/:id", server.revokeMandate)
+	authRoutes.POST("/mandates/:id/direct_debits", server.collectDirectDebit)
+	authRoutes.GET("/mandates/:id/direct_debits", server.listDirectDebits)
+	authRoutes.POST("/direct_debits/:id/dispute", server.disputeDirectDebit)
+
+	authRoutes.POST("/categories", server.createSpendingCategory)
+	authRoutes.GET("/categories", server.listSpendingCategories)
+	authRoutes.PUT("/categories/:id/budgets/:currency", server.setCategoryBudget)
+	authRoutes.DELETE("/categories/:id/budgets/:currency", server.deleteCategoryBudget)
+	authRoutes.POST("/categorization_rules", server.createCategorizationRule)
+	authRoutes.GET("/categorization_rules", server.listCategorizationRules)
+	authRoutes.DELETE("/categorization_rules/:id", server.deleteCategorizationRule)
+	authRoutes.PUT("/transfers/:id/category", server.setTransferCategory)
+	authRoutes.DELETE("/transfers/:id/category", server.deleteTransferCategory)
+	authRoutes.GET("/spending", server.getSpending)
+
+	authRoutes.POST("/escrows", server.createEscrow)
+	authRoutes.GET("/accounts/:id/escrows", server.listEscrows)
+	authRoutes.GET("/escrows/:id", server.getEscrow)
+	authRoutes.POST("/escrows/:id/release", server.releaseEscrow)
+	authRoutes.POST("/escrows/:id/dispute", server.disputeEscrow)
+	authRoutes.POST("/escrows/:id/resolve", server.resolveEscrow)
+	authRoutes.GET("/escrow_disputes", server.listEscrowDisputes)
+
+	merchantRoutes := router.Group("/card_authorizations").Use(merchantMiddleware(config.CardMerchantKey))
+	merchantRoutes.POST("", server.authorizeCard)
+	merchantRoutes.POST("/:id/clear", server.clearCardAuthorization)
+	merchantRoutes.POST("/:id/release", server.releaseCardAuthorization)
 
 	server.router = router
 	return server, nil
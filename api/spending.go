@@ -0,0 +1,121 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/spending"
+)
+
+// maxSpendingMonths limits the months a single spending summary covers
+const maxSpendingMonths = 12
+
+type spendingRequest struct {
+	From time.Time `form:"from" binding:"required" time_format:"2006-01" time_utc:"1"`
+	To   time.Time `form:"to" binding:"required" time_format:"2006-01" time_utc:"1"`
+}
+
+type spendingLineResponse struct {
+	CategoryID int64  `json:"category_id,omitempty"`
+	Category   string `json:"category,omitempty"`
+	Currency   string `json:"currency"`
+	// Budget and Remaining are left out when the category has no budget in the currency
+	Budget    *int64 `json:"budget,omitempty"`
+	Spent     int64  `json:"spent"`
+	Remaining *int64 `json:"remaining,omitempty"`
+	Transfers int64  `json:"transfers"`
+}
+
+type spendingMonthResponse struct {
+	// Month is the month of the spending, e.g. 2026-06
+	Month string                 `json:"month"`
+	Lines []spendingLineResponse `json:"lines"`
+}
+
+func newSpendingMonthResponse(month spending.Month) spendingMonthResponse {
+	rsp := spendingMonthResponse{
+		Month: month.Month.Format("2006-01"),
+		Lines: make([]spendingLineResponse, 0, len(month.Lines)),
+	}
+	for _, line := range month.Lines {
+		lineRsp := spendingLineResponse{
+			CategoryID: line.CategoryID,
+			Category:   line.Category,
+			Currency:   line.Currency,
+			Spent:      line.Spent,
+			Transfers:  line.Transfers,
+		}
+		if line.HasBudget() {
+			budget, remaining := line.Budget, line.Remaining()
+			lineRsp.Budget = &budget
+			lineRsp.Remaining = &remaining
+		}
+		rsp.Lines = append(rsp.Lines, lineRsp)
+	}
+	return rsp
+}
+
+// getSpending sums up what the user spent from their personal accounts by category, for the months from and to,
+// both included, against the budgets of the categories. Uncategorized spending comes last each month.
+func (server *Server) getSpending(ctx *gin.Context) {
+	var req spendingRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// the summary ends at the start of the month after to
+	to := req.To.AddDate(0, 1, 0)
+	if !to.After(req.From) {
+		err := errors.New("from must not be after to")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if to.After(req.From.AddDate(0, maxSpendingMonths, 0)) {
+		err := fmt.Errorf("a spending summary can't cover more than %d months", maxSpendingMonths)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	categories, err := server.store.ListSpendingCategories(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	budgets, err := server.store.ListCategoryBudgets(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.ListMonthlySpending(ctx, db.ListMonthlySpendingParams{
+		Owner:    authPayload.Username,
+		FromTime: req.From,
+		ToTime:   to,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	months := spending.Summarize(req.From, to, categories, budgets, rows)
+
+	rsp := make([]spendingMonthResponse, 0, len(months))
+	for _, month := range months {
+		rsp = append(rsp, newSpendingMonthResponse(month))
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
@@ -0,0 +1,227 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+type spendingCategoryResponse struct {
+	ID        int64               `json:"id"`
+	Name      string              `json:"name"`
+	CreatedAt time.Time           `json:"created_at"`
+	Budgets   []db.CategoryBudget `json:"budgets"`
+}
+
+type createSpendingCategoryRequest struct {
+	Name string `json:"name" binding:"required,max=64"`
+}
+
+// createSpendingCategory adds a category the user can sort their outgoing transfers into
+func (server *Server) createSpendingCategory(ctx *gin.Context) {
+	var req createSpendingCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	category, err := server.store.CreateSpendingCategory(ctx, db.CreateSpendingCategoryParams{
+		Owner: authPayload.Username,
+		Name:  req.Name,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, spendingCategoryResponse{
+		ID:        category.ID,
+		Name:      category.Name,
+		CreatedAt: category.CreatedAt,
+		Budgets:   []db.CategoryBudget{},
+	})
+}
+
+// listSpendingCategories returns the categories of the user by name, with their monthly budgets
+func (server *Server) listSpendingCategories(ctx *gin.Context) {
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	categories, err := server.store.ListSpendingCategories(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	budgets, err := server.store.ListCategoryBudgets(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	byCategory := make(map[int64][]db.CategoryBudget)
+	for _, budget := range budgets {
+		byCategory[budget.CategoryID] = append(byCategory[budget.CategoryID], budget)
+	}
+
+	rsp := make([]spendingCategoryResponse, 0, len(categories))
+	for _, category := range categories {
+		categoryBudgets := byCategory[category.ID]
+		if categoryBudgets == nil {
+			categoryBudgets = []db.CategoryBudget{}
+		}
+		rsp = append(rsp, spendingCategoryResponse{
+			ID:        category.ID,
+			Name:      category.Name,
+			CreatedAt: category.CreatedAt,
+			Budgets:   categoryBudgets,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type categoryBudgetURI struct {
+	CategoryID int64  `uri:"id" binding:"required,min=1"`
+	Currency   string `uri:"currency" binding:"required,currency"`
+}
+
+type setCategoryBudgetRequest struct {
+	// Amount is what the user plans to spend in the category each month, in cents of the currency
+	Amount int64 `json:"amount" binding:"required,gt=0"`
+}
+
+// setCategoryBudget sets the monthly budget of a category in a currency, replacing the previous one
+func (server *Server) setCategoryBudget(ctx *gin.Context) {
+	var uriReq categoryBudgetURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req setCategoryBudgetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	category, valid := server.validSpendingCategory(ctx, uriReq.CategoryID)
+	if !valid {
+		return
+	}
+
+	existing, err := server.store.GetCategoryBudget(ctx, db.GetCategoryBudgetParams{
+		CategoryID: category.ID,
+		Currency:   uriReq.Currency,
+	})
+	if err == nil {
+		setAuditBefore(ctx, existing)
+	} else if err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	budget, err := server.store.SetCategoryBudget(ctx, db.SetCategoryBudgetParams{
+		CategoryID: category.ID,
+		Currency:   uriReq.Currency,
+		Amount:     req.Amount,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, budget)
+}
+
+// deleteCategoryBudget removes the monthly budget of a category in a currency
+func (server *Server) deleteCategoryBudget(ctx *gin.Context) {
+	var req categoryBudgetURI
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	category, valid := server.validSpendingCategory(ctx, req.CategoryID)
+	if !valid {
+		return
+	}
+
+	budget, err := server.store.GetCategoryBudget(ctx, db.GetCategoryBudgetParams{
+		CategoryID: category.ID,
+		Currency:   req.Currency,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	setAuditBefore(ctx, budget)
+
+	err = server.store.DeleteCategoryBudget(ctx, db.DeleteCategoryBudgetParams{
+		CategoryID: category.ID,
+		Currency:   req.Currency,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "budget deleted successfully"})
+}
+
+// validSpendingCategory loads a category and checks that it belongs to the authenticated user
+func (server *Server) validSpendingCategory(ctx *gin.Context, id int64) (db.SpendingCategory, bool) {
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return db.SpendingCategory{}, false
+	}
+
+	return server.ownedSpendingCategory(ctx, id, authPayload.Username)
+}
+
+// ownedSpendingCategory loads a category and checks that it belongs to owner
+func (server *Server) ownedSpendingCategory(ctx *gin.Context, id int64, owner string) (db.SpendingCategory, bool) {
+	category, err := server.store.GetSpendingCategory(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return category, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return category, false
+	}
+
+	if category.Owner != owner {
+		err := fmt.Errorf("category doesn't belong to %s", owner)
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return category, false
+	}
+
+	return category, true
+}
@@ -0,0 +1,285 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/volskyi-dmytro/st-bank/db/mock"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/token"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+func randomSpendingCategory(owner string) db.SpendingCategory {
+	return db.SpendingCategory{
+		ID:    util.RandomInt(1, 1000),
+		Owner: owner,
+		Name:  util.RandomString(8),
+	}
+}
+
+func TestCreateSpendingCategoryAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	category := randomSpendingCategory(user.Username)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": category.Name},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateSpendingCategoryParams{
+					Owner: user.Username,
+					Name:  category.Name,
+				}
+				store.EXPECT().CreateSpendingCategory(gomock.Any(), gomock.Eq(arg)).Times(1).Return(category, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got spendingCategoryResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, category.ID, got.ID)
+				require.Equal(t, category.Name, got.Name)
+				require.Empty(t, got.Budgets)
+			},
+		},
+		{
+			name: "MissingName",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateSpendingCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicateName",
+			body: gin.H{"name": category.Name},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateSpendingCategory(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.SpendingCategory{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/categories", bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListSpendingCategoriesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	groceries := randomSpendingCategory(user.Username)
+	rent := randomSpendingCategory(user.Username)
+	rent.ID = groceries.ID + 1
+	budget := db.CategoryBudget{CategoryID: rent.ID, Currency: "EUR", Amount: 1000}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListSpendingCategories(gomock.Any(), gomock.Eq(user.Username)).Times(1).
+		Return([]db.SpendingCategory{groceries, rent}, nil)
+	store.EXPECT().ListCategoryBudgets(gomock.Any(), gomock.Eq(user.Username)).Times(1).
+		Return([]db.CategoryBudget{budget}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/categories", nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got []spendingCategoryResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &got)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, groceries.ID, got[0].ID)
+	require.Empty(t, got[0].Budgets)
+	require.Equal(t, rent.ID, got[1].ID)
+	require.Equal(t, []db.CategoryBudget{budget}, got[1].Budgets)
+}
+
+func TestSetCategoryBudgetAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	category := randomSpendingCategory(user.Username)
+	budget := db.CategoryBudget{
+		CategoryID: category.ID,
+		Currency:   "EUR",
+		Amount:     util.RandomMoney() + 1,
+	}
+
+	testCases := []struct {
+		name          string
+		categoryID    int64
+		currency      string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			categoryID: category.ID,
+			currency:   "EUR",
+			body:       gin.H{"amount": budget.Amount},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SetCategoryBudgetParams{
+					CategoryID: category.ID,
+					Currency:   "EUR",
+					Amount:     budget.Amount,
+				}
+				store.EXPECT().GetSpendingCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
+				store.EXPECT().GetCategoryBudget(gomock.Any(), gomock.Any()).Times(1).Return(db.CategoryBudget{}, sql.ErrNoRows)
+				store.EXPECT().SetCategoryBudget(gomock.Any(), gomock.Eq(arg)).Times(1).Return(budget, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.CategoryBudget
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, budget, got)
+			},
+		},
+		{
+			name:       "UnsupportedCurrency",
+			categoryID: category.ID,
+			currency:   "XYZ",
+			body:       gin.H{"amount": budget.Amount},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSpendingCategory(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().SetCategoryBudget(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "NegativeAmount",
+			categoryID: category.ID,
+			currency:   "EUR",
+			body:       gin.H{"amount": -100},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSpendingCategory(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().SetCategoryBudget(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "CategoryOfAnotherUser",
+			categoryID: category.ID,
+			currency:   "EUR",
+			body:       gin.H{"amount": budget.Amount},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized", util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSpendingCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
+				store.EXPECT().SetCategoryBudget(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:       "CategoryNotFound",
+			categoryID: category.ID,
+			currency:   "EUR",
+			body:       gin.H{"amount": budget.Amount},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSpendingCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(db.SpendingCategory{}, sql.ErrNoRows)
+				store.EXPECT().SetCategoryBudget(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/categories/%d/budgets/%s", tc.categoryID, tc.currency)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/volskyi-dmytro/st-bank/db/mock"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+func TestGetSpendingAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	category := randomSpendingCategory(user.Username)
+	budget := db.CategoryBudget{CategoryID: category.ID, Currency: "EUR", Amount: 500}
+
+	may := time.Date(2026, time.May, 1, 0, 0, 0, 0, time.UTC)
+	june := may.AddDate(0, 1, 0)
+	july := june.AddDate(0, 1, 0)
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: url.Values{"from": {"2026-05"}, "to": {"2026-06"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListMonthlySpendingParams{
+					Owner:    user.Username,
+					FromTime: may,
+					ToTime:   july,
+				}
+				rows := []db.ListMonthlySpendingRow{
+					{Month: may, CategoryID: sql.NullInt64{Int64: category.ID, Valid: true}, Currency: "EUR", TransferCount: 3, Spent: 650},
+					{Month: june, Currency: "EUR", TransferCount: 1, Spent: 20},
+				}
+
+				store.EXPECT().ListSpendingCategories(gomock.Any(), gomock.Eq(user.Username)).Times(1).
+					Return([]db.SpendingCategory{category}, nil)
+				store.EXPECT().ListCategoryBudgets(gomock.Any(), gomock.Eq(user.Username)).Times(1).
+					Return([]db.CategoryBudget{budget}, nil)
+				store.EXPECT().ListMonthlySpending(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []spendingMonthResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Len(t, got, 2)
+
+				require.Equal(t, "2026-05", got[0].Month)
+				require.Len(t, got[0].Lines, 1)
+				require.Equal(t, category.Name, got[0].Lines[0].Category)
+				require.Equal(t, int64(650), got[0].Lines[0].Spent)
+				require.Equal(t, int64(500), *got[0].Lines[0].Budget)
+				require.Equal(t, int64(-150), *got[0].Lines[0].Remaining)
+
+				// the budget is still there in June, next to the uncategorized spending
+				require.Equal(t, "2026-06", got[1].Month)
+				require.Len(t, got[1].Lines, 2)
+				require.Equal(t, category.ID, got[1].Lines[0].CategoryID)
+				require.Zero(t, got[1].Lines[0].Spent)
+				require.Equal(t, int64(500), *got[1].Lines[0].Remaining)
+				require.Zero(t, got[1].Lines[1].CategoryID)
+				require.Nil(t, got[1].Lines[1].Budget)
+				require.Equal(t, int64(20), got[1].Lines[1].Spent)
+			},
+		},
+		{
+			name:  "FromAfterTo",
+			query: url.Values{"from": {"2026-06"}, "to": {"2026-05"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListMonthlySpending(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "TooManyMonths",
+			query: url.Values{"from": {"2025-05"}, "to": {"2026-05"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListMonthlySpending(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidMonth",
+			query: url.Values{"from": {"2026-05-01"}, "to": {"2026-06"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListMonthlySpending(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/spending?"+tc.query.Encode(), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -1,19 +1,30 @@
 package api
 
 import (
+	"bytes"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
 	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/fraud"
 )
 
 type transferRequest struct {
-	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
-	Amount        int64  `json:"amount" binding:"required,gt=0"`
-	Currency      string `json:"currency" binding:"required,currency"`
+	FromAccountID int64           `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID   int64           `json:"to_account_id" binding:"required_without=BeneficiaryID,omitempty,min=1"`
+	BeneficiaryID int64           `json:"beneficiary_id" binding:"omitempty,min=1"`
+	Amount        int64           `json:"amount" binding:"required,gt=0"`
+	Currency      string          `json:"currency" binding:"required,currency"`
+	Description   string          `json:"description" binding:"max=140"`
+	Reference     string          `json:"reference" binding:"max=35"`
+	Metadata      json.RawMessage `json:"metadata"`
 }
 
 func (server *Server) createTransfer(ctx *gin.Context) {
@@ -23,6 +34,26 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
+	if len(req.Metadata) > 0 && !isJSONObject(req.Metadata) {
+		err := errors.New("metadata must be a JSON object")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.BeneficiaryID != 0 {
+		if req.ToAccountID != 0 {
+			err := errors.New("to_account_id and beneficiary_id cannot be used together")
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		toAccountID, valid := server.beneficiaryTransferTarget(ctx, req.BeneficiaryID, req.Amount)
+		if !valid {
+			return
+		}
+		req.ToAccountID = toAccountID
+	}
+
 	// Custom validation: accounts cannot be the same
 	if req.FromAccountID == req.ToAccountID {
 		err := fmt.Errorf("from_account_id and to_account_id cannot be the same")
@@ -36,15 +67,49 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
+	if !server.authorizeAccount(ctx, fromAccount, db.AccountPermissionTransfer, req.Amount) {
+		return
+	}
+
 	_, valid = server.validAccount(ctx, req.ToAccountID, req.Currency)
 	if !valid {
 		return
 	}
 
-	// Custom validation: check if from account has sufficient balance
-	if fromAccount.Balance < req.Amount {
-		err := fmt.Errorf("account [%d] has insufficient balance: %d", req.FromAccountID, fromAccount.Balance)
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	if req.BeneficiaryID == 0 && !server.savedBeneficiaryCoolingOff(ctx, req.ToAccountID, req.Amount) {
+		return
+	}
+
+	// Custom validation: check if from account has sufficient balance, including its overdraft limit
+	if !server.sufficientFunds(ctx, fromAccount, req.Amount) {
+		return
+	}
+
+	fraudReview := false
+	if server.fraudEngine != nil {
+		decision, valid := server.assessTransfer(ctx, req)
+		if !valid {
+			return
+		}
+		fraudReview = decision == fraud.Review
+	}
+
+	// Transfers initiated by an initiator of an organization wait for the approvals its policy asks for,
+	// and for a banker afterwards when they are large or suspicious
+	if fromAccount.OrganizationID.Valid {
+		required, valid := server.organizationApprovalRequired(ctx, fromAccount.OrganizationID.Int64, req.Amount)
+		if !valid {
+			return
+		}
+		if required {
+			server.requestTransferApproval(ctx, req, db.TransferApprovalStatusPendingOrganization, fraudReview)
+			return
+		}
+	}
+
+	// Large and suspicious transfers are held until a banker approves them
+	if fraudReview || server.bankReviewRequired(req.Amount) {
+		server.requestTransferApproval(ctx, req, db.TransferApprovalStatusPending, fraudReview)
 		return
 	}
 
@@ -52,10 +117,23 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		FromAccountID: req.FromAccountID,
 		ToAccountID:   req.ToAccountID,
 		Amount:        req.Amount,
+		Description:   req.Description,
+		Reference:     req.Reference,
+		Metadata:      req.Metadata,
 	}
 
 	result, err := server.store.TransferTx(ctx, arg)
 	if err != nil {
+		// the balance may have changed since it was checked
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if referenceAlreadyUsed(err) {
+			err := fmt.Errorf("reference %q was already used for account [%d]", req.Reference, req.FromAccountID)
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -63,6 +141,77 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, result)
 }
 
+type listTransfersRequest struct {
+	AccountID   int64  `form:"account_id" binding:"required,min=1"`
+	PageID      int32  `form:"page_id" binding:"required,min=1"`
+	PageSize    int32  `form:"page_size" binding:"required,min=5,max=10"`
+	Reference   string `form:"reference"`
+	Description string `form:"description"`
+	Metadata    string `form:"metadata"`
+}
+
+// listTransfers returns the transfer history of an account.
+// Results can be narrowed down by exact reference, a description substring,
+// or a JSON object that the transfer metadata must contain.
+func (server *Server) listTransfers(ctx *gin.Context) {
+	var req listTransfersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.Metadata != "" && !isJSONObject([]byte(req.Metadata)) {
+		err := errors.New("metadata must be a JSON object")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, valid := server.authorizedAccount(ctx, req.AccountID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	arg := db.SearchTransfersParams{
+		AccountID:   req.AccountID,
+		Reference:   sql.NullString{String: req.Reference, Valid: req.Reference != ""},
+		Description: sql.NullString{String: escapeLikePattern(req.Description), Valid: req.Description != ""},
+		Metadata:    sql.NullString{String: req.Metadata, Valid: req.Metadata != ""},
+		PageLimit:   req.PageSize,
+		PageOffset:  (req.PageID - 1) * req.PageSize,
+	}
+
+	transfers, err := server.store.SearchTransfers(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transfers)
+}
+
+// isJSONObject reports whether data is a valid JSON object
+func isJSONObject(data []byte) bool {
+	return json.Valid(data) && bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+}
+
+// likePatternEscaper escapes the wildcards of LIKE patterns with the default backslash escape character
+var likePatternEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLikePattern makes the description filter match a literal substring, with % and _ matching themselves
+func escapeLikePattern(pattern string) string {
+	return likePatternEscaper.Replace(pattern)
+}
+
+// transferReferenceConstraint is the unique index keeping the references of a sender's transfers apart
+const transferReferenceConstraint = "transfers_from_account_reference_key"
+
+// referenceAlreadyUsed reports whether a transfer failed because its sender already used its reference.
+// Other unique violations are left to the caller, as they don't mean the client can retry with a new reference.
+func referenceAlreadyUsed(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code.Name() == "unique_violation" && pqErr.Constraint == transferReferenceConstraint
+}
+
 func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
 	account, err := server.store.GetAccount(ctx, accountID)
 	if err != nil {
@@ -80,5 +229,76 @@ func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency s
 		return account, false
 	}
 
+	if account.Type == db.AccountTypeLoan {
+		err := fmt.Errorf("account [%d] is a loan account, it is repaid through its loan", account.ID)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return account, false
+	}
+
+	if account.Type == db.AccountTypePot {
+		err := fmt.Errorf("account [%d] is a pot account, money is moved through its pot", account.ID)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return account, false
+	}
+
+	if account.Type == db.AccountTypeEscrow {
+		err := fmt.Errorf("account [%d] is an escrow account, money is moved through its escrow", account.ID)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return account, false
+	}
+
 	return account, true
-}
\ No newline at end of file
+}
+
+// beneficiaryTransferTarget resolves a saved beneficiary to its account.
+// Beneficiaries added within the cooling-off period can only receive transfers up to a lower limit.
+func (server *Server) beneficiaryTransferTarget(ctx *gin.Context, beneficiaryID int64, amount int64) (int64, bool) {
+	beneficiary, valid := server.validBeneficiary(ctx, beneficiaryID)
+	if !valid {
+		return 0, false
+	}
+
+	if !server.beneficiaryCoolingOff(ctx, beneficiary, amount) {
+		return 0, false
+	}
+
+	return beneficiary.AccountID, true
+}
+
+// savedBeneficiaryCoolingOff applies the cooling-off limit to transfers sent straight to the account of one of
+// the user's beneficiaries, so that it can't be bypassed by leaving out the beneficiary_id
+func (server *Server) savedBeneficiaryCoolingOff(ctx *gin.Context, toAccountID int64, amount int64) bool {
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return false
+	}
+
+	beneficiary, err := server.store.GetBeneficiaryByAccount(ctx, db.GetBeneficiaryByAccountParams{
+		Owner:     authPayload.Username,
+		AccountID: toAccountID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return true
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	return server.beneficiaryCoolingOff(ctx, beneficiary, amount)
+}
+
+// beneficiaryCoolingOff checks that a transfer to a beneficiary added less than the cooling-off period ago
+// is within the cooling-off limit
+func (server *Server) beneficiaryCoolingOff(ctx *gin.Context, beneficiary db.Beneficiary, amount int64) bool {
+	coolingOffEnd := beneficiary.CreatedAt.Add(server.config.BeneficiaryCoolingOffPeriod)
+	if time.Now().Before(coolingOffEnd) && amount > server.config.BeneficiaryCoolingOffLimit {
+		err := fmt.Errorf("beneficiary [%d] is in its cooling-off period until %s: transfer limit is %d",
+			beneficiary.ID, coolingOffEnd.Format(time.RFC3339), server.config.BeneficiaryCoolingOffLimit)
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,233 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+type transferApprovalResponse struct {
+	ID            int64           `json:"id"`
+	FromAccountID int64           `json:"from_account_id"`
+	ToAccountID   int64           `json:"to_account_id"`
+	Amount        int64           `json:"amount"`
+	Currency      string          `json:"currency"`
+	Description   string          `json:"description"`
+	Reference     string          `json:"reference"`
+	Metadata      json.RawMessage `json:"metadata"`
+	Initiator     string          `json:"initiator"`
+	Status        string          `json:"status"`
+	Reviewer      string          `json:"reviewer,omitempty"`
+	TransferID    *int64          `json:"transfer_id,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	ReviewedAt    *time.Time      `json:"reviewed_at,omitempty"`
+	// PaymentRequestID is the payment request the transfer pays, which the review accepts or declines
+	PaymentRequestID *int64 `json:"payment_request_id,omitempty"`
+}
+
+func newTransferApprovalResponse(approval db.TransferApproval) transferApprovalResponse {
+	rsp := transferApprovalResponse{
+		ID:            approval.ID,
+		FromAccountID: approval.FromAccountID,
+		ToAccountID:   approval.ToAccountID,
+		Amount:        approval.Amount,
+		Currency:      approval.Currency,
+		Description:   approval.Description,
+		Reference:     approval.Reference,
+		Metadata:      approval.Metadata,
+		Initiator:     approval.Initiator,
+		Status:        approval.Status,
+		Reviewer:      approval.Reviewer,
+		CreatedAt:     approval.CreatedAt,
+	}
+	if approval.TransferID.Valid {
+		rsp.TransferID = &approval.TransferID.Int64
+	}
+	if approval.PaymentRequestID.Valid {
+		rsp.PaymentRequestID = &approval.PaymentRequestID.Int64
+	}
+	if approval.Status != db.TransferApprovalStatusPending && approval.Status != db.TransferApprovalStatusPendingOrganization {
+		rsp.ReviewedAt = &approval.ReviewedAt
+	}
+	return rsp
+}
+
+// requestTransferApproval stores a validated transfer request until it is reviewed: by a banker when status is
+// pending approval, or by the approvers of the organization holding the account first when it is pending organization.
+// fraudReview records that the fraud checks asked for a banker's review, whatever the amount.
+func (server *Server) requestTransferApproval(ctx *gin.Context, req transferRequest, status string, fraudReview bool) {
+	authPayload, err := getCurrentUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	metadata := req.Metadata
+	if len(metadata) == 0 {
+		metadata = json.RawMessage("{}")
+	}
+
+	arg := db.CreateTransferApprovalParams{
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        req.Amount,
+		Currency:      req.Currency,
+		Description:   req.Description,
+		Reference:     req.Reference,
+		Metadata:      metadata,
+		Initiator:     authPayload.Username,
+		Status:        status,
+		FraudReview:   fraudReview,
+	}
+
+	approval, err := server.store.CreateTransferApproval(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, newTransferApprovalResponse(approval))
+}
+
+// bankReviewRequired reports whether a transfer of amount is large enough to be held for a banker's approval
+func (server *Server) bankReviewRequired(amount int64) bool {
+	threshold := server.config.TransferApprovalThreshold
+	return threshold > 0 && amount > threshold
+}
+
+type transferApprovalURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type reviewTransferApprovalRequest struct {
+	Decision string `json:"decision" binding:"required,oneof=approve reject"`
+}
+
+// reviewTransferApproval lets a banker other than the initiator approve or reject a pending transfer.
+// An approved transfer is executed immediately.
+func (server *Server) reviewTransferApproval(ctx *gin.Context) {
+	var uriReq transferApprovalURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req reviewTransferApprovalRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, valid := server.bankerUser(ctx)
+	if !valid {
+		return
+	}
+
+	approval, err := server.store.GetTransferApproval(ctx, uriReq.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if approval.Initiator == authPayload.Username {
+		ctx.JSON(http.StatusForbidden, errorResponse(db.ErrSelfApproval))
+		return
+	}
+
+	if approval.Status != db.TransferApprovalStatusPending {
+		ctx.JSON(http.StatusBadRequest, errorResponse(db.ErrTransferApprovalNotPending))
+		return
+	}
+	setAuditBefore(ctx, newTransferApprovalResponse(approval))
+
+	approve := req.Decision == "approve"
+	if approve {
+		// the balance may have changed while the transfer was waiting for approval
+		fromAccount, valid := server.validAccount(ctx, approval.FromAccountID, approval.Currency)
+		if !valid {
+			return
+		}
+
+		if !server.sufficientFunds(ctx, fromAccount, approval.Amount) {
+			return
+		}
+	}
+
+	arg := db.ReviewTransferApprovalTxParams{
+		ID:       approval.ID,
+		Reviewer: authPayload.Username,
+		Approve:  approve,
+	}
+
+	result, err := server.store.ReviewTransferApprovalTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrTransferApprovalNotPending) || errors.Is(err, db.ErrInsufficientFunds) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrSelfApproval) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		// the reference may have been used by another transfer while this one was waiting for approval
+		if referenceAlreadyUsed(err) {
+			err := fmt.Errorf("reference %q was already used for account [%d]", approval.Reference, approval.FromAccountID)
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"transfer_approval": newTransferApprovalResponse(result.TransferApproval),
+		"transfer":          result.Transfer,
+	})
+}
+
+type listTransferApprovalsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listTransferApprovals returns the transfers waiting for a banker's review
+func (server *Server) listTransferApprovals(ctx *gin.Context) {
+	var req listTransferApprovalsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, valid := server.bankerUser(ctx); !valid {
+		return
+	}
+
+	arg := db.ListTransferApprovalsParams{
+		Status: db.TransferApprovalStatusPending,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	approvals, err := server.store.ListTransferApprovals(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]transferApprovalResponse, 0, len(approvals))
+	for _, approval := range approvals {
+		rsp = append(rsp, newTransferApprovalResponse(approval))
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
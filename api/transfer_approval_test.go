@@ -0,0 +1,396 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/volskyi-dmytro/st-bank/db/mock"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/token"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+func randomTransferApproval(initiator string, fromAccount, toAccount db.Account) db.TransferApproval {
+	return db.TransferApproval{
+		ID:            util.RandomInt(1, 1000),
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        util.RandomInt(1, 100),
+		Currency:      fromAccount.Currency,
+		Metadata:      json.RawMessage("{}"),
+		Initiator:     initiator,
+		Status:        db.TransferApprovalStatusPending,
+		CreatedAt:     time.Now(),
+	}
+}
+
+func TestReviewTransferApprovalAPI(t *testing.T) {
+	initiator, _ := randomUser(t)
+	banker, _ := randomUser(t)
+
+	fromAccount := randomAccount()
+	fromAccount.Owner = initiator.Username
+	fromAccount.Currency = "USD"
+	fromAccount.Balance = 1000
+	toAccount := randomAccount()
+	toAccount.Currency = "USD"
+
+	approval := randomTransferApproval(initiator.Username, fromAccount, toAccount)
+
+	approved := approval
+	approved.Status = db.TransferApprovalStatusApproved
+	approved.Reviewer = banker.Username
+	approved.TransferID = sql.NullInt64{Int64: 1, Valid: true}
+	approved.ReviewedAt = time.Now()
+
+	rejected := approval
+	rejected.Status = db.TransferApprovalStatusRejected
+	rejected.Reviewer = banker.Username
+	rejected.ReviewedAt = time.Now()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Approve",
+			body: gin.H{"decision": "approve"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, banker.Username, util.BankerRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ReviewTransferApprovalTxParams{
+					ID:       approval.ID,
+					Reviewer: banker.Username,
+					Approve:  true,
+				}
+
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().
+					ReviewTransferApprovalTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ReviewTransferApprovalTxResult{
+						TransferApproval: approved,
+						Transfer:         &db.TransferTxResult{Transfer: db.Transfer{ID: 1}},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchReviewedApproval(t, recorder.Body, approved)
+			},
+		},
+		{
+			name: "Reject",
+			body: gin.H{"decision": "reject"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, banker.Username, util.BankerRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ReviewTransferApprovalTxParams{
+					ID:       approval.ID,
+					Reviewer: banker.Username,
+					Approve:  false,
+				}
+
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					ReviewTransferApprovalTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ReviewTransferApprovalTxResult{TransferApproval: rejected}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchReviewedApproval(t, recorder.Body, rejected)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			body: gin.H{"decision": "approve"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewTransferApprovalTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NotBanker",
+			body: gin.H{"decision": "approve"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, banker.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ReviewTransferApprovalTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InitiatorCannotApprove",
+			body: gin.H{"decision": "approve"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, initiator.Username, util.BankerRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().ReviewTransferApprovalTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyReviewed",
+			body: gin.H{"decision": "approve"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, banker.Username, util.BankerRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(rejected, nil)
+				store.EXPECT().ReviewTransferApprovalTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ReviewedConcurrently",
+			body: gin.H{"decision": "reject"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, banker.Username, util.BankerRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().
+					ReviewTransferApprovalTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReviewTransferApprovalTxResult{}, db.ErrTransferApprovalNotPending)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ReferenceAlreadyUsed",
+			body: gin.H{"decision": "approve"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, banker.Username, util.BankerRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().
+					ReviewTransferApprovalTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReviewTransferApprovalTxResult{}, &pq.Error{Code: "23505", Constraint: transferReferenceConstraint})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "InsufficientBalance",
+			body: gin.H{"decision": "approve"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, banker.Username, util.BankerRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				emptyAccount := fromAccount
+				emptyAccount.Balance = 0
+
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(emptyAccount, nil)
+				store.EXPECT().GetOverdraftLimit(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(int64(0), nil)
+				store.EXPECT().ReviewTransferApprovalTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"decision": "approve"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, banker.Username, util.BankerRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(db.TransferApproval{}, sql.ErrNoRows)
+				store.EXPECT().ReviewTransferApprovalTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidDecision",
+			body: gin.H{"decision": "maybe"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, banker.Username, util.BankerRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ReviewTransferApprovalTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/transfers/%d/approve", approval.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListTransferApprovalsAPI(t *testing.T) {
+	initiator, _ := randomUser(t)
+	banker, _ := randomUser(t)
+
+	n := 5
+	approvals := make([]db.TransferApproval, n)
+	for i := range n {
+		approvals[i] = randomTransferApproval(initiator.Username, randomAccount(), randomAccount())
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: fmt.Sprintf("?page_id=%d&page_size=%d", 1, n),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, banker.Username, util.BankerRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListTransferApprovalsParams{
+					Status: db.TransferApprovalStatusPending,
+					Limit:  int32(n),
+					Offset: 0,
+				}
+
+				store.EXPECT().ListTransferApprovals(gomock.Any(), gomock.Eq(arg)).Times(1).Return(approvals, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []transferApprovalResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Len(t, got, n)
+			},
+		},
+		{
+			name:  "NotBanker",
+			query: fmt.Sprintf("?page_id=%d&page_size=%d", 1, n),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, initiator.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTransferApprovals(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidPageSize",
+			query: fmt.Sprintf("?page_id=%d&page_size=%d", 1, 100),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, banker.Username, util.BankerRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTransferApprovals(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := "/transfer_approvals" + tc.query
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func requireBodyMatchReviewedApproval(t *testing.T, body *bytes.Buffer, approval db.TransferApproval) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var got struct {
+		TransferApproval transferApprovalResponse `json:"transfer_approval"`
+		Transfer         *db.TransferTxResult     `json:"transfer"`
+	}
+	err = json.Unmarshal(data, &got)
+	require.NoError(t, err)
+
+	require.Equal(t, approval.ID, got.TransferApproval.ID)
+	require.Equal(t, approval.Status, got.TransferApproval.Status)
+	require.Equal(t, approval.Reviewer, got.TransferApproval.Reviewer)
+	require.Equal(t, approval.TransferID.Valid, got.TransferApproval.TransferID != nil)
+	require.Equal(t, approval.TransferID.Valid, got.Transfer != nil)
+}
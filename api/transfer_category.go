@@ -0,0 +1,119 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+type setTransferCategoryRequest struct {
+	CategoryID int64 `json:"category_id" binding:"required,min=1"`
+}
+
+// setTransferCategory puts an outgoing transfer in one of the categories of its account's owner, whatever the rules say
+func (server *Server) setTransferCategory(ctx *gin.Context) {
+	var uriReq transferURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req setTransferCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	transfer, fromAccount, valid := server.sentTransfer(ctx, uriReq.ID)
+	if !valid {
+		return
+	}
+
+	// spending is reported to the owner, so members manage the transfer in the owner's categories
+	category, valid := server.ownedSpendingCategory(ctx, req.CategoryID, fromAccount.Owner)
+	if !valid {
+		return
+	}
+
+	existing, err := server.store.GetTransferCategory(ctx, transfer.ID)
+	if err == nil {
+		setAuditBefore(ctx, existing)
+	} else if err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	transferCategory, err := server.store.SetTransferCategory(ctx, db.SetTransferCategoryParams{
+		TransferID: transfer.ID,
+		CategoryID: category.ID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transferCategory)
+}
+
+// deleteTransferCategory hands the categorization of a transfer back to the rules
+func (server *Server) deleteTransferCategory(ctx *gin.Context) {
+	var req transferURI
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	transfer, _, valid := server.sentTransfer(ctx, req.ID)
+	if !valid {
+		return
+	}
+
+	transferCategory, err := server.store.GetTransferCategory(ctx, transfer.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	setAuditBefore(ctx, transferCategory)
+
+	err = server.store.DeleteTransferCategory(ctx, transfer.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "transfer category deleted successfully"})
+}
+
+// sentTransfer loads a transfer sent from a personal account the authenticated user can manage, with that account.
+// Spending only covers personal accounts, so organizations can't categorize transfers.
+func (server *Server) sentTransfer(ctx *gin.Context, id int64) (db.Transfer, db.Account, bool) {
+	transfer, err := server.store.GetTransfer(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return transfer, db.Account{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return transfer, db.Account{}, false
+	}
+
+	fromAccount, valid := server.authorizedAccount(ctx, transfer.FromAccountID, db.AccountPermissionManage)
+	if !valid {
+		return transfer, fromAccount, false
+	}
+
+	if fromAccount.OrganizationID.Valid {
+		err := fmt.Errorf("transfer [%d] wasn't sent from a personal account", transfer.ID)
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return transfer, fromAccount, false
+	}
+
+	return transfer, fromAccount, true
+}
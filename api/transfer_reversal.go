@@ -0,0 +1,80 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+type transferURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type reverseTransferRequest struct {
+	Reason string `json:"reason" binding:"required,max=140"`
+}
+
+// reverseTransfer lets a banker undo a transfer with a compensating transfer.
+// Transfers and entries are append-only, so this is the only way to correct a transfer.
+func (server *Server) reverseTransfer(ctx *gin.Context) {
+	var uriReq transferURI
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req reverseTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload, valid := server.bankerUser(ctx)
+	if !valid {
+		return
+	}
+
+	transfer, err := server.store.GetTransfer(ctx, uriReq.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	setAuditBefore(ctx, transfer)
+
+	// the money is taken back from the recipient
+	toAccount, err := server.store.GetAccount(ctx, transfer.ToAccountID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !server.sufficientFunds(ctx, toAccount, transfer.Amount) {
+		return
+	}
+
+	arg := db.ReverseTransferTxParams{
+		TransferID: transfer.ID,
+		Reason:     req.Reason,
+		ReversedBy: authPayload.Username,
+	}
+
+	result, err := server.store.ReverseTransferTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrTransferAlreadyReversed) || errors.Is(err, db.ErrInsufficientFunds) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -106,8 +106,14 @@ func TestLoginUserAPI(t *testing.T) {
 
 func newTestServer(t *testing.T, store db.Store) *Server {
 	config := util.Config{
-		TokenSymmetricKey:   util.RandomString(32),
-		AccessTokenDuration: time.Minute,
+		TokenSymmetricKey:           util.RandomString(32),
+		AccessTokenDuration:         time.Minute,
+		PaymentRequestDuration:      time.Hour,
+		BeneficiaryCoolingOffPeriod: time.Hour,
+		BeneficiaryCoolingOffLimit:  100,
+		TransferApprovalThreshold:   50,
+		CardMerchantKey:             "merchant-key",
+		DirectDebitDisputeWindow:    time.Hour,
 	}
 
 	server, err := NewServer(config, store)
@@ -0,0 +1,124 @@
+package cards
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// MerchantKeyHeader is the header merchants authenticate to the card authorization endpoints with
+const MerchantKeyHeader = "X-Merchant-Key"
+
+// Details are the card details a cardholder gives a merchant to pay
+type Details struct {
+	PAN         string `json:"pan"`
+	ExpiryMonth int    `json:"expiry_month"`
+	ExpiryYear  int    `json:"expiry_year"`
+	CVV         string `json:"cvv"`
+}
+
+// Authorization is the answer of the bank to a merchant about a card payment
+type Authorization struct {
+	ID            int64      `json:"id"`
+	Amount        int64      `json:"amount"`
+	Currency      string     `json:"currency"`
+	Merchant      string     `json:"merchant"`
+	Status        string     `json:"status"`
+	DeclineReason string     `json:"decline_reason,omitempty"`
+	ClearedAmount int64      `json:"cleared_amount"`
+	CreatedAt     time.Time  `json:"created_at"`
+	SettledAt     *time.Time `json:"settled_at,omitempty"`
+}
+
+// Approved reports whether the payment was approved, its amount is then held on the account of the card
+func (authorization Authorization) Approved() bool {
+	return authorization.Status == "held"
+}
+
+// Merchant simulates a merchant taking card payments through the card authorization endpoints of the API,
+// the way its payment terminal would through a card network: it authorizes a payment when the card is presented,
+// and later clears it when the sale is settled, or releases it when the sale is cancelled.
+type Merchant struct {
+	Name string
+	// BaseURL is the address of the API, e.g. http://localhost:8080
+	BaseURL string
+	// Key is the merchant key configured as CARD_MERCHANT_KEY
+	Key    string
+	Client *http.Client
+}
+
+// NewMerchant creates a merchant simulator calling the API at baseURL
+func NewMerchant(name string, baseURL string, key string) *Merchant {
+	return &Merchant{
+		Name:    name,
+		BaseURL: baseURL,
+		Key:     key,
+		Client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Authorize asks the bank to approve a payment of amount with the card. Declined payments are not an error:
+// the returned authorization says why they were declined.
+func (merchant *Merchant) Authorize(ctx context.Context, card Details, amount int64, currency string) (Authorization, error) {
+	return merchant.call(ctx, "/card_authorizations", map[string]any{
+		"pan":          card.PAN,
+		"expiry_month": card.ExpiryMonth,
+		"expiry_year":  card.ExpiryYear,
+		"cvv":          card.CVV,
+		"amount":       amount,
+		"currency":     currency,
+		"merchant":     merchant.Name,
+	})
+}
+
+// Clear settles an approved payment for amount, up to the amount authorized, or for all of it when amount is 0
+func (merchant *Merchant) Clear(ctx context.Context, authorizationID int64, amount int64) (Authorization, error) {
+	path := fmt.Sprintf("/card_authorizations/%d/clear", authorizationID)
+	return merchant.call(ctx, path, map[string]any{"amount": amount})
+}
+
+// Release cancels an approved payment, so that its amount is no longer held on the account
+func (merchant *Merchant) Release(ctx context.Context, authorizationID int64) (Authorization, error) {
+	path := fmt.Sprintf("/card_authorizations/%d/release", authorizationID)
+	return merchant.call(ctx, path, map[string]any{})
+}
+
+// call posts body to the API and decodes the authorization it answers with.
+// Declines come with the 402 Payment Required status, any other error status is returned as an error.
+func (merchant *Merchant) call(ctx context.Context, path string, body any) (Authorization, error) {
+	var authorization Authorization
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return authorization, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, merchant.BaseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return authorization, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(MerchantKeyHeader, merchant.Key)
+
+	response, err := merchant.Client.Do(request)
+	if err != nil {
+		return authorization, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusPaymentRequired {
+		var rsp struct {
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(response.Body).Decode(&rsp); err != nil || rsp.Error == "" {
+			return authorization, fmt.Errorf("%s: %s", path, response.Status)
+		}
+		return authorization, fmt.Errorf("%s: %s: %s", path, response.Status, rsp.Error)
+	}
+
+	err = json.NewDecoder(response.Body).Decode(&authorization)
+	return authorization, err
+}
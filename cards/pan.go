@@ -0,0 +1,114 @@
+// Package cards issues the numbers of virtual debit cards, and simulates the merchants that take payments with them.
+package cards
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const (
+	// BIN is the issuer identification number the cards of st-bank start with
+	BIN = "431940"
+	// PANLength is the number of digits of a primary account number, the check digit included
+	PANLength = 16
+	// CVVLength is the number of digits of a card verification value
+	CVVLength = 3
+	// ValidityYears is how long a card is valid for after it is issued
+	ValidityYears = 3
+)
+
+var ErrInvalidPAN = errors.New("card number must be 16 digits passing the Luhn check")
+
+// GeneratePAN returns a random primary account number starting with the BIN and ending with its Luhn check digit
+func GeneratePAN() (string, error) {
+	digits, err := randomDigits(PANLength - len(BIN) - 1)
+	if err != nil {
+		return "", err
+	}
+
+	payload := BIN + digits
+	return payload + string(luhnCheckDigit(payload)), nil
+}
+
+// ValidPAN reports whether pan is a 16-digit number with a valid Luhn check digit
+func ValidPAN(pan string) bool {
+	if len(pan) != PANLength || !isDigits(pan) {
+		return false
+	}
+
+	return luhnCheckDigit(pan[:len(pan)-1]) == pan[len(pan)-1]
+}
+
+// luhnCheckDigit computes the digit that makes payload followed by it pass the Luhn check.
+// Starting from the right of the payload, every other digit is doubled, and the digits of the products are summed.
+func luhnCheckDigit(payload string) byte {
+	sum := 0
+	for i := 0; i < len(payload); i++ {
+		digit := int(payload[len(payload)-1-i] - '0')
+		if i%2 == 0 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+
+	return byte('0' + (10-sum%10)%10)
+}
+
+// GenerateCVV returns a random card verification value
+func GenerateCVV() (string, error) {
+	return randomDigits(CVVLength)
+}
+
+// Expiry returns the month and year a card issued at issuedAt expires at the end of
+func Expiry(issuedAt time.Time) (month int, year int) {
+	issuedAt = issuedAt.UTC()
+	return int(issuedAt.Month()), issuedAt.Year() + ValidityYears
+}
+
+// Expired reports whether a card expiring at the end of month in year has expired at now
+func Expired(month int, year int, now time.Time) bool {
+	// the first day of the month after the expiry month
+	end := time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, time.UTC)
+	return !now.UTC().Before(end)
+}
+
+// MaskPAN hides all but the BIN and the last four digits of a card number
+func MaskPAN(pan string) string {
+	if len(pan) != PANLength {
+		return strings.Repeat("*", len(pan))
+	}
+
+	return pan[:len(BIN)] + strings.Repeat("*", PANLength-len(BIN)-4) + pan[PANLength-4:]
+}
+
+// randomDigits returns n digits drawn from a cryptographically secure source,
+// card numbers and verification values must not be predictable
+func randomDigits(n int) (string, error) {
+	var sb strings.Builder
+	sb.Grow(n)
+
+	for i := 0; i < n; i++ {
+		digit, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(byte('0' + digit.Int64()))
+	}
+
+	return sb.String(), nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
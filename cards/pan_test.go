@@ -0,0 +1,58 @@
+package cards
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidPAN(t *testing.T) {
+	// well-known test card numbers
+	require.True(t, ValidPAN("4111111111111111"))
+	require.True(t, ValidPAN("5555555555554444"))
+
+	require.False(t, ValidPAN("4111111111111112"))
+	require.False(t, ValidPAN("411111111111111"))
+	require.False(t, ValidPAN("41111111111111a1"))
+	require.False(t, ValidPAN(""))
+}
+
+func TestGeneratePAN(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		pan, err := GeneratePAN()
+		require.NoError(t, err)
+		require.Len(t, pan, PANLength)
+		require.True(t, strings.HasPrefix(pan, BIN))
+		require.True(t, ValidPAN(pan), pan)
+
+		seen[pan] = true
+	}
+	require.Greater(t, len(seen), 90)
+}
+
+func TestGenerateCVV(t *testing.T) {
+	cvv, err := GenerateCVV()
+	require.NoError(t, err)
+	require.Len(t, cvv, CVVLength)
+	require.True(t, isDigits(cvv))
+}
+
+func TestExpiry(t *testing.T) {
+	month, year := Expiry(time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC))
+	require.Equal(t, 10, month)
+	require.Equal(t, 2029, year)
+
+	// cards are valid through the last day of their expiry month
+	require.False(t, Expired(10, 2029, time.Date(2029, time.October, 31, 23, 59, 59, 0, time.UTC)))
+	require.True(t, Expired(10, 2029, time.Date(2029, time.November, 1, 0, 0, 0, 0, time.UTC)))
+	require.True(t, Expired(12, 2028, time.Date(2029, time.January, 1, 0, 0, 0, 0, time.UTC)))
+	require.False(t, Expired(12, 2029, time.Date(2029, time.December, 31, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestMaskPAN(t *testing.T) {
+	require.Equal(t, "431940******1234", MaskPAN("4319401111111234"))
+	require.Equal(t, "****", MaskPAN("1234"))
+}
@@ -0,0 +1,374 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/escrow"
+	"github.com/volskyi-dmytro/st-bank/fees"
+	"github.com/volskyi-dmytro/st-bank/interest"
+	"github.com/volskyi-dmytro/st-bank/loans"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+// balanceSnapshotMargin keeps snapshots clear of transactions that are still in flight,
+// since their entries carry the time the transaction started
+const balanceSnapshotMargin = time.Minute
+
+// runCommand runs a maintenance command instead of starting the server
+func runCommand(ctx context.Context, config util.Config, store db.Store, name string, args []string) error {
+	switch name {
+	case "reconcile":
+		return runReconcile(ctx, store, args)
+	case "verify-audit":
+		return runVerifyAudit(ctx, store, args)
+	case "snapshot-balances":
+		return runSnapshotBalances(ctx, store, args)
+	case "accrue-interest":
+		return runAccrueInterest(ctx, store, args)
+	case "post-interest":
+		return runPostInterest(ctx, store, args)
+	case "charge-fees":
+		return runChargeFees(ctx, config, store, args)
+	case "collect-installments":
+		return runCollectInstallments(ctx, store, args)
+	case "refund-escrows":
+		return runRefundEscrows(ctx, store, args)
+	case "set-role":
+		return runSetRole(ctx, store, args)
+	default:
+		return fmt.Errorf("unknown command %q", name)
+	}
+}
+
+// runReconcile checks the ledger invariants and optionally corrects account drift.
+// It fails when the ledger is not balanced so that it can be used from scheduled jobs.
+func runReconcile(ctx context.Context, store db.Store, args []string) error {
+	flags := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	chunkSize := flags.Int("chunk-size", db.DefaultReconciliationChunkSize, "number of rows read per query")
+	correct := flags.Bool("correct", false, "book a correction entry for every drifted account")
+	reason := flags.String("reason", "", "reason recorded with each correction")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := store.Reconcile(ctx, db.ReconcileParams{
+		ChunkSize: int32(*chunkSize),
+		Correct:   *correct,
+		Reason:    *reason,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot reconcile ledger: %w", err)
+	}
+
+	log.Printf("checked %d accounts and %d transfers", report.AccountsChecked, report.TransfersChecked)
+
+	for _, drift := range report.AccountDrifts {
+		log.Printf("account [%d] balance %d %s differs from its entries total %d by %d",
+			drift.AccountID, drift.Balance, drift.Currency, drift.EntriesTotal, drift.Amount())
+		if drift.Adjustment != nil {
+			log.Printf("account [%d] corrected with entry [%d]", drift.AccountID, drift.Adjustment.EntryID)
+		}
+	}
+
+	for _, transfer := range report.UnbalancedTransfers {
+		log.Printf("transfer [%d] has %d entries: %d matching debit, %d matching credit",
+			transfer.ID, transfer.EntryCount, transfer.DebitCount, transfer.CreditCount)
+	}
+
+	for _, total := range report.CurrencyImbalances {
+		log.Printf("%s journal postings sum up to %d instead of zero", total.Currency, total.Total)
+	}
+
+	if !report.Balanced() {
+		return errors.New("ledger is not balanced")
+	}
+
+	log.Println("ledger is balanced")
+	return nil
+}
+
+// runVerifyAudit walks the audit log hash chain and fails at the first broken link
+func runVerifyAudit(ctx context.Context, store db.Store, args []string) error {
+	flags := flag.NewFlagSet("verify-audit", flag.ContinueOnError)
+	chunkSize := flags.Int("chunk-size", db.DefaultAuditLogChunkSize, "number of rows read per query")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	verification, err := store.VerifyAuditLog(ctx, int32(*chunkSize))
+	if err != nil {
+		return fmt.Errorf("cannot verify audit log: %w", err)
+	}
+
+	log.Printf("checked %d audit log entries", verification.EntriesChecked)
+
+	if !verification.Intact() {
+		return fmt.Errorf("audit log entry [%d] is broken: %s",
+			verification.BrokenLink.ID, verification.BrokenLink.Reason)
+	}
+
+	log.Println("audit log is intact")
+	return nil
+}
+
+// runSnapshotBalances stores the balance of every account as of a cutoff, by default the start of the current UTC day.
+// It is meant to run periodically, e.g. daily after midnight, and is safe to run again for the same cutoff.
+func runSnapshotBalances(ctx context.Context, store db.Store, args []string) error {
+	flags := flag.NewFlagSet("snapshot-balances", flag.ContinueOnError)
+	asOf := flags.String("as-of", "", "cutoff of the snapshot in RFC 3339 format, the start of the current UTC day by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	takenAt := time.Now().UTC().Truncate(24 * time.Hour)
+	if *asOf != "" {
+		var err error
+		takenAt, err = time.Parse(time.RFC3339, *asOf)
+		if err != nil {
+			return fmt.Errorf("invalid cutoff: %w", err)
+		}
+	}
+
+	if time.Since(takenAt) < balanceSnapshotMargin {
+		return fmt.Errorf("cutoff must be at least %s in the past", balanceSnapshotMargin)
+	}
+
+	count, err := store.CreateBalanceSnapshots(ctx, takenAt)
+	if err != nil {
+		return fmt.Errorf("cannot create balance snapshots: %w", err)
+	}
+
+	log.Printf("created %d balance snapshots as of %s", count, takenAt.Format(time.RFC3339))
+	return nil
+}
+
+// runAccrueInterest accrues a day of interest on savings and overdrawn accounts, by default for the previous UTC day.
+// It is meant to run daily after midnight, and is safe to run again for the same day.
+func runAccrueInterest(ctx context.Context, store db.Store, args []string) error {
+	flags := flag.NewFlagSet("accrue-interest", flag.ContinueOnError)
+	date := flags.String("date", "", "day to accrue in YYYY-MM-DD format, the previous UTC day by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	day := interest.Date(time.Now()).AddDate(0, 0, -1)
+	if *date != "" {
+		var err error
+		day, err = time.Parse(time.DateOnly, *date)
+		if err != nil {
+			return fmt.Errorf("invalid date: %w", err)
+		}
+	}
+
+	// interest is computed on the end of day balance, so the day must be over
+	if time.Since(day.AddDate(0, 0, 1)) < balanceSnapshotMargin {
+		return fmt.Errorf("day must be over for at least %s", balanceSnapshotMargin)
+	}
+
+	report, err := interest.Accrue(ctx, store, day)
+	if err != nil {
+		return fmt.Errorf("cannot accrue interest: %w", err)
+	}
+
+	log.Printf("accrued interest of %s for %d accounts, %d had already accrued",
+		report.Day.Format(time.DateOnly), report.Accrued, report.Skipped)
+	return nil
+}
+
+// runPostInterest posts the interest accrued during a month, by default the previous UTC month.
+// It is meant to run monthly once the accruals of the month are done, and is safe to run again for the same month.
+func runPostInterest(ctx context.Context, store db.Store, args []string) error {
+	flags := flag.NewFlagSet("post-interest", flag.ContinueOnError)
+	monthFlag := flags.String("month", "", "month to post in YYYY-MM format, the previous UTC month by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	today := interest.Date(time.Now())
+	month := today.AddDate(0, 0, 1-today.Day()).AddDate(0, -1, 0)
+	if *monthFlag != "" {
+		var err error
+		month, err = time.Parse("2006-01", *monthFlag)
+		if err != nil {
+			return fmt.Errorf("invalid month: %w", err)
+		}
+	}
+
+	if month.AddDate(0, 1, 0).After(today) {
+		return errors.New("month must be over")
+	}
+
+	report, err := interest.PostMonth(ctx, store, month)
+	if err != nil {
+		return fmt.Errorf("cannot post interest: %w", err)
+	}
+
+	log.Printf("posted interest up to %s to %d accounts, %d were already posted",
+		report.PeriodEnd.Format(time.DateOnly), report.Posted, report.Skipped)
+	for currency, total := range report.Totals {
+		log.Printf("credited %d %s of interest", total, currency)
+	}
+	for currency, total := range report.Charged {
+		log.Printf("charged %d %s of overdraft interest", total, currency)
+	}
+	return nil
+}
+
+// runChargeFees bills the maintenance fee of a month, by default the previous UTC month.
+// It is meant to run monthly, and is safe to run again for the same month.
+func runChargeFees(ctx context.Context, config util.Config, store db.Store, args []string) error {
+	flags := flag.NewFlagSet("charge-fees", flag.ContinueOnError)
+	monthFlag := flags.String("month", "", "month to bill in YYYY-MM format, the previous UTC month by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	today := interest.Date(time.Now())
+	month := today.AddDate(0, 0, 1-today.Day()).AddDate(0, -1, 0)
+	if *monthFlag != "" {
+		var err error
+		month, err = time.Parse("2006-01", *monthFlag)
+		if err != nil {
+			return fmt.Errorf("invalid month: %w", err)
+		}
+	}
+
+	if month.AddDate(0, 1, 0).After(today) {
+		return errors.New("month must be over")
+	}
+
+	biller, err := newBiller(config, store)
+	if err != nil {
+		return err
+	}
+
+	report, err := biller.ChargeMonth(ctx, month)
+	if err != nil {
+		return fmt.Errorf("cannot charge fees: %w", err)
+	}
+
+	log.Printf("billed maintenance fees of %s: %d charged, %d partially charged, %d in arrears, %d waived, %d already billed",
+		report.Period.Format("2006-01"),
+		report.Statuses[db.AccountFeeStatusCharged], report.Statuses[db.AccountFeeStatusPartial],
+		report.Statuses[db.AccountFeeStatusArrears], report.Statuses[db.AccountFeeStatusWaived], report.Skipped)
+	for currency, total := range report.Charged {
+		log.Printf("charged %d %s of fees, %d %s in arrears", total, currency, report.Arrears[currency], currency)
+	}
+	return nil
+}
+
+// runCollectInstallments collects the loan installments due on a day, by default the current UTC day,
+// along with the installments still in arrears. It is meant to run daily, and is safe to run again for the same day.
+func runCollectInstallments(ctx context.Context, store db.Store, args []string) error {
+	flags := flag.NewFlagSet("collect-installments", flag.ContinueOnError)
+	date := flags.String("date", "", "due date to collect in YYYY-MM-DD format, the current UTC day by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	day := interest.Date(time.Now())
+	if *date != "" {
+		var err error
+		day, err = time.Parse(time.DateOnly, *date)
+		if err != nil {
+			return fmt.Errorf("invalid date: %w", err)
+		}
+	}
+
+	if day.After(interest.Date(time.Now())) {
+		return errors.New("installments can't be collected before their due date")
+	}
+
+	report, err := loans.CollectDue(ctx, store, day)
+	if err != nil {
+		return fmt.Errorf("cannot collect installments: %w", err)
+	}
+
+	log.Printf("collected loan installments due by %s: %d paid, %d in arrears, %d already paid",
+		report.Day.Format(time.DateOnly), report.Paid, report.Arrears, report.Skipped)
+	for currency, total := range report.Collected {
+		log.Printf("collected %d %s of installments", total, currency)
+	}
+	return nil
+}
+
+// runRefundEscrows refunds to their payer the escrows still held past their deadline.
+// It is meant to run often, e.g. every few minutes, and is safe to run again.
+func runRefundEscrows(ctx context.Context, store db.Store, args []string) error {
+	flags := flag.NewFlagSet("refund-escrows", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := escrow.RefundExpired(ctx, store, time.Now())
+	if err != nil {
+		return fmt.Errorf("cannot refund escrows: %w", err)
+	}
+
+	log.Printf("refunded escrows past their deadline: %d refunded, %d already released or disputed",
+		report.Refunded, report.Skipped)
+	for currency, total := range report.Amounts {
+		log.Printf("refunded %d %s of escrows", total, currency)
+	}
+	return nil
+}
+
+// runSetRole gives a user a role, e.g. the banker role, which no API call can grant
+func runSetRole(ctx context.Context, store db.Store, args []string) error {
+	flags := flag.NewFlagSet("set-role", flag.ContinueOnError)
+	username := flags.String("username", "", "user to update")
+	role := flags.String("role", "", fmt.Sprintf("new role of the user, %s or %s", util.DepositorRole, util.BankerRole))
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *username == "" {
+		return errors.New("username is required")
+	}
+
+	if !util.IsSupportedRole(*role) {
+		return fmt.Errorf("unsupported role %q", *role)
+	}
+
+	user, err := store.UpdateUserRole(ctx, db.UpdateUserRoleParams{
+		Username: *username,
+		Role:     *role,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("user %q not found", *username)
+		}
+		return fmt.Errorf("cannot set role: %w", err)
+	}
+
+	log.Printf("user %s now has the %s role", user.Username, user.Role)
+	return nil
+}
+
+// newBiller creates the maintenance fee biller with the waiver rules enabled in the config
+func newBiller(config util.Config, store db.Store) (*fees.Biller, error) {
+	var waivers []fees.WaiverRule
+	if config.MaintenanceFeeWaiverRoles != "" {
+		var roles []string
+		for _, role := range strings.Split(config.MaintenanceFeeWaiverRoles, ",") {
+			roles = append(roles, strings.TrimSpace(role))
+		}
+		waivers = append(waivers, fees.NewRoleWaiver(roles...))
+	}
+	if config.MaintenanceFeeWaiverBalance > 0 {
+		waivers = append(waivers, fees.NewMinimumBalanceWaiver(config.MaintenanceFeeWaiverBalance))
+	}
+	if config.MaintenanceFeeWaiverAccountAge > 0 {
+		waivers = append(waivers, fees.NewAccountAgeWaiver(config.MaintenanceFeeWaiverAccountAge))
+	}
+
+	return fees.NewBiller(store, config.MaintenanceFee, waivers...)
+}
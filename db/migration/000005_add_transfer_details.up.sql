@@ -0,0 +1,11 @@
+ALTER TABLE "transfers" ADD COLUMN "description" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "transfers" ADD COLUMN "reference" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "transfers" ADD COLUMN "metadata" jsonb NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN "transfers"."reference" IS 'client-supplied, unique per sender';
+
+CREATE UNIQUE INDEX "transfers_from_account_reference_key" ON "transfers" ("from_account_id", "reference") WHERE "reference" <> '';
+
+CREATE INDEX ON "transfers" USING GIN ("metadata" jsonb_path_ops);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "fraud_decisions";
\ No newline at end of file
@@ -0,0 +1,24 @@
+CREATE TABLE "fraud_decisions" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "score" integer NOT NULL,
+  "decision" varchar NOT NULL,
+  "reason_codes" varchar[] NOT NULL DEFAULT '{}',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "fraud_decisions" ("username");
+
+CREATE INDEX ON "fraud_decisions" ("decision");
+
+COMMENT ON COLUMN "fraud_decisions"."decision" IS 'allow, review or block';
+
+ALTER TABLE "fraud_decisions" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "fraud_decisions" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "fraud_decisions" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
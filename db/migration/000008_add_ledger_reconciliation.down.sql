@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "reconciliation_adjustments";
+
+ALTER TABLE IF EXISTS "entries" DROP COLUMN IF EXISTS "transfer_id";
\ No newline at end of file
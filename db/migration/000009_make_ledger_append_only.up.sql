@@ -0,0 +1,37 @@
+CREATE FUNCTION reject_ledger_change() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION '% is append-only: % is not allowed, book a compensating entry instead', TG_TABLE_NAME, TG_OP;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "entries_append_only"
+BEFORE UPDATE OR DELETE ON "entries"
+FOR EACH ROW EXECUTE FUNCTION reject_ledger_change();
+
+CREATE TRIGGER "entries_no_truncate"
+BEFORE TRUNCATE ON "entries"
+FOR EACH STATEMENT EXECUTE FUNCTION reject_ledger_change();
+
+CREATE TRIGGER "transfers_append_only"
+BEFORE UPDATE OR DELETE ON "transfers"
+FOR EACH ROW EXECUTE FUNCTION reject_ledger_change();
+
+CREATE TRIGGER "transfers_no_truncate"
+BEFORE TRUNCATE ON "transfers"
+FOR EACH STATEMENT EXECUTE FUNCTION reject_ledger_change();
+
+CREATE TABLE "transfer_reversals" (
+  "transfer_id" bigint PRIMARY KEY,
+  "reversal_id" bigint UNIQUE NOT NULL,
+  "reason" varchar NOT NULL,
+  "reversed_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "transfer_reversals"."reversal_id" IS 'compensating transfer in the opposite direction';
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("reversal_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("reversed_by") REFERENCES "users" ("username");
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS "entries_journal_entry_balanced" ON "entries";
+
+DROP FUNCTION IF EXISTS check_journal_entry_balanced();
+
+ALTER TABLE IF EXISTS "entries" DROP COLUMN IF EXISTS "journal_entry_id";
+
+DROP TABLE IF EXISTS "journal_entries";
\ No newline at end of file
@@ -0,0 +1,66 @@
+CREATE TABLE "journal_entries" (
+  "id" bigserial PRIMARY KEY,
+  "kind" varchar NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "journal_entries"."kind" IS 'what the journal entry records, e.g. transfer';
+
+ALTER TABLE "entries" ADD COLUMN "journal_entry_id" bigint;
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("journal_entry_id") REFERENCES "journal_entries" ("id");
+
+CREATE INDEX ON "entries" ("journal_entry_id");
+
+-- Every existing transfer gets a journal entry holding its two postings
+CREATE TEMPORARY TABLE "transfer_journals" AS
+SELECT
+  "id" AS "transfer_id",
+  nextval('journal_entries_id_seq') AS "journal_entry_id",
+  "description",
+  "created_at"
+FROM "transfers";
+
+INSERT INTO "journal_entries" ("id", "kind", "description", "created_at")
+SELECT "journal_entry_id", 'transfer', "description", "created_at"
+FROM "transfer_journals";
+
+ALTER TABLE "entries" DISABLE TRIGGER "entries_append_only";
+
+UPDATE "entries" e
+SET "journal_entry_id" = tj."journal_entry_id"
+FROM "transfer_journals" tj
+WHERE e."transfer_id" = tj."transfer_id";
+
+ALTER TABLE "entries" ENABLE TRIGGER "entries_append_only";
+
+DROP TABLE "transfer_journals";
+
+-- The postings of a journal entry must sum up to zero in every currency by the time the transaction commits
+CREATE FUNCTION check_journal_entry_balanced() RETURNS trigger AS $$
+DECLARE
+  unbalanced_currency varchar;
+BEGIN
+  SELECT a."currency" INTO unbalanced_currency
+  FROM "entries" e
+  JOIN "accounts" a ON a."id" = e."account_id"
+  WHERE e."journal_entry_id" = NEW."journal_entry_id"
+  GROUP BY a."currency"
+  HAVING sum(e."amount") <> 0
+  LIMIT 1;
+
+  IF unbalanced_currency IS NOT NULL THEN
+    RAISE EXCEPTION 'journal entry % is not balanced in %', NEW."journal_entry_id", unbalanced_currency;
+  END IF;
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER "entries_journal_entry_balanced"
+AFTER INSERT ON "entries"
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW
+WHEN (NEW."journal_entry_id" IS NOT NULL)
+EXECUTE FUNCTION check_journal_entry_balanced();
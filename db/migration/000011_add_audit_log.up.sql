@@ -0,0 +1,26 @@
+CREATE TABLE "audit_log" (
+  "id" bigserial PRIMARY KEY,
+  "actor" varchar NOT NULL,
+  "action" varchar NOT NULL,
+  "resource" varchar NOT NULL,
+  "request_id" varchar NOT NULL,
+  "before" jsonb NOT NULL DEFAULT 'null',
+  "after" jsonb NOT NULL DEFAULT 'null',
+  "created_at" timestamptz NOT NULL,
+  "prev_hash" varchar NOT NULL,
+  "hash" varchar UNIQUE NOT NULL
+);
+
+CREATE INDEX ON "audit_log" ("actor");
+
+CREATE INDEX ON "audit_log" ("request_id");
+
+COMMENT ON COLUMN "audit_log"."actor" IS 'username of the caller, empty for anonymous calls';
+
+COMMENT ON COLUMN "audit_log"."action" IS 'HTTP method and route, e.g. POST /transfers';
+
+COMMENT ON COLUMN "audit_log"."before" IS 'state of the resource before the call, null if unknown';
+
+COMMENT ON COLUMN "audit_log"."after" IS 'response body of the call';
+
+COMMENT ON COLUMN "audit_log"."hash" IS 'hex SHA-256 of the row content and prev_hash';
\ No newline at end of file
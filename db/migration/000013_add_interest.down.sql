@@ -0,0 +1,19 @@
+DROP TABLE IF EXISTS "system_accounts";
+
+DROP TABLE IF EXISTS "interest_postings";
+
+DROP TABLE IF EXISTS "interest_accruals";
+
+DROP TABLE IF EXISTS "account_interest_plans";
+
+DROP TABLE IF EXISTS "interest_plans";
+
+DELETE FROM "accounts" WHERE "owner" = 'system';
+
+DELETE FROM "users" WHERE "username" = 'system';
+
+DROP INDEX IF EXISTS "accounts_owner_currency_type_key";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_currency_key" UNIQUE ("owner", "currency");
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "type";
\ No newline at end of file
@@ -0,0 +1,101 @@
+ALTER TABLE "accounts" ADD COLUMN "type" varchar NOT NULL DEFAULT 'checking';
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_type_check" CHECK ("type" IN ('checking', 'savings', 'internal'));
+
+COMMENT ON COLUMN "accounts"."type" IS 'checking, savings or internal for the accounts of the bank itself';
+
+-- Users can hold a checking and a savings account in the same currency,
+-- the bank holds one internal account per purpose and currency
+ALTER TABLE "accounts" DROP CONSTRAINT "owner_currency_key";
+
+CREATE UNIQUE INDEX "accounts_owner_currency_type_key" ON "accounts" ("owner", "currency", "type") WHERE "type" <> 'internal';
+
+CREATE TABLE "interest_plans" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar UNIQUE NOT NULL,
+  "annual_rate_bps" integer NOT NULL,
+  "day_count" varchar NOT NULL DEFAULT 'ACT/365',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("annual_rate_bps" >= 0),
+  CHECK ("day_count" IN ('ACT/365', 'ACT/360', '30/360'))
+);
+
+COMMENT ON COLUMN "interest_plans"."annual_rate_bps" IS 'annual interest rate in basis points, 250 is 2.5%';
+
+COMMENT ON COLUMN "interest_plans"."day_count" IS 'day count convention of the daily accruals: ACT/365, ACT/360 or 30/360';
+
+CREATE TABLE "account_interest_plans" (
+  "account_id" bigint PRIMARY KEY,
+  "interest_plan_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "account_interest_plans" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_interest_plans" ADD FOREIGN KEY ("interest_plan_id") REFERENCES "interest_plans" ("id");
+
+CREATE TABLE "interest_accruals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "accrual_date" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "annual_rate_bps" integer NOT NULL,
+  "day_count" varchar NOT NULL,
+  "amount" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+-- A day accrues only once, running the accrual job again does nothing
+CREATE UNIQUE INDEX ON "interest_accruals" ("account_id", "accrual_date");
+
+COMMENT ON COLUMN "interest_accruals"."balance" IS 'end of day balance the interest is computed on';
+
+COMMENT ON COLUMN "interest_accruals"."amount" IS 'interest in millionths of a cent';
+
+CREATE TABLE "interest_postings" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "period_end" date NOT NULL,
+  "accrued" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "journal_entry_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("journal_entry_id") REFERENCES "journal_entries" ("id");
+
+CREATE UNIQUE INDEX ON "interest_postings" ("account_id", "period_end");
+
+COMMENT ON COLUMN "interest_postings"."accrued" IS 'total interest accrued up to period_end in millionths of a cent';
+
+COMMENT ON COLUMN "interest_postings"."amount" IS 'cents credited, the fractions of a cent are carried over to the next period';
+
+-- The bank books its side of postings such as interest on internal accounts owned by the system user,
+-- which can't log in since its password hash is empty
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email")
+VALUES ('system', '', 'st-bank', 'system@st-bank.internal');
+
+CREATE TABLE "system_accounts" (
+  "purpose" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "account_id" bigint UNIQUE NOT NULL,
+  PRIMARY KEY ("purpose", "currency")
+);
+
+ALTER TABLE "system_accounts" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+COMMENT ON COLUMN "system_accounts"."purpose" IS 'what the internal account books, e.g. interest_expense';
+
+WITH "created" AS (
+  INSERT INTO "accounts" ("owner", "balance", "currency", "type")
+  SELECT 'system', 0, "currency", 'internal'
+  FROM unnest(ARRAY['USD', 'EUR', 'UAH']) AS "currency"
+  RETURNING "id", "currency"
+)
+INSERT INTO "system_accounts" ("purpose", "currency", "account_id")
+SELECT 'interest_expense', "currency", "id"
+FROM "created";
\ No newline at end of file
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "account_fees";
+
+WITH "deleted" AS (
+  DELETE FROM "system_accounts" WHERE "purpose" = 'fee_income'
+  RETURNING "account_id"
+)
+DELETE FROM "accounts" WHERE "id" IN (SELECT "account_id" FROM "deleted");
\ No newline at end of file
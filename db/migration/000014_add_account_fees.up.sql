@@ -0,0 +1,42 @@
+CREATE TABLE "account_fees" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "period" date NOT NULL,
+  "amount" bigint NOT NULL,
+  "charged" bigint NOT NULL DEFAULT 0,
+  "arrears" bigint NOT NULL DEFAULT 0,
+  "status" varchar NOT NULL,
+  "waiver_reason" varchar NOT NULL DEFAULT '',
+  "journal_entry_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("status" IN ('charged', 'partial', 'arrears', 'waived'))
+);
+
+ALTER TABLE "account_fees" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_fees" ADD FOREIGN KEY ("journal_entry_id") REFERENCES "journal_entries" ("id");
+
+-- An account is billed once per period, running the billing job again does nothing
+CREATE UNIQUE INDEX ON "account_fees" ("account_id", "period");
+
+COMMENT ON COLUMN "account_fees"."period" IS 'first day of the month the fee is for';
+
+COMMENT ON COLUMN "account_fees"."amount" IS 'fee due for the period';
+
+COMMENT ON COLUMN "account_fees"."charged" IS 'part of the fee taken from the balance';
+
+COMMENT ON COLUMN "account_fees"."arrears" IS 'part of the fee the balance could not cover';
+
+COMMENT ON COLUMN "account_fees"."status" IS 'charged, partial, arrears or waived';
+
+COMMENT ON COLUMN "account_fees"."waiver_reason" IS 'rule that waived the fee, e.g. minimum_balance';
+
+WITH "created" AS (
+  INSERT INTO "accounts" ("owner", "balance", "currency", "type")
+  SELECT 'system', 0, "currency", 'internal'
+  FROM unnest(ARRAY['USD', 'EUR', 'UAH']) AS "currency"
+  RETURNING "id", "currency"
+)
+INSERT INTO "system_accounts" ("purpose", "currency", "account_id")
+SELECT 'fee_income', "currency", "id"
+FROM "created";
\ No newline at end of file
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS "overdraft_notifications";
+
+DROP TABLE IF EXISTS "overdraft_facilities";
+
+COMMENT ON COLUMN "interest_postings"."amount" IS 'cents credited, the fractions of a cent are carried over to the next period';
+
+WITH "deleted" AS (
+  DELETE FROM "system_accounts" WHERE "purpose" = 'overdraft_interest_income'
+  RETURNING "account_id"
+)
+DELETE FROM "accounts" WHERE "id" IN (SELECT "account_id" FROM "deleted");
\ No newline at end of file
@@ -0,0 +1,51 @@
+CREATE TABLE "overdraft_facilities" (
+  "account_id" bigint PRIMARY KEY,
+  "overdraft_limit" bigint NOT NULL,
+  "annual_rate_bps" integer NOT NULL,
+  "granted_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("overdraft_limit" >= 0),
+  CHECK ("annual_rate_bps" >= 0)
+);
+
+ALTER TABLE "overdraft_facilities" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "overdraft_facilities" ADD FOREIGN KEY ("granted_by") REFERENCES "users" ("username");
+
+COMMENT ON COLUMN "overdraft_facilities"."overdraft_limit" IS 'how far below zero the balance can go, 0 once the facility is revoked';
+
+COMMENT ON COLUMN "overdraft_facilities"."annual_rate_bps" IS 'annual interest rate charged on negative balances in basis points, accrued ACT/365';
+
+COMMENT ON COLUMN "overdraft_facilities"."granted_by" IS 'banker who last granted or revoked the facility';
+
+CREATE TABLE "overdraft_notifications" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "transfer_id" bigint NOT NULL,
+  "balance" bigint NOT NULL,
+  "overdraft_limit" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "overdraft_notifications" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "overdraft_notifications" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "overdraft_notifications" ("account_id", "created_at");
+
+COMMENT ON COLUMN "overdraft_notifications"."transfer_id" IS 'transfer that took the balance below zero';
+
+COMMENT ON COLUMN "overdraft_notifications"."balance" IS 'balance right after the transfer';
+
+COMMENT ON COLUMN "interest_postings"."amount" IS 'cents credited, or debited when negative for overdraft interest, the fractions of a cent are carried over to the next period';
+
+WITH "created" AS (
+  INSERT INTO "accounts" ("owner", "balance", "currency", "type")
+  SELECT 'system', 0, "currency", 'internal'
+  FROM unnest(ARRAY['USD', 'EUR', 'UAH']) AS "currency"
+  RETURNING "id", "currency"
+)
+INSERT INTO "system_accounts" ("purpose", "currency", "account_id")
+SELECT 'overdraft_interest_income', "currency", "id"
+FROM "created";
\ No newline at end of file
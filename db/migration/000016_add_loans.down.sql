@@ -0,0 +1,21 @@
+DROP TABLE IF EXISTS "loan_installments";
+
+DROP TABLE IF EXISTS "loans";
+
+WITH "deleted" AS (
+  DELETE FROM "system_accounts" WHERE "purpose" = 'loan_interest_income'
+  RETURNING "account_id"
+)
+DELETE FROM "accounts" WHERE "id" IN (SELECT "account_id" FROM "deleted");
+
+DELETE FROM "accounts" WHERE "type" = 'loan';
+
+DROP INDEX IF EXISTS "accounts_owner_currency_type_key";
+
+CREATE UNIQUE INDEX "accounts_owner_currency_type_key" ON "accounts" ("owner", "currency", "type") WHERE "type" <> 'internal';
+
+ALTER TABLE "accounts" DROP CONSTRAINT "accounts_type_check";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_type_check" CHECK ("type" IN ('checking', 'savings', 'internal'));
+
+COMMENT ON COLUMN "accounts"."type" IS 'checking, savings or internal for the accounts of the bank itself';
\ No newline at end of file
@@ -0,0 +1,80 @@
+ALTER TABLE "accounts" DROP CONSTRAINT "accounts_type_check";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_type_check" CHECK ("type" IN ('checking', 'savings', 'internal', 'loan'));
+
+COMMENT ON COLUMN "accounts"."type" IS 'checking, savings, loan for the outstanding principal of a loan, or internal for the accounts of the bank itself';
+
+-- A user can have several loans in the same currency
+DROP INDEX "accounts_owner_currency_type_key";
+
+CREATE UNIQUE INDEX "accounts_owner_currency_type_key" ON "accounts" ("owner", "currency", "type") WHERE "type" IN ('checking', 'savings');
+
+CREATE TABLE "loans" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint UNIQUE NOT NULL,
+  "repayment_account_id" bigint NOT NULL,
+  "principal" bigint NOT NULL,
+  "annual_rate_bps" integer NOT NULL,
+  "term_months" integer NOT NULL,
+  "method" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "originated_by" varchar NOT NULL,
+  "journal_entry_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "repaid_at" timestamptz,
+  CHECK ("principal" > 0),
+  CHECK ("annual_rate_bps" >= 0),
+  CHECK ("term_months" > 0),
+  CHECK ("method" IN ('annuity', 'linear')),
+  CHECK ("status" IN ('active', 'repaid'))
+);
+
+ALTER TABLE "loans" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "loans" ADD FOREIGN KEY ("repayment_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "loans" ADD FOREIGN KEY ("originated_by") REFERENCES "users" ("username");
+
+ALTER TABLE "loans" ADD FOREIGN KEY ("journal_entry_id") REFERENCES "journal_entries" ("id");
+
+COMMENT ON COLUMN "loans"."account_id" IS 'loan account, its negative balance is the principal left to repay';
+
+COMMENT ON COLUMN "loans"."repayment_account_id" IS 'account the loan was disbursed to and installments are collected from';
+
+COMMENT ON COLUMN "loans"."method" IS 'annuity for equal installments, linear for equal principal repayments';
+
+COMMENT ON COLUMN "loans"."status" IS 'active or repaid';
+
+CREATE TABLE "loan_installments" (
+  "id" bigserial PRIMARY KEY,
+  "loan_id" bigint NOT NULL,
+  "number" integer NOT NULL,
+  "due_date" date NOT NULL,
+  "principal" bigint NOT NULL,
+  "interest" bigint NOT NULL,
+  "paid_principal" bigint NOT NULL DEFAULT 0,
+  "paid_interest" bigint NOT NULL DEFAULT 0,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "paid_at" timestamptz,
+  CHECK ("status" IN ('pending', 'paid', 'arrears'))
+);
+
+ALTER TABLE "loan_installments" ADD FOREIGN KEY ("loan_id") REFERENCES "loans" ("id");
+
+CREATE UNIQUE INDEX ON "loan_installments" ("loan_id", "number");
+
+CREATE INDEX ON "loan_installments" ("due_date") WHERE "status" IN ('pending', 'arrears');
+
+COMMENT ON COLUMN "loan_installments"."interest" IS 'interest due, reduced when the loan is repaid early';
+
+COMMENT ON COLUMN "loan_installments"."status" IS 'pending until due, paid, or arrears when it could not be collected in full on its due date';
+
+WITH "created" AS (
+  INSERT INTO "accounts" ("owner", "balance", "currency", "type")
+  SELECT 'system', 0, "currency", 'internal'
+  FROM unnest(ARRAY['USD', 'EUR', 'UAH']) AS "currency"
+  RETURNING "id", "currency"
+)
+INSERT INTO "system_accounts" ("purpose", "currency", "account_id")
+SELECT 'loan_interest_income', "currency", "id"
+FROM "created";
\ No newline at end of file
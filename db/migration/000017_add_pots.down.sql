@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS "pots";
+
+DELETE FROM "accounts" WHERE "type" = 'pot';
+
+ALTER TABLE "accounts" DROP CONSTRAINT "accounts_type_check";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_type_check" CHECK ("type" IN ('checking', 'savings', 'internal', 'loan'));
+
+COMMENT ON COLUMN "accounts"."type" IS 'checking, savings, loan for the outstanding principal of a loan, or internal for the accounts of the bank itself';
\ No newline at end of file
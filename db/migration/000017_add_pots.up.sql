@@ -0,0 +1,32 @@
+ALTER TABLE "accounts" DROP CONSTRAINT "accounts_type_check";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_type_check" CHECK ("type" IN ('checking', 'savings', 'internal', 'loan', 'pot'));
+
+COMMENT ON COLUMN "accounts"."type" IS 'checking, savings, pot for a sub-account of a checking or savings account, loan for the outstanding principal of a loan, or internal for the accounts of the bank itself';
+
+CREATE TABLE "pots" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint UNIQUE NOT NULL,
+  "parent_account_id" bigint NOT NULL,
+  "name" varchar NOT NULL,
+  "target_amount" bigint NOT NULL DEFAULT 0,
+  "round_up_to" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("target_amount" >= 0),
+  CHECK ("round_up_to" >= 0)
+);
+
+ALTER TABLE "pots" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "pots" ADD FOREIGN KEY ("parent_account_id") REFERENCES "accounts" ("id");
+
+CREATE UNIQUE INDEX ON "pots" ("parent_account_id", "name");
+
+-- Spare change is swept to a single pot per parent account
+CREATE UNIQUE INDEX "pots_round_up_key" ON "pots" ("parent_account_id") WHERE "round_up_to" > 0;
+
+COMMENT ON COLUMN "pots"."account_id" IS 'pot account holding the money set aside';
+
+COMMENT ON COLUMN "pots"."target_amount" IS 'amount the owner is saving up to, 0 for none';
+
+COMMENT ON COLUMN "pots"."round_up_to" IS 'outgoing transfers of the parent account are rounded up to a multiple of it and the spare change is moved to the pot, 0 to disable';
\ No newline at end of file
@@ -0,0 +1,23 @@
+CREATE TABLE "account_members" (
+  "account_id" bigint NOT NULL,
+  "username" varchar NOT NULL,
+  "permission" varchar NOT NULL,
+  "transfer_limit" bigint NOT NULL DEFAULT 0,
+  "granted_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "username"),
+  CHECK ("permission" IN ('view', 'transfer', 'manage')),
+  CHECK ("transfer_limit" >= 0)
+);
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("granted_by") REFERENCES "users" ("username");
+
+CREATE INDEX ON "account_members" ("username");
+
+COMMENT ON COLUMN "account_members"."permission" IS 'view to read the account, transfer to also send money from it up to the transfer limit, or manage to act as a joint owner';
+
+COMMENT ON COLUMN "account_members"."transfer_limit" IS 'largest single transfer a member with the transfer permission can make, 0 for no limit';
\ No newline at end of file
@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS "transfer_approval_signatures";
+
+UPDATE "transfer_approvals" SET "status" = 'rejected' WHERE "status" = 'pending_organization';
+
+COMMENT ON COLUMN "transfer_approvals"."status" IS 'pending_approval, approved or rejected';
+
+DROP INDEX IF EXISTS "accounts_organization_currency_type_key";
+
+DROP INDEX IF EXISTS "accounts_owner_currency_type_key";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "organization_id";
+
+CREATE UNIQUE INDEX "accounts_owner_currency_type_key" ON "accounts" ("owner", "currency", "type") WHERE "type" IN ('checking', 'savings');
+
+DROP TABLE IF EXISTS "organization_members";
+
+DROP TABLE IF EXISTS "organizations";
\ No newline at end of file
@@ -0,0 +1,62 @@
+CREATE TABLE "organizations" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar UNIQUE NOT NULL,
+  "required_approvals" int NOT NULL DEFAULT 1,
+  "approval_threshold" bigint NOT NULL DEFAULT 0,
+  "created_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("required_approvals" >= 0),
+  CHECK ("approval_threshold" >= 0)
+);
+
+ALTER TABLE "organizations" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("username");
+
+COMMENT ON COLUMN "organizations"."required_approvals" IS 'approvals from approvers or owners that transfers initiated by an initiator need, 0 to execute them directly';
+
+COMMENT ON COLUMN "organizations"."approval_threshold" IS 'transfers up to this amount are executed without approvals';
+
+CREATE TABLE "organization_members" (
+  "organization_id" bigint NOT NULL,
+  "username" varchar NOT NULL,
+  "role" varchar NOT NULL,
+  "added_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("organization_id", "username"),
+  CHECK ("role" IN ('owner', 'approver', 'initiator', 'viewer'))
+);
+
+ALTER TABLE "organization_members" ADD FOREIGN KEY ("organization_id") REFERENCES "organizations" ("id");
+
+ALTER TABLE "organization_members" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "organization_members" ADD FOREIGN KEY ("added_by") REFERENCES "users" ("username");
+
+CREATE INDEX ON "organization_members" ("username");
+
+COMMENT ON COLUMN "organization_members"."role" IS 'owner to manage the organization and its accounts, approver to approve transfers, initiator to initiate them, or viewer';
+
+ALTER TABLE "accounts" ADD COLUMN "organization_id" bigint;
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("organization_id") REFERENCES "organizations" ("id");
+
+COMMENT ON COLUMN "accounts"."organization_id" IS 'organization holding the account, opened by the owner in its name';
+
+-- The owner of an organization can also hold accounts of their own in the same currency
+DROP INDEX "accounts_owner_currency_type_key";
+
+CREATE UNIQUE INDEX "accounts_owner_currency_type_key" ON "accounts" ("owner", "currency", "type") WHERE "type" IN ('checking', 'savings') AND "organization_id" IS NULL;
+
+CREATE UNIQUE INDEX "accounts_organization_currency_type_key" ON "accounts" ("organization_id", "currency", "type") WHERE "type" IN ('checking', 'savings');
+
+CREATE TABLE "transfer_approval_signatures" (
+  "transfer_approval_id" bigint NOT NULL,
+  "approver" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("transfer_approval_id", "approver")
+);
+
+ALTER TABLE "transfer_approval_signatures" ADD FOREIGN KEY ("transfer_approval_id") REFERENCES "transfer_approvals" ("id");
+
+ALTER TABLE "transfer_approval_signatures" ADD FOREIGN KEY ("approver") REFERENCES "users" ("username");
+
+COMMENT ON COLUMN "transfer_approvals"."status" IS 'pending_organization while approvers of the organization holding the account sign, pending_approval, approved or rejected';
\ No newline at end of file
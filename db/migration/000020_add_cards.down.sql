@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS "card_authorizations";
+
+DROP TABLE IF EXISTS "cards";
+
+WITH "deleted" AS (
+  DELETE FROM "system_accounts" WHERE "purpose" = 'card_settlement'
+  RETURNING "account_id"
+)
+DELETE FROM "accounts" WHERE "id" IN (SELECT "account_id" FROM "deleted");
\ No newline at end of file
@@ -0,0 +1,72 @@
+CREATE TABLE "cards" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "holder" varchar NOT NULL,
+  "pan" varchar UNIQUE NOT NULL,
+  "expiry_month" integer NOT NULL,
+  "expiry_year" integer NOT NULL,
+  "hashed_cvv" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "spending_limit" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("expiry_month" BETWEEN 1 AND 12),
+  CHECK ("status" IN ('active', 'frozen')),
+  CHECK ("spending_limit" >= 0)
+);
+
+ALTER TABLE "cards" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "cards" ADD FOREIGN KEY ("holder") REFERENCES "users" ("username");
+
+CREATE INDEX ON "cards" ("account_id");
+
+COMMENT ON COLUMN "cards"."holder" IS 'user the card was issued to';
+
+COMMENT ON COLUMN "cards"."pan" IS 'primary account number, 16 digits passing the Luhn check';
+
+COMMENT ON COLUMN "cards"."hashed_cvv" IS 'bcrypt hash of the card verification value, which is only shown when the card is issued';
+
+COMMENT ON COLUMN "cards"."status" IS 'active, or frozen to decline every authorization until it is unfrozen';
+
+COMMENT ON COLUMN "cards"."spending_limit" IS 'most the card can spend per UTC day, 0 for no limit';
+
+CREATE TABLE "card_authorizations" (
+  "id" bigserial PRIMARY KEY,
+  "card_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "merchant" varchar NOT NULL,
+  "status" varchar NOT NULL,
+  "decline_reason" varchar NOT NULL DEFAULT '',
+  "cleared_amount" bigint NOT NULL DEFAULT 0,
+  "journal_entry_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "settled_at" timestamptz,
+  CHECK ("amount" > 0),
+  CHECK ("status" IN ('held', 'cleared', 'released', 'declined')),
+  CHECK ("cleared_amount" >= 0 AND "cleared_amount" <= "amount")
+);
+
+ALTER TABLE "card_authorizations" ADD FOREIGN KEY ("card_id") REFERENCES "cards" ("id");
+
+ALTER TABLE "card_authorizations" ADD FOREIGN KEY ("journal_entry_id") REFERENCES "journal_entries" ("id");
+
+CREATE INDEX ON "card_authorizations" ("card_id", "created_at");
+
+CREATE INDEX ON "card_authorizations" ("card_id") WHERE "status" = 'held';
+
+COMMENT ON COLUMN "card_authorizations"."status" IS 'held while the amount is reserved on the account, cleared once the merchant settled it, released when the merchant dropped it, or declined';
+
+COMMENT ON COLUMN "card_authorizations"."cleared_amount" IS 'amount the merchant settled, up to the amount held';
+
+COMMENT ON COLUMN "card_authorizations"."journal_entry_id" IS 'card journal entry debiting the account when the authorization was cleared';
+
+WITH "created" AS (
+  INSERT INTO "accounts" ("owner", "balance", "currency", "type")
+  SELECT 'system', 0, "currency", 'internal'
+  FROM unnest(ARRAY['USD', 'EUR', 'UAH']) AS "currency"
+  RETURNING "id", "currency"
+)
+INSERT INTO "system_accounts" ("purpose", "currency", "account_id")
+SELECT 'card_settlement', "currency", "id"
+FROM "created";
\ No newline at end of file
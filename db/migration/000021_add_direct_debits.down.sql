@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "direct_debits";
+
+DROP TABLE IF EXISTS "mandates";
\ No newline at end of file
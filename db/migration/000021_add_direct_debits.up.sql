@@ -0,0 +1,62 @@
+CREATE TABLE "mandates" (
+  "id" bigserial PRIMARY KEY,
+  "debtor_account_id" bigint NOT NULL,
+  "creditor_account_id" bigint NOT NULL,
+  "max_amount" bigint NOT NULL,
+  "frequency" varchar NOT NULL,
+  "reference" varchar NOT NULL DEFAULT '',
+  "status" varchar NOT NULL DEFAULT 'active',
+  "granted_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "revoked_at" timestamptz,
+  CHECK ("debtor_account_id" <> "creditor_account_id"),
+  CHECK ("max_amount" > 0),
+  CHECK ("frequency" IN ('daily', 'weekly', 'monthly', 'yearly')),
+  CHECK ("status" IN ('active', 'revoked'))
+);
+
+ALTER TABLE "mandates" ADD FOREIGN KEY ("debtor_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "mandates" ADD FOREIGN KEY ("creditor_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "mandates" ADD FOREIGN KEY ("granted_by") REFERENCES "users" ("username");
+
+CREATE INDEX ON "mandates" ("debtor_account_id");
+
+CREATE INDEX ON "mandates" ("creditor_account_id");
+
+COMMENT ON COLUMN "mandates"."max_amount" IS 'largest amount a single direct debit can collect';
+
+COMMENT ON COLUMN "mandates"."frequency" IS 'daily, weekly, monthly or yearly: at most one direct debit is collected per period, counted from the previous one';
+
+COMMENT ON COLUMN "mandates"."reference" IS 'reference of the mandate at the creditor, e.g. a contract number';
+
+COMMENT ON COLUMN "mandates"."status" IS 'active, or revoked by the debtor to stop any further direct debit';
+
+CREATE TABLE "direct_debits" (
+  "id" bigserial PRIMARY KEY,
+  "mandate_id" bigint NOT NULL,
+  "transfer_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'collected',
+  "dispute_reason" varchar NOT NULL DEFAULT '',
+  "reversal_id" bigint,
+  "collected_at" timestamptz NOT NULL DEFAULT (now()),
+  "disputed_at" timestamptz,
+  CHECK ("amount" > 0),
+  CHECK ("status" IN ('collected', 'disputed'))
+);
+
+ALTER TABLE "direct_debits" ADD FOREIGN KEY ("mandate_id") REFERENCES "mandates" ("id");
+
+ALTER TABLE "direct_debits" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "direct_debits" ADD FOREIGN KEY ("reversal_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "direct_debits" ("mandate_id", "collected_at");
+
+COMMENT ON COLUMN "direct_debits"."transfer_id" IS 'transfer from the debtor to the creditor account that collected the amount';
+
+COMMENT ON COLUMN "direct_debits"."status" IS 'collected, or disputed by the debtor and refunded';
+
+COMMENT ON COLUMN "direct_debits"."reversal_id" IS 'transfer refunding the debtor when the direct debit was disputed';
\ No newline at end of file
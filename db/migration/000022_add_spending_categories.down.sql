@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "transfer_categories";
+
+DROP TABLE IF EXISTS "categorization_rules";
+
+DROP TABLE IF EXISTS "category_budgets";
+
+DROP TABLE IF EXISTS "spending_categories";
\ No newline at end of file
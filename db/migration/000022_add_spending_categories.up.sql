@@ -0,0 +1,54 @@
+CREATE TABLE "spending_categories" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "name" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "spending_categories" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+CREATE UNIQUE INDEX ON "spending_categories" ("owner", "name");
+
+CREATE TABLE "category_budgets" (
+  "category_id" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "amount" bigint NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("category_id", "currency"),
+  CHECK ("amount" > 0)
+);
+
+ALTER TABLE "category_budgets" ADD FOREIGN KEY ("category_id") REFERENCES "spending_categories" ("id");
+
+COMMENT ON COLUMN "category_budgets"."amount" IS 'amount the owner plans to spend in the category each month, in the currency';
+
+CREATE TABLE "categorization_rules" (
+  "id" bigserial PRIMARY KEY,
+  "category_id" bigint NOT NULL,
+  "recipient_account_id" bigint,
+  "memo_contains" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK (("recipient_account_id" IS NULL) <> ("memo_contains" = ''))
+);
+
+ALTER TABLE "categorization_rules" ADD FOREIGN KEY ("category_id") REFERENCES "spending_categories" ("id");
+
+ALTER TABLE "categorization_rules" ADD FOREIGN KEY ("recipient_account_id") REFERENCES "accounts" ("id");
+
+CREATE INDEX ON "categorization_rules" ("category_id");
+
+COMMENT ON COLUMN "categorization_rules"."recipient_account_id" IS 'outgoing transfers to this account fall into the category';
+
+COMMENT ON COLUMN "categorization_rules"."memo_contains" IS 'outgoing transfers whose description contains this text, ignoring case, fall into the category';
+
+CREATE TABLE "transfer_categories" (
+  "transfer_id" bigint PRIMARY KEY,
+  "category_id" bigint NOT NULL,
+  "categorized_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "transfer_categories" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_categories" ADD FOREIGN KEY ("category_id") REFERENCES "spending_categories" ("id");
+
+COMMENT ON COLUMN "transfer_categories"."category_id" IS 'category assigned by the sender, taking precedence over the categorization rules';
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "entries_account_id_created_at_idx";
+
+CREATE INDEX "entries_account_id_created_at_idx" ON "entries" ("account_id", "created_at");
\ No newline at end of file
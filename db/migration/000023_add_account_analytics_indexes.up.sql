@@ -0,0 +1,4 @@
+-- Analytics aggregate the entries of an account over a period, which the index can answer on its own with these columns
+DROP INDEX IF EXISTS "entries_account_id_created_at_idx";
+
+CREATE INDEX "entries_account_id_created_at_idx" ON "entries" ("account_id", "created_at") INCLUDE ("amount", "transfer_id");
\ No newline at end of file
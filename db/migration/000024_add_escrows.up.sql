@@ -0,0 +1,65 @@
+ALTER TABLE "accounts" DROP CONSTRAINT "accounts_type_check";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_type_check" CHECK ("type" IN ('checking', 'savings', 'internal', 'loan', 'pot', 'escrow'));
+
+COMMENT ON COLUMN "accounts"."type" IS 'checking, savings, pot for a sub-account of a checking or savings account, loan for the outstanding principal of a loan, escrow for the money held by an escrow, or internal for the accounts of the bank itself';
+
+CREATE TABLE "escrows" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint UNIQUE NOT NULL,
+  "payer_account_id" bigint NOT NULL,
+  "payee_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "status" varchar NOT NULL DEFAULT 'held',
+  "deadline" timestamptz NOT NULL,
+  "created_by" varchar NOT NULL,
+  "funding_transfer_id" bigint NOT NULL,
+  "settlement_transfer_id" bigint,
+  "dispute_reason" varchar NOT NULL DEFAULT '',
+  "disputed_by" varchar,
+  "settled_by" varchar,
+  "resolution_note" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "disputed_at" timestamptz,
+  "settled_at" timestamptz,
+  CHECK ("payer_account_id" <> "payee_account_id"),
+  CHECK ("amount" > 0),
+  CHECK ("status" IN ('held', 'disputed', 'released', 'refunded'))
+);
+
+ALTER TABLE "escrows" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "escrows" ADD FOREIGN KEY ("payer_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "escrows" ADD FOREIGN KEY ("payee_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "escrows" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("username");
+
+ALTER TABLE "escrows" ADD FOREIGN KEY ("funding_transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "escrows" ADD FOREIGN KEY ("settlement_transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "escrows" ADD FOREIGN KEY ("disputed_by") REFERENCES "users" ("username");
+
+ALTER TABLE "escrows" ADD FOREIGN KEY ("settled_by") REFERENCES "users" ("username");
+
+CREATE INDEX ON "escrows" ("payer_account_id");
+
+CREATE INDEX ON "escrows" ("payee_account_id");
+
+CREATE INDEX ON "escrows" ("deadline") WHERE "status" = 'held';
+
+COMMENT ON COLUMN "escrows"."account_id" IS 'escrow account holding the amount, owned by the bank';
+
+COMMENT ON COLUMN "escrows"."status" IS 'held until the payer releases it, disputed by a party until an arbiter resolves it, then released to the payee or refunded to the payer';
+
+COMMENT ON COLUMN "escrows"."deadline" IS 'time after which an escrow still held is refunded to the payer';
+
+COMMENT ON COLUMN "escrows"."funding_transfer_id" IS 'transfer from the payer to the escrow account';
+
+COMMENT ON COLUMN "escrows"."settlement_transfer_id" IS 'transfer from the escrow account to the payee when released, or back to the payer when refunded';
+
+COMMENT ON COLUMN "escrows"."settled_by" IS 'payer who released the escrow or arbiter who resolved its dispute, null when it was refunded at its deadline';
+
+COMMENT ON COLUMN "escrows"."resolution_note" IS 'note of the arbiter resolving the dispute';
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS "transfer_approvals" DROP COLUMN IF EXISTS "fraud_review";
\ No newline at end of file
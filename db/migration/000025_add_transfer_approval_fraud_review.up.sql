@@ -0,0 +1,3 @@
+ALTER TABLE "transfer_approvals" ADD COLUMN "fraud_review" boolean NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN "transfer_approvals"."fraud_review" IS 'set when the fraud checks asked for a review, so that a banker reviews the transfer once the approvers of its organization signed it';
\ No newline at end of file
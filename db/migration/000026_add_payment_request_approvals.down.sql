@@ -0,0 +1,5 @@
+UPDATE "payment_requests" SET "status" = 'pending' WHERE "status" = 'pending_approval';
+
+ALTER TABLE IF EXISTS "transfer_approvals" DROP COLUMN IF EXISTS "payment_request_id";
+
+COMMENT ON COLUMN "payment_requests"."status" IS 'pending, accepted or declined';
\ No newline at end of file
@@ -0,0 +1,9 @@
+ALTER TABLE "transfer_approvals" ADD COLUMN "payment_request_id" bigint;
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("payment_request_id") REFERENCES "payment_requests" ("id");
+
+CREATE UNIQUE INDEX ON "transfer_approvals" ("payment_request_id");
+
+COMMENT ON COLUMN "transfer_approvals"."payment_request_id" IS 'payment request the transfer pays, accepted or declined together with the review';
+
+COMMENT ON COLUMN "payment_requests"."status" IS 'pending, pending_approval while the payment waits for a banker, accepted or declined';
\ No newline at end of file
@@ -0,0 +1,7 @@
+COMMENT ON COLUMN "reconciliation_adjustments"."amount" IS 'balance minus entries total, booked as a correction entry';
+
+WITH "deleted" AS (
+  DELETE FROM "system_accounts" WHERE "purpose" = 'reconciliation_suspense'
+  RETURNING "account_id"
+)
+DELETE FROM "accounts" WHERE "id" IN (SELECT "account_id" FROM "deleted");
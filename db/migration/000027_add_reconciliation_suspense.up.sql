@@ -0,0 +1,11 @@
+WITH "created" AS (
+  INSERT INTO "accounts" ("owner", "balance", "currency", "type")
+  SELECT 'system', 0, "currency", 'internal'
+  FROM unnest(ARRAY['USD', 'EUR', 'UAH']) AS "currency"
+  RETURNING "id", "currency"
+)
+INSERT INTO "system_accounts" ("purpose", "currency", "account_id")
+SELECT 'reconciliation_suspense', "currency", "id"
+FROM "created";
+
+COMMENT ON COLUMN "reconciliation_adjustments"."amount" IS 'balance minus entries total, booked as a correction journal entry against the reconciliation suspense account';
@@ -0,0 +1,9 @@
+UPDATE "cards" SET "status" = 'frozen' WHERE "status" = 'blocked';
+
+ALTER TABLE "cards" DROP CONSTRAINT "cards_status_check";
+
+ALTER TABLE "cards" ADD CONSTRAINT "cards_status_check" CHECK ("status" IN ('active', 'frozen'));
+
+COMMENT ON COLUMN "cards"."status" IS 'active, or frozen to decline every authorization until it is unfrozen';
+
+ALTER TABLE "cards" DROP COLUMN "failed_cvv_attempts";
@@ -0,0 +1,9 @@
+ALTER TABLE "cards" ADD COLUMN "failed_cvv_attempts" integer NOT NULL DEFAULT 0;
+
+ALTER TABLE "cards" DROP CONSTRAINT "cards_status_check";
+
+ALTER TABLE "cards" ADD CONSTRAINT "cards_status_check" CHECK ("status" IN ('active', 'frozen', 'blocked'));
+
+COMMENT ON COLUMN "cards"."status" IS 'active, frozen to decline every authorization until it is unfrozen, or blocked after too many failed CVV attempts';
+
+COMMENT ON COLUMN "cards"."failed_cvv_attempts" IS 'authorizations declined for invalid card details in a row, reset by a valid one or a status change';
@@ -7,6 +7,7 @@ package mockdb
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
@@ -35,123 +36,1888 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AcceptPaymentRequestTx mocks base method.
+func (m *MockStore) AcceptPaymentRequestTx(arg0 context.Context, arg1 db.AcceptPaymentRequestTxParams) (db.AcceptPaymentRequestTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptPaymentRequestTx", arg0, arg1)
+	ret0, _ := ret[0].(db.AcceptPaymentRequestTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptPaymentRequestTx indicates an expected call of AcceptPaymentRequestTx.
+func (mr *MockStoreMockRecorder) AcceptPaymentRequestTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptPaymentRequestTx", reflect.TypeOf((*MockStore)(nil).AcceptPaymentRequestTx), arg0, arg1)
+}
+
+// AddOrganizationMember mocks base method.
+func (m *MockStore) AddOrganizationMember(arg0 context.Context, arg1 db.AddOrganizationMemberParams) (db.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOrganizationMember", arg0, arg1)
+	ret0, _ := ret[0].(db.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddOrganizationMember indicates an expected call of AddOrganizationMember.
+func (mr *MockStoreMockRecorder) AddOrganizationMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrganizationMember", reflect.TypeOf((*MockStore)(nil).AddOrganizationMember), arg0, arg1)
+}
+
+// AppendAuditLogTx mocks base method.
+func (m *MockStore) AppendAuditLogTx(arg0 context.Context, arg1 db.AppendAuditLogParams) (db.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendAuditLogTx", arg0, arg1)
+	ret0, _ := ret[0].(db.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AppendAuditLogTx indicates an expected call of AppendAuditLogTx.
+func (mr *MockStoreMockRecorder) AppendAuditLogTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendAuditLogTx", reflect.TypeOf((*MockStore)(nil).AppendAuditLogTx), arg0, arg1)
+}
+
+// AuthorizeCardTx mocks base method.
+func (m *MockStore) AuthorizeCardTx(arg0 context.Context, arg1 db.AuthorizeCardTxParams) (db.AuthorizeCardTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeCardTx", arg0, arg1)
+	ret0, _ := ret[0].(db.AuthorizeCardTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizeCardTx indicates an expected call of AuthorizeCardTx.
+func (mr *MockStoreMockRecorder) AuthorizeCardTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeCardTx", reflect.TypeOf((*MockStore)(nil).AuthorizeCardTx), arg0, arg1)
+}
+
+// ChargeFeeTx mocks base method.
+func (m *MockStore) ChargeFeeTx(arg0 context.Context, arg1 db.ChargeFeeTxParams) (db.ChargeFeeTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeFeeTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ChargeFeeTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChargeFeeTx indicates an expected call of ChargeFeeTx.
+func (mr *MockStoreMockRecorder) ChargeFeeTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeFeeTx", reflect.TypeOf((*MockStore)(nil).ChargeFeeTx), arg0, arg1)
+}
+
+// ClearCardAuthorizationTx mocks base method.
+func (m *MockStore) ClearCardAuthorizationTx(arg0 context.Context, arg1 db.ClearCardAuthorizationTxParams) (db.ClearCardAuthorizationTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearCardAuthorizationTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ClearCardAuthorizationTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearCardAuthorizationTx indicates an expected call of ClearCardAuthorizationTx.
+func (mr *MockStoreMockRecorder) ClearCardAuthorizationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCardAuthorizationTx", reflect.TypeOf((*MockStore)(nil).ClearCardAuthorizationTx), arg0, arg1)
+}
+
+// CollectDirectDebitTx mocks base method.
+func (m *MockStore) CollectDirectDebitTx(arg0 context.Context, arg1 db.CollectDirectDebitTxParams) (db.CollectDirectDebitTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectDirectDebitTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CollectDirectDebitTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectDirectDebitTx indicates an expected call of CollectDirectDebitTx.
+func (mr *MockStoreMockRecorder) CollectDirectDebitTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectDirectDebitTx", reflect.TypeOf((*MockStore)(nil).CollectDirectDebitTx), arg0, arg1)
+}
+
+// CollectLoanInstallmentTx mocks base method.
+func (m *MockStore) CollectLoanInstallmentTx(arg0 context.Context, arg1 db.CollectLoanInstallmentTxParams) (db.CollectLoanInstallmentTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectLoanInstallmentTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CollectLoanInstallmentTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectLoanInstallmentTx indicates an expected call of CollectLoanInstallmentTx.
+func (mr *MockStoreMockRecorder) CollectLoanInstallmentTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectLoanInstallmentTx", reflect.TypeOf((*MockStore)(nil).CollectLoanInstallmentTx), arg0, arg1)
+}
+
+// CountOrganizationOwners mocks base method.
+func (m *MockStore) CountOrganizationOwners(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOrganizationOwners", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOrganizationOwners indicates an expected call of CountOrganizationOwners.
+func (mr *MockStoreMockRecorder) CountOrganizationOwners(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOrganizationOwners", reflect.TypeOf((*MockStore)(nil).CountOrganizationOwners), arg0, arg1)
+}
+
+// CountTransfersSince mocks base method.
+func (m *MockStore) CountTransfersSince(arg0 context.Context, arg1 db.CountTransfersSinceParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTransfersSince", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTransfersSince indicates an expected call of CountTransfersSince.
+func (mr *MockStoreMockRecorder) CountTransfersSince(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTransfersSince", reflect.TypeOf((*MockStore)(nil).CountTransfersSince), arg0, arg1)
+}
+
+// CountTransfersToAccount mocks base method.
+func (m *MockStore) CountTransfersToAccount(arg0 context.Context, arg1 db.CountTransfersToAccountParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTransfersToAccount", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTransfersToAccount indicates an expected call of CountTransfersToAccount.
+func (mr *MockStoreMockRecorder) CountTransfersToAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTransfersToAccount", reflect.TypeOf((*MockStore)(nil).CountTransfersToAccount), arg0, arg1)
+}
+
 // CreateAccount mocks base method.
 func (m *MockStore) CreateAccount(arg0 context.Context, arg1 db.CreateAccountParams) (db.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAccount", arg0, arg1)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "CreateAccount", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockStoreMockRecorder) CreateAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), arg0, arg1)
+}
+
+// CreateBalanceSnapshots mocks base method.
+func (m *MockStore) CreateBalanceSnapshots(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBalanceSnapshots", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBalanceSnapshots indicates an expected call of CreateBalanceSnapshots.
+func (mr *MockStoreMockRecorder) CreateBalanceSnapshots(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBalanceSnapshots", reflect.TypeOf((*MockStore)(nil).CreateBalanceSnapshots), arg0, arg1)
+}
+
+// CreateBeneficiary mocks base method.
+func (m *MockStore) CreateBeneficiary(arg0 context.Context, arg1 db.CreateBeneficiaryParams) (db.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBeneficiary", arg0, arg1)
+	ret0, _ := ret[0].(db.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBeneficiary indicates an expected call of CreateBeneficiary.
+func (mr *MockStoreMockRecorder) CreateBeneficiary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBeneficiary", reflect.TypeOf((*MockStore)(nil).CreateBeneficiary), arg0, arg1)
+}
+
+// CreateCard mocks base method.
+func (m *MockStore) CreateCard(arg0 context.Context, arg1 db.CreateCardParams) (db.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCard", arg0, arg1)
+	ret0, _ := ret[0].(db.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCard indicates an expected call of CreateCard.
+func (mr *MockStoreMockRecorder) CreateCard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCard", reflect.TypeOf((*MockStore)(nil).CreateCard), arg0, arg1)
+}
+
+// CreateCardAuthorization mocks base method.
+func (m *MockStore) CreateCardAuthorization(arg0 context.Context, arg1 db.CreateCardAuthorizationParams) (db.CardAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCardAuthorization", arg0, arg1)
+	ret0, _ := ret[0].(db.CardAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCardAuthorization indicates an expected call of CreateCardAuthorization.
+func (mr *MockStoreMockRecorder) CreateCardAuthorization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCardAuthorization", reflect.TypeOf((*MockStore)(nil).CreateCardAuthorization), arg0, arg1)
+}
+
+// CreateCategorizationRule mocks base method.
+func (m *MockStore) CreateCategorizationRule(arg0 context.Context, arg1 db.CreateCategorizationRuleParams) (db.CategorizationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategorizationRule", arg0, arg1)
+	ret0, _ := ret[0].(db.CategorizationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategorizationRule indicates an expected call of CreateCategorizationRule.
+func (mr *MockStoreMockRecorder) CreateCategorizationRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategorizationRule", reflect.TypeOf((*MockStore)(nil).CreateCategorizationRule), arg0, arg1)
+}
+
+// CreateEscrowTx mocks base method.
+func (m *MockStore) CreateEscrowTx(arg0 context.Context, arg1 db.CreateEscrowTxParams) (db.CreateEscrowTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEscrowTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateEscrowTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEscrowTx indicates an expected call of CreateEscrowTx.
+func (mr *MockStoreMockRecorder) CreateEscrowTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEscrowTx", reflect.TypeOf((*MockStore)(nil).CreateEscrowTx), arg0, arg1)
+}
+
+// CreateFraudDecision mocks base method.
+func (m *MockStore) CreateFraudDecision(arg0 context.Context, arg1 db.CreateFraudDecisionParams) (db.FraudDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFraudDecision", arg0, arg1)
+	ret0, _ := ret[0].(db.FraudDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFraudDecision indicates an expected call of CreateFraudDecision.
+func (mr *MockStoreMockRecorder) CreateFraudDecision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFraudDecision", reflect.TypeOf((*MockStore)(nil).CreateFraudDecision), arg0, arg1)
+}
+
+// CreateInterestAccrual mocks base method.
+func (m *MockStore) CreateInterestAccrual(arg0 context.Context, arg1 db.CreateInterestAccrualParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInterestAccrual", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInterestAccrual indicates an expected call of CreateInterestAccrual.
+func (mr *MockStoreMockRecorder) CreateInterestAccrual(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInterestAccrual", reflect.TypeOf((*MockStore)(nil).CreateInterestAccrual), arg0, arg1)
+}
+
+// CreateInterestPlan mocks base method.
+func (m *MockStore) CreateInterestPlan(arg0 context.Context, arg1 db.CreateInterestPlanParams) (db.InterestPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInterestPlan", arg0, arg1)
+	ret0, _ := ret[0].(db.InterestPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInterestPlan indicates an expected call of CreateInterestPlan.
+func (mr *MockStoreMockRecorder) CreateInterestPlan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInterestPlan", reflect.TypeOf((*MockStore)(nil).CreateInterestPlan), arg0, arg1)
+}
+
+// CreateMandate mocks base method.
+func (m *MockStore) CreateMandate(arg0 context.Context, arg1 db.CreateMandateParams) (db.Mandate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMandate", arg0, arg1)
+	ret0, _ := ret[0].(db.Mandate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMandate indicates an expected call of CreateMandate.
+func (mr *MockStoreMockRecorder) CreateMandate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMandate", reflect.TypeOf((*MockStore)(nil).CreateMandate), arg0, arg1)
+}
+
+// CreateOrganizationTx mocks base method.
+func (m *MockStore) CreateOrganizationTx(arg0 context.Context, arg1 db.CreateOrganizationTxParams) (db.CreateOrganizationTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganizationTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateOrganizationTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganizationTx indicates an expected call of CreateOrganizationTx.
+func (mr *MockStoreMockRecorder) CreateOrganizationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganizationTx", reflect.TypeOf((*MockStore)(nil).CreateOrganizationTx), arg0, arg1)
+}
+
+// CreatePaymentRequest mocks base method.
+func (m *MockStore) CreatePaymentRequest(arg0 context.Context, arg1 db.CreatePaymentRequestParams) (db.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentRequest", arg0, arg1)
+	ret0, _ := ret[0].(db.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentRequest indicates an expected call of CreatePaymentRequest.
+func (mr *MockStoreMockRecorder) CreatePaymentRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentRequest", reflect.TypeOf((*MockStore)(nil).CreatePaymentRequest), arg0, arg1)
+}
+
+// CreatePotTx mocks base method.
+func (m *MockStore) CreatePotTx(arg0 context.Context, arg1 db.CreatePotTxParams) (db.CreatePotTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePotTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreatePotTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePotTx indicates an expected call of CreatePotTx.
+func (mr *MockStoreMockRecorder) CreatePotTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePotTx", reflect.TypeOf((*MockStore)(nil).CreatePotTx), arg0, arg1)
+}
+
+// CreateSavingsAccountTx mocks base method.
+func (m *MockStore) CreateSavingsAccountTx(arg0 context.Context, arg1 db.CreateSavingsAccountTxParams) (db.CreateSavingsAccountTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSavingsAccountTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateSavingsAccountTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSavingsAccountTx indicates an expected call of CreateSavingsAccountTx.
+func (mr *MockStoreMockRecorder) CreateSavingsAccountTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavingsAccountTx", reflect.TypeOf((*MockStore)(nil).CreateSavingsAccountTx), arg0, arg1)
+}
+
+// CreateSpendingCategory mocks base method.
+func (m *MockStore) CreateSpendingCategory(arg0 context.Context, arg1 db.CreateSpendingCategoryParams) (db.SpendingCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSpendingCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.SpendingCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSpendingCategory indicates an expected call of CreateSpendingCategory.
+func (mr *MockStoreMockRecorder) CreateSpendingCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSpendingCategory", reflect.TypeOf((*MockStore)(nil).CreateSpendingCategory), arg0, arg1)
+}
+
+// CreateTransferApproval mocks base method.
+func (m *MockStore) CreateTransferApproval(arg0 context.Context, arg1 db.CreateTransferApprovalParams) (db.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferApproval", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferApproval indicates an expected call of CreateTransferApproval.
+func (mr *MockStoreMockRecorder) CreateTransferApproval(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferApproval", reflect.TypeOf((*MockStore)(nil).CreateTransferApproval), arg0, arg1)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+}
+
+// DeclinePaymentRequest mocks base method.
+func (m *MockStore) DeclinePaymentRequest(arg0 context.Context, arg1 int64) (db.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclinePaymentRequest", arg0, arg1)
+	ret0, _ := ret[0].(db.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeclinePaymentRequest indicates an expected call of DeclinePaymentRequest.
+func (mr *MockStoreMockRecorder) DeclinePaymentRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclinePaymentRequest", reflect.TypeOf((*MockStore)(nil).DeclinePaymentRequest), arg0, arg1)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockStore) DeleteAccount(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockStoreMockRecorder) DeleteAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), arg0, arg1)
+}
+
+// DeleteBeneficiary mocks base method.
+func (m *MockStore) DeleteBeneficiary(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBeneficiary", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBeneficiary indicates an expected call of DeleteBeneficiary.
+func (mr *MockStoreMockRecorder) DeleteBeneficiary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBeneficiary", reflect.TypeOf((*MockStore)(nil).DeleteBeneficiary), arg0, arg1)
+}
+
+// DeleteCategorizationRule mocks base method.
+func (m *MockStore) DeleteCategorizationRule(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategorizationRule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategorizationRule indicates an expected call of DeleteCategorizationRule.
+func (mr *MockStoreMockRecorder) DeleteCategorizationRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategorizationRule", reflect.TypeOf((*MockStore)(nil).DeleteCategorizationRule), arg0, arg1)
+}
+
+// DeleteCategoryBudget mocks base method.
+func (m *MockStore) DeleteCategoryBudget(arg0 context.Context, arg1 db.DeleteCategoryBudgetParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategoryBudget", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategoryBudget indicates an expected call of DeleteCategoryBudget.
+func (mr *MockStoreMockRecorder) DeleteCategoryBudget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategoryBudget", reflect.TypeOf((*MockStore)(nil).DeleteCategoryBudget), arg0, arg1)
+}
+
+// DeleteTransferCategory mocks base method.
+func (m *MockStore) DeleteTransferCategory(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTransferCategory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTransferCategory indicates an expected call of DeleteTransferCategory.
+func (mr *MockStoreMockRecorder) DeleteTransferCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransferCategory", reflect.TypeOf((*MockStore)(nil).DeleteTransferCategory), arg0, arg1)
+}
+
+// DisputeDirectDebitTx mocks base method.
+func (m *MockStore) DisputeDirectDebitTx(arg0 context.Context, arg1 db.DisputeDirectDebitTxParams) (db.DisputeDirectDebitTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisputeDirectDebitTx", arg0, arg1)
+	ret0, _ := ret[0].(db.DisputeDirectDebitTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisputeDirectDebitTx indicates an expected call of DisputeDirectDebitTx.
+func (mr *MockStoreMockRecorder) DisputeDirectDebitTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisputeDirectDebitTx", reflect.TypeOf((*MockStore)(nil).DisputeDirectDebitTx), arg0, arg1)
+}
+
+// DisputeEscrow mocks base method.
+func (m *MockStore) DisputeEscrow(arg0 context.Context, arg1 db.DisputeEscrowParams) (db.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisputeEscrow", arg0, arg1)
+	ret0, _ := ret[0].(db.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisputeEscrow indicates an expected call of DisputeEscrow.
+func (mr *MockStoreMockRecorder) DisputeEscrow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisputeEscrow", reflect.TypeOf((*MockStore)(nil).DisputeEscrow), arg0, arg1)
+}
+
+// GetAccount mocks base method.
+func (m *MockStore) GetAccount(arg0 context.Context, arg1 int64) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockStoreMockRecorder) GetAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), arg0, arg1)
+}
+
+// GetAccountMember mocks base method.
+func (m *MockStore) GetAccountMember(arg0 context.Context, arg1 db.GetAccountMemberParams) (db.AccountMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountMember", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountMember indicates an expected call of GetAccountMember.
+func (mr *MockStoreMockRecorder) GetAccountMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountMember", reflect.TypeOf((*MockStore)(nil).GetAccountMember), arg0, arg1)
+}
+
+// GetBalanceAsOf mocks base method.
+func (m *MockStore) GetBalanceAsOf(arg0 context.Context, arg1 db.GetBalanceAsOfParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceAsOf", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceAsOf indicates an expected call of GetBalanceAsOf.
+func (mr *MockStoreMockRecorder) GetBalanceAsOf(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceAsOf", reflect.TypeOf((*MockStore)(nil).GetBalanceAsOf), arg0, arg1)
+}
+
+// GetBeneficiary mocks base method.
+func (m *MockStore) GetBeneficiary(arg0 context.Context, arg1 int64) (db.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBeneficiary", arg0, arg1)
+	ret0, _ := ret[0].(db.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBeneficiary indicates an expected call of GetBeneficiary.
+func (mr *MockStoreMockRecorder) GetBeneficiary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeneficiary", reflect.TypeOf((*MockStore)(nil).GetBeneficiary), arg0, arg1)
+}
+
+// GetBeneficiaryByAccount mocks base method.
+func (m *MockStore) GetBeneficiaryByAccount(arg0 context.Context, arg1 db.GetBeneficiaryByAccountParams) (db.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBeneficiaryByAccount", arg0, arg1)
+	ret0, _ := ret[0].(db.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBeneficiaryByAccount indicates an expected call of GetBeneficiaryByAccount.
+func (mr *MockStoreMockRecorder) GetBeneficiaryByAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeneficiaryByAccount", reflect.TypeOf((*MockStore)(nil).GetBeneficiaryByAccount), arg0, arg1)
+}
+
+// GetCard mocks base method.
+func (m *MockStore) GetCard(arg0 context.Context, arg1 int64) (db.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCard", arg0, arg1)
+	ret0, _ := ret[0].(db.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCard indicates an expected call of GetCard.
+func (mr *MockStoreMockRecorder) GetCard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCard", reflect.TypeOf((*MockStore)(nil).GetCard), arg0, arg1)
+}
+
+// GetCardAuthorization mocks base method.
+func (m *MockStore) GetCardAuthorization(arg0 context.Context, arg1 int64) (db.CardAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardAuthorization", arg0, arg1)
+	ret0, _ := ret[0].(db.CardAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardAuthorization indicates an expected call of GetCardAuthorization.
+func (mr *MockStoreMockRecorder) GetCardAuthorization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardAuthorization", reflect.TypeOf((*MockStore)(nil).GetCardAuthorization), arg0, arg1)
+}
+
+// GetCardByPAN mocks base method.
+func (m *MockStore) GetCardByPAN(arg0 context.Context, arg1 string) (db.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardByPAN", arg0, arg1)
+	ret0, _ := ret[0].(db.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardByPAN indicates an expected call of GetCardByPAN.
+func (mr *MockStoreMockRecorder) GetCardByPAN(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardByPAN", reflect.TypeOf((*MockStore)(nil).GetCardByPAN), arg0, arg1)
+}
+
+// GetCategorizationRule mocks base method.
+func (m *MockStore) GetCategorizationRule(arg0 context.Context, arg1 int64) (db.CategorizationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategorizationRule", arg0, arg1)
+	ret0, _ := ret[0].(db.CategorizationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategorizationRule indicates an expected call of GetCategorizationRule.
+func (mr *MockStoreMockRecorder) GetCategorizationRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategorizationRule", reflect.TypeOf((*MockStore)(nil).GetCategorizationRule), arg0, arg1)
+}
+
+// GetCategoryBudget mocks base method.
+func (m *MockStore) GetCategoryBudget(arg0 context.Context, arg1 db.GetCategoryBudgetParams) (db.CategoryBudget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryBudget", arg0, arg1)
+	ret0, _ := ret[0].(db.CategoryBudget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryBudget indicates an expected call of GetCategoryBudget.
+func (mr *MockStoreMockRecorder) GetCategoryBudget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryBudget", reflect.TypeOf((*MockStore)(nil).GetCategoryBudget), arg0, arg1)
+}
+
+// GetDailyBalanceStats mocks base method.
+func (m *MockStore) GetDailyBalanceStats(arg0 context.Context, arg1 db.GetDailyBalanceStatsParams) (db.GetDailyBalanceStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyBalanceStats", arg0, arg1)
+	ret0, _ := ret[0].(db.GetDailyBalanceStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyBalanceStats indicates an expected call of GetDailyBalanceStats.
+func (mr *MockStoreMockRecorder) GetDailyBalanceStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyBalanceStats", reflect.TypeOf((*MockStore)(nil).GetDailyBalanceStats), arg0, arg1)
+}
+
+// GetDirectDebit mocks base method.
+func (m *MockStore) GetDirectDebit(arg0 context.Context, arg1 int64) (db.DirectDebit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDirectDebit", arg0, arg1)
+	ret0, _ := ret[0].(db.DirectDebit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDirectDebit indicates an expected call of GetDirectDebit.
+func (mr *MockStoreMockRecorder) GetDirectDebit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectDebit", reflect.TypeOf((*MockStore)(nil).GetDirectDebit), arg0, arg1)
+}
+
+// GetEntry mocks base method.
+func (m *MockStore) GetEntry(arg0 context.Context, arg1 int64) (db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntry", arg0, arg1)
+	ret0, _ := ret[0].(db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntry indicates an expected call of GetEntry.
+func (mr *MockStoreMockRecorder) GetEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), arg0, arg1)
+}
+
+// GetEscrow mocks base method.
+func (m *MockStore) GetEscrow(arg0 context.Context, arg1 int64) (db.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEscrow", arg0, arg1)
+	ret0, _ := ret[0].(db.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEscrow indicates an expected call of GetEscrow.
+func (mr *MockStoreMockRecorder) GetEscrow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEscrow", reflect.TypeOf((*MockStore)(nil).GetEscrow), arg0, arg1)
+}
+
+// GetLoan mocks base method.
+func (m *MockStore) GetLoan(arg0 context.Context, arg1 int64) (db.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoan", arg0, arg1)
+	ret0, _ := ret[0].(db.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoan indicates an expected call of GetLoan.
+func (mr *MockStoreMockRecorder) GetLoan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoan", reflect.TypeOf((*MockStore)(nil).GetLoan), arg0, arg1)
+}
+
+// GetMandate mocks base method.
+func (m *MockStore) GetMandate(arg0 context.Context, arg1 int64) (db.Mandate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMandate", arg0, arg1)
+	ret0, _ := ret[0].(db.Mandate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMandate indicates an expected call of GetMandate.
+func (mr *MockStoreMockRecorder) GetMandate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMandate", reflect.TypeOf((*MockStore)(nil).GetMandate), arg0, arg1)
+}
+
+// GetOrganization mocks base method.
+func (m *MockStore) GetOrganization(arg0 context.Context, arg1 int64) (db.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganization", arg0, arg1)
+	ret0, _ := ret[0].(db.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganization indicates an expected call of GetOrganization.
+func (mr *MockStoreMockRecorder) GetOrganization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganization", reflect.TypeOf((*MockStore)(nil).GetOrganization), arg0, arg1)
+}
+
+// GetOrganizationMember mocks base method.
+func (m *MockStore) GetOrganizationMember(arg0 context.Context, arg1 db.GetOrganizationMemberParams) (db.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationMember", arg0, arg1)
+	ret0, _ := ret[0].(db.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationMember indicates an expected call of GetOrganizationMember.
+func (mr *MockStoreMockRecorder) GetOrganizationMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationMember", reflect.TypeOf((*MockStore)(nil).GetOrganizationMember), arg0, arg1)
+}
+
+// GetOverdraftFacility mocks base method.
+func (m *MockStore) GetOverdraftFacility(arg0 context.Context, arg1 int64) (db.OverdraftFacility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdraftFacility", arg0, arg1)
+	ret0, _ := ret[0].(db.OverdraftFacility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdraftFacility indicates an expected call of GetOverdraftFacility.
+func (mr *MockStoreMockRecorder) GetOverdraftFacility(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdraftFacility", reflect.TypeOf((*MockStore)(nil).GetOverdraftFacility), arg0, arg1)
+}
+
+// GetOverdraftLimit mocks base method.
+func (m *MockStore) GetOverdraftLimit(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdraftLimit", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdraftLimit indicates an expected call of GetOverdraftLimit.
+func (mr *MockStoreMockRecorder) GetOverdraftLimit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdraftLimit", reflect.TypeOf((*MockStore)(nil).GetOverdraftLimit), arg0, arg1)
+}
+
+// GetPaymentRequest mocks base method.
+func (m *MockStore) GetPaymentRequest(arg0 context.Context, arg1 int64) (db.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentRequest", arg0, arg1)
+	ret0, _ := ret[0].(db.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentRequest indicates an expected call of GetPaymentRequest.
+func (mr *MockStoreMockRecorder) GetPaymentRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentRequest", reflect.TypeOf((*MockStore)(nil).GetPaymentRequest), arg0, arg1)
+}
+
+// GetPot mocks base method.
+func (m *MockStore) GetPot(arg0 context.Context, arg1 int64) (db.Pot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPot", arg0, arg1)
+	ret0, _ := ret[0].(db.Pot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPot indicates an expected call of GetPot.
+func (mr *MockStoreMockRecorder) GetPot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPot", reflect.TypeOf((*MockStore)(nil).GetPot), arg0, arg1)
+}
+
+// GetSpendingCategory mocks base method.
+func (m *MockStore) GetSpendingCategory(arg0 context.Context, arg1 int64) (db.SpendingCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpendingCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.SpendingCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSpendingCategory indicates an expected call of GetSpendingCategory.
+func (mr *MockStoreMockRecorder) GetSpendingCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpendingCategory", reflect.TypeOf((*MockStore)(nil).GetSpendingCategory), arg0, arg1)
+}
+
+// GetTransfer mocks base method.
+func (m *MockStore) GetTransfer(arg0 context.Context, arg1 int64) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfer", arg0, arg1)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockStoreMockRecorder) GetTransfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), arg0, arg1)
+}
+
+// GetTransferAmountStats mocks base method.
+func (m *MockStore) GetTransferAmountStats(arg0 context.Context, arg1 string) (db.GetTransferAmountStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferAmountStats", arg0, arg1)
+	ret0, _ := ret[0].(db.GetTransferAmountStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferAmountStats indicates an expected call of GetTransferAmountStats.
+func (mr *MockStoreMockRecorder) GetTransferAmountStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferAmountStats", reflect.TypeOf((*MockStore)(nil).GetTransferAmountStats), arg0, arg1)
+}
+
+// GetTransferApproval mocks base method.
+func (m *MockStore) GetTransferApproval(arg0 context.Context, arg1 int64) (db.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferApproval", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferApproval indicates an expected call of GetTransferApproval.
+func (mr *MockStoreMockRecorder) GetTransferApproval(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferApproval", reflect.TypeOf((*MockStore)(nil).GetTransferApproval), arg0, arg1)
+}
+
+// GetTransferCategory mocks base method.
+func (m *MockStore) GetTransferCategory(arg0 context.Context, arg1 int64) (db.TransferCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferCategory indicates an expected call of GetTransferCategory.
+func (mr *MockStoreMockRecorder) GetTransferCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferCategory", reflect.TypeOf((*MockStore)(nil).GetTransferCategory), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+}
+
+// GrantAccountMember mocks base method.
+func (m *MockStore) GrantAccountMember(arg0 context.Context, arg1 db.GrantAccountMemberParams) (db.AccountMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantAccountMember", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantAccountMember indicates an expected call of GrantAccountMember.
+func (mr *MockStoreMockRecorder) GrantAccountMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantAccountMember", reflect.TypeOf((*MockStore)(nil).GrantAccountMember), arg0, arg1)
+}
+
+// GrantOverdraftFacility mocks base method.
+func (m *MockStore) GrantOverdraftFacility(arg0 context.Context, arg1 db.GrantOverdraftFacilityParams) (db.OverdraftFacility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantOverdraftFacility", arg0, arg1)
+	ret0, _ := ret[0].(db.OverdraftFacility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantOverdraftFacility indicates an expected call of GrantOverdraftFacility.
+func (mr *MockStoreMockRecorder) GrantOverdraftFacility(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantOverdraftFacility", reflect.TypeOf((*MockStore)(nil).GrantOverdraftFacility), arg0, arg1)
+}
+
+// IncrementCardFailedCVVAttempts mocks base method.
+func (m *MockStore) IncrementCardFailedCVVAttempts(arg0 context.Context, arg1 db.IncrementCardFailedCVVAttemptsParams) (db.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementCardFailedCVVAttempts", arg0, arg1)
+	ret0, _ := ret[0].(db.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementCardFailedCVVAttempts indicates an expected call of IncrementCardFailedCVVAttempts.
+func (mr *MockStoreMockRecorder) IncrementCardFailedCVVAttempts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementCardFailedCVVAttempts", reflect.TypeOf((*MockStore)(nil).IncrementCardFailedCVVAttempts), arg0, arg1)
+}
+
+// ListAccountFees mocks base method.
+func (m *MockStore) ListAccountFees(arg0 context.Context, arg1 db.ListAccountFeesParams) ([]db.AccountFee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountFees", arg0, arg1)
+	ret0, _ := ret[0].([]db.AccountFee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountFees indicates an expected call of ListAccountFees.
+func (mr *MockStoreMockRecorder) ListAccountFees(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountFees", reflect.TypeOf((*MockStore)(nil).ListAccountFees), arg0, arg1)
+}
+
+// ListAccountMembers mocks base method.
+func (m *MockStore) ListAccountMembers(arg0 context.Context, arg1 int64) ([]db.AccountMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountMembers", arg0, arg1)
+	ret0, _ := ret[0].([]db.AccountMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountMembers indicates an expected call of ListAccountMembers.
+func (mr *MockStoreMockRecorder) ListAccountMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountMembers", reflect.TypeOf((*MockStore)(nil).ListAccountMembers), arg0, arg1)
+}
+
+// ListAccounts mocks base method.
+func (m *MockStore) ListAccounts(arg0 context.Context, arg1 db.ListAccountsParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockStoreMockRecorder) ListAccounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), arg0, arg1)
+}
+
+// ListAccruingAccounts mocks base method.
+func (m *MockStore) ListAccruingAccounts(arg0 context.Context, arg1 db.ListAccruingAccountsParams) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccruingAccounts", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccruingAccounts indicates an expected call of ListAccruingAccounts.
+func (mr *MockStoreMockRecorder) ListAccruingAccounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccruingAccounts", reflect.TypeOf((*MockStore)(nil).ListAccruingAccounts), arg0, arg1)
+}
+
+// ListBeneficiaries mocks base method.
+func (m *MockStore) ListBeneficiaries(arg0 context.Context, arg1 db.ListBeneficiariesParams) ([]db.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBeneficiaries", arg0, arg1)
+	ret0, _ := ret[0].([]db.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBeneficiaries indicates an expected call of ListBeneficiaries.
+func (mr *MockStoreMockRecorder) ListBeneficiaries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBeneficiaries", reflect.TypeOf((*MockStore)(nil).ListBeneficiaries), arg0, arg1)
+}
+
+// ListBillableAccounts mocks base method.
+func (m *MockStore) ListBillableAccounts(arg0 context.Context, arg1 time.Time) ([]db.ListBillableAccountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBillableAccounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListBillableAccountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBillableAccounts indicates an expected call of ListBillableAccounts.
+func (mr *MockStoreMockRecorder) ListBillableAccounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBillableAccounts", reflect.TypeOf((*MockStore)(nil).ListBillableAccounts), arg0, arg1)
+}
+
+// ListCardAuthorizations mocks base method.
+func (m *MockStore) ListCardAuthorizations(arg0 context.Context, arg1 db.ListCardAuthorizationsParams) ([]db.CardAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCardAuthorizations", arg0, arg1)
+	ret0, _ := ret[0].([]db.CardAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCardAuthorizations indicates an expected call of ListCardAuthorizations.
+func (mr *MockStoreMockRecorder) ListCardAuthorizations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCardAuthorizations", reflect.TypeOf((*MockStore)(nil).ListCardAuthorizations), arg0, arg1)
+}
+
+// ListCards mocks base method.
+func (m *MockStore) ListCards(arg0 context.Context, arg1 int64) ([]db.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCards", arg0, arg1)
+	ret0, _ := ret[0].([]db.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCards indicates an expected call of ListCards.
+func (mr *MockStoreMockRecorder) ListCards(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCards", reflect.TypeOf((*MockStore)(nil).ListCards), arg0, arg1)
+}
+
+// ListCashFlow mocks base method.
+func (m *MockStore) ListCashFlow(arg0 context.Context, arg1 db.ListCashFlowParams) ([]db.ListCashFlowRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCashFlow", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListCashFlowRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCashFlow indicates an expected call of ListCashFlow.
+func (mr *MockStoreMockRecorder) ListCashFlow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCashFlow", reflect.TypeOf((*MockStore)(nil).ListCashFlow), arg0, arg1)
+}
+
+// ListCategorizationRules mocks base method.
+func (m *MockStore) ListCategorizationRules(arg0 context.Context, arg1 string) ([]db.CategorizationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategorizationRules", arg0, arg1)
+	ret0, _ := ret[0].([]db.CategorizationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategorizationRules indicates an expected call of ListCategorizationRules.
+func (mr *MockStoreMockRecorder) ListCategorizationRules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategorizationRules", reflect.TypeOf((*MockStore)(nil).ListCategorizationRules), arg0, arg1)
+}
+
+// ListCategoryBudgets mocks base method.
+func (m *MockStore) ListCategoryBudgets(arg0 context.Context, arg1 string) ([]db.CategoryBudget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategoryBudgets", arg0, arg1)
+	ret0, _ := ret[0].([]db.CategoryBudget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategoryBudgets indicates an expected call of ListCategoryBudgets.
+func (mr *MockStoreMockRecorder) ListCategoryBudgets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoryBudgets", reflect.TypeOf((*MockStore)(nil).ListCategoryBudgets), arg0, arg1)
+}
+
+// ListDirectDebits mocks base method.
+func (m *MockStore) ListDirectDebits(arg0 context.Context, arg1 db.ListDirectDebitsParams) ([]db.DirectDebit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDirectDebits", arg0, arg1)
+	ret0, _ := ret[0].([]db.DirectDebit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDirectDebits indicates an expected call of ListDirectDebits.
+func (mr *MockStoreMockRecorder) ListDirectDebits(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDirectDebits", reflect.TypeOf((*MockStore)(nil).ListDirectDebits), arg0, arg1)
+}
+
+// ListDisputedEscrows mocks base method.
+func (m *MockStore) ListDisputedEscrows(arg0 context.Context, arg1 db.ListDisputedEscrowsParams) ([]db.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDisputedEscrows", arg0, arg1)
+	ret0, _ := ret[0].([]db.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDisputedEscrows indicates an expected call of ListDisputedEscrows.
+func (mr *MockStoreMockRecorder) ListDisputedEscrows(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDisputedEscrows", reflect.TypeOf((*MockStore)(nil).ListDisputedEscrows), arg0, arg1)
+}
+
+// ListDueLoanInstallments mocks base method.
+func (m *MockStore) ListDueLoanInstallments(arg0 context.Context, arg1 time.Time) ([]db.ListDueLoanInstallmentsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueLoanInstallments", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListDueLoanInstallmentsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueLoanInstallments indicates an expected call of ListDueLoanInstallments.
+func (mr *MockStoreMockRecorder) ListDueLoanInstallments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueLoanInstallments", reflect.TypeOf((*MockStore)(nil).ListDueLoanInstallments), arg0, arg1)
+}
+
+// ListEscrows mocks base method.
+func (m *MockStore) ListEscrows(arg0 context.Context, arg1 db.ListEscrowsParams) ([]db.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEscrows", arg0, arg1)
+	ret0, _ := ret[0].([]db.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEscrows indicates an expected call of ListEscrows.
+func (mr *MockStoreMockRecorder) ListEscrows(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEscrows", reflect.TypeOf((*MockStore)(nil).ListEscrows), arg0, arg1)
+}
+
+// ListExpiredEscrows mocks base method.
+func (m *MockStore) ListExpiredEscrows(arg0 context.Context, arg1 time.Time) ([]db.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredEscrows", arg0, arg1)
+	ret0, _ := ret[0].([]db.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredEscrows indicates an expected call of ListExpiredEscrows.
+func (mr *MockStoreMockRecorder) ListExpiredEscrows(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredEscrows", reflect.TypeOf((*MockStore)(nil).ListExpiredEscrows), arg0, arg1)
+}
+
+// ListInterestBearingAccounts mocks base method.
+func (m *MockStore) ListInterestBearingAccounts(arg0 context.Context, arg1 time.Time) ([]db.ListInterestBearingAccountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInterestBearingAccounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListInterestBearingAccountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInterestBearingAccounts indicates an expected call of ListInterestBearingAccounts.
+func (mr *MockStoreMockRecorder) ListInterestBearingAccounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInterestBearingAccounts", reflect.TypeOf((*MockStore)(nil).ListInterestBearingAccounts), arg0, arg1)
+}
+
+// ListInterestPlans mocks base method.
+func (m *MockStore) ListInterestPlans(arg0 context.Context, arg1 db.ListInterestPlansParams) ([]db.InterestPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInterestPlans", arg0, arg1)
+	ret0, _ := ret[0].([]db.InterestPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInterestPlans indicates an expected call of ListInterestPlans.
+func (mr *MockStoreMockRecorder) ListInterestPlans(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInterestPlans", reflect.TypeOf((*MockStore)(nil).ListInterestPlans), arg0, arg1)
+}
+
+// ListLargestEntries mocks base method.
+func (m *MockStore) ListLargestEntries(arg0 context.Context, arg1 db.ListLargestEntriesParams) ([]db.ListLargestEntriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLargestEntries", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListLargestEntriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLargestEntries indicates an expected call of ListLargestEntries.
+func (mr *MockStoreMockRecorder) ListLargestEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLargestEntries", reflect.TypeOf((*MockStore)(nil).ListLargestEntries), arg0, arg1)
+}
+
+// ListLoanInstallments mocks base method.
+func (m *MockStore) ListLoanInstallments(arg0 context.Context, arg1 int64) ([]db.LoanInstallment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLoanInstallments", arg0, arg1)
+	ret0, _ := ret[0].([]db.LoanInstallment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLoanInstallments indicates an expected call of ListLoanInstallments.
+func (mr *MockStoreMockRecorder) ListLoanInstallments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoanInstallments", reflect.TypeOf((*MockStore)(nil).ListLoanInstallments), arg0, arg1)
+}
+
+// ListLoans mocks base method.
+func (m *MockStore) ListLoans(arg0 context.Context, arg1 db.ListLoansParams) ([]db.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLoans", arg0, arg1)
+	ret0, _ := ret[0].([]db.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLoans indicates an expected call of ListLoans.
+func (mr *MockStoreMockRecorder) ListLoans(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoans", reflect.TypeOf((*MockStore)(nil).ListLoans), arg0, arg1)
+}
+
+// ListMandates mocks base method.
+func (m *MockStore) ListMandates(arg0 context.Context, arg1 db.ListMandatesParams) ([]db.Mandate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMandates", arg0, arg1)
+	ret0, _ := ret[0].([]db.Mandate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMandates indicates an expected call of ListMandates.
+func (mr *MockStoreMockRecorder) ListMandates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMandates", reflect.TypeOf((*MockStore)(nil).ListMandates), arg0, arg1)
+}
+
+// ListMonthlySpending mocks base method.
+func (m *MockStore) ListMonthlySpending(arg0 context.Context, arg1 db.ListMonthlySpendingParams) ([]db.ListMonthlySpendingRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMonthlySpending", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListMonthlySpendingRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMonthlySpending indicates an expected call of ListMonthlySpending.
+func (mr *MockStoreMockRecorder) ListMonthlySpending(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMonthlySpending", reflect.TypeOf((*MockStore)(nil).ListMonthlySpending), arg0, arg1)
+}
+
+// ListOrganizationAccounts mocks base method.
+func (m *MockStore) ListOrganizationAccounts(arg0 context.Context, arg1 db.ListOrganizationAccountsParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizationAccounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganizationAccounts indicates an expected call of ListOrganizationAccounts.
+func (mr *MockStoreMockRecorder) ListOrganizationAccounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizationAccounts", reflect.TypeOf((*MockStore)(nil).ListOrganizationAccounts), arg0, arg1)
+}
+
+// ListOrganizationMembers mocks base method.
+func (m *MockStore) ListOrganizationMembers(arg0 context.Context, arg1 int64) ([]db.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizationMembers", arg0, arg1)
+	ret0, _ := ret[0].([]db.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganizationMembers indicates an expected call of ListOrganizationMembers.
+func (mr *MockStoreMockRecorder) ListOrganizationMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizationMembers", reflect.TypeOf((*MockStore)(nil).ListOrganizationMembers), arg0, arg1)
+}
+
+// ListOrganizationTransferApprovals mocks base method.
+func (m *MockStore) ListOrganizationTransferApprovals(arg0 context.Context, arg1 db.ListOrganizationTransferApprovalsParams) ([]db.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizationTransferApprovals", arg0, arg1)
+	ret0, _ := ret[0].([]db.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganizationTransferApprovals indicates an expected call of ListOrganizationTransferApprovals.
+func (mr *MockStoreMockRecorder) ListOrganizationTransferApprovals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizationTransferApprovals", reflect.TypeOf((*MockStore)(nil).ListOrganizationTransferApprovals), arg0, arg1)
+}
+
+// ListOverdraftAccounts mocks base method.
+func (m *MockStore) ListOverdraftAccounts(arg0 context.Context, arg1 time.Time) ([]db.ListOverdraftAccountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOverdraftAccounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListOverdraftAccountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOverdraftAccounts indicates an expected call of ListOverdraftAccounts.
+func (mr *MockStoreMockRecorder) ListOverdraftAccounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverdraftAccounts", reflect.TypeOf((*MockStore)(nil).ListOverdraftAccounts), arg0, arg1)
+}
+
+// ListOverdraftNotifications mocks base method.
+func (m *MockStore) ListOverdraftNotifications(arg0 context.Context, arg1 db.ListOverdraftNotificationsParams) ([]db.OverdraftNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOverdraftNotifications", arg0, arg1)
+	ret0, _ := ret[0].([]db.OverdraftNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOverdraftNotifications indicates an expected call of ListOverdraftNotifications.
+func (mr *MockStoreMockRecorder) ListOverdraftNotifications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverdraftNotifications", reflect.TypeOf((*MockStore)(nil).ListOverdraftNotifications), arg0, arg1)
+}
+
+// ListPaymentRequests mocks base method.
+func (m *MockStore) ListPaymentRequests(arg0 context.Context, arg1 db.ListPaymentRequestsParams) ([]db.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPaymentRequests", arg0, arg1)
+	ret0, _ := ret[0].([]db.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPaymentRequests indicates an expected call of ListPaymentRequests.
+func (mr *MockStoreMockRecorder) ListPaymentRequests(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentRequests", reflect.TypeOf((*MockStore)(nil).ListPaymentRequests), arg0, arg1)
+}
+
+// ListPotBalances mocks base method.
+func (m *MockStore) ListPotBalances(arg0 context.Context, arg1 string) ([]db.ListPotBalancesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPotBalances", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPotBalancesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPotBalances indicates an expected call of ListPotBalances.
+func (mr *MockStoreMockRecorder) ListPotBalances(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPotBalances", reflect.TypeOf((*MockStore)(nil).ListPotBalances), arg0, arg1)
+}
+
+// ListPots mocks base method.
+func (m *MockStore) ListPots(arg0 context.Context, arg1 int64) ([]db.ListPotsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPots", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPotsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPots indicates an expected call of ListPots.
+func (mr *MockStoreMockRecorder) ListPots(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPots", reflect.TypeOf((*MockStore)(nil).ListPots), arg0, arg1)
+}
+
+// ListSpendingCategories mocks base method.
+func (m *MockStore) ListSpendingCategories(arg0 context.Context, arg1 string) ([]db.SpendingCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSpendingCategories", arg0, arg1)
+	ret0, _ := ret[0].([]db.SpendingCategory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateAccount indicates an expected call of CreateAccount.
-func (mr *MockStoreMockRecorder) CreateAccount(arg0, arg1 interface{}) *gomock.Call {
+// ListSpendingCategories indicates an expected call of ListSpendingCategories.
+func (mr *MockStoreMockRecorder) ListSpendingCategories(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSpendingCategories", reflect.TypeOf((*MockStore)(nil).ListSpendingCategories), arg0, arg1)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+// ListStatementEntries mocks base method.
+func (m *MockStore) ListStatementEntries(arg0 context.Context, arg1 db.ListStatementEntriesParams) ([]db.ListStatementEntriesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "ListStatementEntries", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListStatementEntriesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+// ListStatementEntries indicates an expected call of ListStatementEntries.
+func (mr *MockStoreMockRecorder) ListStatementEntries(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatementEntries", reflect.TypeOf((*MockStore)(nil).ListStatementEntries), arg0, arg1)
 }
 
-// DeleteAccount mocks base method.
-func (m *MockStore) DeleteAccount(arg0 context.Context, arg1 int64) error {
+// ListTopCounterparties mocks base method.
+func (m *MockStore) ListTopCounterparties(arg0 context.Context, arg1 db.ListTopCounterpartiesParams) ([]db.ListTopCounterpartiesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAccount", arg0, arg1)
+	ret := m.ctrl.Call(m, "ListTopCounterparties", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListTopCounterpartiesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTopCounterparties indicates an expected call of ListTopCounterparties.
+func (mr *MockStoreMockRecorder) ListTopCounterparties(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTopCounterparties", reflect.TypeOf((*MockStore)(nil).ListTopCounterparties), arg0, arg1)
+}
+
+// ListTransferApprovals mocks base method.
+func (m *MockStore) ListTransferApprovals(arg0 context.Context, arg1 db.ListTransferApprovalsParams) ([]db.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferApprovals", arg0, arg1)
+	ret0, _ := ret[0].([]db.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferApprovals indicates an expected call of ListTransferApprovals.
+func (mr *MockStoreMockRecorder) ListTransferApprovals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferApprovals", reflect.TypeOf((*MockStore)(nil).ListTransferApprovals), arg0, arg1)
+}
+
+// ListUserOrganizations mocks base method.
+func (m *MockStore) ListUserOrganizations(arg0 context.Context, arg1 string) ([]db.ListUserOrganizationsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserOrganizations", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListUserOrganizationsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserOrganizations indicates an expected call of ListUserOrganizations.
+func (mr *MockStoreMockRecorder) ListUserOrganizations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserOrganizations", reflect.TypeOf((*MockStore)(nil).ListUserOrganizations), arg0, arg1)
+}
+
+// MovePotTx mocks base method.
+func (m *MockStore) MovePotTx(arg0 context.Context, arg1 db.MovePotTxParams) (db.MovePotTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MovePotTx", arg0, arg1)
+	ret0, _ := ret[0].(db.MovePotTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MovePotTx indicates an expected call of MovePotTx.
+func (mr *MockStoreMockRecorder) MovePotTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MovePotTx", reflect.TypeOf((*MockStore)(nil).MovePotTx), arg0, arg1)
+}
+
+// OriginateLoanTx mocks base method.
+func (m *MockStore) OriginateLoanTx(arg0 context.Context, arg1 db.OriginateLoanTxParams) (db.OriginateLoanTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OriginateLoanTx", arg0, arg1)
+	ret0, _ := ret[0].(db.OriginateLoanTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OriginateLoanTx indicates an expected call of OriginateLoanTx.
+func (mr *MockStoreMockRecorder) OriginateLoanTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OriginateLoanTx", reflect.TypeOf((*MockStore)(nil).OriginateLoanTx), arg0, arg1)
+}
+
+// PostInterestTx mocks base method.
+func (m *MockStore) PostInterestTx(arg0 context.Context, arg1 db.PostInterestTxParams) (db.PostInterestTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostInterestTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PostInterestTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostInterestTx indicates an expected call of PostInterestTx.
+func (mr *MockStoreMockRecorder) PostInterestTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInterestTx", reflect.TypeOf((*MockStore)(nil).PostInterestTx), arg0, arg1)
+}
+
+// Reconcile mocks base method.
+func (m *MockStore) Reconcile(arg0 context.Context, arg1 db.ReconcileParams) (db.ReconciliationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", arg0, arg1)
+	ret0, _ := ret[0].(db.ReconciliationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockStoreMockRecorder) Reconcile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockStore)(nil).Reconcile), arg0, arg1)
+}
+
+// RefundEscrowTx mocks base method.
+func (m *MockStore) RefundEscrowTx(arg0 context.Context, arg1 int64) (db.EscrowTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundEscrowTx", arg0, arg1)
+	ret0, _ := ret[0].(db.EscrowTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundEscrowTx indicates an expected call of RefundEscrowTx.
+func (mr *MockStoreMockRecorder) RefundEscrowTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundEscrowTx", reflect.TypeOf((*MockStore)(nil).RefundEscrowTx), arg0, arg1)
+}
+
+// ReleaseCardAuthorization mocks base method.
+func (m *MockStore) ReleaseCardAuthorization(arg0 context.Context, arg1 int64) (db.CardAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseCardAuthorization", arg0, arg1)
+	ret0, _ := ret[0].(db.CardAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseCardAuthorization indicates an expected call of ReleaseCardAuthorization.
+func (mr *MockStoreMockRecorder) ReleaseCardAuthorization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseCardAuthorization", reflect.TypeOf((*MockStore)(nil).ReleaseCardAuthorization), arg0, arg1)
+}
+
+// ReleaseEscrowTx mocks base method.
+func (m *MockStore) ReleaseEscrowTx(arg0 context.Context, arg1 db.ReleaseEscrowTxParams) (db.EscrowTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseEscrowTx", arg0, arg1)
+	ret0, _ := ret[0].(db.EscrowTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseEscrowTx indicates an expected call of ReleaseEscrowTx.
+func (mr *MockStoreMockRecorder) ReleaseEscrowTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseEscrowTx", reflect.TypeOf((*MockStore)(nil).ReleaseEscrowTx), arg0, arg1)
+}
+
+// RemoveOrganizationMember mocks base method.
+func (m *MockStore) RemoveOrganizationMember(arg0 context.Context, arg1 db.RemoveOrganizationMemberParams) (db.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveOrganizationMember", arg0, arg1)
+	ret0, _ := ret[0].(db.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveOrganizationMember indicates an expected call of RemoveOrganizationMember.
+func (mr *MockStoreMockRecorder) RemoveOrganizationMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveOrganizationMember", reflect.TypeOf((*MockStore)(nil).RemoveOrganizationMember), arg0, arg1)
+}
+
+// RepayLoanTx mocks base method.
+func (m *MockStore) RepayLoanTx(arg0 context.Context, arg1 db.RepayLoanTxParams) (db.RepayLoanTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepayLoanTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RepayLoanTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepayLoanTx indicates an expected call of RepayLoanTx.
+func (mr *MockStoreMockRecorder) RepayLoanTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepayLoanTx", reflect.TypeOf((*MockStore)(nil).RepayLoanTx), arg0, arg1)
+}
+
+// RequestPaymentApprovalTx mocks base method.
+func (m *MockStore) RequestPaymentApprovalTx(arg0 context.Context, arg1 db.RequestPaymentApprovalTxParams) (db.RequestPaymentApprovalTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPaymentApprovalTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RequestPaymentApprovalTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestPaymentApprovalTx indicates an expected call of RequestPaymentApprovalTx.
+func (mr *MockStoreMockRecorder) RequestPaymentApprovalTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPaymentApprovalTx", reflect.TypeOf((*MockStore)(nil).RequestPaymentApprovalTx), arg0, arg1)
+}
+
+// ResetCardFailedCVVAttempts mocks base method.
+func (m *MockStore) ResetCardFailedCVVAttempts(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetCardFailedCVVAttempts", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteAccount indicates an expected call of DeleteAccount.
-func (mr *MockStoreMockRecorder) DeleteAccount(arg0, arg1 interface{}) *gomock.Call {
+// ResetCardFailedCVVAttempts indicates an expected call of ResetCardFailedCVVAttempts.
+func (mr *MockStoreMockRecorder) ResetCardFailedCVVAttempts(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetCardFailedCVVAttempts", reflect.TypeOf((*MockStore)(nil).ResetCardFailedCVVAttempts), arg0, arg1)
 }
 
-// GetAccount mocks base method.
-func (m *MockStore) GetAccount(arg0 context.Context, arg1 int64) (db.Account, error) {
+// ResolveEscrowTx mocks base method.
+func (m *MockStore) ResolveEscrowTx(arg0 context.Context, arg1 db.ResolveEscrowTxParams) (db.EscrowTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccount", arg0, arg1)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "ResolveEscrowTx", arg0, arg1)
+	ret0, _ := ret[0].(db.EscrowTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccount indicates an expected call of GetAccount.
-func (mr *MockStoreMockRecorder) GetAccount(arg0, arg1 interface{}) *gomock.Call {
+// ResolveEscrowTx indicates an expected call of ResolveEscrowTx.
+func (mr *MockStoreMockRecorder) ResolveEscrowTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveEscrowTx", reflect.TypeOf((*MockStore)(nil).ResolveEscrowTx), arg0, arg1)
 }
 
-// GetEntry mocks base method.
-func (m *MockStore) GetEntry(arg0 context.Context, arg1 int64) (db.Entry, error) {
+// ReverseTransferTx mocks base method.
+func (m *MockStore) ReverseTransferTx(arg0 context.Context, arg1 db.ReverseTransferTxParams) (db.ReverseTransferTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEntry", arg0, arg1)
-	ret0, _ := ret[0].(db.Entry)
+	ret := m.ctrl.Call(m, "ReverseTransferTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ReverseTransferTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEntry indicates an expected call of GetEntry.
-func (mr *MockStoreMockRecorder) GetEntry(arg0, arg1 interface{}) *gomock.Call {
+// ReverseTransferTx indicates an expected call of ReverseTransferTx.
+func (mr *MockStoreMockRecorder) ReverseTransferTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReverseTransferTx", reflect.TypeOf((*MockStore)(nil).ReverseTransferTx), arg0, arg1)
 }
 
-// GetTransfer mocks base method.
-func (m *MockStore) GetTransfer(arg0 context.Context, arg1 int64) (db.Transfer, error) {
+// ReviewTransferApprovalTx mocks base method.
+func (m *MockStore) ReviewTransferApprovalTx(arg0 context.Context, arg1 db.ReviewTransferApprovalTxParams) (db.ReviewTransferApprovalTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransfer", arg0, arg1)
-	ret0, _ := ret[0].(db.Transfer)
+	ret := m.ctrl.Call(m, "ReviewTransferApprovalTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ReviewTransferApprovalTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTransfer indicates an expected call of GetTransfer.
-func (mr *MockStoreMockRecorder) GetTransfer(arg0, arg1 interface{}) *gomock.Call {
+// ReviewTransferApprovalTx indicates an expected call of ReviewTransferApprovalTx.
+func (mr *MockStoreMockRecorder) ReviewTransferApprovalTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewTransferApprovalTx", reflect.TypeOf((*MockStore)(nil).ReviewTransferApprovalTx), arg0, arg1)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (db.User, error) {
+// RevokeAccountMember mocks base method.
+func (m *MockStore) RevokeAccountMember(arg0 context.Context, arg1 db.RevokeAccountMemberParams) (db.AccountMember, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "RevokeAccountMember", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountMember)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+// RevokeAccountMember indicates an expected call of RevokeAccountMember.
+func (mr *MockStoreMockRecorder) RevokeAccountMember(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAccountMember", reflect.TypeOf((*MockStore)(nil).RevokeAccountMember), arg0, arg1)
 }
 
-// ListAccounts mocks base method.
-func (m *MockStore) ListAccounts(arg0 context.Context, arg1 db.ListAccountsParams) ([]db.Account, error) {
+// RevokeMandate mocks base method.
+func (m *MockStore) RevokeMandate(arg0 context.Context, arg1 int64) (db.Mandate, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAccounts", arg0, arg1)
-	ret0, _ := ret[0].([]db.Account)
+	ret := m.ctrl.Call(m, "RevokeMandate", arg0, arg1)
+	ret0, _ := ret[0].(db.Mandate)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAccounts indicates an expected call of ListAccounts.
-func (mr *MockStoreMockRecorder) ListAccounts(arg0, arg1 interface{}) *gomock.Call {
+// RevokeMandate indicates an expected call of RevokeMandate.
+func (mr *MockStoreMockRecorder) RevokeMandate(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeMandate", reflect.TypeOf((*MockStore)(nil).RevokeMandate), arg0, arg1)
+}
+
+// RevokeOverdraftFacility mocks base method.
+func (m *MockStore) RevokeOverdraftFacility(arg0 context.Context, arg1 db.RevokeOverdraftFacilityParams) (db.OverdraftFacility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOverdraftFacility", arg0, arg1)
+	ret0, _ := ret[0].(db.OverdraftFacility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeOverdraftFacility indicates an expected call of RevokeOverdraftFacility.
+func (mr *MockStoreMockRecorder) RevokeOverdraftFacility(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOverdraftFacility", reflect.TypeOf((*MockStore)(nil).RevokeOverdraftFacility), arg0, arg1)
+}
+
+// SearchTransfers mocks base method.
+func (m *MockStore) SearchTransfers(arg0 context.Context, arg1 db.SearchTransfersParams) ([]db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTransfers", arg0, arg1)
+	ret0, _ := ret[0].([]db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTransfers indicates an expected call of SearchTransfers.
+func (mr *MockStoreMockRecorder) SearchTransfers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTransfers", reflect.TypeOf((*MockStore)(nil).SearchTransfers), arg0, arg1)
+}
+
+// SetCategoryBudget mocks base method.
+func (m *MockStore) SetCategoryBudget(arg0 context.Context, arg1 db.SetCategoryBudgetParams) (db.CategoryBudget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCategoryBudget", arg0, arg1)
+	ret0, _ := ret[0].(db.CategoryBudget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCategoryBudget indicates an expected call of SetCategoryBudget.
+func (mr *MockStoreMockRecorder) SetCategoryBudget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCategoryBudget", reflect.TypeOf((*MockStore)(nil).SetCategoryBudget), arg0, arg1)
+}
+
+// SetTransferCategory mocks base method.
+func (m *MockStore) SetTransferCategory(arg0 context.Context, arg1 db.SetTransferCategoryParams) (db.TransferCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTransferCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTransferCategory indicates an expected call of SetTransferCategory.
+func (mr *MockStoreMockRecorder) SetTransferCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTransferCategory", reflect.TypeOf((*MockStore)(nil).SetTransferCategory), arg0, arg1)
+}
+
+// SignTransferApprovalTx mocks base method.
+func (m *MockStore) SignTransferApprovalTx(arg0 context.Context, arg1 db.SignTransferApprovalTxParams) (db.SignTransferApprovalTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignTransferApprovalTx", arg0, arg1)
+	ret0, _ := ret[0].(db.SignTransferApprovalTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignTransferApprovalTx indicates an expected call of SignTransferApprovalTx.
+func (mr *MockStoreMockRecorder) SignTransferApprovalTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignTransferApprovalTx", reflect.TypeOf((*MockStore)(nil).SignTransferApprovalTx), arg0, arg1)
+}
+
+// TransferBatchTx mocks base method.
+func (m *MockStore) TransferBatchTx(arg0 context.Context, arg1 db.TransferBatchTxParams) (db.TransferBatchTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferBatchTx", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferBatchTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferBatchTx indicates an expected call of TransferBatchTx.
+func (mr *MockStoreMockRecorder) TransferBatchTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferBatchTx", reflect.TypeOf((*MockStore)(nil).TransferBatchTx), arg0, arg1)
 }
 
 // TransferTx mocks base method.
@@ -169,17 +1935,107 @@ func (mr *MockStoreMockRecorder) TransferTx(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferTx", reflect.TypeOf((*MockStore)(nil).TransferTx), arg0, arg1)
 }
 
-// UpdateAccount mocks base method.
-func (m *MockStore) UpdateAccount(arg0 context.Context, arg1 db.UpdateAccountParams) (db.Account, error) {
+// UpdateBeneficiary mocks base method.
+func (m *MockStore) UpdateBeneficiary(arg0 context.Context, arg1 db.UpdateBeneficiaryParams) (db.Beneficiary, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateAccount", arg0, arg1)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "UpdateBeneficiary", arg0, arg1)
+	ret0, _ := ret[0].(db.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBeneficiary indicates an expected call of UpdateBeneficiary.
+func (mr *MockStoreMockRecorder) UpdateBeneficiary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBeneficiary", reflect.TypeOf((*MockStore)(nil).UpdateBeneficiary), arg0, arg1)
+}
+
+// UpdateCardSpendingLimit mocks base method.
+func (m *MockStore) UpdateCardSpendingLimit(arg0 context.Context, arg1 db.UpdateCardSpendingLimitParams) (db.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCardSpendingLimit", arg0, arg1)
+	ret0, _ := ret[0].(db.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCardSpendingLimit indicates an expected call of UpdateCardSpendingLimit.
+func (mr *MockStoreMockRecorder) UpdateCardSpendingLimit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCardSpendingLimit", reflect.TypeOf((*MockStore)(nil).UpdateCardSpendingLimit), arg0, arg1)
+}
+
+// UpdateCardStatus mocks base method.
+func (m *MockStore) UpdateCardStatus(arg0 context.Context, arg1 db.UpdateCardStatusParams) (db.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCardStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCardStatus indicates an expected call of UpdateCardStatus.
+func (mr *MockStoreMockRecorder) UpdateCardStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCardStatus", reflect.TypeOf((*MockStore)(nil).UpdateCardStatus), arg0, arg1)
+}
+
+// UpdateOrganizationPolicy mocks base method.
+func (m *MockStore) UpdateOrganizationPolicy(arg0 context.Context, arg1 db.UpdateOrganizationPolicyParams) (db.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrganizationPolicy", arg0, arg1)
+	ret0, _ := ret[0].(db.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrganizationPolicy indicates an expected call of UpdateOrganizationPolicy.
+func (mr *MockStoreMockRecorder) UpdateOrganizationPolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrganizationPolicy", reflect.TypeOf((*MockStore)(nil).UpdateOrganizationPolicy), arg0, arg1)
+}
+
+// UpdatePot mocks base method.
+func (m *MockStore) UpdatePot(arg0 context.Context, arg1 db.UpdatePotParams) (db.Pot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePot", arg0, arg1)
+	ret0, _ := ret[0].(db.Pot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePot indicates an expected call of UpdatePot.
+func (mr *MockStoreMockRecorder) UpdatePot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePot", reflect.TypeOf((*MockStore)(nil).UpdatePot), arg0, arg1)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockStore) UpdateUserRole(arg0 context.Context, arg1 db.UpdateUserRoleParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockStoreMockRecorder) UpdateUserRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockStore)(nil).UpdateUserRole), arg0, arg1)
+}
+
+// VerifyAuditLog mocks base method.
+func (m *MockStore) VerifyAuditLog(arg0 context.Context, arg1 int32) (db.AuditLogVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAuditLog", arg0, arg1)
+	ret0, _ := ret[0].(db.AuditLogVerification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateAccount indicates an expected call of UpdateAccount.
-func (mr *MockStoreMockRecorder) UpdateAccount(arg0, arg1 interface{}) *gomock.Call {
+// VerifyAuditLog indicates an expected call of VerifyAuditLog.
+func (mr *MockStoreMockRecorder) VerifyAuditLog(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockStore)(nil).UpdateAccount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAuditLog", reflect.TypeOf((*MockStore)(nil).VerifyAuditLog), arg0, arg1)
 }
@@ -2,26 +2,41 @@
 INSERT INTO accounts (
     owner,
     balance,
-    currency
+    currency,
+    type,
+    organization_id
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4, $5
 ) RETURNING *;
 
 -- name: GetAccount :one
 SELECT * FROM accounts
 WHERE id = $1 LIMIT 1;
 
+-- name: GetAccountForUpdate :one
+SELECT * FROM accounts
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
 -- name: ListAccounts :many
 SELECT * FROM accounts
-WHERE owner = $1
+WHERE (owner = $1 OR id IN (SELECT account_id FROM account_members WHERE username = $1))
+  AND type <> 'pot' AND organization_id IS NULL
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: ListOrganizationAccounts :many
+SELECT * FROM accounts
+WHERE organization_id = $1
 ORDER BY id
 LIMIT $2
 OFFSET $3;
 
--- name: UpdateAccount :one
+-- name: AddAccountBalance :one
 UPDATE accounts
-SET balance = $2
-WHERE id = $1
+SET balance = balance + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
 RETURNING *;
 
 -- name: DeleteAccount :exec
@@ -0,0 +1,88 @@
+-- name: ListCashFlow :many
+-- Money in and out of an account per day, week or month of the period, in UTC. Periods without entries are left out.
+SELECT
+    date_trunc(sqlc.arg(bucket)::text, e.created_at AT TIME ZONE 'UTC')::date AS period,
+    COALESCE(sum(e.amount) FILTER (WHERE e.amount > 0), 0)::bigint AS inflow,
+    COALESCE(-sum(e.amount) FILTER (WHERE e.amount < 0), 0)::bigint AS outflow,
+    count(*)::bigint AS entry_count
+FROM entries e
+WHERE e.account_id = sqlc.arg(account_id)
+  AND e.created_at >= sqlc.arg(from_time)
+  AND e.created_at < sqlc.arg(to_time)
+GROUP BY 1
+ORDER BY 1;
+
+-- name: ListTopCounterparties :many
+-- Accounts an account exchanged the most money with over the period, both ways.
+SELECT
+    CASE WHEN t.from_account_id = e.account_id THEN t.to_account_id ELSE t.from_account_id END::bigint AS counterparty_account_id,
+    ca.owner AS counterparty_owner,
+    COALESCE(sum(e.amount) FILTER (WHERE e.amount > 0), 0)::bigint AS inflow,
+    COALESCE(-sum(e.amount) FILTER (WHERE e.amount < 0), 0)::bigint AS outflow,
+    count(*)::bigint AS transfer_count
+FROM entries e
+JOIN transfers t ON t.id = e.transfer_id
+JOIN accounts ca ON ca.id = CASE WHEN t.from_account_id = e.account_id THEN t.to_account_id ELSE t.from_account_id END
+WHERE e.account_id = sqlc.arg(account_id)
+  AND e.created_at >= sqlc.arg(from_time)
+  AND e.created_at < sqlc.arg(to_time)
+GROUP BY 1, 2
+ORDER BY sum(abs(e.amount)) DESC, 1
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListLargestEntries :many
+SELECT
+    e.id,
+    e.amount,
+    e.created_at,
+    e.transfer_id,
+    COALESCE(CASE WHEN t.from_account_id = e.account_id THEN t.to_account_id ELSE t.from_account_id END, 0)::bigint AS counterparty_account_id,
+    COALESCE(ca.owner, '')::varchar AS counterparty_owner,
+    COALESCE(t.description, j.description, '')::varchar AS description
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+LEFT JOIN accounts ca ON ca.id = CASE WHEN t.from_account_id = e.account_id THEN t.to_account_id ELSE t.from_account_id END
+LEFT JOIN journal_entries j ON j.id = e.journal_entry_id
+WHERE e.account_id = sqlc.arg(account_id)
+  AND e.created_at >= sqlc.arg(from_time)
+  AND e.created_at < sqlc.arg(to_time)
+ORDER BY abs(e.amount) DESC, e.id
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetDailyBalanceStats :one
+-- Average, lowest and highest closing balance of an account over the days of the period, in UTC.
+-- The opening balance adds up the entries after the latest snapshot, as balances as of a point in time do.
+WITH snapshot AS (
+    SELECT balance, taken_at
+    FROM balance_snapshots
+    WHERE account_id = sqlc.arg(account_id)
+      AND taken_at < sqlc.arg(from_time)
+    ORDER BY taken_at DESC
+    LIMIT 1
+), opening AS (
+    SELECT COALESCE((SELECT balance FROM snapshot), 0) + COALESCE(sum(e.amount), 0) AS balance
+    FROM entries e
+    WHERE e.account_id = sqlc.arg(account_id)
+      AND e.created_at > COALESCE((SELECT taken_at FROM snapshot), '-infinity')
+      AND e.created_at < sqlc.arg(from_time)
+), daily AS (
+    SELECT date_trunc('day', e.created_at AT TIME ZONE 'UTC') AS day, sum(e.amount) AS amount
+    FROM entries e
+    WHERE e.account_id = sqlc.arg(account_id)
+      AND e.created_at >= sqlc.arg(from_time)
+      AND e.created_at < sqlc.arg(to_time)
+    GROUP BY 1
+), closing AS (
+    SELECT (SELECT balance FROM opening) + sum(COALESCE(daily.amount, 0)) OVER (ORDER BY d.day) AS balance
+    FROM generate_series(
+        sqlc.arg(from_time)::timestamptz AT TIME ZONE 'UTC',
+        sqlc.arg(to_time)::timestamptz AT TIME ZONE 'UTC' - interval '1 day',
+        interval '1 day'
+    ) AS d(day)
+    LEFT JOIN daily ON daily.day = d.day
+)
+SELECT
+    COALESCE(round(avg(balance)), 0)::bigint AS average_balance,
+    COALESCE(min(balance), 0)::bigint AS min_balance,
+    COALESCE(max(balance), 0)::bigint AS max_balance
+FROM closing;
\ No newline at end of file
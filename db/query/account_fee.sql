@@ -0,0 +1,40 @@
+-- name: ListBillableAccounts :many
+SELECT
+    a.id,
+    a.owner,
+    a.balance,
+    a.currency,
+    a.type,
+    a.created_at,
+    u.role AS owner_role
+FROM accounts a
+JOIN users u ON u.username = a.owner
+WHERE a.type IN ('checking', 'savings')
+  AND a.created_at < sqlc.arg(period_end)
+ORDER BY a.id;
+
+-- name: CreateAccountFee :one
+INSERT INTO account_fees (
+    account_id,
+    period,
+    amount,
+    charged,
+    arrears,
+    status,
+    waiver_reason,
+    journal_entry_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetAccountFee :one
+SELECT * FROM account_fees
+WHERE account_id = $1 AND period = $2
+LIMIT 1;
+
+-- name: ListAccountFees :many
+SELECT * FROM account_fees
+WHERE account_id = $1
+ORDER BY period DESC
+LIMIT $2
+OFFSET $3;
\ No newline at end of file
@@ -0,0 +1,29 @@
+-- name: GrantAccountMember :one
+INSERT INTO account_members (
+    account_id,
+    username,
+    permission,
+    transfer_limit,
+    granted_by
+) VALUES (
+    $1, $2, $3, $4, $5
+) ON CONFLICT (account_id, username) DO UPDATE
+SET permission = EXCLUDED.permission,
+    transfer_limit = EXCLUDED.transfer_limit,
+    granted_by = EXCLUDED.granted_by
+RETURNING *;
+
+-- name: GetAccountMember :one
+SELECT * FROM account_members
+WHERE account_id = $1 AND username = $2
+LIMIT 1;
+
+-- name: ListAccountMembers :many
+SELECT * FROM account_members
+WHERE account_id = $1
+ORDER BY created_at, username;
+
+-- name: RevokeAccountMember :one
+DELETE FROM account_members
+WHERE account_id = $1 AND username = $2
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,28 @@
+-- name: LockAuditLog :exec
+SELECT pg_advisory_xact_lock(sqlc.arg(lock_id)::bigint);
+
+-- name: GetLastAuditLogEntry :one
+SELECT * FROM audit_log
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: CreateAuditLogEntry :one
+INSERT INTO audit_log (
+    actor,
+    action,
+    resource,
+    request_id,
+    before,
+    after,
+    created_at,
+    prev_hash,
+    hash
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: ListAuditLogEntries :many
+SELECT * FROM audit_log
+WHERE id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(chunk_size);
\ No newline at end of file
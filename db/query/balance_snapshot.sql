@@ -0,0 +1,42 @@
+-- name: CreateBalanceSnapshots :execrows
+INSERT INTO balance_snapshots (
+    account_id,
+    balance,
+    taken_at
+)
+SELECT
+    a.id,
+    COALESCE(s.balance, 0) + COALESCE((
+        SELECT sum(e.amount)
+        FROM entries e
+        WHERE e.account_id = a.id
+          AND e.created_at > COALESCE(s.taken_at, '-infinity')
+          AND e.created_at <= sqlc.arg(taken_at)
+    ), 0),
+    sqlc.arg(taken_at)
+FROM accounts a
+LEFT JOIN LATERAL (
+    SELECT bs.balance, bs.taken_at
+    FROM balance_snapshots bs
+    WHERE bs.account_id = a.id
+      AND bs.taken_at <= sqlc.arg(taken_at)
+    ORDER BY bs.taken_at DESC
+    LIMIT 1
+) s ON true
+WHERE a.created_at <= sqlc.arg(taken_at)
+ON CONFLICT (account_id, taken_at) DO NOTHING;
+
+-- name: GetBalanceAsOf :one
+WITH snapshot AS (
+    SELECT balance, taken_at
+    FROM balance_snapshots
+    WHERE account_id = sqlc.arg(account_id)
+      AND taken_at <= sqlc.arg(as_of)
+    ORDER BY taken_at DESC
+    LIMIT 1
+)
+SELECT (COALESCE((SELECT balance FROM snapshot), 0) + COALESCE(sum(e.amount), 0))::bigint AS balance
+FROM entries e
+WHERE e.account_id = sqlc.arg(account_id)
+  AND e.created_at > COALESCE((SELECT taken_at FROM snapshot), '-infinity')
+  AND e.created_at <= sqlc.arg(as_of);
\ No newline at end of file
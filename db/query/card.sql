@@ -0,0 +1,53 @@
+-- name: CreateCard :one
+INSERT INTO cards (
+    account_id,
+    holder,
+    pan,
+    expiry_month,
+    expiry_year,
+    hashed_cvv,
+    spending_limit
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetCard :one
+SELECT * FROM cards
+WHERE id = $1 LIMIT 1;
+
+-- name: GetCardByPAN :one
+SELECT * FROM cards
+WHERE pan = $1 LIMIT 1;
+
+-- name: ListCards :many
+SELECT * FROM cards
+WHERE account_id = $1
+ORDER BY id;
+
+-- name: UpdateCardStatus :one
+UPDATE cards
+SET status = $2,
+    failed_cvv_attempts = 0
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateCardSpendingLimit :one
+UPDATE cards
+SET spending_limit = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: IncrementCardFailedCVVAttempts :one
+UPDATE cards
+SET failed_cvv_attempts = failed_cvv_attempts + 1,
+    status = CASE
+        WHEN failed_cvv_attempts + 1 >= sqlc.arg(max_attempts)::integer THEN 'blocked'
+        ELSE status
+    END
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ResetCardFailedCVVAttempts :exec
+UPDATE cards
+SET failed_cvv_attempts = 0
+WHERE id = $1 AND failed_cvv_attempts > 0;
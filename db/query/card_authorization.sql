@@ -0,0 +1,56 @@
+-- name: CreateCardAuthorization :one
+INSERT INTO card_authorizations (
+    card_id,
+    amount,
+    currency,
+    merchant,
+    status,
+    decline_reason
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetCardAuthorization :one
+SELECT * FROM card_authorizations
+WHERE id = $1 LIMIT 1;
+
+-- name: GetCardAuthorizationForUpdate :one
+SELECT * FROM card_authorizations
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListCardAuthorizations :many
+SELECT * FROM card_authorizations
+WHERE card_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: GetCardSpending :one
+SELECT COALESCE(sum(CASE WHEN status = 'cleared' THEN cleared_amount ELSE amount END), 0)::bigint AS spending
+FROM card_authorizations
+WHERE card_id = $1
+  AND status IN ('held', 'cleared')
+  AND created_at >= $2;
+
+-- name: GetHeldAmount :one
+SELECT COALESCE(sum(ca.amount), 0)::bigint AS held_amount
+FROM card_authorizations ca
+JOIN cards c ON c.id = ca.card_id
+WHERE c.account_id = $1 AND ca.status = 'held';
+
+-- name: ClearCardAuthorization :one
+UPDATE card_authorizations
+SET status = 'cleared',
+    cleared_amount = $2,
+    journal_entry_id = $3,
+    settled_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: ReleaseCardAuthorization :one
+UPDATE card_authorizations
+SET status = 'released',
+    settled_at = now()
+WHERE id = $1 AND status = 'held'
+RETURNING *;
\ No newline at end of file
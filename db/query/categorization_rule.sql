@@ -0,0 +1,21 @@
+-- name: CreateCategorizationRule :one
+INSERT INTO categorization_rules (
+    category_id,
+    recipient_account_id,
+    memo_contains
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetCategorizationRule :one
+SELECT * FROM categorization_rules
+WHERE id = $1 LIMIT 1;
+
+-- name: ListCategorizationRules :many
+SELECT r.* FROM categorization_rules r
+JOIN spending_categories c ON c.id = r.category_id
+WHERE c.owner = $1
+ORDER BY r.id;
+
+-- name: DeleteCategorizationRule :exec
+DELETE FROM categorization_rules WHERE id = $1;
\ No newline at end of file
@@ -0,0 +1,25 @@
+-- name: SetCategoryBudget :one
+INSERT INTO category_budgets (
+    category_id,
+    currency,
+    amount
+) VALUES (
+    $1, $2, $3
+) ON CONFLICT (category_id, currency) DO UPDATE
+SET amount = EXCLUDED.amount,
+    updated_at = now()
+RETURNING *;
+
+-- name: GetCategoryBudget :one
+SELECT * FROM category_budgets
+WHERE category_id = $1 AND currency = $2 LIMIT 1;
+
+-- name: ListCategoryBudgets :many
+SELECT b.* FROM category_budgets b
+JOIN spending_categories c ON c.id = b.category_id
+WHERE c.owner = $1
+ORDER BY b.category_id, b.currency;
+
+-- name: DeleteCategoryBudget :exec
+DELETE FROM category_budgets
+WHERE category_id = $1 AND currency = $2;
\ No newline at end of file
@@ -0,0 +1,40 @@
+-- name: CreateDirectDebit :one
+INSERT INTO direct_debits (
+    mandate_id,
+    transfer_id,
+    amount
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: DisputeDirectDebit :one
+UPDATE direct_debits
+SET
+    status = 'disputed',
+    dispute_reason = $2,
+    reversal_id = $3,
+    disputed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetDirectDebit :one
+SELECT * FROM direct_debits
+WHERE id = $1 LIMIT 1;
+
+-- name: GetDirectDebitForUpdate :one
+SELECT * FROM direct_debits
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetLastDirectDebit :one
+SELECT * FROM direct_debits
+WHERE mandate_id = $1
+ORDER BY collected_at DESC
+LIMIT 1;
+
+-- name: ListDirectDebits :many
+SELECT * FROM direct_debits
+WHERE mandate_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
\ No newline at end of file
@@ -1,15 +1,22 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
     account_id,
-    amount
+    amount,
+    transfer_id,
+    journal_entry_id
 ) VALUES (
-    $1, $2
+    $1, $2, $3, $4
 ) RETURNING *;
 
 -- name: GetEntry :one
 SELECT * FROM entries
 WHERE id = $1 LIMIT 1;
 
+-- name: GetEntriesTotal :one
+SELECT COALESCE(sum(amount), 0)::bigint AS total
+FROM entries
+WHERE account_id = $1;
+
 -- name: ListEntries :many
 SELECT * FROM entries
 WHERE account_id = $1
@@ -17,11 +24,26 @@ ORDER BY id
 LIMIT $2
 OFFSET $3;
 
--- name: UpdateEntry :one
-UPDATE entries
-SET amount = $2
-WHERE id = $1
-RETURNING *;
+-- name: ListJournalEntryPostings :many
+SELECT * FROM entries
+WHERE journal_entry_id = $1
+ORDER BY id;
 
--- name: DeleteEntry :exec
-DELETE FROM entries WHERE id = $1;
\ No newline at end of file
+-- name: ListStatementEntries :many
+SELECT
+    e.id,
+    e.amount,
+    e.created_at,
+    e.transfer_id,
+    COALESCE(CASE WHEN t.from_account_id = e.account_id THEN t.to_account_id ELSE t.from_account_id END, 0)::bigint AS counterparty_account_id,
+    COALESCE(ca.owner, '')::varchar AS counterparty_owner,
+    COALESCE(t.description, j.description, '')::varchar AS description,
+    COALESCE(t.reference, '')::varchar AS reference
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+LEFT JOIN accounts ca ON ca.id = CASE WHEN t.from_account_id = e.account_id THEN t.to_account_id ELSE t.from_account_id END
+LEFT JOIN journal_entries j ON j.id = e.journal_entry_id
+WHERE e.account_id = sqlc.arg(account_id)
+  AND e.created_at >= sqlc.arg(from_time)
+  AND e.created_at < sqlc.arg(to_time)
+ORDER BY e.created_at, e.id;
\ No newline at end of file
@@ -0,0 +1,64 @@
+-- name: CreateEscrow :one
+INSERT INTO escrows (
+    account_id,
+    payer_account_id,
+    payee_account_id,
+    amount,
+    description,
+    deadline,
+    created_by,
+    funding_transfer_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: DisputeEscrow :one
+UPDATE escrows
+SET
+    status = 'disputed',
+    dispute_reason = $2,
+    disputed_by = $3,
+    disputed_at = now()
+WHERE id = $1 AND status = 'held'
+RETURNING *;
+
+-- name: GetEscrow :one
+SELECT * FROM escrows
+WHERE id = $1 LIMIT 1;
+
+-- name: GetEscrowForUpdate :one
+SELECT * FROM escrows
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListDisputedEscrows :many
+SELECT * FROM escrows
+WHERE status = 'disputed'
+ORDER BY disputed_at, id
+LIMIT $1
+OFFSET $2;
+
+-- name: ListEscrows :many
+SELECT * FROM escrows
+WHERE
+    payer_account_id = $1 OR
+    payee_account_id = $2
+ORDER BY id DESC
+LIMIT $3
+OFFSET $4;
+
+-- name: ListExpiredEscrows :many
+SELECT * FROM escrows
+WHERE status = 'held' AND deadline <= $1
+ORDER BY deadline, id;
+
+-- name: SettleEscrow :one
+UPDATE escrows
+SET
+    status = $2,
+    settlement_transfer_id = $3,
+    settled_by = $4,
+    resolution_note = $5,
+    settled_at = now()
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,17 @@
+-- name: CreateFraudDecision :one
+INSERT INTO fraud_decisions (
+    username,
+    from_account_id,
+    to_account_id,
+    amount,
+    currency,
+    score,
+    decision,
+    reason_codes
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetFraudDecision :one
+SELECT * FROM fraud_decisions
+WHERE id = $1 LIMIT 1;
@@ -0,0 +1,93 @@
+-- name: CreateInterestPlan :one
+INSERT INTO interest_plans (
+    name,
+    annual_rate_bps,
+    day_count
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetInterestPlan :one
+SELECT * FROM interest_plans
+WHERE id = $1 LIMIT 1;
+
+-- name: ListInterestPlans :many
+SELECT * FROM interest_plans
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: CreateAccountInterestPlan :one
+INSERT INTO account_interest_plans (
+    account_id,
+    interest_plan_id
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: ListInterestBearingAccounts :many
+SELECT
+    a.id AS account_id,
+    a.currency,
+    p.annual_rate_bps,
+    p.day_count
+FROM accounts a
+JOIN account_interest_plans ap ON ap.account_id = a.id
+JOIN interest_plans p ON p.id = ap.interest_plan_id
+WHERE a.type = 'savings'
+  AND a.created_at < sqlc.arg(day_end)
+ORDER BY a.id;
+
+-- name: CreateInterestAccrual :execrows
+INSERT INTO interest_accruals (
+    account_id,
+    accrual_date,
+    balance,
+    annual_rate_bps,
+    day_count,
+    amount
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) ON CONFLICT (account_id, accrual_date) DO NOTHING;
+
+-- name: ListInterestAccruals :many
+SELECT * FROM interest_accruals
+WHERE account_id = $1
+ORDER BY accrual_date
+LIMIT $2
+OFFSET $3;
+
+-- name: ListAccruingAccounts :many
+SELECT DISTINCT account_id
+FROM interest_accruals
+WHERE accrual_date > sqlc.arg(period_start)
+  AND accrual_date <= sqlc.arg(period_end)
+ORDER BY account_id;
+
+-- name: GetAccruedInterest :one
+SELECT COALESCE(sum(amount), 0)::bigint AS accrued
+FROM interest_accruals
+WHERE account_id = sqlc.arg(account_id)
+  AND accrual_date <= sqlc.arg(period_end);
+
+-- name: GetPostedInterest :one
+SELECT COALESCE(sum(amount), 0)::bigint AS posted
+FROM interest_postings
+WHERE account_id = sqlc.arg(account_id)
+  AND period_end < sqlc.arg(period_end);
+
+-- name: CreateInterestPosting :one
+INSERT INTO interest_postings (
+    account_id,
+    period_end,
+    accrued,
+    amount,
+    journal_entry_id
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetInterestPosting :one
+SELECT * FROM interest_postings
+WHERE account_id = $1 AND period_end = $2
+LIMIT 1;
\ No newline at end of file
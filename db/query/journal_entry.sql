@@ -0,0 +1,11 @@
+-- name: CreateJournalEntry :one
+INSERT INTO journal_entries (
+    kind,
+    description
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: GetJournalEntry :one
+SELECT * FROM journal_entries
+WHERE id = $1 LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,80 @@
+-- name: CreateLoan :one
+INSERT INTO loans (
+    account_id,
+    repayment_account_id,
+    principal,
+    annual_rate_bps,
+    term_months,
+    method,
+    originated_by,
+    journal_entry_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetLoan :one
+SELECT * FROM loans
+WHERE id = $1 LIMIT 1;
+
+-- name: GetLoanForUpdate :one
+SELECT * FROM loans
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListLoans :many
+SELECT l.* FROM loans l
+JOIN accounts a ON a.id = l.account_id
+WHERE a.owner = sqlc.arg(owner)
+ORDER BY l.id
+LIMIT sqlc.arg(page_limit)
+OFFSET sqlc.arg(page_offset);
+
+-- name: MarkLoanRepaid :one
+UPDATE loans
+SET status = 'repaid',
+    repaid_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateLoanInstallment :one
+INSERT INTO loan_installments (
+    loan_id,
+    number,
+    due_date,
+    principal,
+    interest
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetLoanInstallment :one
+SELECT * FROM loan_installments
+WHERE id = $1 LIMIT 1;
+
+-- name: ListLoanInstallments :many
+SELECT * FROM loan_installments
+WHERE loan_id = $1
+ORDER BY number;
+
+-- name: ListDueLoanInstallments :many
+SELECT i.id, i.loan_id
+FROM loan_installments i
+JOIN loans l ON l.id = i.loan_id
+WHERE l.status = 'active'
+  AND i.status IN ('pending', 'arrears')
+  AND i.due_date <= sqlc.arg(day)
+ORDER BY i.due_date, i.id;
+
+-- name: CountUnpaidLoanInstallments :one
+SELECT count(*) FROM loan_installments
+WHERE loan_id = $1 AND status <> 'paid';
+
+-- name: UpdateLoanInstallment :one
+UPDATE loan_installments
+SET interest = $2,
+    paid_principal = $3,
+    paid_interest = $4,
+    status = $5,
+    paid_at = $6
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,37 @@
+-- name: CreateMandate :one
+INSERT INTO mandates (
+    debtor_account_id,
+    creditor_account_id,
+    max_amount,
+    frequency,
+    reference,
+    granted_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetMandate :one
+SELECT * FROM mandates
+WHERE id = $1 LIMIT 1;
+
+-- name: GetMandateForUpdate :one
+SELECT * FROM mandates
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListMandates :many
+SELECT * FROM mandates
+WHERE
+    debtor_account_id = $1 OR
+    creditor_account_id = $2
+ORDER BY id DESC
+LIMIT $3
+OFFSET $4;
+
+-- name: RevokeMandate :one
+UPDATE mandates
+SET
+    status = 'revoked',
+    revoked_at = now()
+WHERE id = $1 AND status = 'active'
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,58 @@
+-- name: CreateOrganization :one
+INSERT INTO organizations (
+    name,
+    required_approvals,
+    approval_threshold,
+    created_by
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetOrganization :one
+SELECT * FROM organizations
+WHERE id = $1 LIMIT 1;
+
+-- name: UpdateOrganizationPolicy :one
+UPDATE organizations
+SET required_approvals = $2,
+    approval_threshold = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: ListUserOrganizations :many
+SELECT o.*, m.role FROM organizations o
+JOIN organization_members m ON m.organization_id = o.id
+WHERE m.username = $1
+ORDER BY o.id;
+
+-- name: AddOrganizationMember :one
+INSERT INTO organization_members (
+    organization_id,
+    username,
+    role,
+    added_by
+) VALUES (
+    $1, $2, $3, $4
+) ON CONFLICT (organization_id, username) DO UPDATE
+SET role = EXCLUDED.role,
+    added_by = EXCLUDED.added_by
+RETURNING *;
+
+-- name: GetOrganizationMember :one
+SELECT * FROM organization_members
+WHERE organization_id = $1 AND username = $2
+LIMIT 1;
+
+-- name: ListOrganizationMembers :many
+SELECT * FROM organization_members
+WHERE organization_id = $1
+ORDER BY created_at, username;
+
+-- name: CountOrganizationOwners :one
+SELECT count(*) FROM organization_members
+WHERE organization_id = $1 AND role = 'owner';
+
+-- name: RemoveOrganizationMember :one
+DELETE FROM organization_members
+WHERE organization_id = $1 AND username = $2
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,55 @@
+-- name: GrantOverdraftFacility :one
+INSERT INTO overdraft_facilities (
+    account_id,
+    overdraft_limit,
+    annual_rate_bps,
+    granted_by
+) VALUES (
+    $1, $2, $3, $4
+) ON CONFLICT (account_id) DO UPDATE
+SET overdraft_limit = EXCLUDED.overdraft_limit,
+    annual_rate_bps = EXCLUDED.annual_rate_bps,
+    granted_by = EXCLUDED.granted_by,
+    updated_at = now()
+RETURNING *;
+
+-- name: RevokeOverdraftFacility :one
+UPDATE overdraft_facilities
+SET overdraft_limit = 0,
+    granted_by = $2,
+    updated_at = now()
+WHERE account_id = $1
+RETURNING *;
+
+-- name: GetOverdraftFacility :one
+SELECT * FROM overdraft_facilities
+WHERE account_id = $1 LIMIT 1;
+
+-- name: GetOverdraftLimit :one
+SELECT COALESCE((
+    SELECT overdraft_limit FROM overdraft_facilities
+    WHERE account_id = sqlc.arg(account_id)
+), 0)::bigint AS overdraft_limit;
+
+-- name: ListOverdraftAccounts :many
+SELECT account_id, annual_rate_bps
+FROM overdraft_facilities
+WHERE created_at < sqlc.arg(day_end)
+ORDER BY account_id;
+
+-- name: CreateOverdraftNotification :one
+INSERT INTO overdraft_notifications (
+    account_id,
+    transfer_id,
+    balance,
+    overdraft_limit
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListOverdraftNotifications :many
+SELECT * FROM overdraft_notifications
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
\ No newline at end of file
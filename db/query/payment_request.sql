@@ -0,0 +1,43 @@
+-- name: CreatePaymentRequest :one
+INSERT INTO payment_requests (
+    requester,
+    payer,
+    to_account_id,
+    amount,
+    currency,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetPaymentRequest :one
+SELECT * FROM payment_requests
+WHERE id = $1 LIMIT 1;
+
+-- name: GetPaymentRequestForUpdate :one
+SELECT * FROM payment_requests
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListPaymentRequests :many
+SELECT * FROM payment_requests
+WHERE
+    requester = $1 OR
+    payer = $2
+ORDER BY id DESC
+LIMIT $3
+OFFSET $4;
+
+-- name: UpdatePaymentRequestStatus :one
+UPDATE payment_requests
+SET
+    status = $2,
+    transfer_id = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: DeclinePaymentRequest :one
+UPDATE payment_requests
+SET status = 'declined'
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
@@ -0,0 +1,41 @@
+-- name: CreatePot :one
+INSERT INTO pots (
+    account_id,
+    parent_account_id,
+    name,
+    target_amount,
+    round_up_to
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetPot :one
+SELECT * FROM pots
+WHERE id = $1 LIMIT 1;
+
+-- name: GetRoundUpPot :one
+SELECT * FROM pots
+WHERE parent_account_id = $1 AND round_up_to > 0
+LIMIT 1;
+
+-- name: ListPots :many
+SELECT p.*, a.balance FROM pots p
+JOIN accounts a ON a.id = p.account_id
+WHERE p.parent_account_id = $1
+ORDER BY p.id;
+
+-- name: ListPotBalances :many
+SELECT p.parent_account_id, count(*) AS pots, COALESCE(sum(a.balance), 0)::bigint AS balance
+FROM pots p
+JOIN accounts a ON a.id = p.account_id
+WHERE a.owner = $1
+   OR p.parent_account_id IN (SELECT account_id FROM account_members WHERE username = $1)
+GROUP BY p.parent_account_id;
+
+-- name: UpdatePot :one
+UPDATE pots
+SET name = $2,
+    target_amount = $3,
+    round_up_to = $4
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
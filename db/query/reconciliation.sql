@@ -0,0 +1,51 @@
+-- name: ListAccountLedgerTotals :many
+SELECT
+    a.id,
+    a.currency,
+    a.balance,
+    COALESCE(sum(e.amount), 0)::bigint AS entries_total
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id
+WHERE a.id > sqlc.arg(after_id)
+GROUP BY a.id
+ORDER BY a.id
+LIMIT sqlc.arg(chunk_size);
+
+-- name: ListTransferEntryCounts :many
+SELECT
+    t.id,
+    count(e.id) AS entry_count,
+    count(e.id) FILTER (WHERE e.account_id = t.from_account_id AND e.amount = -t.amount) AS debit_count,
+    count(e.id) FILTER (WHERE e.account_id = t.to_account_id AND e.amount = t.amount) AS credit_count
+FROM transfers t
+LEFT JOIN entries e ON e.transfer_id = t.id
+WHERE t.id > sqlc.arg(after_id)
+GROUP BY t.id
+ORDER BY t.id
+LIMIT sqlc.arg(chunk_size);
+
+-- name: ListCurrencyEntryTotals :many
+SELECT
+    a.currency,
+    COALESCE(sum(e.amount), 0)::bigint AS total
+FROM entries e
+JOIN accounts a ON a.id = e.account_id
+WHERE e.journal_entry_id IS NOT NULL
+GROUP BY a.currency
+ORDER BY a.currency;
+
+-- name: CreateReconciliationAdjustment :one
+INSERT INTO reconciliation_adjustments (
+    account_id,
+    entry_id,
+    balance,
+    entries_total,
+    amount,
+    reason
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetReconciliationAdjustment :one
+SELECT * FROM reconciliation_adjustments
+WHERE id = $1 LIMIT 1;
@@ -0,0 +1,53 @@
+-- name: CreateSpendingCategory :one
+INSERT INTO spending_categories (
+    owner,
+    name
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: GetSpendingCategory :one
+SELECT * FROM spending_categories
+WHERE id = $1 LIMIT 1;
+
+-- name: ListSpendingCategories :many
+SELECT * FROM spending_categories
+WHERE owner = $1
+ORDER BY name;
+
+-- name: ListMonthlySpending :many
+-- Outgoing transfers from the personal accounts of the owner to other owners, by month, category and currency.
+-- A category assigned to the transfer wins over the rules, and rules on the recipient over rules on the memo.
+-- Reversed transfers aren't spending, and transfers between the accounts of the owner, e.g. to pots, are moves.
+SELECT
+    date_trunc('month', t.created_at AT TIME ZONE 'UTC')::date AS month,
+    COALESCE(tc.category_id, rc.category_id) AS category_id,
+    a.currency,
+    COUNT(*)::bigint AS transfer_count,
+    (-SUM(e.amount))::bigint AS spent
+FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+JOIN accounts ra ON ra.id = t.to_account_id
+JOIN entries e ON e.transfer_id = t.id AND e.account_id = t.from_account_id
+LEFT JOIN transfer_reversals r ON r.transfer_id = t.id
+LEFT JOIN transfer_categories tc ON tc.transfer_id = t.id
+LEFT JOIN LATERAL (
+    SELECT cr.category_id
+    FROM categorization_rules cr
+    JOIN spending_categories sc ON sc.id = cr.category_id
+    WHERE sc.owner = a.owner
+      AND (
+        cr.recipient_account_id = t.to_account_id OR
+        (cr.memo_contains <> '' AND position(lower(cr.memo_contains) IN lower(t.description)) > 0)
+      )
+    ORDER BY cr.recipient_account_id IS NULL, cr.id
+    LIMIT 1
+) rc ON true
+WHERE a.owner = sqlc.arg(owner)
+  AND a.organization_id IS NULL
+  AND ra.owner <> a.owner
+  AND r.transfer_id IS NULL
+  AND t.created_at >= sqlc.arg(from_time)
+  AND t.created_at < sqlc.arg(to_time)
+GROUP BY 1, 2, 3
+ORDER BY 1, 2, 3;
\ No newline at end of file
@@ -1,16 +1,37 @@
+-- name: CountTransfersSince :one
+SELECT count(*) FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = sqlc.arg(owner) AND t.created_at >= sqlc.arg(since);
+
+-- name: CountTransfersToAccount :one
+SELECT count(*) FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = $1 AND t.to_account_id = $2;
+
 -- name: CreateTransfer :one
 INSERT INTO transfers (
     from_account_id,
     to_account_id,
-    amount
+    amount,
+    description,
+    reference,
+    metadata
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4, $5, $6
 ) RETURNING *;
 
 -- name: GetTransfer :one
 SELECT * FROM transfers
 WHERE id = $1 LIMIT 1;
 
+-- name: GetTransferAmountStats :one
+SELECT
+    count(*) AS transfer_count,
+    COALESCE(avg(t.amount), 0)::bigint AS average_amount
+FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = $1;
+
 -- name: ListTransfers :many
 SELECT * FROM transfers
 WHERE 
@@ -20,11 +41,13 @@ ORDER BY id
 LIMIT $3
 OFFSET $4;
 
--- name: UpdateTransfer :one
-UPDATE transfers
-SET amount = $2
-WHERE id = $1
-RETURNING *;
-
--- name: DeleteTransfer :exec
-DELETE FROM transfers WHERE id = $1;
\ No newline at end of file
+-- name: SearchTransfers :many
+SELECT * FROM transfers
+WHERE
+    (from_account_id = sqlc.arg(account_id) OR to_account_id = sqlc.arg(account_id))
+    AND (sqlc.narg(reference)::varchar IS NULL OR reference = sqlc.narg(reference))
+    AND (sqlc.narg(description)::varchar IS NULL OR description ILIKE '%' || sqlc.narg(description) || '%')
+    AND (sqlc.narg(metadata)::text IS NULL OR metadata @> sqlc.narg(metadata)::jsonb)
+ORDER BY id DESC
+LIMIT sqlc.arg(page_limit)
+OFFSET sqlc.arg(page_offset);
\ No newline at end of file
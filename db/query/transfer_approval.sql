@@ -0,0 +1,63 @@
+-- name: CreateTransferApproval :one
+INSERT INTO transfer_approvals (
+    from_account_id,
+    to_account_id,
+    amount,
+    currency,
+    description,
+    reference,
+    metadata,
+    initiator,
+    status,
+    fraud_review,
+    payment_request_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING *;
+
+-- name: GetTransferApproval :one
+SELECT * FROM transfer_approvals
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTransferApprovalForUpdate :one
+SELECT * FROM transfer_approvals
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListTransferApprovals :many
+SELECT * FROM transfer_approvals
+WHERE status = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: ListOrganizationTransferApprovals :many
+SELECT ta.* FROM transfer_approvals ta
+JOIN accounts a ON a.id = ta.from_account_id
+WHERE a.organization_id = sqlc.arg(organization_id) AND ta.status = sqlc.arg(status)
+ORDER BY ta.id
+LIMIT sqlc.arg(page_limit)
+OFFSET sqlc.arg(page_offset);
+
+-- name: UpdateTransferApproval :one
+UPDATE transfer_approvals
+SET
+    status = $2,
+    reviewer = $3,
+    transfer_id = $4,
+    reviewed_at = now()
+WHERE id = $1
+RETURNING *;
+
+
+-- name: CreateTransferApprovalSignature :execrows
+INSERT INTO transfer_approval_signatures (
+    transfer_approval_id,
+    approver
+) VALUES (
+    $1, $2
+) ON CONFLICT (transfer_approval_id, approver) DO NOTHING;
+
+-- name: CountTransferApprovalSignatures :one
+SELECT count(*) FROM transfer_approval_signatures
+WHERE transfer_approval_id = $1;
\ No newline at end of file
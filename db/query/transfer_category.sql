@@ -0,0 +1,17 @@
+-- name: SetTransferCategory :one
+INSERT INTO transfer_categories (
+    transfer_id,
+    category_id
+) VALUES (
+    $1, $2
+) ON CONFLICT (transfer_id) DO UPDATE
+SET category_id = EXCLUDED.category_id,
+    categorized_at = now()
+RETURNING *;
+
+-- name: GetTransferCategory :one
+SELECT * FROM transfer_categories
+WHERE transfer_id = $1 LIMIT 1;
+
+-- name: DeleteTransferCategory :exec
+DELETE FROM transfer_categories WHERE transfer_id = $1;
\ No newline at end of file
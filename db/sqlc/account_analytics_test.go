@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createEntryAt adds an entry to an account at a given time, to lay out its history over several days
+func createEntryAt(t *testing.T, account Account, amount int64, createdAt time.Time) {
+	_, err := testDB.ExecContext(context.Background(),
+		"INSERT INTO entries (account_id, amount, created_at) VALUES ($1, $2, $3)",
+		account.ID, amount, createdAt,
+	)
+	require.NoError(t, err)
+}
+
+func TestAccountAnalytics(t *testing.T) {
+	store := NewStore(testDB)
+	account := createAccountWithBalance(t, "EUR", 1000)
+	account1 := createAccountWithBalance(t, "EUR", 1000)
+	account2 := createAccountWithBalance(t, "EUR", 1000)
+
+	transfer := func(from, to Account, amount int64, description string) {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: from.ID,
+			ToAccountID:   to.ID,
+			Amount:        amount,
+			Description:   description,
+		})
+		require.NoError(t, err)
+	}
+
+	transfer(account, account1, 100, "rent")
+	transfer(account, account2, 30, "groceries")
+	transfer(account1, account, 50, "refund")
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	from, to := today.AddDate(0, 0, -1), today.AddDate(0, 0, 1)
+
+	t.Run("CashFlow", func(t *testing.T) {
+		rows, err := testQueries.ListCashFlow(context.Background(), ListCashFlowParams{
+			Bucket:    "day",
+			AccountID: account.ID,
+			FromTime:  from,
+			ToTime:    to,
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		require.Equal(t, today, rows[0].Period.UTC())
+		require.Equal(t, int64(50), rows[0].Inflow)
+		require.Equal(t, int64(130), rows[0].Outflow)
+		require.Equal(t, int64(3), rows[0].EntryCount)
+
+		rows, err = testQueries.ListCashFlow(context.Background(), ListCashFlowParams{
+			Bucket:    "month",
+			AccountID: account.ID,
+			FromTime:  from,
+			ToTime:    to,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, rows)
+		require.Equal(t, time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), rows[len(rows)-1].Period.UTC())
+	})
+
+	t.Run("TopCounterparties", func(t *testing.T) {
+		rows, err := testQueries.ListTopCounterparties(context.Background(), ListTopCounterpartiesParams{
+			AccountID: account.ID,
+			FromTime:  from,
+			ToTime:    to,
+			RowLimit:  10,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []ListTopCounterpartiesRow{
+			{CounterpartyAccountID: account1.ID, CounterpartyOwner: account1.Owner, Inflow: 50, Outflow: 100, TransferCount: 2},
+			{CounterpartyAccountID: account2.ID, CounterpartyOwner: account2.Owner, Outflow: 30, TransferCount: 1},
+		}, rows)
+
+		rows, err = testQueries.ListTopCounterparties(context.Background(), ListTopCounterpartiesParams{
+			AccountID: account.ID,
+			FromTime:  from,
+			ToTime:    to,
+			RowLimit:  1,
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		require.Equal(t, account1.ID, rows[0].CounterpartyAccountID)
+	})
+
+	t.Run("LargestEntries", func(t *testing.T) {
+		rows, err := testQueries.ListLargestEntries(context.Background(), ListLargestEntriesParams{
+			AccountID: account.ID,
+			FromTime:  from,
+			ToTime:    to,
+			RowLimit:  2,
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		require.Equal(t, int64(-100), rows[0].Amount)
+		require.Equal(t, account1.ID, rows[0].CounterpartyAccountID)
+		require.Equal(t, "rent", rows[0].Description)
+		require.Equal(t, int64(50), rows[1].Amount)
+		require.Equal(t, "refund", rows[1].Description)
+	})
+
+	t.Run("OtherPeriod", func(t *testing.T) {
+		rows, err := testQueries.ListCashFlow(context.Background(), ListCashFlowParams{
+			Bucket:    "week",
+			AccountID: account.ID,
+			FromTime:  to,
+			ToTime:    to.AddDate(0, 0, 7),
+		})
+		require.NoError(t, err)
+		require.Empty(t, rows)
+	})
+}
+
+func TestGetDailyBalanceStats(t *testing.T) {
+	account := createRandomAccount(t)
+	day1 := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	createEntryAt(t, account, 20, day1.AddDate(0, 0, -10))
+	createEntryAt(t, account, 100, day1.Add(9*time.Hour))
+	createEntryAt(t, account, -40, day1.AddDate(0, 0, 2).Add(18*time.Hour))
+	// after the period
+	createEntryAt(t, account, 500, day1.AddDate(0, 0, 4))
+
+	// the days close at 120, 120, 80 and 80
+	stats, err := testQueries.GetDailyBalanceStats(context.Background(), GetDailyBalanceStatsParams{
+		AccountID: account.ID,
+		FromTime:  day1,
+		ToTime:    day1.AddDate(0, 0, 4),
+	})
+	require.NoError(t, err)
+	require.Equal(t, GetDailyBalanceStatsRow{AverageBalance: 100, MinBalance: 80, MaxBalance: 120}, stats)
+
+	// without entries in the period, every day closes at the opening balance
+	stats, err = testQueries.GetDailyBalanceStats(context.Background(), GetDailyBalanceStatsParams{
+		AccountID: account.ID,
+		FromTime:  day1.AddDate(0, 0, -5),
+		ToTime:    day1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, GetDailyBalanceStatsRow{AverageBalance: 20, MinBalance: 20, MaxBalance: 20}, stats)
+}
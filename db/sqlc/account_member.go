@@ -0,0 +1,32 @@
+package db
+
+// Permissions an account owner can grant to other users, each including the ones before it
+const (
+	AccountPermissionView     = "view"
+	AccountPermissionTransfer = "transfer"
+	AccountPermissionManage   = "manage"
+)
+
+var accountPermissionLevels = map[string]int{
+	AccountPermissionView:     1,
+	AccountPermissionTransfer: 2,
+	AccountPermissionManage:   3,
+}
+
+// AccountPermissionIncludes reports whether the granted account permission covers permission
+func AccountPermissionIncludes(granted string, permission string) bool {
+	return accountPermissionLevels[granted] >= accountPermissionLevels[permission]
+}
+
+// Allows reports whether the member's grant covers the permission. For transfers, amount is the amount sent,
+// which must be within the transfer limit unless the member manages the account.
+func (member AccountMember) Allows(permission string, amount int64) bool {
+	if !AccountPermissionIncludes(member.Permission, permission) {
+		return false
+	}
+
+	if member.Permission == AccountPermissionTransfer && member.TransferLimit > 0 {
+		return amount <= member.TransferLimit
+	}
+	return true
+}
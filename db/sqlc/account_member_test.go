@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomAccountMember shares an account with a new user for testing
+func createRandomAccountMember(t *testing.T, account Account, permission string) AccountMember {
+	user := createRandomUser(t)
+
+	arg := GrantAccountMemberParams{
+		AccountID:  account.ID,
+		Username:   user.Username,
+		Permission: permission,
+		GrantedBy:  account.Owner,
+	}
+
+	member, err := testQueries.GrantAccountMember(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.AccountID, member.AccountID)
+	require.Equal(t, arg.Username, member.Username)
+	require.Equal(t, arg.Permission, member.Permission)
+	require.Zero(t, member.TransferLimit)
+	require.Equal(t, arg.GrantedBy, member.GrantedBy)
+	require.NotZero(t, member.CreatedAt)
+
+	return member
+}
+
+func TestGrantAccountMember(t *testing.T) {
+	account := createRandomAccount(t)
+	member1 := createRandomAccountMember(t, account, AccountPermissionView)
+
+	// granting again changes the permission of the member
+	member2, err := testQueries.GrantAccountMember(context.Background(), GrantAccountMemberParams{
+		AccountID:     account.ID,
+		Username:      member1.Username,
+		Permission:    AccountPermissionTransfer,
+		TransferLimit: 5000,
+		GrantedBy:     account.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountPermissionTransfer, member2.Permission)
+	require.Equal(t, int64(5000), member2.TransferLimit)
+	require.Equal(t, member1.CreatedAt, member2.CreatedAt)
+
+	member3 := createRandomAccountMember(t, account, AccountPermissionManage)
+
+	members, err := testQueries.ListAccountMembers(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, []AccountMember{member2, member3}, members)
+}
+
+func TestRevokeAccountMember(t *testing.T) {
+	account := createRandomAccount(t)
+	member := createRandomAccountMember(t, account, AccountPermissionView)
+
+	arg := RevokeAccountMemberParams{AccountID: account.ID, Username: member.Username}
+
+	revoked, err := testQueries.RevokeAccountMember(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, member, revoked)
+
+	_, err = testQueries.GetAccountMember(context.Background(), GetAccountMemberParams(arg))
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.RevokeAccountMember(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+// TestListAccountsShared tests that accounts shared with a user are listed with their own
+func TestListAccountsShared(t *testing.T) {
+	account := createRandomAccount(t)
+	member := createRandomAccountMember(t, account, AccountPermissionView)
+	pot := createRandomPot(t, account, 0)
+
+	_, err := NewStore(testDB).MovePotTx(context.Background(), MovePotTxParams{PotID: pot.Pot.ID, Amount: 1})
+	require.NoError(t, err)
+
+	accounts, err := testQueries.ListAccounts(context.Background(), ListAccountsParams{
+		Owner:  member.Username,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ID, accounts[0].ID)
+
+	balances, err := testQueries.ListPotBalances(context.Background(), member.Username)
+	require.NoError(t, err)
+	require.Equal(t, []ListPotBalancesRow{{ParentAccountID: account.ID, Pots: 1, Balance: 1}}, balances)
+}
@@ -12,12 +12,20 @@ import (
 
 // createRandomAccount creates a random account for testing
 func createRandomAccount(t *testing.T) Account {
+	return createRandomAccountWithCurrency(t, util.RandomCurrency())
+}
+
+// createRandomAccountWithCurrency creates a random account holding the given currency for testing.
+// Accounts that exchange money must hold the same currency, or the journal entry won't balance.
+func createRandomAccountWithCurrency(t *testing.T, currency string) Account {
 	user := createRandomUser(t)
 
 	arg := CreateAccountParams{
-		Owner:    user.Username,
-		Balance:  util.RandomMoney(),
-		Currency: util.RandomCurrency(),
+		Owner: user.Username,
+		// enough for the transfers of the tests, accounts without an overdraft facility can't go below zero
+		Balance:  util.RandomInt(100, 1000),
+		Currency: currency,
+		Type:     AccountTypeChecking,
 	}
 
 	account, err := testQueries.CreateAccount(context.Background(), arg)
@@ -28,6 +36,7 @@ func createRandomAccount(t *testing.T) Account {
 	require.Equal(t, arg.Balance, account.Balance)
 	require.Equal(t, arg.Currency, account.Currency)
 	require.Equal(t, arg.Owner, account.Owner)
+	require.Equal(t, arg.Type, account.Type)
 
 	require.NotZero(t, account.ID)
 	require.NotZero(t, account.CreatedAt)
@@ -56,27 +65,6 @@ func TestGetAccount(t *testing.T) {
 	require.WithinDuration(t, account1.CreatedAt, account2.CreatedAt, time.Second)
 }
 
-// TestUpdateAccount tests the UpdateAccount function
-func TestUpdateAccount(t *testing.T) {
-	account1 := createRandomAccount(t)
-
-	arg := UpdateAccountParams{
-		ID:      account1.ID,
-		Balance: util.RandomMoney(),
-	}
-
-	account2, err := testQueries.UpdateAccount(context.Background(), arg)
-	require.NoError(t, err)
-	require.NotEmpty(t, account2)
-
-	require.Equal(t, account1.ID, account2.ID)
-	require.Equal(t, arg.Balance, account2.Balance)
-	require.Equal(t, account1.Currency, account2.Currency)
-	require.Equal(t, account1.Owner, account2.Owner)
-
-	require.WithinDuration(t, account1.CreatedAt, account2.CreatedAt, time.Second)
-}
-
 // TestDeleteAccount tests the DeleteAccount function
 func TestDeleteAccount(t *testing.T) {
 	account1 := createRandomAccount(t)
@@ -0,0 +1,327 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AuditLogGenesisHash is the previous hash of the first audit log entry
+var AuditLogGenesisHash = strings.Repeat("0", sha256.Size*2)
+
+// auditLogLockID is the advisory lock serializing appends to the audit log, so that the chain never forks
+const auditLogLockID = 7_461_636_865
+
+// DefaultAuditLogChunkSize is the number of audit log entries read per query when verifying the chain
+const DefaultAuditLogChunkSize = 500
+
+// AppendAuditLogParams contains the input parameters of a new audit log entry
+type AppendAuditLogParams struct {
+	Actor     string          `json:"actor"`
+	Action    string          `json:"action"`
+	Resource  string          `json:"resource"`
+	RequestID string          `json:"request_id"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+}
+
+// AppendAuditLogTx appends an entry to the audit log, chained to the hash of the previous entry
+func (store *SQLStore) AppendAuditLogTx(ctx context.Context, arg AppendAuditLogParams) (AuditLog, error) {
+	var entry AuditLog
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		entry, err = appendAuditLog(ctx, q, arg)
+		return err
+	})
+
+	return entry, err
+}
+
+// appendAuditLog appends an entry to the audit log using the given queries.
+// It must be called inside a database transaction, which holds the audit log lock until it ends.
+func appendAuditLog(ctx context.Context, q *Queries, arg AppendAuditLogParams) (AuditLog, error) {
+	err := q.LockAuditLog(ctx, auditLogLockID)
+	if err != nil {
+		return AuditLog{}, err
+	}
+
+	prevHash := AuditLogGenesisHash
+	last, err := q.GetLastAuditLogEntry(ctx)
+	if err == nil {
+		prevHash = last.Hash
+	} else if err != sql.ErrNoRows {
+		return AuditLog{}, err
+	}
+
+	before, err := canonicalJSON(arg.Before)
+	if err != nil {
+		return AuditLog{}, fmt.Errorf("invalid before state: %w", err)
+	}
+
+	after, err := canonicalJSON(arg.After)
+	if err != nil {
+		return AuditLog{}, fmt.Errorf("invalid after state: %w", err)
+	}
+
+	entry := AuditLog{
+		Actor:     arg.Actor,
+		Action:    arg.Action,
+		Resource:  arg.Resource,
+		RequestID: arg.RequestID,
+		Before:    before,
+		After:     after,
+		// postgres keeps microseconds, the hash must be computed from what is stored
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		PrevHash:  prevHash,
+	}
+
+	hash, err := auditLogHash(entry)
+	if err != nil {
+		return AuditLog{}, err
+	}
+
+	return q.CreateAuditLogEntry(ctx, CreateAuditLogEntryParams{
+		Actor:     entry.Actor,
+		Action:    entry.Action,
+		Resource:  entry.Resource,
+		RequestID: entry.RequestID,
+		Before:    entry.Before,
+		After:     entry.After,
+		CreatedAt: entry.CreatedAt,
+		PrevHash:  entry.PrevHash,
+		Hash:      hash,
+	})
+}
+
+// AuditLogBreak is the first entry of the audit log that doesn't match the chain
+type AuditLogBreak struct {
+	ID     int64  `json:"id"`
+	Reason string `json:"reason"`
+}
+
+// AuditLogVerification is the result of walking the audit log chain
+type AuditLogVerification struct {
+	EntriesChecked int `json:"entries_checked"`
+	// BrokenLink is nil if the chain is intact
+	BrokenLink *AuditLogBreak `json:"broken_link,omitempty"`
+}
+
+// Intact reports whether every entry of the audit log matches its hash and links to the entry before it
+func (verification AuditLogVerification) Intact() bool {
+	return verification.BrokenLink == nil
+}
+
+// VerifyAuditLog walks the audit log from the first entry and stops at the first broken link.
+// An entry is broken when its content doesn't match its hash, or when its previous hash isn't the hash of the entry before it,
+// which is the case when entries were changed, removed or inserted.
+func (q *Queries) VerifyAuditLog(ctx context.Context, chunkSize int32) (AuditLogVerification, error) {
+	var verification AuditLogVerification
+
+	if chunkSize <= 0 {
+		chunkSize = DefaultAuditLogChunkSize
+	}
+
+	prevHash := AuditLogGenesisHash
+	var afterID int64
+	for {
+		entries, err := q.ListAuditLogEntries(ctx, ListAuditLogEntriesParams{
+			AfterID:   afterID,
+			ChunkSize: chunkSize,
+		})
+		if err != nil {
+			return verification, err
+		}
+
+		for _, entry := range entries {
+			verification.EntriesChecked++
+
+			if entry.PrevHash != prevHash {
+				verification.BrokenLink = &AuditLogBreak{
+					ID:     entry.ID,
+					Reason: "previous hash doesn't match the entry before it",
+				}
+				return verification, nil
+			}
+
+			hash, err := auditLogHash(entry)
+			if err != nil {
+				return verification, err
+			}
+
+			if entry.Hash != hash {
+				verification.BrokenLink = &AuditLogBreak{
+					ID:     entry.ID,
+					Reason: "hash doesn't match the content of the entry",
+				}
+				return verification, nil
+			}
+
+			prevHash = entry.Hash
+		}
+
+		if len(entries) < int(chunkSize) {
+			break
+		}
+		afterID = entries[len(entries)-1].ID
+	}
+
+	return verification, nil
+}
+
+// auditLogHash computes the hex SHA-256 of the content of an audit log entry and its previous hash.
+// JSON values are hashed in canonical form, since jsonb doesn't keep the key order or formatting it was given.
+func auditLogHash(entry AuditLog) (string, error) {
+	before, err := canonicalJSON(entry.Before)
+	if err != nil {
+		return "", err
+	}
+
+	after, err := canonicalJSON(entry.After)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := json.Marshal(struct {
+		Actor     string          `json:"actor"`
+		Action    string          `json:"action"`
+		Resource  string          `json:"resource"`
+		RequestID string          `json:"request_id"`
+		Before    json.RawMessage `json:"before"`
+		After     json.RawMessage `json:"after"`
+		CreatedAt string          `json:"created_at"`
+		PrevHash  string          `json:"prev_hash"`
+	}{
+		Actor:     entry.Actor,
+		Action:    entry.Action,
+		Resource:  entry.Resource,
+		RequestID: entry.RequestID,
+		Before:    before,
+		After:     after,
+		CreatedAt: entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+		PrevHash:  entry.PrevHash,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalJSON re-encodes a JSON value with sorted object keys, no insignificant whitespace and numbers in
+// canonical decimal form. Numbers are kept exact rather than decoded as float64, which would change amounts and IDs
+// above 2^53 before they are hashed. An empty value is encoded as null.
+func canonicalJSON(data json.RawMessage) (json.RawMessage, error) {
+	if len(data) == 0 {
+		return json.RawMessage("null"), nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+
+	value, err := canonicalNumbers(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(value)
+}
+
+// canonicalNumbers replaces the numbers of a decoded JSON value with their canonical form
+func canonicalNumbers(value any) (any, error) {
+	switch value := value.(type) {
+	case json.Number:
+		return canonicalNumber(value)
+	case map[string]any:
+		for key, item := range value {
+			canonical, err := canonicalNumbers(item)
+			if err != nil {
+				return nil, err
+			}
+			value[key] = canonical
+		}
+	case []any:
+		for i, item := range value {
+			canonical, err := canonicalNumbers(item)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = canonical
+		}
+	}
+	return value, nil
+}
+
+// maxCanonicalNumberDigits bounds the digits written for a number, so that a huge exponent can't blow up its expansion
+const maxCanonicalNumberDigits = 1000
+
+// canonicalNumber writes a JSON number as a plain decimal without exponent, leading or trailing zeros,
+// so that 1, 1.0 and 1e0 hash the same however jsonb stores them
+func canonicalNumber(number json.Number) (json.Number, error) {
+	text := string(number)
+
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(text, "-")
+
+	exponent := 0
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		var err error
+		exponent, err = strconv.Atoi(text[i+1:])
+		if err != nil {
+			return "", fmt.Errorf("invalid number %q: %w", number, err)
+		}
+		text = text[:i]
+	}
+
+	// the value is 0.digits * 10^point
+	integer, fraction, _ := strings.Cut(text, ".")
+	digits := integer + fraction
+	point := len(integer) + exponent
+
+	for len(digits) > 0 && digits[0] == '0' {
+		digits = digits[1:]
+		point--
+	}
+	digits = strings.TrimRight(digits, "0")
+
+	if digits == "" {
+		return "0", nil
+	}
+
+	if point > maxCanonicalNumberDigits || point < -maxCanonicalNumberDigits {
+		return "", fmt.Errorf("number %q is out of range", number)
+	}
+
+	var canonical string
+	switch {
+	case point <= 0:
+		canonical = "0." + strings.Repeat("0", -point) + digits
+	case point >= len(digits):
+		canonical = digits + strings.Repeat("0", point-len(digits))
+	default:
+		canonical = digits[:point] + "." + digits[point:]
+	}
+
+	if negative {
+		canonical = "-" + canonical
+	}
+	return json.Number(canonical), nil
+}
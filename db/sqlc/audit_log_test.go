@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+// randomAuditLogParams creates random audit log entry parameters for testing
+func randomAuditLogParams() AppendAuditLogParams {
+	return AppendAuditLogParams{
+		Actor:     util.RandomOwner(),
+		Action:    "PUT /accounts/:id",
+		Resource:  "/accounts/1",
+		RequestID: util.RandomString(12),
+		Before:    json.RawMessage(`{"balance": 10, "currency": "USD"}`),
+		After:     json.RawMessage(`{"currency": "USD", "balance": 20}`),
+	}
+}
+
+// TestAppendAuditLogTx tests that every audit log entry is chained to the one before it
+func TestAppendAuditLogTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	arg := randomAuditLogParams()
+	entry1, err := store.AppendAuditLogTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, entry1.ID)
+	require.Equal(t, arg.Actor, entry1.Actor)
+	require.Equal(t, arg.Action, entry1.Action)
+	require.Equal(t, arg.Resource, entry1.Resource)
+	require.Equal(t, arg.RequestID, entry1.RequestID)
+	require.JSONEq(t, string(arg.Before), string(entry1.Before))
+	require.JSONEq(t, string(arg.After), string(entry1.After))
+	require.Len(t, entry1.Hash, 64)
+
+	entry2, err := store.AppendAuditLogTx(context.Background(), randomAuditLogParams())
+	require.NoError(t, err)
+	require.Equal(t, entry1.Hash, entry2.PrevHash)
+	require.NotEqual(t, entry1.Hash, entry2.Hash)
+
+	// the hash stays valid after a round trip through jsonb
+	hash, err := auditLogHash(entry1)
+	require.NoError(t, err)
+	require.Equal(t, entry1.Hash, hash)
+}
+
+// TestVerifyAuditLog tests that changing or removing an entry breaks the chain at that entry.
+// The entries are tampered with inside a transaction that is rolled back, so the chain stays intact.
+func TestVerifyAuditLog(t *testing.T) {
+	testCases := []struct {
+		name   string
+		tamper func(t *testing.T, q *Queries, entries []AuditLog) int64
+	}{
+		{
+			name: "Intact",
+			tamper: func(t *testing.T, q *Queries, entries []AuditLog) int64 {
+				return 0
+			},
+		},
+		{
+			name: "ChangedContent",
+			tamper: func(t *testing.T, q *Queries, entries []AuditLog) int64 {
+				_, err := q.db.ExecContext(context.Background(), "UPDATE audit_log SET actor = 'mallory' WHERE id = $1", entries[1].ID)
+				require.NoError(t, err)
+				return entries[1].ID
+			},
+		},
+		{
+			name: "RecomputedHash",
+			tamper: func(t *testing.T, q *Queries, entries []AuditLog) int64 {
+				entry := entries[1]
+				entry.Actor = "mallory"
+				hash, err := auditLogHash(entry)
+				require.NoError(t, err)
+
+				_, err = q.db.ExecContext(context.Background(), "UPDATE audit_log SET actor = $2, hash = $3 WHERE id = $1", entry.ID, entry.Actor, hash)
+				require.NoError(t, err)
+				return entries[2].ID
+			},
+		},
+		{
+			name: "RemovedEntry",
+			tamper: func(t *testing.T, q *Queries, entries []AuditLog) int64 {
+				_, err := q.db.ExecContext(context.Background(), "DELETE FROM audit_log WHERE id = $1", entries[1].ID)
+				require.NoError(t, err)
+				return entries[2].ID
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			tx, err := testDB.BeginTx(context.Background(), nil)
+			require.NoError(t, err)
+			defer tx.Rollback()
+
+			q := New(tx)
+			entries := make([]AuditLog, 3)
+			for i := range entries {
+				entries[i], err = appendAuditLog(context.Background(), q, randomAuditLogParams())
+				require.NoError(t, err)
+			}
+
+			brokenID := tc.tamper(t, q, entries)
+
+			verification, err := q.VerifyAuditLog(context.Background(), 2)
+			require.NoError(t, err)
+			require.NotZero(t, verification.EntriesChecked)
+
+			if brokenID == 0 {
+				require.True(t, verification.Intact())
+				return
+			}
+
+			require.False(t, verification.Intact())
+			require.Equal(t, brokenID, verification.BrokenLink.ID)
+		})
+	}
+}
+
+// TestCanonicalJSON tests that equivalent JSON values have the same canonical form
+func TestCanonicalJSON(t *testing.T) {
+	data1, err := canonicalJSON(json.RawMessage(`{"b": 1, "a": [true, null, "x"]}`))
+	require.NoError(t, err)
+
+	data2, err := canonicalJSON(json.RawMessage(`{"a":[true,null,"x"],"b":1.0}`))
+	require.NoError(t, err)
+
+	require.Equal(t, `{"a":[true,null,"x"],"b":1}`, string(data1))
+	require.Equal(t, data1, data2)
+
+	// numbers are kept exact, beyond the precision of float64, and written without exponent
+	data3, err := canonicalJSON(json.RawMessage(`{"id": 9007199254740993, "amount": -12.30e1, "rate": 1.5E-3}`))
+	require.NoError(t, err)
+	require.Equal(t, `{"amount":-123,"id":9007199254740993,"rate":0.0015}`, string(data3))
+
+	empty, err := canonicalJSON(nil)
+	require.NoError(t, err)
+	require.Equal(t, "null", string(empty))
+
+	_, err = canonicalJSON(json.RawMessage(`{"a":`))
+	require.Error(t, err)
+
+	_, err = canonicalJSON(json.RawMessage(`{} {}`))
+	require.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetBalanceAsOf tests that historical balances add up the entries up to the given time
+func TestGetBalanceAsOf(t *testing.T) {
+	account := createRandomAccount(t)
+
+	// no entries yet
+	balance, err := testQueries.GetBalanceAsOf(context.Background(), GetBalanceAsOfParams{
+		AccountID: account.ID,
+		AsOf:      time.Now(),
+	})
+	require.NoError(t, err)
+	require.Zero(t, balance)
+
+	entry1 := createRandomEntry(t, account)
+	entry2 := createRandomEntry(t, account)
+
+	balance, err = testQueries.GetBalanceAsOf(context.Background(), GetBalanceAsOfParams{
+		AccountID: account.ID,
+		AsOf:      entry2.CreatedAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, entry1.Amount+entry2.Amount, balance)
+
+	balance, err = testQueries.GetBalanceAsOf(context.Background(), GetBalanceAsOfParams{
+		AccountID: account.ID,
+		AsOf:      entry1.CreatedAt.Add(-time.Microsecond),
+	})
+	require.NoError(t, err)
+	require.Zero(t, balance)
+}
+
+// TestCreateBalanceSnapshots tests that snapshots give the same balances as adding up every entry
+func TestCreateBalanceSnapshots(t *testing.T) {
+	account := createRandomAccount(t)
+	entry1 := createRandomEntry(t, account)
+	takenAt := entry1.CreatedAt
+
+	count, err := testQueries.CreateBalanceSnapshots(context.Background(), takenAt)
+	require.NoError(t, err)
+	require.NotZero(t, count)
+
+	var snapshot BalanceSnapshot
+	err = testDB.QueryRowContext(context.Background(),
+		"SELECT id, account_id, balance, taken_at, created_at FROM balance_snapshots WHERE account_id = $1",
+		account.ID,
+	).Scan(&snapshot.ID, &snapshot.AccountID, &snapshot.Balance, &snapshot.TakenAt, &snapshot.CreatedAt)
+	require.NoError(t, err)
+	require.Equal(t, entry1.Amount, snapshot.Balance)
+	require.WithinDuration(t, takenAt, snapshot.TakenAt, time.Microsecond)
+
+	// taking the same snapshot again changes nothing
+	_, err = testQueries.CreateBalanceSnapshots(context.Background(), takenAt)
+	require.NoError(t, err)
+
+	entry2 := createRandomEntry(t, account)
+
+	balance, err := testQueries.GetBalanceAsOf(context.Background(), GetBalanceAsOfParams{
+		AccountID: account.ID,
+		AsOf:      takenAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, entry1.Amount, balance)
+
+	balance, err = testQueries.GetBalanceAsOf(context.Background(), GetBalanceAsOfParams{
+		AccountID: account.ID,
+		AsOf:      entry2.CreatedAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, entry1.Amount+entry2.Amount, balance)
+
+	// a later snapshot builds on the earlier one
+	_, err = testQueries.CreateBalanceSnapshots(context.Background(), entry2.CreatedAt)
+	require.NoError(t, err)
+
+	var balance2 int64
+	err = testDB.QueryRowContext(context.Background(),
+		"SELECT balance FROM balance_snapshots WHERE account_id = $1 AND taken_at = $2",
+		account.ID, entry2.CreatedAt,
+	).Scan(&balance2)
+	require.NoError(t, err)
+	require.Equal(t, entry1.Amount+entry2.Amount, balance2)
+}
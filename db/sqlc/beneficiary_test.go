@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+// createRandomBeneficiary creates a random beneficiary for testing
+func createRandomBeneficiary(t *testing.T) Beneficiary {
+	user := createRandomUser(t)
+	account := createRandomAccount(t)
+
+	arg := CreateBeneficiaryParams{
+		Owner:     user.Username,
+		Nickname:  util.RandomOwner(),
+		AccountID: account.ID,
+	}
+
+	beneficiary, err := testQueries.CreateBeneficiary(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, beneficiary)
+
+	require.Equal(t, arg.Owner, beneficiary.Owner)
+	require.Equal(t, arg.Nickname, beneficiary.Nickname)
+	require.Equal(t, arg.AccountID, beneficiary.AccountID)
+
+	require.NotZero(t, beneficiary.ID)
+	require.NotZero(t, beneficiary.CreatedAt)
+
+	return beneficiary
+}
+
+// TestCreateBeneficiary tests the CreateBeneficiary function
+func TestCreateBeneficiary(t *testing.T) {
+	beneficiary := createRandomBeneficiary(t)
+
+	// the same account cannot be saved twice by the same user
+	_, err := testQueries.CreateBeneficiary(context.Background(), CreateBeneficiaryParams{
+		Owner:     beneficiary.Owner,
+		Nickname:  util.RandomOwner(),
+		AccountID: beneficiary.AccountID,
+	})
+	require.Error(t, err)
+}
+
+// TestGetBeneficiary tests the GetBeneficiary function
+func TestGetBeneficiary(t *testing.T) {
+	beneficiary1 := createRandomBeneficiary(t)
+	beneficiary2, err := testQueries.GetBeneficiary(context.Background(), beneficiary1.ID)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, beneficiary2)
+
+	require.Equal(t, beneficiary1.ID, beneficiary2.ID)
+	require.Equal(t, beneficiary1.Owner, beneficiary2.Owner)
+	require.Equal(t, beneficiary1.Nickname, beneficiary2.Nickname)
+	require.Equal(t, beneficiary1.AccountID, beneficiary2.AccountID)
+
+	require.WithinDuration(t, beneficiary1.CreatedAt, beneficiary2.CreatedAt, time.Second)
+}
+
+// TestUpdateBeneficiary tests the UpdateBeneficiary function
+func TestUpdateBeneficiary(t *testing.T) {
+	beneficiary1 := createRandomBeneficiary(t)
+
+	arg := UpdateBeneficiaryParams{
+		ID:       beneficiary1.ID,
+		Nickname: util.RandomOwner(),
+	}
+
+	beneficiary2, err := testQueries.UpdateBeneficiary(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, beneficiary1.ID, beneficiary2.ID)
+	require.Equal(t, arg.Nickname, beneficiary2.Nickname)
+	require.Equal(t, beneficiary1.AccountID, beneficiary2.AccountID)
+	require.WithinDuration(t, beneficiary1.CreatedAt, beneficiary2.CreatedAt, time.Second)
+}
+
+// TestDeleteBeneficiary tests the DeleteBeneficiary function
+func TestDeleteBeneficiary(t *testing.T) {
+	beneficiary1 := createRandomBeneficiary(t)
+
+	err := testQueries.DeleteBeneficiary(context.Background(), beneficiary1.ID)
+	require.NoError(t, err)
+
+	beneficiary2, err := testQueries.GetBeneficiary(context.Background(), beneficiary1.ID)
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	require.Empty(t, beneficiary2)
+}
+
+// TestListBeneficiaries tests the ListBeneficiaries function
+func TestListBeneficiaries(t *testing.T) {
+	beneficiary := createRandomBeneficiary(t)
+
+	beneficiaries, err := testQueries.ListBeneficiaries(context.Background(), ListBeneficiariesParams{
+		Owner:  beneficiary.Owner,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, beneficiaries, 1)
+	require.Equal(t, beneficiary.ID, beneficiaries[0].ID)
+}
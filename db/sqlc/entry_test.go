@@ -2,10 +2,10 @@ package db
 
 import (
 	"context"
-	"database/sql"
 	"testing"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 	"github.com/volskyi-dmytro/st-bank/util"
 )
@@ -53,38 +53,28 @@ func TestGetEntry(t *testing.T) {
 	require.WithinDuration(t, entry1.CreatedAt, entry2.CreatedAt, time.Second)
 }
 
-// TestUpdateEntry tests the UpdateEntry function
-func TestUpdateEntry(t *testing.T) {
+// TestEntriesAreAppendOnly tests that entries cannot be changed once written
+func TestEntriesAreAppendOnly(t *testing.T) {
 	account := createRandomAccount(t)
-	entry1 := createRandomEntry(t, account)
-
-	arg := UpdateEntryParams{
-		ID:     entry1.ID,
-		Amount: util.RandomMoney(),
-	}
-
-	entry2, err := testQueries.UpdateEntry(context.Background(), arg)
-	require.NoError(t, err)
-	require.NotEmpty(t, entry2)
+	entry := createRandomEntry(t, account)
 
-	require.Equal(t, entry1.ID, entry2.ID)
-	require.Equal(t, entry1.AccountID, entry2.AccountID)
-	require.Equal(t, arg.Amount, entry2.Amount)
+	_, err := testDB.ExecContext(context.Background(), "UPDATE entries SET amount = $2 WHERE id = $1", entry.ID, util.RandomMoney())
+	requireAppendOnlyError(t, err)
 
-	require.WithinDuration(t, entry1.CreatedAt, entry2.CreatedAt, time.Second)
-}
+	_, err = testDB.ExecContext(context.Background(), "DELETE FROM entries WHERE id = $1", entry.ID)
+	requireAppendOnlyError(t, err)
 
-// TestDeleteEntry tests the DeleteEntry function
-func TestDeleteEntry(t *testing.T) {
-	account := createRandomAccount(t)
-	entry1 := createRandomEntry(t, account)
-	err := testQueries.DeleteEntry(context.Background(), entry1.ID)
+	entry2, err := testQueries.GetEntry(context.Background(), entry.ID)
 	require.NoError(t, err)
+	require.Equal(t, entry.Amount, entry2.Amount)
+}
 
-	entry2, err := testQueries.GetEntry(context.Background(), entry1.ID)
-	require.Error(t, err)
-	require.EqualError(t, err, sql.ErrNoRows.Error())
-	require.Empty(t, entry2)
+// requireAppendOnlyError checks that a write was rejected by the append-only ledger triggers
+func requireAppendOnlyError(t *testing.T, err error) {
+	var pqErr *pq.Error
+	require.ErrorAs(t, err, &pqErr)
+	require.Equal(t, "raise_exception", pqErr.Code.Name())
+	require.Contains(t, pqErr.Message, "append-only")
 }
 
 // TestListEntries tests the ListEntries function
@@ -110,3 +100,52 @@ func TestListEntries(t *testing.T) {
 		require.Equal(t, account.ID, entry.AccountID)
 	}
 }
+
+// TestListStatementEntries tests that statement entries carry the counterparty of their transfer
+func TestListStatementEntries(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Description:   "dinner",
+		Reference:     util.RandomString(10),
+	})
+	require.NoError(t, err)
+
+	correction := createRandomEntry(t, account1)
+
+	entries, err := testQueries.ListStatementEntries(context.Background(), ListStatementEntriesParams{
+		AccountID: account1.ID,
+		FromTime:  result.FromEntry.CreatedAt,
+		ToTime:    correction.CreatedAt.Add(time.Second),
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	require.Equal(t, result.FromEntry.ID, entries[0].ID)
+	require.Equal(t, int64(-10), entries[0].Amount)
+	require.Equal(t, result.Transfer.ID, entries[0].TransferID.Int64)
+	require.Equal(t, account2.ID, entries[0].CounterpartyAccountID)
+	require.Equal(t, account2.Owner, entries[0].CounterpartyOwner)
+	require.Equal(t, "dinner", entries[0].Description)
+	require.Equal(t, result.Transfer.Reference, entries[0].Reference)
+
+	require.Equal(t, correction.ID, entries[1].ID)
+	require.False(t, entries[1].TransferID.Valid)
+	require.Zero(t, entries[1].CounterpartyAccountID)
+	require.Empty(t, entries[1].CounterpartyOwner)
+
+	// the end of the period is excluded
+	entries, err = testQueries.ListStatementEntries(context.Background(), ListStatementEntriesParams{
+		AccountID: account1.ID,
+		FromTime:  result.FromEntry.CreatedAt,
+		ToTime:    correction.CreatedAt,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
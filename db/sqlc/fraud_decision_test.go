@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+// createRandomFraudDecision creates a random fraud decision for testing
+func createRandomFraudDecision(t *testing.T) FraudDecision {
+	fromAccount := createRandomAccount(t)
+	toAccount := createRandomAccount(t)
+
+	arg := CreateFraudDecisionParams{
+		Username:      fromAccount.Owner,
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        util.RandomMoney(),
+		Currency:      fromAccount.Currency,
+		Score:         110,
+		Decision:      "block",
+		ReasonCodes:   []string{"new_recipient_large_amount", "amount_deviation"},
+	}
+
+	decision, err := testQueries.CreateFraudDecision(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, decision)
+
+	require.Equal(t, arg.Username, decision.Username)
+	require.Equal(t, arg.FromAccountID, decision.FromAccountID)
+	require.Equal(t, arg.ToAccountID, decision.ToAccountID)
+	require.Equal(t, arg.Amount, decision.Amount)
+	require.Equal(t, arg.Currency, decision.Currency)
+	require.Equal(t, arg.Score, decision.Score)
+	require.Equal(t, arg.Decision, decision.Decision)
+	require.Equal(t, arg.ReasonCodes, decision.ReasonCodes)
+
+	require.NotZero(t, decision.ID)
+	require.NotZero(t, decision.CreatedAt)
+
+	return decision
+}
+
+func TestCreateFraudDecision(t *testing.T) {
+	createRandomFraudDecision(t)
+}
+
+func TestGetFraudDecision(t *testing.T) {
+	decision1 := createRandomFraudDecision(t)
+	decision2, err := testQueries.GetFraudDecision(context.Background(), decision1.ID)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, decision2)
+
+	require.Equal(t, decision1.ID, decision2.ID)
+	require.Equal(t, decision1.Decision, decision2.Decision)
+	require.Equal(t, decision1.ReasonCodes, decision2.ReasonCodes)
+}
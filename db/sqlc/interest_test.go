@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+// createRandomInterestPlan creates a random interest plan for testing
+func createRandomInterestPlan(t *testing.T) InterestPlan {
+	arg := CreateInterestPlanParams{
+		Name:          util.RandomString(12),
+		AnnualRateBps: int32(util.RandomInt(1, 1000)),
+		DayCount:      "ACT/365",
+	}
+
+	plan, err := testQueries.CreateInterestPlan(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, plan.ID)
+	require.Equal(t, arg.Name, plan.Name)
+	require.Equal(t, arg.AnnualRateBps, plan.AnnualRateBps)
+	require.Equal(t, arg.DayCount, plan.DayCount)
+	require.NotZero(t, plan.CreatedAt)
+
+	return plan
+}
+
+// TestCreateInterestPlan tests that plans are stored and can't share a name or use an unknown day count
+func TestCreateInterestPlan(t *testing.T) {
+	plan := createRandomInterestPlan(t)
+
+	plan2, err := testQueries.GetInterestPlan(context.Background(), plan.ID)
+	require.NoError(t, err)
+	require.Equal(t, plan.Name, plan2.Name)
+
+	_, err = testQueries.CreateInterestPlan(context.Background(), CreateInterestPlanParams{
+		Name:          plan.Name,
+		AnnualRateBps: 100,
+		DayCount:      "ACT/365",
+	})
+	require.Error(t, err)
+
+	_, err = testQueries.CreateInterestPlan(context.Background(), CreateInterestPlanParams{
+		Name:          util.RandomString(12),
+		AnnualRateBps: 100,
+		DayCount:      "ACT/ACT",
+	})
+	require.Error(t, err)
+}
+
+// TestCreateInterestAccrual tests that an account accrues interest only once per day
+func TestCreateInterestAccrual(t *testing.T) {
+	account := createRandomAccount(t)
+	day := time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	arg := CreateInterestAccrualParams{
+		AccountID:     account.ID,
+		AccrualDate:   day,
+		Balance:       account.Balance,
+		AnnualRateBps: 250,
+		DayCount:      "ACT/365",
+		Amount:        12345,
+	}
+
+	count, err := testQueries.CreateInterestAccrual(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	arg.Amount = 54321
+	count, err = testQueries.CreateInterestAccrual(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	accruals, err := testQueries.ListInterestAccruals(context.Background(), ListInterestAccrualsParams{
+		AccountID: account.ID,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, accruals, 1)
+	require.Equal(t, int64(12345), accruals[0].Amount)
+	require.True(t, day.Equal(accruals[0].AccrualDate))
+
+	accountIDs, err := testQueries.ListAccruingAccounts(context.Background(), ListAccruingAccountsParams{
+		PeriodStart: day.AddDate(0, 0, -1),
+		PeriodEnd:   day,
+	})
+	require.NoError(t, err)
+	require.Contains(t, accountIDs, account.ID)
+}
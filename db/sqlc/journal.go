@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+)
+
+// Kinds of journal entries booked by the store
+const (
+	JournalKindTransfer       = "transfer"
+	JournalKindInterest       = "interest"
+	JournalKindFee            = "fee"
+	JournalKindLoan           = "loan"
+	JournalKindPot            = "pot"
+	JournalKindCard           = "card"
+	JournalKindReconciliation = "reconciliation"
+)
+
+var ErrTooFewPostings = errors.New("a journal entry needs at least two postings")
+
+// Posting is a single leg of a journal entry: an amount debited (negative) or credited (positive) to an account
+type Posting struct {
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+}
+
+// postJournalEntryParams contains the input parameters of a journal entry
+type postJournalEntryParams struct {
+	Kind        string
+	Description string
+	// TransferID links the postings to the transfer they belong to, if any
+	TransferID sql.NullInt64
+	Postings   []Posting
+	// BookedAccounts holds the accounts whose balance already includes their postings, so that only their
+	// entries are created, as when the entries of an account are brought in line with its balance
+	BookedAccounts map[int64]bool
+}
+
+// postJournalEntryResult is the result of booking a journal entry
+type postJournalEntryResult struct {
+	JournalEntry JournalEntry
+	// Entries holds one entry per posting, in the order of the postings
+	Entries []Entry
+	// Accounts holds the updated accounts by their ID
+	Accounts map[int64]Account
+}
+
+// postJournalEntry books a journal entry with its postings and updates the balances of the accounts.
+// It must be called inside a database transaction: the postings are checked to sum up to zero
+// in every currency when the transaction commits.
+func postJournalEntry(ctx context.Context, q *Queries, arg postJournalEntryParams) (postJournalEntryResult, error) {
+	var result postJournalEntryResult
+	var err error
+
+	if len(arg.Postings) < 2 {
+		return result, ErrTooFewPostings
+	}
+
+	result.JournalEntry, err = q.CreateJournalEntry(ctx, CreateJournalEntryParams{
+		Kind:        arg.Kind,
+		Description: arg.Description,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	journalEntryID := sql.NullInt64{
+		Int64: result.JournalEntry.ID,
+		Valid: true,
+	}
+
+	result.Entries = make([]Entry, 0, len(arg.Postings))
+	amounts := make(map[int64]int64, len(arg.Postings))
+	for _, posting := range arg.Postings {
+		entry, err := q.CreateEntry(ctx, CreateEntryParams{
+			AccountID:      posting.AccountID,
+			Amount:         posting.Amount,
+			TransferID:     arg.TransferID,
+			JournalEntryID: journalEntryID,
+		})
+		if err != nil {
+			return result, err
+		}
+
+		result.Entries = append(result.Entries, entry)
+		if !arg.BookedAccounts[posting.AccountID] {
+			amounts[posting.AccountID] += posting.Amount
+		}
+	}
+
+	// Always update accounts in order of their IDs to avoid deadlocks
+	accountIDs := make([]int64, 0, len(amounts))
+	for accountID := range amounts {
+		accountIDs = append(accountIDs, accountID)
+	}
+	sort.Slice(accountIDs, func(i, j int) bool {
+		return accountIDs[i] < accountIDs[j]
+	})
+
+	result.Accounts = make(map[int64]Account, len(accountIDs))
+	for _, accountID := range accountIDs {
+		account, err := updateAccountBalance(ctx, q, accountID, amounts[accountID])
+		if err != nil {
+			return result, err
+		}
+		result.Accounts[accountID] = account
+	}
+
+	return result, nil
+}
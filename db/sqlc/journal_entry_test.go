@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+// createRandomJournalEntry creates a random journal entry without postings for testing
+func createRandomJournalEntry(t *testing.T) JournalEntry {
+	arg := CreateJournalEntryParams{
+		Kind:        JournalKindTransfer,
+		Description: util.RandomString(12),
+	}
+
+	journal, err := testQueries.CreateJournalEntry(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, journal)
+
+	require.Equal(t, arg.Kind, journal.Kind)
+	require.Equal(t, arg.Description, journal.Description)
+
+	require.NotZero(t, journal.ID)
+	require.NotZero(t, journal.CreatedAt)
+
+	return journal
+}
+
+// TestCreateJournalEntry tests the CreateJournalEntry function
+func TestCreateJournalEntry(t *testing.T) {
+	createRandomJournalEntry(t)
+}
+
+// TestGetJournalEntry tests the GetJournalEntry function
+func TestGetJournalEntry(t *testing.T) {
+	journal1 := createRandomJournalEntry(t)
+	journal2, err := testQueries.GetJournalEntry(context.Background(), journal1.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, journal2)
+
+	require.Equal(t, journal1.ID, journal2.ID)
+	require.Equal(t, journal1.Kind, journal2.Kind)
+	require.Equal(t, journal1.Description, journal2.Description)
+	require.WithinDuration(t, journal1.CreatedAt, journal2.CreatedAt, time.Second)
+}
+
+// TestPostJournalEntry tests that a journal entry with several postings is booked in one go
+func TestPostJournalEntry(t *testing.T) {
+	store := NewStore(testDB).(*SQLStore)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+	account3 := createRandomAccountWithCurrency(t, account1.Currency)
+
+	// a payment of 30 from account1, of which 5 goes to account3 as a fee
+	var result postJournalEntryResult
+	err := store.execTx(context.Background(), func(q *Queries) error {
+		var err error
+		result, err = postJournalEntry(context.Background(), q, postJournalEntryParams{
+			Kind:        JournalKindTransfer,
+			Description: "payment with fee",
+			Postings: []Posting{
+				{AccountID: account1.ID, Amount: -30},
+				{AccountID: account2.ID, Amount: 25},
+				{AccountID: account3.ID, Amount: 5},
+			},
+		})
+		return err
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Entries, 3)
+	require.Equal(t, account1.Balance-30, result.Accounts[account1.ID].Balance)
+	require.Equal(t, account2.Balance+25, result.Accounts[account2.ID].Balance)
+	require.Equal(t, account3.Balance+5, result.Accounts[account3.ID].Balance)
+
+	postings, err := store.ListJournalEntryPostings(context.Background(), sql.NullInt64{
+		Int64: result.JournalEntry.ID,
+		Valid: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, postings, 3)
+	for i, posting := range postings {
+		require.Equal(t, result.Entries[i].ID, posting.ID)
+	}
+}
+
+// TestPostJournalEntryUnbalanced tests that a journal entry whose postings don't sum up to zero is rejected on commit
+func TestPostJournalEntryUnbalanced(t *testing.T) {
+	store := NewStore(testDB).(*SQLStore)
+
+	account1 := createRandomAccountWithCurrency(t, "USD")
+	account2 := createRandomAccountWithCurrency(t, "USD")
+	account3 := createRandomAccountWithCurrency(t, "EUR")
+
+	testCases := []struct {
+		name     string
+		postings []Posting
+	}{
+		{
+			name: "AmountsDontMatch",
+			postings: []Posting{
+				{AccountID: account1.ID, Amount: -10},
+				{AccountID: account2.ID, Amount: 9},
+			},
+		},
+		{
+			name: "CurrenciesDontMatch",
+			postings: []Posting{
+				{AccountID: account1.ID, Amount: -10},
+				{AccountID: account3.ID, Amount: 10},
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			var journal postJournalEntryResult
+			err := store.execTx(context.Background(), func(q *Queries) error {
+				var err error
+				journal, err = postJournalEntry(context.Background(), q, postJournalEntryParams{
+					Kind:     JournalKindTransfer,
+					Postings: tc.postings,
+				})
+				return err
+			})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "not balanced")
+
+			// nothing of the journal entry was committed
+			_, err = store.GetJournalEntry(context.Background(), journal.JournalEntry.ID)
+			require.ErrorIs(t, err, sql.ErrNoRows)
+		})
+	}
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+}
+
+// TestPostJournalEntryTooFewPostings tests that a journal entry needs a debit and a credit
+func TestPostJournalEntryTooFewPostings(t *testing.T) {
+	account := createRandomAccount(t)
+
+	_, err := postJournalEntry(context.Background(), testQueries, postJournalEntryParams{
+		Kind:     JournalKindTransfer,
+		Postings: []Posting{{AccountID: account.ID, Amount: 10}},
+	})
+	require.ErrorIs(t, err, ErrTooFewPostings)
+}
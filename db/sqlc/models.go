@@ -5,6 +5,8 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 )
 
@@ -14,23 +16,451 @@ type Account struct {
 	Balance   int64     `json:"balance"`
 	Currency  string    `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
+	// checking, savings, pot for a sub-account of a checking or savings account, loan for the outstanding principal of a loan, escrow for the money held by an escrow, or internal for the accounts of the bank itself
+	Type string `json:"type"`
+	// organization holding the account, opened by the owner in its name
+	OrganizationID sql.NullInt64 `json:"organization_id"`
+}
+
+type AccountFee struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// first day of the month the fee is for
+	Period time.Time `json:"period"`
+	// fee due for the period
+	Amount int64 `json:"amount"`
+	// part of the fee taken from the balance
+	Charged int64 `json:"charged"`
+	// part of the fee the balance could not cover
+	Arrears int64 `json:"arrears"`
+	// charged, partial, arrears or waived
+	Status string `json:"status"`
+	// rule that waived the fee, e.g. minimum_balance
+	WaiverReason   string        `json:"waiver_reason"`
+	JournalEntryID sql.NullInt64 `json:"journal_entry_id"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+type AccountInterestPlan struct {
+	AccountID      int64     `json:"account_id"`
+	InterestPlanID int64     `json:"interest_plan_id"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type AccountMember struct {
+	AccountID int64  `json:"account_id"`
+	Username  string `json:"username"`
+	// view to read the account, transfer to also send money from it up to the transfer limit, or manage to act as a joint owner
+	Permission string `json:"permission"`
+	// largest single transfer a member with the transfer permission can make, 0 for no limit
+	TransferLimit int64     `json:"transfer_limit"`
+	GrantedBy     string    `json:"granted_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type AuditLog struct {
+	ID int64 `json:"id"`
+	// username of the caller, empty for anonymous calls
+	Actor string `json:"actor"`
+	// HTTP method and route, e.g. POST /transfers
+	Action    string `json:"action"`
+	Resource  string `json:"resource"`
+	RequestID string `json:"request_id"`
+	// state of the resource before the call, null if unknown
+	Before json.RawMessage `json:"before"`
+	// response body of the call
+	After     json.RawMessage `json:"after"`
+	CreatedAt time.Time       `json:"created_at"`
+	PrevHash  string          `json:"prev_hash"`
+	// hex SHA-256 of the row content and prev_hash
+	Hash string `json:"hash"`
+}
+
+type BalanceSnapshot struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// sum of the entries of the account created up to taken_at
+	Balance   int64     `json:"balance"`
+	TakenAt   time.Time `json:"taken_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Beneficiary struct {
+	ID        int64     `json:"id"`
+	Owner     string    `json:"owner"`
+	Nickname  string    `json:"nickname"`
+	AccountID int64     `json:"account_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Card struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// user the card was issued to
+	Holder string `json:"holder"`
+	// primary account number, 16 digits passing the Luhn check
+	Pan         string `json:"pan"`
+	ExpiryMonth int32  `json:"expiry_month"`
+	ExpiryYear  int32  `json:"expiry_year"`
+	// bcrypt hash of the card verification value, which is only shown when the card is issued
+	HashedCvv string `json:"hashed_cvv"`
+	// active, frozen to decline every authorization until it is unfrozen, or blocked after too many failed CVV attempts
+	Status string `json:"status"`
+	// most the card can spend per UTC day, 0 for no limit
+	SpendingLimit int64     `json:"spending_limit"`
+	CreatedAt     time.Time `json:"created_at"`
+	// authorizations declined for invalid card details in a row, reset by a valid one or a status change
+	FailedCvvAttempts int32 `json:"failed_cvv_attempts"`
+}
+
+type CardAuthorization struct {
+	ID       int64  `json:"id"`
+	CardID   int64  `json:"card_id"`
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	Merchant string `json:"merchant"`
+	// held while the amount is reserved on the account, cleared once the merchant settled it, released when the merchant dropped it, or declined
+	Status        string `json:"status"`
+	DeclineReason string `json:"decline_reason"`
+	// amount the merchant settled, up to the amount held
+	ClearedAmount int64 `json:"cleared_amount"`
+	// card journal entry debiting the account when the authorization was cleared
+	JournalEntryID sql.NullInt64 `json:"journal_entry_id"`
+	CreatedAt      time.Time     `json:"created_at"`
+	SettledAt      sql.NullTime  `json:"settled_at"`
+}
+
+type CategorizationRule struct {
+	ID         int64 `json:"id"`
+	CategoryID int64 `json:"category_id"`
+	// outgoing transfers to this account fall into the category
+	RecipientAccountID sql.NullInt64 `json:"recipient_account_id"`
+	// outgoing transfers whose description contains this text, ignoring case, fall into the category
+	MemoContains string    `json:"memo_contains"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type CategoryBudget struct {
+	CategoryID int64  `json:"category_id"`
+	Currency   string `json:"currency"`
+	// amount the owner plans to spend in the category each month, in the currency
+	Amount    int64     `json:"amount"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type DirectDebit struct {
+	ID        int64 `json:"id"`
+	MandateID int64 `json:"mandate_id"`
+	// transfer from the debtor to the creditor account that collected the amount
+	TransferID int64 `json:"transfer_id"`
+	Amount     int64 `json:"amount"`
+	// collected, or disputed by the debtor and refunded
+	Status        string `json:"status"`
+	DisputeReason string `json:"dispute_reason"`
+	// transfer refunding the debtor when the direct debit was disputed
+	ReversalID  sql.NullInt64 `json:"reversal_id"`
+	CollectedAt time.Time     `json:"collected_at"`
+	DisputedAt  sql.NullTime  `json:"disputed_at"`
 }
 
 type Entry struct {
 	ID        int64 `json:"id"`
 	AccountID int64 `json:"account_id"`
 	// can be negative or positive
+	Amount         int64         `json:"amount"`
+	CreatedAt      time.Time     `json:"created_at"`
+	TransferID     sql.NullInt64 `json:"transfer_id"`
+	JournalEntryID sql.NullInt64 `json:"journal_entry_id"`
+}
+
+type Escrow struct {
+	ID int64 `json:"id"`
+	// escrow account holding the amount, owned by the bank
+	AccountID      int64  `json:"account_id"`
+	PayerAccountID int64  `json:"payer_account_id"`
+	PayeeAccountID int64  `json:"payee_account_id"`
+	Amount         int64  `json:"amount"`
+	Description    string `json:"description"`
+	// held until the payer releases it, disputed by a party until an arbiter resolves it, then released to the payee or refunded to the payer
+	Status string `json:"status"`
+	// time after which an escrow still held is refunded to the payer
+	Deadline  time.Time `json:"deadline"`
+	CreatedBy string    `json:"created_by"`
+	// transfer from the payer to the escrow account
+	FundingTransferID int64 `json:"funding_transfer_id"`
+	// transfer from the escrow account to the payee when released, or back to the payer when refunded
+	SettlementTransferID sql.NullInt64  `json:"settlement_transfer_id"`
+	DisputeReason        string         `json:"dispute_reason"`
+	DisputedBy           sql.NullString `json:"disputed_by"`
+	// payer who released the escrow or arbiter who resolved its dispute, null when it was refunded at its deadline
+	SettledBy sql.NullString `json:"settled_by"`
+	// note of the arbiter resolving the dispute
+	ResolutionNote string       `json:"resolution_note"`
+	CreatedAt      time.Time    `json:"created_at"`
+	DisputedAt     sql.NullTime `json:"disputed_at"`
+	SettledAt      sql.NullTime `json:"settled_at"`
+}
+
+type FraudDecision struct {
+	ID            int64  `json:"id"`
+	Username      string `json:"username"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	Score         int32  `json:"score"`
+	// allow, review or block
+	Decision    string    `json:"decision"`
+	ReasonCodes []string  `json:"reason_codes"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type InterestAccrual struct {
+	ID          int64     `json:"id"`
+	AccountID   int64     `json:"account_id"`
+	AccrualDate time.Time `json:"accrual_date"`
+	// end of day balance the interest is computed on
+	Balance       int64  `json:"balance"`
+	AnnualRateBps int32  `json:"annual_rate_bps"`
+	DayCount      string `json:"day_count"`
+	// interest in millionths of a cent
+	Amount    int64     `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type InterestPlan struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// annual interest rate in basis points, 250 is 2.5%
+	AnnualRateBps int32 `json:"annual_rate_bps"`
+	// day count convention of the daily accruals: ACT/365, ACT/360 or 30/360
+	DayCount  string    `json:"day_count"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type InterestPosting struct {
+	ID        int64     `json:"id"`
+	AccountID int64     `json:"account_id"`
+	PeriodEnd time.Time `json:"period_end"`
+	// total interest accrued up to period_end in millionths of a cent
+	Accrued int64 `json:"accrued"`
+	// cents credited, or debited when negative for overdraft interest, the fractions of a cent are carried over to the next period
+	Amount         int64         `json:"amount"`
+	JournalEntryID sql.NullInt64 `json:"journal_entry_id"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+type JournalEntry struct {
+	ID int64 `json:"id"`
+	// what the journal entry records, e.g. transfer
+	Kind        string    `json:"kind"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Loan struct {
+	ID int64 `json:"id"`
+	// loan account, its negative balance is the principal left to repay
+	AccountID int64 `json:"account_id"`
+	// account the loan was disbursed to and installments are collected from
+	RepaymentAccountID int64 `json:"repayment_account_id"`
+	Principal          int64 `json:"principal"`
+	AnnualRateBps      int32 `json:"annual_rate_bps"`
+	TermMonths         int32 `json:"term_months"`
+	// annuity for equal installments, linear for equal principal repayments
+	Method string `json:"method"`
+	// active or repaid
+	Status         string       `json:"status"`
+	OriginatedBy   string       `json:"originated_by"`
+	JournalEntryID int64        `json:"journal_entry_id"`
+	CreatedAt      time.Time    `json:"created_at"`
+	RepaidAt       sql.NullTime `json:"repaid_at"`
+}
+
+type LoanInstallment struct {
+	ID        int64     `json:"id"`
+	LoanID    int64     `json:"loan_id"`
+	Number    int32     `json:"number"`
+	DueDate   time.Time `json:"due_date"`
+	Principal int64     `json:"principal"`
+	// interest due, reduced when the loan is repaid early
+	Interest      int64 `json:"interest"`
+	PaidPrincipal int64 `json:"paid_principal"`
+	PaidInterest  int64 `json:"paid_interest"`
+	// pending until due, paid, or arrears when it could not be collected in full on its due date
+	Status string       `json:"status"`
+	PaidAt sql.NullTime `json:"paid_at"`
+}
+
+type Mandate struct {
+	ID                int64 `json:"id"`
+	DebtorAccountID   int64 `json:"debtor_account_id"`
+	CreditorAccountID int64 `json:"creditor_account_id"`
+	// largest amount a single direct debit can collect
+	MaxAmount int64 `json:"max_amount"`
+	// daily, weekly, monthly or yearly: at most one direct debit is collected per period, counted from the previous one
+	Frequency string `json:"frequency"`
+	// reference of the mandate at the creditor, e.g. a contract number
+	Reference string `json:"reference"`
+	// active, or revoked by the debtor to stop any further direct debit
+	Status    string       `json:"status"`
+	GrantedBy string       `json:"granted_by"`
+	CreatedAt time.Time    `json:"created_at"`
+	RevokedAt sql.NullTime `json:"revoked_at"`
+}
+
+type Organization struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// approvals from approvers or owners that transfers initiated by an initiator need, 0 to execute them directly
+	RequiredApprovals int32 `json:"required_approvals"`
+	// transfers up to this amount are executed without approvals
+	ApprovalThreshold int64     `json:"approval_threshold"`
+	CreatedBy         string    `json:"created_by"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type OrganizationMember struct {
+	OrganizationID int64  `json:"organization_id"`
+	Username       string `json:"username"`
+	// owner to manage the organization and its accounts, approver to approve transfers, initiator to initiate them, or viewer
+	Role      string    `json:"role"`
+	AddedBy   string    `json:"added_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type OverdraftFacility struct {
+	AccountID int64 `json:"account_id"`
+	// how far below zero the balance can go, 0 once the facility is revoked
+	OverdraftLimit int64 `json:"overdraft_limit"`
+	// annual interest rate charged on negative balances in basis points, accrued ACT/365
+	AnnualRateBps int32 `json:"annual_rate_bps"`
+	// banker who last granted or revoked the facility
+	GrantedBy string    `json:"granted_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type OverdraftNotification struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// transfer that took the balance below zero
+	TransferID int64 `json:"transfer_id"`
+	// balance right after the transfer
+	Balance        int64     `json:"balance"`
+	OverdraftLimit int64     `json:"overdraft_limit"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type PaymentRequest struct {
+	ID          int64  `json:"id"`
+	Requester   string `json:"requester"`
+	Payer       string `json:"payer"`
+	ToAccountID int64  `json:"to_account_id"`
+	// must be positive
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	// pending, pending_approval while the payment waits for a banker, accepted or declined
+	Status     string        `json:"status"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	ExpiresAt  time.Time     `json:"expires_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type Pot struct {
+	ID int64 `json:"id"`
+	// pot account holding the money set aside
+	AccountID       int64  `json:"account_id"`
+	ParentAccountID int64  `json:"parent_account_id"`
+	Name            string `json:"name"`
+	// amount the owner is saving up to, 0 for none
+	TargetAmount int64 `json:"target_amount"`
+	// outgoing transfers of the parent account are rounded up to a multiple of it and the spare change is moved to the pot, 0 to disable
+	RoundUpTo int64     `json:"round_up_to"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ReconciliationAdjustment struct {
+	ID           int64 `json:"id"`
+	AccountID    int64 `json:"account_id"`
+	EntryID      int64 `json:"entry_id"`
+	Balance      int64 `json:"balance"`
+	EntriesTotal int64 `json:"entries_total"`
+	// balance minus entries total, booked as a correction entry
 	Amount    int64     `json:"amount"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SpendingCategory struct {
+	ID        int64     `json:"id"`
+	Owner     string    `json:"owner"`
+	Name      string    `json:"name"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type SystemAccount struct {
+	// what the internal account books, e.g. interest_expense
+	Purpose   string `json:"purpose"`
+	Currency  string `json:"currency"`
+	AccountID int64  `json:"account_id"`
+}
+
 type Transfer struct {
 	ID            int64 `json:"id"`
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
 	// must be positive
-	Amount    int64     `json:"amount"`
-	CreatedAt time.Time `json:"created_at"`
+	Amount      int64     `json:"amount"`
+	CreatedAt   time.Time `json:"created_at"`
+	Description string    `json:"description"`
+	// client-supplied, unique per sender
+	Reference string          `json:"reference"`
+	Metadata  json.RawMessage `json:"metadata"`
+}
+
+type TransferApproval struct {
+	ID            int64           `json:"id"`
+	FromAccountID int64           `json:"from_account_id"`
+	ToAccountID   int64           `json:"to_account_id"`
+	Amount        int64           `json:"amount"`
+	Currency      string          `json:"currency"`
+	Description   string          `json:"description"`
+	Reference     string          `json:"reference"`
+	Metadata      json.RawMessage `json:"metadata"`
+	Initiator     string          `json:"initiator"`
+	// pending_organization while approvers of the organization holding the account sign, pending_approval, approved or rejected
+	Status     string        `json:"status"`
+	Reviewer   string        `json:"reviewer"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	CreatedAt  time.Time     `json:"created_at"`
+	ReviewedAt time.Time     `json:"reviewed_at"`
+	// set when the fraud checks asked for a review, so that a banker reviews the transfer once the approvers of its organization signed it
+	FraudReview bool `json:"fraud_review"`
+	// payment request the transfer pays, accepted or declined together with the review
+	PaymentRequestID sql.NullInt64 `json:"payment_request_id"`
+}
+
+type TransferApprovalSignature struct {
+	TransferApprovalID int64     `json:"transfer_approval_id"`
+	Approver           string    `json:"approver"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+type TransferCategory struct {
+	TransferID int64 `json:"transfer_id"`
+	// category assigned by the sender, taking precedence over the categorization rules
+	CategoryID    int64     `json:"category_id"`
+	CategorizedAt time.Time `json:"categorized_at"`
+}
+
+type TransferReversal struct {
+	TransferID int64 `json:"transfer_id"`
+	// compensating transfer in the opposite direction
+	ReversalID int64     `json:"reversal_id"`
+	Reason     string    `json:"reason"`
+	ReversedBy string    `json:"reversed_by"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 type User struct {
@@ -40,4 +470,5 @@ type User struct {
 	Email             string    `json:"email"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	Role              string    `json:"role"`
 }
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrInsufficientFunds = errors.New("insufficient funds, the transfer would exceed the overdraft limit of the account")
+
+// checkOverdraft enforces the overdraft limit of the account a transfer was debited from,
+// given its balance after the transfer. Accounts without a facility can't go below zero,
+// and the money held by card authorizations can't be transferred.
+// When the transfer takes the balance from zero or above to below zero, the owner is notified.
+// It must be called inside the transaction of the transfer, which the balance update keeps the account locked in.
+func checkOverdraft(ctx context.Context, q *Queries, transfer Transfer, account Account) error {
+	available, err := availableBalance(ctx, q, account)
+	if err != nil {
+		return err
+	}
+
+	if available >= 0 {
+		return nil
+	}
+
+	limit, err := q.GetOverdraftLimit(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+
+	if available < -limit {
+		return ErrInsufficientFunds
+	}
+
+	if account.Balance >= 0 || account.Balance+transfer.Amount < 0 {
+		// only the holds are covered by the overdraft, or the account was already overdrawn
+		return nil
+	}
+
+	_, err = q.CreateOverdraftNotification(ctx, CreateOverdraftNotificationParams{
+		AccountID:      account.ID,
+		TransferID:     transfer.ID,
+		Balance:        account.Balance,
+		OverdraftLimit: limit,
+	})
+	return err
+}
+
+// availableBalance returns the balance of the account less the money held by its open card authorizations.
+func availableBalance(ctx context.Context, q *Queries, account Account) (int64, error) {
+	held, err := q.GetHeldAmount(ctx, account.ID)
+	if err != nil {
+		return 0, err
+	}
+	return account.Balance - held, nil
+}
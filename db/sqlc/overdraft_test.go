@@ -0,0 +1,139 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// grantOverdraft grants an overdraft facility with the given limit to an account for testing
+func grantOverdraft(t *testing.T, account Account, limit int64) OverdraftFacility {
+	banker := createRandomUser(t)
+
+	arg := GrantOverdraftFacilityParams{
+		AccountID:      account.ID,
+		OverdraftLimit: limit,
+		AnnualRateBps:  1900,
+		GrantedBy:      banker.Username,
+	}
+
+	facility, err := testQueries.GrantOverdraftFacility(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.AccountID, facility.AccountID)
+	require.Equal(t, arg.OverdraftLimit, facility.OverdraftLimit)
+	require.Equal(t, arg.AnnualRateBps, facility.AnnualRateBps)
+	require.Equal(t, arg.GrantedBy, facility.GrantedBy)
+
+	return facility
+}
+
+func TestGrantOverdraftFacility(t *testing.T) {
+	account := createRandomAccount(t)
+
+	limit, err := testQueries.GetOverdraftLimit(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Zero(t, limit)
+
+	facility1 := grantOverdraft(t, account, 1000)
+
+	// granting again changes the terms of the facility
+	facility2 := grantOverdraft(t, account, 5000)
+	require.Equal(t, facility1.CreatedAt, facility2.CreatedAt)
+	require.True(t, facility2.UpdatedAt.After(facility1.UpdatedAt))
+
+	limit, err = testQueries.GetOverdraftLimit(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(5000), limit)
+}
+
+func TestRevokeOverdraftFacility(t *testing.T) {
+	account := createRandomAccount(t)
+	facility := grantOverdraft(t, account, 1000)
+	banker := createRandomUser(t)
+
+	revoked, err := testQueries.RevokeOverdraftFacility(context.Background(), RevokeOverdraftFacilityParams{
+		AccountID: account.ID,
+		GrantedBy: banker.Username,
+	})
+	require.NoError(t, err)
+	require.Zero(t, revoked.OverdraftLimit)
+	require.Equal(t, facility.AnnualRateBps, revoked.AnnualRateBps)
+	require.Equal(t, banker.Username, revoked.GrantedBy)
+
+	limit, err := testQueries.GetOverdraftLimit(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Zero(t, limit)
+}
+
+// TestTransferTxOverdraft tests that transfers can take an account below zero only within its overdraft limit,
+// and that the owner is notified when the account goes below zero
+func TestTransferTxOverdraft(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithBalance(t, "USD", 100)
+	account2 := createAccountWithBalance(t, "USD", 0)
+	grantOverdraft(t, account1, 500)
+
+	transferTo := func(amount int64) (TransferTxResult, error) {
+		return store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+		})
+	}
+
+	result, err := transferTo(300)
+	require.NoError(t, err)
+	require.Equal(t, int64(-200), result.FromAccount.Balance)
+
+	// the account is already overdrawn, no new notification
+	result, err = transferTo(200)
+	require.NoError(t, err)
+	require.Equal(t, int64(-400), result.FromAccount.Balance)
+
+	// the transfer would exceed the limit and is rolled back
+	_, err = transferTo(200)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	account1, err = store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(-400), account1.Balance)
+
+	account2, err = store.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(500), account2.Balance)
+
+	notifications, err := testQueries.ListOverdraftNotifications(context.Background(), ListOverdraftNotificationsParams{
+		AccountID: account1.ID,
+		Limit:     5,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	require.Equal(t, int64(-200), notifications[0].Balance)
+	require.Equal(t, int64(500), notifications[0].OverdraftLimit)
+}
+
+// TestTransferTxWithoutOverdraft tests that accounts without a facility can't go below zero
+func TestTransferTxWithoutOverdraft(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithBalance(t, "EUR", 100)
+	account2 := createAccountWithBalance(t, "EUR", 0)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        101,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.FromAccount.Balance)
+}
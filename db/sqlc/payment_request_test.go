@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+// createRandomPaymentRequest creates a random payment request for testing
+func createRandomPaymentRequest(t *testing.T) PaymentRequest {
+	account := createRandomAccount(t)
+	payer := createRandomUser(t)
+
+	arg := CreatePaymentRequestParams{
+		Requester:   account.Owner,
+		Payer:       payer.Username,
+		ToAccountID: account.ID,
+		Amount:      util.RandomInt(1, 100),
+		Currency:    account.Currency,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	request, err := testQueries.CreatePaymentRequest(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, request)
+
+	require.Equal(t, arg.Requester, request.Requester)
+	require.Equal(t, arg.Payer, request.Payer)
+	require.Equal(t, arg.ToAccountID, request.ToAccountID)
+	require.Equal(t, arg.Amount, request.Amount)
+	require.Equal(t, arg.Currency, request.Currency)
+	require.Equal(t, PaymentRequestStatusPending, request.Status)
+	require.False(t, request.TransferID.Valid)
+	require.WithinDuration(t, arg.ExpiresAt, request.ExpiresAt, time.Second)
+
+	require.NotZero(t, request.ID)
+	require.NotZero(t, request.CreatedAt)
+
+	return request
+}
+
+// TestCreatePaymentRequest tests the CreatePaymentRequest function
+func TestCreatePaymentRequest(t *testing.T) {
+	createRandomPaymentRequest(t)
+}
+
+// TestGetPaymentRequest tests the GetPaymentRequest function
+func TestGetPaymentRequest(t *testing.T) {
+	request1 := createRandomPaymentRequest(t)
+	request2, err := testQueries.GetPaymentRequest(context.Background(), request1.ID)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, request2)
+
+	require.Equal(t, request1.ID, request2.ID)
+	require.Equal(t, request1.Requester, request2.Requester)
+	require.Equal(t, request1.Payer, request2.Payer)
+	require.Equal(t, request1.Amount, request2.Amount)
+	require.Equal(t, request1.Status, request2.Status)
+
+	require.WithinDuration(t, request1.CreatedAt, request2.CreatedAt, time.Second)
+}
+
+// TestListPaymentRequests tests that both requesters and payers see a request
+func TestListPaymentRequests(t *testing.T) {
+	request := createRandomPaymentRequest(t)
+
+	for _, username := range []string{request.Requester, request.Payer} {
+		requests, err := testQueries.ListPaymentRequests(context.Background(), ListPaymentRequestsParams{
+			Requester: username,
+			Payer:     username,
+			Limit:     5,
+			Offset:    0,
+		})
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+		require.Equal(t, request.ID, requests[0].ID)
+	}
+}
+
+// TestDeclinePaymentRequest tests that only pending requests can be declined
+func TestDeclinePaymentRequest(t *testing.T) {
+	request1 := createRandomPaymentRequest(t)
+
+	request2, err := testQueries.DeclinePaymentRequest(context.Background(), request1.ID)
+	require.NoError(t, err)
+	require.Equal(t, PaymentRequestStatusDeclined, request2.Status)
+
+	_, err = testQueries.DeclinePaymentRequest(context.Background(), request1.ID)
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
@@ -0,0 +1,216 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DefaultReconciliationChunkSize is the number of rows read per query when no chunk size is given
+const DefaultReconciliationChunkSize = 500
+
+// ReconcileParams contains the input parameters of the ledger reconciliation
+type ReconcileParams struct {
+	ChunkSize int32 `json:"chunk_size"`
+	// Correct books a correction entry for every account whose balance drifted from its entries
+	Correct bool   `json:"correct"`
+	Reason  string `json:"reason"`
+}
+
+// AccountDrift describes an account whose balance doesn't match the sum of its entries
+type AccountDrift struct {
+	AccountID    int64  `json:"account_id"`
+	Currency     string `json:"currency"`
+	Balance      int64  `json:"balance"`
+	EntriesTotal int64  `json:"entries_total"`
+	// Adjustment is set when a correction entry was booked for the drift
+	Adjustment *ReconciliationAdjustment `json:"adjustment,omitempty"`
+}
+
+// Amount returns the difference between the balance and the entries of the account
+func (drift AccountDrift) Amount() int64 {
+	return drift.Balance - drift.EntriesTotal
+}
+
+// ReconciliationReport is the result of the ledger reconciliation
+type ReconciliationReport struct {
+	AccountsChecked  int            `json:"accounts_checked"`
+	TransfersChecked int            `json:"transfers_checked"`
+	AccountDrifts    []AccountDrift `json:"account_drifts"`
+	// UnbalancedTransfers lists transfers without exactly one debit and one credit entry
+	UnbalancedTransfers []ListTransferEntryCountsRow `json:"unbalanced_transfers"`
+	// CurrencyImbalances lists currencies whose journal postings don't sum up to zero
+	CurrencyImbalances []ListCurrencyEntryTotalsRow `json:"currency_imbalances"`
+}
+
+// Balanced reports whether the reconciliation found no problems.
+// Drifts that were corrected still count as problems, since they were found by this run.
+func (report ReconciliationReport) Balanced() bool {
+	return len(report.AccountDrifts) == 0 &&
+		len(report.UnbalancedTransfers) == 0 &&
+		len(report.CurrencyImbalances) == 0
+}
+
+// Reconcile verifies the ledger invariants:
+// every account balance equals the sum of its entries, every transfer has exactly one debit and one credit entry,
+// and the journal postings of each currency sum up to zero.
+// Accounts and transfers are scanned in chunks so that the check doesn't hold long-running locks.
+func (store *SQLStore) Reconcile(ctx context.Context, arg ReconcileParams) (ReconciliationReport, error) {
+	report := ReconciliationReport{
+		AccountDrifts:       []AccountDrift{},
+		UnbalancedTransfers: []ListTransferEntryCountsRow{},
+		CurrencyImbalances:  []ListCurrencyEntryTotalsRow{},
+	}
+
+	if arg.Correct && arg.Reason == "" {
+		return report, errors.New("a reason is required to correct the ledger")
+	}
+
+	chunkSize := arg.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultReconciliationChunkSize
+	}
+
+	var afterID int64
+	for {
+		accounts, err := store.ListAccountLedgerTotals(ctx, ListAccountLedgerTotalsParams{
+			AfterID:   afterID,
+			ChunkSize: chunkSize,
+		})
+		if err != nil {
+			return report, err
+		}
+
+		for _, account := range accounts {
+			if account.Balance == account.EntriesTotal {
+				continue
+			}
+
+			drift := AccountDrift{
+				AccountID:    account.ID,
+				Currency:     account.Currency,
+				Balance:      account.Balance,
+				EntriesTotal: account.EntriesTotal,
+			}
+
+			if arg.Correct {
+				drift.Adjustment, err = store.correctAccountDrift(ctx, account.ID, arg.Reason)
+				if err != nil {
+					return report, err
+				}
+			}
+
+			report.AccountDrifts = append(report.AccountDrifts, drift)
+		}
+
+		report.AccountsChecked += len(accounts)
+		if len(accounts) < int(chunkSize) {
+			break
+		}
+		afterID = accounts[len(accounts)-1].ID
+	}
+
+	afterID = 0
+	for {
+		transfers, err := store.ListTransferEntryCounts(ctx, ListTransferEntryCountsParams{
+			AfterID:   afterID,
+			ChunkSize: chunkSize,
+		})
+		if err != nil {
+			return report, err
+		}
+
+		for _, transfer := range transfers {
+			if transfer.EntryCount != 2 || transfer.DebitCount != 1 || transfer.CreditCount != 1 {
+				report.UnbalancedTransfers = append(report.UnbalancedTransfers, transfer)
+			}
+		}
+
+		report.TransfersChecked += len(transfers)
+		if len(transfers) < int(chunkSize) {
+			break
+		}
+		afterID = transfers[len(transfers)-1].ID
+	}
+
+	totals, err := store.ListCurrencyEntryTotals(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	for _, total := range totals {
+		if total.Total != 0 {
+			report.CurrencyImbalances = append(report.CurrencyImbalances, total)
+		}
+	}
+
+	return report, nil
+}
+
+// correctAccountDrift books a correction journal entry that brings the entries of an account in line with its balance,
+// together with an audit record of the adjustment. The other leg goes to the reconciliation suspense account of
+// the currency, so that the journal stays balanced and the correction shows up in the currency totals.
+// The drift is measured again with the account locked, so nil is returned if it was resolved in the meantime.
+func (store *SQLStore) correctAccountDrift(ctx context.Context, accountID int64, reason string) (*ReconciliationAdjustment, error) {
+	var adjustment *ReconciliationAdjustment
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+
+		entriesTotal, err := q.GetEntriesTotal(ctx, accountID)
+		if err != nil {
+			return err
+		}
+
+		amount := account.Balance - entriesTotal
+		if amount == 0 {
+			return nil
+		}
+
+		suspense, err := q.GetSystemAccount(ctx, GetSystemAccountParams{
+			Purpose:  SystemAccountReconciliationSuspense,
+			Currency: account.Currency,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot get the reconciliation suspense account for %s: %w", account.Currency, err)
+		}
+
+		if suspense.AccountID == accountID {
+			return fmt.Errorf("account [%d] is the reconciliation suspense account, it can't be corrected against itself", accountID)
+		}
+
+		// the balance of the account already holds the amount, only its entry is missing
+		journal, err := postJournalEntry(ctx, q, postJournalEntryParams{
+			Kind:        JournalKindReconciliation,
+			Description: reason,
+			Postings: []Posting{
+				{AccountID: accountID, Amount: amount},
+				{AccountID: suspense.AccountID, Amount: -amount},
+			},
+			BookedAccounts: map[int64]bool{accountID: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result, err := q.CreateReconciliationAdjustment(ctx, CreateReconciliationAdjustmentParams{
+			AccountID:    accountID,
+			EntryID:      journal.Entries[0].ID,
+			Balance:      account.Balance,
+			EntriesTotal: entriesTotal,
+			Amount:       amount,
+			Reason:       reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		adjustment = &result
+		return nil
+	})
+
+	return adjustment, err
+}
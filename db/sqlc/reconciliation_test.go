@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+func findAccountDrift(report ReconciliationReport, accountID int64) *AccountDrift {
+	for i := range report.AccountDrifts {
+		if report.AccountDrifts[i].AccountID == accountID {
+			return &report.AccountDrifts[i]
+		}
+	}
+	return nil
+}
+
+func TestReconcile(t *testing.T) {
+	store := NewStore(testDB)
+
+	// accounts opened with a balance have no entries backing it
+	account, err := testQueries.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+		ID:     createRandomAccount(t).ID,
+		Amount: util.RandomInt(1, 1000),
+	})
+	require.NoError(t, err)
+
+	report, err := store.Reconcile(context.Background(), ReconcileParams{ChunkSize: 10})
+	require.NoError(t, err)
+	require.False(t, report.Balanced())
+	require.NotZero(t, report.AccountsChecked)
+
+	drift := findAccountDrift(report, account.ID)
+	require.NotNil(t, drift)
+	require.Equal(t, account.Balance, drift.Balance)
+	require.Equal(t, int64(0), drift.EntriesTotal)
+	require.Equal(t, account.Balance, drift.Amount())
+	require.Nil(t, drift.Adjustment)
+}
+
+func TestReconcileCorrection(t *testing.T) {
+	store := NewStore(testDB)
+
+	account := createRandomAccount(t)
+	createRandomEntry(t, account)
+
+	entriesTotal, err := testQueries.GetEntriesTotal(context.Background(), account.ID)
+	require.NoError(t, err)
+
+	_, err = store.Reconcile(context.Background(), ReconcileParams{Correct: true})
+	require.Error(t, err)
+
+	reason := "test correction " + util.RandomString(6)
+	report, err := store.Reconcile(context.Background(), ReconcileParams{
+		Correct: true,
+		Reason:  reason,
+	})
+	require.NoError(t, err)
+
+	drift := findAccountDrift(report, account.ID)
+	require.NotNil(t, drift)
+	require.NotNil(t, drift.Adjustment)
+	require.Equal(t, account.Balance-entriesTotal, drift.Adjustment.Amount)
+	require.Equal(t, reason, drift.Adjustment.Reason)
+
+	adjustment, err := testQueries.GetReconciliationAdjustment(context.Background(), drift.Adjustment.ID)
+	require.NoError(t, err)
+	require.Equal(t, *drift.Adjustment, adjustment)
+
+	entry, err := testQueries.GetEntry(context.Background(), adjustment.EntryID)
+	require.NoError(t, err)
+	require.Equal(t, account.ID, entry.AccountID)
+	require.Equal(t, adjustment.Amount, entry.Amount)
+	require.False(t, entry.TransferID.Valid)
+
+	// the correction is a balanced journal entry against the suspense account, which doesn't drift itself
+	require.True(t, entry.JournalEntryID.Valid)
+	journal, err := testQueries.GetJournalEntry(context.Background(), entry.JournalEntryID.Int64)
+	require.NoError(t, err)
+	require.Equal(t, JournalKindReconciliation, journal.Kind)
+
+	suspense, err := testQueries.GetSystemAccount(context.Background(), GetSystemAccountParams{
+		Purpose:  SystemAccountReconciliationSuspense,
+		Currency: account.Currency,
+	})
+	require.NoError(t, err)
+
+	// the balance of the corrected account is left as it was
+	corrected, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance, corrected.Balance)
+
+	// the corrected account is consistent on the next run, and so are the suspense account and the currency
+	report, err = store.Reconcile(context.Background(), ReconcileParams{})
+	require.NoError(t, err)
+	require.Nil(t, findAccountDrift(report, account.ID))
+	require.Nil(t, findAccountDrift(report, suspense.AccountID))
+	for _, imbalance := range report.CurrencyImbalances {
+		require.NotEqual(t, account.Currency, imbalance.Currency)
+	}
+}
+
+func TestReconcileTransfers(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, result.Transfer.ID, result.FromEntry.TransferID.Int64)
+	require.Equal(t, result.Transfer.ID, result.ToEntry.TransferID.Int64)
+
+	// a transfer record without entries
+	transfer := createTransferBetweenAccounts(t, account1.ID, account2.ID)
+
+	report, err := store.Reconcile(context.Background(), ReconcileParams{ChunkSize: 10})
+	require.NoError(t, err)
+	require.NotZero(t, report.TransfersChecked)
+
+	var unbalanced []int64
+	for _, row := range report.UnbalancedTransfers {
+		unbalanced = append(unbalanced, row.ID)
+	}
+	require.Contains(t, unbalanced, transfer.ID)
+	require.NotContains(t, unbalanced, result.Transfer.ID)
+
+	for _, total := range report.CurrencyImbalances {
+		require.NotZero(t, total.Total)
+	}
+}
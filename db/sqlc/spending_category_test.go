@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+func createRandomSpendingCategory(t *testing.T, owner string) SpendingCategory {
+	arg := CreateSpendingCategoryParams{
+		Owner: owner,
+		Name:  util.RandomString(8),
+	}
+
+	category, err := testQueries.CreateSpendingCategory(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, category.ID)
+	require.Equal(t, arg.Owner, category.Owner)
+	require.Equal(t, arg.Name, category.Name)
+	require.NotZero(t, category.CreatedAt)
+
+	return category
+}
+
+func TestSetCategoryBudget(t *testing.T) {
+	user := createRandomUser(t)
+	category := createRandomSpendingCategory(t, user.Username)
+
+	budget, err := testQueries.SetCategoryBudget(context.Background(), SetCategoryBudgetParams{
+		CategoryID: category.ID,
+		Currency:   "EUR",
+		Amount:     300,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(300), budget.Amount)
+
+	// setting it again replaces the amount
+	budget, err = testQueries.SetCategoryBudget(context.Background(), SetCategoryBudgetParams{
+		CategoryID: category.ID,
+		Currency:   "EUR",
+		Amount:     450,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(450), budget.Amount)
+
+	budgets, err := testQueries.ListCategoryBudgets(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, []CategoryBudget{budget}, budgets)
+
+	err = testQueries.DeleteCategoryBudget(context.Background(), DeleteCategoryBudgetParams{
+		CategoryID: category.ID,
+		Currency:   "EUR",
+	})
+	require.NoError(t, err)
+
+	budgets, err = testQueries.ListCategoryBudgets(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Empty(t, budgets)
+}
+
+func TestListMonthlySpending(t *testing.T) {
+	store := NewStore(testDB)
+	from := createAccountWithBalance(t, "EUR", 1000)
+	landlord := createAccountWithBalance(t, "EUR", 0)
+	cafe := createAccountWithBalance(t, "EUR", 0)
+	shop := createAccountWithBalance(t, "EUR", 0)
+
+	rent := createRandomSpendingCategory(t, from.Owner)
+	coffee := createRandomSpendingCategory(t, from.Owner)
+	gifts := createRandomSpendingCategory(t, from.Owner)
+
+	_, err := testQueries.CreateCategorizationRule(context.Background(), CreateCategorizationRuleParams{
+		CategoryID:         rent.ID,
+		RecipientAccountID: sql.NullInt64{Int64: landlord.ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.CreateCategorizationRule(context.Background(), CreateCategorizationRuleParams{
+		CategoryID:   coffee.ID,
+		MemoContains: "Coffee",
+	})
+	require.NoError(t, err)
+
+	transfer := func(to Account, amount int64, description string) Transfer {
+		result, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: from.ID,
+			ToAccountID:   to.ID,
+			Amount:        amount,
+			Description:   description,
+		})
+		require.NoError(t, err)
+		return result.Transfer
+	}
+
+	transfer(landlord, 500, "june rent")
+	transfer(cafe, 4, "morning coffee")
+	transfer(cafe, 6, "COFFEE and cake")
+	// the rule on the recipient wins over the rule on the memo
+	transfer(landlord, 10, "coffee machine repair")
+	transfer(shop, 30, "groceries")
+	present := transfer(shop, 50, "coffee mug")
+	mistake := transfer(shop, 70, "wrong amount")
+
+	_, err = testQueries.SetTransferCategory(context.Background(), SetTransferCategoryParams{
+		TransferID: present.ID,
+		CategoryID: gifts.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: mistake.ID,
+		Reason:     "wrong amount",
+		ReversedBy: from.Owner,
+	})
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	rows, err := testQueries.ListMonthlySpending(context.Background(), ListMonthlySpendingParams{
+		Owner:    from.Owner,
+		FromTime: month.AddDate(0, -1, 0),
+		ToTime:   month.AddDate(0, 1, 0),
+	})
+	require.NoError(t, err)
+
+	spent := make(map[int64]int64)
+	transfers := make(map[int64]int64)
+	for _, row := range rows {
+		require.Equal(t, month, row.Month.UTC())
+		require.Equal(t, "EUR", row.Currency)
+		spent[row.CategoryID.Int64] += row.Spent
+		transfers[row.CategoryID.Int64] += row.TransferCount
+	}
+
+	require.Equal(t, int64(510), spent[rent.ID])
+	require.Equal(t, int64(2), transfers[rent.ID])
+	require.Equal(t, int64(10), spent[coffee.ID])
+	require.Equal(t, int64(2), transfers[coffee.ID])
+	require.Equal(t, int64(50), spent[gifts.ID])
+	// the reversed transfer isn't spending
+	require.Equal(t, int64(30), spent[0])
+	require.Equal(t, int64(1), transfers[0])
+}
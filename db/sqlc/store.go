@@ -3,7 +3,9 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"time"
 )
 
 // Store interface defines all functions to execute db queries and transactions
@@ -13,11 +15,135 @@ type Store interface {
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
-	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
 	DeleteAccount(ctx context.Context, id int64) error
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	SearchTransfers(ctx context.Context, arg SearchTransfersParams) ([]Transfer, error)
+	CountTransfersSince(ctx context.Context, arg CountTransfersSinceParams) (int64, error)
+	CountTransfersToAccount(ctx context.Context, arg CountTransfersToAccountParams) (int64, error)
+	GetTransferAmountStats(ctx context.Context, owner string) (GetTransferAmountStatsRow, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (PaymentRequest, error)
+	GetPaymentRequest(ctx context.Context, id int64) (PaymentRequest, error)
+	ListPaymentRequests(ctx context.Context, arg ListPaymentRequestsParams) ([]PaymentRequest, error)
+	DeclinePaymentRequest(ctx context.Context, id int64) (PaymentRequest, error)
+	AcceptPaymentRequestTx(ctx context.Context, arg AcceptPaymentRequestTxParams) (AcceptPaymentRequestTxResult, error)
+	RequestPaymentApprovalTx(ctx context.Context, arg RequestPaymentApprovalTxParams) (RequestPaymentApprovalTxResult, error)
+	CreateBeneficiary(ctx context.Context, arg CreateBeneficiaryParams) (Beneficiary, error)
+	GetBeneficiary(ctx context.Context, id int64) (Beneficiary, error)
+	GetBeneficiaryByAccount(ctx context.Context, arg GetBeneficiaryByAccountParams) (Beneficiary, error)
+	ListBeneficiaries(ctx context.Context, arg ListBeneficiariesParams) ([]Beneficiary, error)
+	UpdateBeneficiary(ctx context.Context, arg UpdateBeneficiaryParams) (Beneficiary, error)
+	DeleteBeneficiary(ctx context.Context, id int64) error
+	CreateTransferApproval(ctx context.Context, arg CreateTransferApprovalParams) (TransferApproval, error)
+	GetTransferApproval(ctx context.Context, id int64) (TransferApproval, error)
+	ListTransferApprovals(ctx context.Context, arg ListTransferApprovalsParams) ([]TransferApproval, error)
+	ReviewTransferApprovalTx(ctx context.Context, arg ReviewTransferApprovalTxParams) (ReviewTransferApprovalTxResult, error)
+	CreateFraudDecision(ctx context.Context, arg CreateFraudDecisionParams) (FraudDecision, error)
+	Reconcile(ctx context.Context, arg ReconcileParams) (ReconciliationReport, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error)
+	AppendAuditLogTx(ctx context.Context, arg AppendAuditLogParams) (AuditLog, error)
+	VerifyAuditLog(ctx context.Context, chunkSize int32) (AuditLogVerification, error)
+	GetBalanceAsOf(ctx context.Context, arg GetBalanceAsOfParams) (int64, error)
+	CreateBalanceSnapshots(ctx context.Context, takenAt time.Time) (int64, error)
+	ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error)
+	TransferBatchTx(ctx context.Context, arg TransferBatchTxParams) (TransferBatchTxResult, error)
+	CreateInterestPlan(ctx context.Context, arg CreateInterestPlanParams) (InterestPlan, error)
+	ListInterestPlans(ctx context.Context, arg ListInterestPlansParams) ([]InterestPlan, error)
+	CreateSavingsAccountTx(ctx context.Context, arg CreateSavingsAccountTxParams) (CreateSavingsAccountTxResult, error)
+	ListInterestBearingAccounts(ctx context.Context, dayEnd time.Time) ([]ListInterestBearingAccountsRow, error)
+	CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (int64, error)
+	ListAccruingAccounts(ctx context.Context, arg ListAccruingAccountsParams) ([]int64, error)
+	PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error)
+	ListBillableAccounts(ctx context.Context, periodEnd time.Time) ([]ListBillableAccountsRow, error)
+	ChargeFeeTx(ctx context.Context, arg ChargeFeeTxParams) (ChargeFeeTxResult, error)
+	ListAccountFees(ctx context.Context, arg ListAccountFeesParams) ([]AccountFee, error)
+	GetOverdraftLimit(ctx context.Context, accountID int64) (int64, error)
+	GetOverdraftFacility(ctx context.Context, accountID int64) (OverdraftFacility, error)
+	GrantOverdraftFacility(ctx context.Context, arg GrantOverdraftFacilityParams) (OverdraftFacility, error)
+	RevokeOverdraftFacility(ctx context.Context, arg RevokeOverdraftFacilityParams) (OverdraftFacility, error)
+	ListOverdraftNotifications(ctx context.Context, arg ListOverdraftNotificationsParams) ([]OverdraftNotification, error)
+	ListOverdraftAccounts(ctx context.Context, dayEnd time.Time) ([]ListOverdraftAccountsRow, error)
+	GetLoan(ctx context.Context, id int64) (Loan, error)
+	ListLoans(ctx context.Context, arg ListLoansParams) ([]Loan, error)
+	ListLoanInstallments(ctx context.Context, loanID int64) ([]LoanInstallment, error)
+	OriginateLoanTx(ctx context.Context, arg OriginateLoanTxParams) (OriginateLoanTxResult, error)
+	RepayLoanTx(ctx context.Context, arg RepayLoanTxParams) (RepayLoanTxResult, error)
+	ListDueLoanInstallments(ctx context.Context, day time.Time) ([]ListDueLoanInstallmentsRow, error)
+	CollectLoanInstallmentTx(ctx context.Context, arg CollectLoanInstallmentTxParams) (CollectLoanInstallmentTxResult, error)
+	CreatePotTx(ctx context.Context, arg CreatePotTxParams) (CreatePotTxResult, error)
+	GetPot(ctx context.Context, id int64) (Pot, error)
+	ListPots(ctx context.Context, parentAccountID int64) ([]ListPotsRow, error)
+	ListPotBalances(ctx context.Context, owner string) ([]ListPotBalancesRow, error)
+	UpdatePot(ctx context.Context, arg UpdatePotParams) (Pot, error)
+	MovePotTx(ctx context.Context, arg MovePotTxParams) (MovePotTxResult, error)
+	GrantAccountMember(ctx context.Context, arg GrantAccountMemberParams) (AccountMember, error)
+	GetAccountMember(ctx context.Context, arg GetAccountMemberParams) (AccountMember, error)
+	ListAccountMembers(ctx context.Context, accountID int64) ([]AccountMember, error)
+	RevokeAccountMember(ctx context.Context, arg RevokeAccountMemberParams) (AccountMember, error)
+	ListOrganizationAccounts(ctx context.Context, arg ListOrganizationAccountsParams) ([]Account, error)
+	CreateOrganizationTx(ctx context.Context, arg CreateOrganizationTxParams) (CreateOrganizationTxResult, error)
+	GetOrganization(ctx context.Context, id int64) (Organization, error)
+	UpdateOrganizationPolicy(ctx context.Context, arg UpdateOrganizationPolicyParams) (Organization, error)
+	ListUserOrganizations(ctx context.Context, username string) ([]ListUserOrganizationsRow, error)
+	AddOrganizationMember(ctx context.Context, arg AddOrganizationMemberParams) (OrganizationMember, error)
+	GetOrganizationMember(ctx context.Context, arg GetOrganizationMemberParams) (OrganizationMember, error)
+	ListOrganizationMembers(ctx context.Context, organizationID int64) ([]OrganizationMember, error)
+	CountOrganizationOwners(ctx context.Context, organizationID int64) (int64, error)
+	RemoveOrganizationMember(ctx context.Context, arg RemoveOrganizationMemberParams) (OrganizationMember, error)
+	ListOrganizationTransferApprovals(ctx context.Context, arg ListOrganizationTransferApprovalsParams) ([]TransferApproval, error)
+	SignTransferApprovalTx(ctx context.Context, arg SignTransferApprovalTxParams) (SignTransferApprovalTxResult, error)
+	CreateCard(ctx context.Context, arg CreateCardParams) (Card, error)
+	GetCard(ctx context.Context, id int64) (Card, error)
+	GetCardByPAN(ctx context.Context, pan string) (Card, error)
+	ListCards(ctx context.Context, accountID int64) ([]Card, error)
+	UpdateCardStatus(ctx context.Context, arg UpdateCardStatusParams) (Card, error)
+	UpdateCardSpendingLimit(ctx context.Context, arg UpdateCardSpendingLimitParams) (Card, error)
+	IncrementCardFailedCVVAttempts(ctx context.Context, arg IncrementCardFailedCVVAttemptsParams) (Card, error)
+	ResetCardFailedCVVAttempts(ctx context.Context, id int64) error
+	CreateCardAuthorization(ctx context.Context, arg CreateCardAuthorizationParams) (CardAuthorization, error)
+	GetCardAuthorization(ctx context.Context, id int64) (CardAuthorization, error)
+	ListCardAuthorizations(ctx context.Context, arg ListCardAuthorizationsParams) ([]CardAuthorization, error)
+	ReleaseCardAuthorization(ctx context.Context, id int64) (CardAuthorization, error)
+	AuthorizeCardTx(ctx context.Context, arg AuthorizeCardTxParams) (AuthorizeCardTxResult, error)
+	ClearCardAuthorizationTx(ctx context.Context, arg ClearCardAuthorizationTxParams) (ClearCardAuthorizationTxResult, error)
+	CreateMandate(ctx context.Context, arg CreateMandateParams) (Mandate, error)
+	GetMandate(ctx context.Context, id int64) (Mandate, error)
+	ListMandates(ctx context.Context, arg ListMandatesParams) ([]Mandate, error)
+	RevokeMandate(ctx context.Context, id int64) (Mandate, error)
+	GetDirectDebit(ctx context.Context, id int64) (DirectDebit, error)
+	ListDirectDebits(ctx context.Context, arg ListDirectDebitsParams) ([]DirectDebit, error)
+	CollectDirectDebitTx(ctx context.Context, arg CollectDirectDebitTxParams) (CollectDirectDebitTxResult, error)
+	DisputeDirectDebitTx(ctx context.Context, arg DisputeDirectDebitTxParams) (DisputeDirectDebitTxResult, error)
+	CreateSpendingCategory(ctx context.Context, arg CreateSpendingCategoryParams) (SpendingCategory, error)
+	GetSpendingCategory(ctx context.Context, id int64) (SpendingCategory, error)
+	ListSpendingCategories(ctx context.Context, owner string) ([]SpendingCategory, error)
+	SetCategoryBudget(ctx context.Context, arg SetCategoryBudgetParams) (CategoryBudget, error)
+	GetCategoryBudget(ctx context.Context, arg GetCategoryBudgetParams) (CategoryBudget, error)
+	ListCategoryBudgets(ctx context.Context, owner string) ([]CategoryBudget, error)
+	DeleteCategoryBudget(ctx context.Context, arg DeleteCategoryBudgetParams) error
+	CreateCategorizationRule(ctx context.Context, arg CreateCategorizationRuleParams) (CategorizationRule, error)
+	GetCategorizationRule(ctx context.Context, id int64) (CategorizationRule, error)
+	ListCategorizationRules(ctx context.Context, owner string) ([]CategorizationRule, error)
+	DeleteCategorizationRule(ctx context.Context, id int64) error
+	SetTransferCategory(ctx context.Context, arg SetTransferCategoryParams) (TransferCategory, error)
+	GetTransferCategory(ctx context.Context, transferID int64) (TransferCategory, error)
+	DeleteTransferCategory(ctx context.Context, transferID int64) error
+	ListMonthlySpending(ctx context.Context, arg ListMonthlySpendingParams) ([]ListMonthlySpendingRow, error)
+	ListCashFlow(ctx context.Context, arg ListCashFlowParams) ([]ListCashFlowRow, error)
+	ListTopCounterparties(ctx context.Context, arg ListTopCounterpartiesParams) ([]ListTopCounterpartiesRow, error)
+	GetDailyBalanceStats(ctx context.Context, arg GetDailyBalanceStatsParams) (GetDailyBalanceStatsRow, error)
+	ListLargestEntries(ctx context.Context, arg ListLargestEntriesParams) ([]ListLargestEntriesRow, error)
+	CreateEscrowTx(ctx context.Context, arg CreateEscrowTxParams) (CreateEscrowTxResult, error)
+	GetEscrow(ctx context.Context, id int64) (Escrow, error)
+	ListEscrows(ctx context.Context, arg ListEscrowsParams) ([]Escrow, error)
+	DisputeEscrow(ctx context.Context, arg DisputeEscrowParams) (Escrow, error)
+	ReleaseEscrowTx(ctx context.Context, arg ReleaseEscrowTxParams) (EscrowTxResult, error)
+	ListDisputedEscrows(ctx context.Context, arg ListDisputedEscrowsParams) ([]Escrow, error)
+	ResolveEscrowTx(ctx context.Context, arg ResolveEscrowTxParams) (EscrowTxResult, error)
+	ListExpiredEscrows(ctx context.Context, deadline time.Time) ([]Escrow, error)
+	RefundEscrowTx(ctx context.Context, escrowID int64) (EscrowTxResult, error)
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
 }
 
 // SQLStore provides all functions to execute SQL queries and transactions
@@ -54,107 +180,101 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 
 // TransferTxParams contains the input parameters of the transfer transaction
 type TransferTxParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID int64           `json:"from_account_id"`
+	ToAccountID   int64           `json:"to_account_id"`
+	Amount        int64           `json:"amount"`
+	Description   string          `json:"description"`
+	Reference     string          `json:"reference"`
+	Metadata      json.RawMessage `json:"metadata"`
 }
 
 // TransferTxResult is the result of the transfer transaction
 type TransferTxResult struct {
-	Transfer    Transfer `json:"transfer"`
-	FromAccount Account  `json:"from_account"`
-	ToAccount   Account  `json:"to_account"`
-	FromEntry   Entry    `json:"from_entry"`
-	ToEntry     Entry    `json:"to_entry"`
+	Transfer     Transfer     `json:"transfer"`
+	JournalEntry JournalEntry `json:"journal_entry"`
+	FromAccount  Account      `json:"from_account"`
+	ToAccount    Account      `json:"to_account"`
+	FromEntry    Entry        `json:"from_entry"`
+	ToEntry      Entry        `json:"to_entry"`
+	// RoundUp is the spare change moved to the round-up pot of the sending account, if any
+	RoundUp *RoundUp `json:"round_up,omitempty"`
 }
 
 // TransferTx performs a money transfer from one account to the other.
-// It creates a transfer record, add account entries, and update accounts' balance within a single database transaction
+// It creates a transfer record, books a journal entry with a debit and a credit posting, and updates accounts' balance within a single database transaction.
+// The spare change of the transfer is then swept to the round-up pot of the sending account, if it has one.
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
 
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
-
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
-			FromAccountID: arg.FromAccountID,
-			ToAccountID:   arg.ToAccountID,
-			Amount:        arg.Amount,
-		})
-		if err != nil {
-			return err
-		}
-
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
-		if err != nil {
-			return err
-		}
-
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
+		result, err = transfer(ctx, q, arg)
 		if err != nil {
 			return err
 		}
 
-		result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-
+		// only the transfers customers make are rounded up, not reversals, collections or other bookings
+		result.RoundUp, result.FromAccount, err = roundUp(ctx, q, result.Transfer, result.FromAccount)
 		return err
 	})
 
 	return result, err
 }
 
-// addMoney adds money to account balances.
-// It gets the current balance and updates it with the new amount.
-// To avoid deadlocks, always update accounts in order of their IDs.
-func addMoney(
-	ctx context.Context,
-	q *Queries,
-	accountID1 int64,
-	amount1 int64,
-	accountID2 int64,
-	amount2 int64,
-) (account1 Account, account2 Account, err error) {
-	// Always update accounts in the same order to avoid deadlocks
-	if accountID1 < accountID2 {
-		account1, err = updateAccountBalance(ctx, q, accountID1, amount1)
-		if err != nil {
-			return
-		}
-
-		account2, err = updateAccountBalance(ctx, q, accountID2, amount2)
-		if err != nil {
-			return
-		}
-	} else {
-		account2, err = updateAccountBalance(ctx, q, accountID2, amount2)
-		if err != nil {
-			return
-		}
+// transfer moves money between two accounts using the given queries.
+// It must be called inside a database transaction so that it can be combined with other writes.
+func transfer(ctx context.Context, q *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+	var err error
 
-		account1, err = updateAccountBalance(ctx, q, accountID1, amount1)
-		if err != nil {
-			return
-		}
+	metadata := arg.Metadata
+	if len(metadata) == 0 {
+		metadata = json.RawMessage(`{}`)
 	}
 
-	return
-}
+	result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		Description:   arg.Description,
+		Reference:     arg.Reference,
+		Metadata:      metadata,
+	})
+	if err != nil {
+		return result, err
+	}
 
-// updateAccountBalance atomically updates an account's balance
-func updateAccountBalance(ctx context.Context, q *Queries, accountID int64, amount int64) (Account, error) {
-	account, err := q.GetAccount(ctx, accountID)
+	journal, err := postJournalEntry(ctx, q, postJournalEntryParams{
+		Kind:        JournalKindTransfer,
+		Description: arg.Description,
+		TransferID: sql.NullInt64{
+			Int64: result.Transfer.ID,
+			Valid: true,
+		},
+		Postings: []Posting{
+			{AccountID: arg.FromAccountID, Amount: -arg.Amount},
+			{AccountID: arg.ToAccountID, Amount: arg.Amount},
+		},
+	})
 	if err != nil {
-		return account, err
+		return result, err
 	}
 
-	return q.UpdateAccount(ctx, UpdateAccountParams{
-		ID:      accountID,
-		Balance: account.Balance + amount,
+	result.JournalEntry = journal.JournalEntry
+	result.FromEntry = journal.Entries[0]
+	result.ToEntry = journal.Entries[1]
+	result.FromAccount = journal.Accounts[arg.FromAccountID]
+	result.ToAccount = journal.Accounts[arg.ToAccountID]
+
+	err = checkOverdraft(ctx, q, result.Transfer, result.FromAccount)
+	return result, err
+}
+
+// updateAccountBalance atomically updates an account's balance.
+// The row stays locked until the transaction ends, so the returned balance can be checked before committing.
+func updateAccountBalance(ctx context.Context, q *Queries, accountID int64, amount int64) (Account, error) {
+	return q.AddAccountBalance(ctx, AddAccountBalanceParams{
+		ID:     accountID,
+		Amount: amount,
 	})
 }
\ No newline at end of file
@@ -18,7 +18,7 @@ func TestTransferTx(t *testing.T) {
 	store := NewStore(testDB)
 
 	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
 	fmt.Println(">> before:", account1.Balance, account2.Balance)
 
 	n := 5
@@ -70,6 +70,13 @@ func TestTransferTx(t *testing.T) {
 		_, err = store.GetEntry(context.Background(), toEntry.ID)
 		require.NoError(t, err)
 
+		// check journal entry
+		journal := result.JournalEntry
+		require.NotZero(t, journal.ID)
+		require.Equal(t, JournalKindTransfer, journal.Kind)
+		require.Equal(t, journal.ID, fromEntry.JournalEntryID.Int64)
+		require.Equal(t, journal.ID, toEntry.JournalEntryID.Int64)
+
 		// check accounts
 		fromAccount := result.FromAccount
 		require.NotEmpty(t, fromAccount)
@@ -110,7 +117,7 @@ func TestTransferTxDeadlock(t *testing.T) {
 	store := NewStore(testDB)
 
 	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
 	fmt.Println(">> before:", account1.Balance, account2.Balance)
 
 	n := 10
@@ -118,13 +125,13 @@ func TestTransferTxDeadlock(t *testing.T) {
 	
 	// Ensure both accounts have sufficient balance for all transactions
 	minBalance := int64(n) * amount
-	account1, _ = store.UpdateAccount(context.Background(), UpdateAccountParams{
-		ID:      account1.ID,
-		Balance: minBalance,
+	account1, _ = testQueries.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+		ID:     account1.ID,
+		Amount: minBalance,
 	})
-	account2, _ = store.UpdateAccount(context.Background(), UpdateAccountParams{
-		ID:      account2.ID,
-		Balance: minBalance,
+	account2, _ = testQueries.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+		ID:     account2.ID,
+		Amount: minBalance,
 	})
 	
 	fmt.Println(">> after balance adjustment:", account1.Balance, account2.Balance)
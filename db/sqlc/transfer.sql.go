@@ -7,26 +7,78 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
 )
 
+const countTransfersSince = `-- name: CountTransfersSince :one
+SELECT count(*) FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = $1 AND t.created_at >= $2
+`
+
+type CountTransfersSinceParams struct {
+	Owner string    `json:"owner"`
+	Since time.Time `json:"since"`
+}
+
+func (q *Queries) CountTransfersSince(ctx context.Context, arg CountTransfersSinceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countTransfersSince, arg.Owner, arg.Since)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countTransfersToAccount = `-- name: CountTransfersToAccount :one
+SELECT count(*) FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = $1 AND t.to_account_id = $2
+`
+
+type CountTransfersToAccountParams struct {
+	Owner       string `json:"owner"`
+	ToAccountID int64  `json:"to_account_id"`
+}
+
+func (q *Queries) CountTransfersToAccount(ctx context.Context, arg CountTransfersToAccountParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countTransfersToAccount, arg.Owner, arg.ToAccountID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (
     from_account_id,
     to_account_id,
-    amount
+    amount,
+    description,
+    reference,
+    metadata
 ) VALUES (
-    $1, $2, $3
-) RETURNING id, from_account_id, to_account_id, amount, created_at
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, from_account_id, to_account_id, amount, created_at, description, reference, metadata
 `
 
 type CreateTransferParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID int64           `json:"from_account_id"`
+	ToAccountID   int64           `json:"to_account_id"`
+	Amount        int64           `json:"amount"`
+	Description   string          `json:"description"`
+	Reference     string          `json:"reference"`
+	Metadata      json.RawMessage `json:"metadata"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRowContext(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Description,
+		arg.Reference,
+		arg.Metadata,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -34,21 +86,15 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Description,
+		&i.Reference,
+		&i.Metadata,
 	)
 	return i, err
 }
 
-const deleteTransfer = `-- name: DeleteTransfer :exec
-DELETE FROM transfers WHERE id = $1
-`
-
-func (q *Queries) DeleteTransfer(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteTransfer, id)
-	return err
-}
-
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, description, reference, metadata FROM transfers
 WHERE id = $1 LIMIT 1
 `
 
@@ -61,12 +107,36 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Description,
+		&i.Reference,
+		&i.Metadata,
 	)
 	return i, err
 }
 
+const getTransferAmountStats = `-- name: GetTransferAmountStats :one
+SELECT
+    count(*) AS transfer_count,
+    COALESCE(avg(t.amount), 0)::bigint AS average_amount
+FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = $1
+`
+
+type GetTransferAmountStatsRow struct {
+	TransferCount int64 `json:"transfer_count"`
+	AverageAmount int64 `json:"average_amount"`
+}
+
+func (q *Queries) GetTransferAmountStats(ctx context.Context, owner string) (GetTransferAmountStatsRow, error) {
+	row := q.db.QueryRowContext(ctx, getTransferAmountStats, owner)
+	var i GetTransferAmountStatsRow
+	err := row.Scan(&i.TransferCount, &i.AverageAmount)
+	return i, err
+}
+
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, description, reference, metadata FROM transfers
 WHERE 
     from_account_id = $1 OR
     to_account_id = $2
@@ -102,6 +172,9 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
 		); err != nil {
 			return nil, err
 		}
@@ -116,27 +189,62 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 	return items, nil
 }
 
-const updateTransfer = `-- name: UpdateTransfer :one
-UPDATE transfers
-SET amount = $2
-WHERE id = $1
-RETURNING id, from_account_id, to_account_id, amount, created_at
+const searchTransfers = `-- name: SearchTransfers :many
+SELECT id, from_account_id, to_account_id, amount, created_at, description, reference, metadata FROM transfers
+WHERE
+    (from_account_id = $1 OR to_account_id = $1)
+    AND ($2::varchar IS NULL OR reference = $2)
+    AND ($3::varchar IS NULL OR description ILIKE '%' || $3 || '%')
+    AND ($4::text IS NULL OR metadata @> $4::jsonb)
+ORDER BY id DESC
+LIMIT $5
+OFFSET $6
 `
 
-type UpdateTransferParams struct {
-	ID     int64 `json:"id"`
-	Amount int64 `json:"amount"`
+type SearchTransfersParams struct {
+	AccountID   int64          `json:"account_id"`
+	Reference   sql.NullString `json:"reference"`
+	Description sql.NullString `json:"description"`
+	Metadata    sql.NullString `json:"metadata"`
+	PageLimit   int32          `json:"page_limit"`
+	PageOffset  int32          `json:"page_offset"`
 }
 
-func (q *Queries) UpdateTransfer(ctx context.Context, arg UpdateTransferParams) (Transfer, error) {
-	row := q.db.QueryRowContext(ctx, updateTransfer, arg.ID, arg.Amount)
-	var i Transfer
-	err := row.Scan(
-		&i.ID,
-		&i.FromAccountID,
-		&i.ToAccountID,
-		&i.Amount,
-		&i.CreatedAt,
+func (q *Queries) SearchTransfers(ctx context.Context, arg SearchTransfersParams) ([]Transfer, error) {
+	rows, err := q.db.QueryContext(ctx, searchTransfers,
+		arg.AccountID,
+		arg.Reference,
+		arg.Description,
+		arg.Metadata,
+		arg.PageLimit,
+		arg.PageOffset,
 	)
-	return i, err
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+// createRandomTransferApproval creates a random transfer pending approval for testing
+func createRandomTransferApproval(t *testing.T) TransferApproval {
+	fromAccount := createRandomAccount(t)
+	toAccount := createRandomAccountWithCurrency(t, fromAccount.Currency)
+
+	arg := CreateTransferApprovalParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        util.RandomInt(1, 10),
+		Currency:      fromAccount.Currency,
+		Description:   "treasury move",
+		Reference:     util.RandomString(10),
+		Metadata:      json.RawMessage(`{"desk": "treasury"}`),
+		Initiator:     fromAccount.Owner,
+		Status:        TransferApprovalStatusPending,
+	}
+
+	approval, err := testQueries.CreateTransferApproval(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, approval)
+
+	require.Equal(t, arg.FromAccountID, approval.FromAccountID)
+	require.Equal(t, arg.ToAccountID, approval.ToAccountID)
+	require.Equal(t, arg.Amount, approval.Amount)
+	require.Equal(t, arg.Currency, approval.Currency)
+	require.Equal(t, arg.Description, approval.Description)
+	require.Equal(t, arg.Reference, approval.Reference)
+	require.JSONEq(t, string(arg.Metadata), string(approval.Metadata))
+	require.Equal(t, arg.Initiator, approval.Initiator)
+	require.Equal(t, TransferApprovalStatusPending, approval.Status)
+	require.Empty(t, approval.Reviewer)
+	require.False(t, approval.TransferID.Valid)
+
+	require.NotZero(t, approval.ID)
+	require.NotZero(t, approval.CreatedAt)
+
+	return approval
+}
+
+func TestCreateTransferApproval(t *testing.T) {
+	createRandomTransferApproval(t)
+}
+
+func TestGetTransferApproval(t *testing.T) {
+	approval1 := createRandomTransferApproval(t)
+	approval2, err := testQueries.GetTransferApproval(context.Background(), approval1.ID)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, approval2)
+
+	require.Equal(t, approval1.ID, approval2.ID)
+	require.Equal(t, approval1.Initiator, approval2.Initiator)
+	require.Equal(t, approval1.Amount, approval2.Amount)
+	require.Equal(t, approval1.Status, approval2.Status)
+}
+
+func TestListTransferApprovals(t *testing.T) {
+	for range 5 {
+		createRandomTransferApproval(t)
+	}
+
+	arg := ListTransferApprovalsParams{
+		Status: TransferApprovalStatusPending,
+		Limit:  5,
+		Offset: 0,
+	}
+
+	approvals, err := testQueries.ListTransferApprovals(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, approvals, 5)
+
+	for _, approval := range approvals {
+		require.Equal(t, TransferApprovalStatusPending, approval.Status)
+	}
+}
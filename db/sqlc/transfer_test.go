@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -19,6 +20,9 @@ func createRandomTransfer(t *testing.T) Transfer {
 		FromAccountID: account1.ID,
 		ToAccountID:   account2.ID,
 		Amount:        util.RandomMoney(),
+		Description:   util.RandomString(12),
+		Reference:     util.RandomString(8),
+		Metadata:      json.RawMessage(`{"source": "test"}`),
 	}
 
 	transfer, err := testQueries.CreateTransfer(context.Background(), arg)
@@ -29,6 +33,9 @@ func createRandomTransfer(t *testing.T) Transfer {
 	require.Equal(t, arg.FromAccountID, transfer.FromAccountID)
 	require.Equal(t, arg.ToAccountID, transfer.ToAccountID)
 	require.Equal(t, arg.Amount, transfer.Amount)
+	require.Equal(t, arg.Description, transfer.Description)
+	require.Equal(t, arg.Reference, transfer.Reference)
+	require.JSONEq(t, string(arg.Metadata), string(transfer.Metadata))
 
 	require.NotZero(t, transfer.ID)
 	require.NotZero(t, transfer.CreatedAt)
@@ -57,37 +64,19 @@ func TestGetTransfer(t *testing.T) {
 	require.WithinDuration(t, transfer1.CreatedAt, transfer2.CreatedAt, time.Second)
 }
 
-// TestUpdateTransfer tests the UpdateTransfer function
-func TestUpdateTransfer(t *testing.T) {
-	transfer1 := createRandomTransfer(t)
-
-	arg := UpdateTransferParams{
-		ID:     transfer1.ID,
-		Amount: util.RandomMoney(),
-	}
-
-	transfer2, err := testQueries.UpdateTransfer(context.Background(), arg)
-	require.NoError(t, err)
-	require.NotEmpty(t, transfer2)
+// TestTransfersAreAppendOnly tests that transfers cannot be changed once written
+func TestTransfersAreAppendOnly(t *testing.T) {
+	transfer := createRandomTransfer(t)
 
-	require.Equal(t, transfer1.ID, transfer2.ID)
-	require.Equal(t, transfer1.FromAccountID, transfer2.FromAccountID)
-	require.Equal(t, transfer1.ToAccountID, transfer2.ToAccountID)
-	require.Equal(t, arg.Amount, transfer2.Amount)
+	_, err := testDB.ExecContext(context.Background(), "UPDATE transfers SET amount = $2 WHERE id = $1", transfer.ID, util.RandomMoney())
+	requireAppendOnlyError(t, err)
 
-	require.WithinDuration(t, transfer1.CreatedAt, transfer2.CreatedAt, time.Second)
-}
+	_, err = testDB.ExecContext(context.Background(), "DELETE FROM transfers WHERE id = $1", transfer.ID)
+	requireAppendOnlyError(t, err)
 
-// TestDeleteTransfer tests the DeleteTransfer function
-func TestDeleteTransfer(t *testing.T) {
-	transfer1 := createRandomTransfer(t)
-	err := testQueries.DeleteTransfer(context.Background(), transfer1.ID)
+	transfer2, err := testQueries.GetTransfer(context.Background(), transfer.ID)
 	require.NoError(t, err)
-
-	transfer2, err := testQueries.GetTransfer(context.Background(), transfer1.ID)
-	require.Error(t, err)
-	require.EqualError(t, err, sql.ErrNoRows.Error())
-	require.Empty(t, transfer2)
+	require.Equal(t, transfer.Amount, transfer2.Amount)
 }
 
 // TestListTransfers tests the ListTransfers function
@@ -123,6 +112,7 @@ func createTransferBetweenAccounts(t *testing.T, fromAccountID, toAccountID int6
 		FromAccountID: fromAccountID,
 		ToAccountID:   toAccountID,
 		Amount:        util.RandomMoney(),
+		Metadata:      json.RawMessage(`{}`),
 	}
 
 	transfer, err := testQueries.CreateTransfer(context.Background(), arg)
@@ -130,4 +120,126 @@ func createTransferBetweenAccounts(t *testing.T, fromAccountID, toAccountID int6
 	require.NotEmpty(t, transfer)
 
 	return transfer
-}
\ No newline at end of file
+}
+
+// TestCreateTransferDuplicateReference tests that a reference can only be used once per sender
+func TestCreateTransferDuplicateReference(t *testing.T) {
+	transfer := createRandomTransfer(t)
+
+	_, err := testQueries.CreateTransfer(context.Background(), CreateTransferParams{
+		FromAccountID: transfer.FromAccountID,
+		ToAccountID:   transfer.ToAccountID,
+		Amount:        transfer.Amount,
+		Reference:     transfer.Reference,
+		Metadata:      json.RawMessage(`{}`),
+	})
+	require.Error(t, err)
+
+	// an empty reference is never considered a duplicate
+	createTransferBetweenAccounts(t, transfer.FromAccountID, transfer.ToAccountID)
+	createTransferBetweenAccounts(t, transfer.FromAccountID, transfer.ToAccountID)
+}
+
+// TestSearchTransfers tests filtering the transfer history
+func TestSearchTransfers(t *testing.T) {
+	transfer := createRandomTransfer(t)
+	createTransferBetweenAccounts(t, transfer.FromAccountID, transfer.ToAccountID)
+
+	testCases := []struct {
+		name string
+		arg  SearchTransfersParams
+		n    int
+	}{
+		{
+			name: "NoFilter",
+			arg:  SearchTransfersParams{AccountID: transfer.ToAccountID},
+			n:    2,
+		},
+		{
+			name: "Reference",
+			arg: SearchTransfersParams{
+				AccountID: transfer.FromAccountID,
+				Reference: sql.NullString{String: transfer.Reference, Valid: true},
+			},
+			n: 1,
+		},
+		{
+			name: "Description",
+			arg: SearchTransfersParams{
+				AccountID:   transfer.FromAccountID,
+				Description: sql.NullString{String: transfer.Description[2:8], Valid: true},
+			},
+			n: 1,
+		},
+		{
+			name: "Metadata",
+			arg: SearchTransfersParams{
+				AccountID: transfer.FromAccountID,
+				Metadata:  sql.NullString{String: `{"source": "test"}`, Valid: true},
+			},
+			n: 1,
+		},
+		{
+			name: "NoMatch",
+			arg: SearchTransfersParams{
+				AccountID: transfer.FromAccountID,
+				Metadata:  sql.NullString{String: `{"source": "other"}`, Valid: true},
+			},
+			n: 0,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.arg.PageLimit = 5
+			transfers, err := testQueries.SearchTransfers(context.Background(), tc.arg)
+			require.NoError(t, err)
+			require.Len(t, transfers, tc.n)
+		})
+	}
+}
+
+func TestTransferHistoryQueries(t *testing.T) {
+	fromAccount := createRandomAccount(t)
+	toAccount := createRandomAccount(t)
+	otherAccount := createRandomAccount(t)
+
+	start := time.Now().Add(-time.Second)
+	transfer1 := createTransferBetweenAccounts(t, fromAccount.ID, toAccount.ID)
+	transfer2 := createTransferBetweenAccounts(t, fromAccount.ID, toAccount.ID)
+
+	count, err := testQueries.CountTransfersSince(context.Background(), CountTransfersSinceParams{
+		Owner: fromAccount.Owner,
+		Since: start,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	count, err = testQueries.CountTransfersSince(context.Background(), CountTransfersSinceParams{
+		Owner: fromAccount.Owner,
+		Since: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	count, err = testQueries.CountTransfersToAccount(context.Background(), CountTransfersToAccountParams{
+		Owner:       fromAccount.Owner,
+		ToAccountID: toAccount.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	count, err = testQueries.CountTransfersToAccount(context.Background(), CountTransfersToAccountParams{
+		Owner:       fromAccount.Owner,
+		ToAccountID: otherAccount.ID,
+	})
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	stats, err := testQueries.GetTransferAmountStats(context.Background(), fromAccount.Owner)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), stats.TransferCount)
+	require.InDelta(t, (transfer1.Amount+transfer2.Amount)/2, stats.AverageAmount, 1)
+}
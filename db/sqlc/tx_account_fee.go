@@ -0,0 +1,130 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Account fee statuses
+const (
+	AccountFeeStatusCharged = "charged"
+	// AccountFeeStatusPartial fees were charged up to the balance, the rest is in arrears
+	AccountFeeStatusPartial = "partial"
+	// AccountFeeStatusArrears fees couldn't be charged at all
+	AccountFeeStatusArrears = "arrears"
+	AccountFeeStatusWaived  = "waived"
+)
+
+var ErrFeeAlreadyCharged = errors.New("fee is already charged for the period")
+
+// ChargeFeeTxParams contains the input parameters of the charge fee transaction
+type ChargeFeeTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// Period is the first day of the month the fee is for
+	Period time.Time `json:"period"`
+	Amount int64     `json:"amount"`
+	// WaiverReason waives the fee when it is set
+	WaiverReason string `json:"waiver_reason"`
+}
+
+// ChargeFeeTxResult is the result of the charge fee transaction.
+// JournalEntry is only set when some of the fee was charged.
+type ChargeFeeTxResult struct {
+	AccountFee   AccountFee    `json:"account_fee"`
+	Account      Account       `json:"account"`
+	JournalEntry *JournalEntry `json:"journal_entry,omitempty"`
+}
+
+// ChargeFeeTx charges the fee of a period to an account, crediting the fee income account of the bank
+// in the same journal entry. When the available balance, which excludes card holds, doesn't cover the fee,
+// the available balance is charged and the rest of the fee is recorded as arrears. An account is only billed once per period:
+// it returns ErrFeeAlreadyCharged when the account already has a fee for the period.
+func (store *SQLStore) ChargeFeeTx(ctx context.Context, arg ChargeFeeTxParams) (ChargeFeeTxResult, error) {
+	var result ChargeFeeTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// the lock keeps the balance from changing between the check and the charge
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		result.Account = account
+
+		_, err = q.GetAccountFee(ctx, GetAccountFeeParams{
+			AccountID: account.ID,
+			Period:    arg.Period,
+		})
+		if err == nil {
+			return ErrFeeAlreadyCharged
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		fee := CreateAccountFeeParams{
+			AccountID:    account.ID,
+			Period:       arg.Period,
+			Amount:       arg.Amount,
+			WaiverReason: arg.WaiverReason,
+		}
+
+		// money held by card authorizations is spoken for, so fees never draw on it or the overdraft
+		available, err := availableBalance(ctx, q, account)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case arg.WaiverReason != "":
+			fee.Status = AccountFeeStatusWaived
+		case available >= arg.Amount:
+			fee.Status = AccountFeeStatusCharged
+			fee.Charged = arg.Amount
+		case available > 0:
+			fee.Status = AccountFeeStatusPartial
+			fee.Charged = available
+		default:
+			fee.Status = AccountFeeStatusArrears
+		}
+		if fee.Status != AccountFeeStatusWaived {
+			fee.Arrears = arg.Amount - fee.Charged
+		}
+
+		if fee.Charged > 0 {
+			income, err := q.GetSystemAccount(ctx, GetSystemAccountParams{
+				Purpose:  SystemAccountFeeIncome,
+				Currency: account.Currency,
+			})
+			if err != nil {
+				return fmt.Errorf("cannot get %s fee income account: %w", account.Currency, err)
+			}
+
+			journal, err := postJournalEntry(ctx, q, postJournalEntryParams{
+				Kind:        JournalKindFee,
+				Description: fmt.Sprintf("maintenance fee %s", arg.Period.Format("2006-01")),
+				Postings: []Posting{
+					{AccountID: account.ID, Amount: -fee.Charged},
+					{AccountID: income.AccountID, Amount: fee.Charged},
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			result.JournalEntry = &journal.JournalEntry
+			result.Account = journal.Accounts[account.ID]
+			fee.JournalEntryID = sql.NullInt64{
+				Int64: journal.JournalEntry.ID,
+				Valid: true,
+			}
+		}
+
+		result.AccountFee, err = q.CreateAccountFee(ctx, fee)
+		return err
+	})
+
+	return result, err
+}
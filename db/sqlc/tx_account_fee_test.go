@@ -0,0 +1,129 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createAccountWithBalance creates a checking account holding the given balance for testing
+func createAccountWithBalance(t *testing.T, currency string, balance int64) Account {
+	user := createRandomUser(t)
+
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  balance,
+		Currency: currency,
+		Type:     AccountTypeChecking,
+	})
+	require.NoError(t, err)
+
+	return account
+}
+
+// TestChargeFeeTx tests that fees are charged once per period, up to the balance of the account not held by cards
+func TestChargeFeeTx(t *testing.T) {
+	store := NewStore(testDB)
+	period := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	income, err := testQueries.GetSystemAccount(context.Background(), GetSystemAccountParams{
+		Purpose:  SystemAccountFeeIncome,
+		Currency: "USD",
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		balance      int64
+		held         int64
+		waiverReason string
+		status       string
+		charged      int64
+		arrears      int64
+	}{
+		{name: "Charged", balance: 1000, status: AccountFeeStatusCharged, charged: 500},
+		{name: "Partial", balance: 200, status: AccountFeeStatusPartial, charged: 200, arrears: 300},
+		{name: "PartialWithHolds", balance: 1000, held: 700, status: AccountFeeStatusPartial, charged: 300, arrears: 200},
+		{name: "ArrearsWithHolds", balance: 400, held: 400, status: AccountFeeStatusArrears, arrears: 500},
+		{name: "Arrears", balance: 0, status: AccountFeeStatusArrears, arrears: 500},
+		{name: "Waived", balance: 1000, waiverReason: "minimum_balance", status: AccountFeeStatusWaived},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			account := createAccountWithBalance(t, "USD", tc.balance)
+			if tc.held > 0 {
+				authorizeCard(t, createRandomCard(t, account, 0), tc.held, "USD")
+			}
+			incomeBefore, err := store.GetAccount(context.Background(), income.AccountID)
+			require.NoError(t, err)
+
+			result, err := store.ChargeFeeTx(context.Background(), ChargeFeeTxParams{
+				AccountID:    account.ID,
+				Period:       period,
+				Amount:       500,
+				WaiverReason: tc.waiverReason,
+			})
+			require.NoError(t, err)
+
+			fee := result.AccountFee
+			require.Equal(t, tc.status, fee.Status)
+			require.Equal(t, int64(500), fee.Amount)
+			require.Equal(t, tc.charged, fee.Charged)
+			require.Equal(t, tc.arrears, fee.Arrears)
+			require.Equal(t, tc.waiverReason, fee.WaiverReason)
+			require.Equal(t, tc.balance-tc.charged, result.Account.Balance)
+
+			if tc.charged > 0 {
+				require.NotNil(t, result.JournalEntry)
+				require.Equal(t, JournalKindFee, result.JournalEntry.Kind)
+				require.Equal(t, result.JournalEntry.ID, fee.JournalEntryID.Int64)
+			} else {
+				require.Nil(t, result.JournalEntry)
+				require.False(t, fee.JournalEntryID.Valid)
+			}
+
+			incomeAfter, err := store.GetAccount(context.Background(), income.AccountID)
+			require.NoError(t, err)
+			require.GreaterOrEqual(t, incomeAfter.Balance-incomeBefore.Balance, tc.charged)
+
+			// the period is only billed once
+			_, err = store.ChargeFeeTx(context.Background(), ChargeFeeTxParams{
+				AccountID: account.ID,
+				Period:    period,
+				Amount:    500,
+			})
+			require.ErrorIs(t, err, ErrFeeAlreadyCharged)
+
+			fees, err := store.ListAccountFees(context.Background(), ListAccountFeesParams{
+				AccountID: account.ID,
+				Limit:     5,
+			})
+			require.NoError(t, err)
+			require.Len(t, fees, 1)
+			require.Equal(t, fee.ID, fees[0].ID)
+		})
+	}
+}
+
+// TestListBillableAccounts tests that the internal accounts of the bank are never billed
+func TestListBillableAccounts(t *testing.T) {
+	account := createRandomAccount(t)
+
+	accounts, err := testQueries.ListBillableAccounts(context.Background(), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	found := false
+	for _, billable := range accounts {
+		require.NotEqual(t, AccountTypeInternal, billable.Type)
+		if billable.ID == account.ID {
+			found = true
+			require.Equal(t, "depositor", billable.OwnerRole)
+		}
+	}
+	require.True(t, found)
+}
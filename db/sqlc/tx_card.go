@@ -0,0 +1,226 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Card statuses
+const (
+	CardStatusActive = "active"
+	CardStatusFrozen = "frozen"
+	// CardStatusBlocked cards had too many failed CVV attempts, unfreezing them lets them pay again
+	CardStatusBlocked = "blocked"
+)
+
+// Card authorization statuses
+const (
+	// CardAuthorizationStatusHeld authorizations reserve their amount on the account until the merchant settles them
+	CardAuthorizationStatusHeld     = "held"
+	CardAuthorizationStatusCleared  = "cleared"
+	CardAuthorizationStatusReleased = "released"
+	CardAuthorizationStatusDeclined = "declined"
+)
+
+// Reasons card authorizations are declined for
+const (
+	CardDeclineInvalidDetails    = "invalid card details"
+	CardDeclineExpired           = "card expired"
+	CardDeclineFrozen            = "card frozen"
+	CardDeclineBlocked           = "card blocked"
+	CardDeclineCurrency          = "currency not supported by the card"
+	CardDeclineSpendingLimit     = "spending limit exceeded"
+	CardDeclineInsufficientFunds = "insufficient funds"
+)
+
+var (
+	ErrCardAuthorizationNotHeld = errors.New("card authorization is no longer held")
+	ErrClearingExceedsHold      = errors.New("cleared amount exceeds the amount held")
+)
+
+// AuthorizeCardTxParams contains the input parameters of the authorize card transaction
+type AuthorizeCardTxParams struct {
+	CardID   int64  `json:"card_id"`
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	Merchant string `json:"merchant"`
+	// SpendingSince is the start of the day the spending limit of the card applies to
+	SpendingSince time.Time `json:"spending_since"`
+}
+
+// AuthorizeCardTxResult is the result of the authorize card transaction
+type AuthorizeCardTxResult struct {
+	CardAuthorization CardAuthorization `json:"card_authorization"`
+	Card              Card              `json:"card"`
+}
+
+// AuthorizeCardTx decides on a card payment and records the authorization. An approved authorization holds its
+// amount on the account of the card until the merchant clears or releases it. Payments are declined when the card
+// is frozen or blocked, in another currency than the account, above the spending limit of the card for the day, or when the
+// balance and overdraft limit, less what is already held, don't cover them. Declines are recorded, not returned
+// as errors. The account is locked so that concurrent authorizations are decided one after the other.
+func (store *SQLStore) AuthorizeCardTx(ctx context.Context, arg AuthorizeCardTxParams) (AuthorizeCardTxResult, error) {
+	var result AuthorizeCardTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Card, err = q.GetCard(ctx, arg.CardID)
+		if err != nil {
+			return err
+		}
+
+		account, err := q.GetAccountForUpdate(ctx, result.Card.AccountID)
+		if err != nil {
+			return err
+		}
+
+		declineReason, err := cardDeclineReason(ctx, q, result.Card, account, arg)
+		if err != nil {
+			return err
+		}
+
+		status := CardAuthorizationStatusHeld
+		if declineReason != "" {
+			status = CardAuthorizationStatusDeclined
+		}
+
+		result.CardAuthorization, err = q.CreateCardAuthorization(ctx, CreateCardAuthorizationParams{
+			CardID:        result.Card.ID,
+			Amount:        arg.Amount,
+			Currency:      arg.Currency,
+			Merchant:      arg.Merchant,
+			Status:        status,
+			DeclineReason: declineReason,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// cardDeclineReason returns why a card payment has to be declined, or an empty string to approve it
+func cardDeclineReason(ctx context.Context, q *Queries, card Card, account Account, arg AuthorizeCardTxParams) (string, error) {
+	if card.Status == CardStatusBlocked {
+		return CardDeclineBlocked, nil
+	}
+	if card.Status != CardStatusActive {
+		return CardDeclineFrozen, nil
+	}
+
+	if arg.Currency != account.Currency {
+		return CardDeclineCurrency, nil
+	}
+
+	if card.SpendingLimit > 0 {
+		spending, err := q.GetCardSpending(ctx, GetCardSpendingParams{
+			CardID:    card.ID,
+			CreatedAt: arg.SpendingSince,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		if spending+arg.Amount > card.SpendingLimit {
+			return CardDeclineSpendingLimit, nil
+		}
+	}
+
+	held, err := q.GetHeldAmount(ctx, account.ID)
+	if err != nil {
+		return "", err
+	}
+
+	limit, err := q.GetOverdraftLimit(ctx, account.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if account.Balance+limit-held < arg.Amount {
+		return CardDeclineInsufficientFunds, nil
+	}
+
+	return "", nil
+}
+
+// ClearCardAuthorizationTxParams contains the input parameters of the clear card authorization transaction
+type ClearCardAuthorizationTxParams struct {
+	ID int64 `json:"id"`
+	// Amount is what the merchant settles, up to the amount held, 0 for the whole amount
+	Amount int64 `json:"amount"`
+}
+
+// ClearCardAuthorizationTxResult is the result of the clear card authorization transaction
+type ClearCardAuthorizationTxResult struct {
+	CardAuthorization CardAuthorization `json:"card_authorization"`
+	Account           Account           `json:"account"`
+	JournalEntry      JournalEntry      `json:"journal_entry"`
+}
+
+// ClearCardAuthorizationTx settles a held card authorization: the amount is debited from the account of the card
+// and credited to the card settlement account of the bank in a card journal entry, which releases the hold.
+// The hold already reserved the money, so the balance can go as far below zero as the overdraft limit allowed then.
+func (store *SQLStore) ClearCardAuthorizationTx(ctx context.Context, arg ClearCardAuthorizationTxParams) (ClearCardAuthorizationTxResult, error) {
+	var result ClearCardAuthorizationTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		authorization, err := q.GetCardAuthorizationForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if authorization.Status != CardAuthorizationStatusHeld {
+			return ErrCardAuthorizationNotHeld
+		}
+
+		amount := arg.Amount
+		if amount == 0 {
+			amount = authorization.Amount
+		}
+		if amount > authorization.Amount {
+			return ErrClearingExceedsHold
+		}
+
+		card, err := q.GetCard(ctx, authorization.CardID)
+		if err != nil {
+			return err
+		}
+
+		settlement, err := q.GetSystemAccount(ctx, GetSystemAccountParams{
+			Purpose:  SystemAccountCardSettlement,
+			Currency: authorization.Currency,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot get %s card settlement account: %w", authorization.Currency, err)
+		}
+
+		journal, err := postJournalEntry(ctx, q, postJournalEntryParams{
+			Kind:        JournalKindCard,
+			Description: fmt.Sprintf("card payment at %s", authorization.Merchant),
+			Postings: []Posting{
+				{AccountID: card.AccountID, Amount: -amount},
+				{AccountID: settlement.AccountID, Amount: amount},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.JournalEntry = journal.JournalEntry
+		result.Account = journal.Accounts[card.AccountID]
+
+		result.CardAuthorization, err = q.ClearCardAuthorization(ctx, ClearCardAuthorizationParams{
+			ID:            authorization.ID,
+			ClearedAmount: amount,
+			JournalEntryID: sql.NullInt64{
+				Int64: journal.JournalEntry.ID,
+				Valid: true,
+			},
+		})
+		return err
+	})
+
+	return result, err
+}
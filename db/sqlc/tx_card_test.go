@@ -0,0 +1,199 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+// createRandomCard issues a card with a random number on the account to its owner for testing
+func createRandomCard(t *testing.T, account Account, spendingLimit int64) Card {
+	arg := CreateCardParams{
+		AccountID:     account.ID,
+		Holder:        account.Owner,
+		Pan:           fmt.Sprintf("431940%010d", util.RandomInt(0, 9999999999)),
+		ExpiryMonth:   12,
+		ExpiryYear:    int32(time.Now().Year() + 3),
+		HashedCvv:     util.RandomString(60),
+		SpendingLimit: spendingLimit,
+	}
+
+	card, err := testQueries.CreateCard(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, card.ID)
+	require.Equal(t, arg.AccountID, card.AccountID)
+	require.Equal(t, arg.Holder, card.Holder)
+	require.Equal(t, arg.Pan, card.Pan)
+	require.Equal(t, CardStatusActive, card.Status)
+	require.Equal(t, arg.SpendingLimit, card.SpendingLimit)
+
+	return card
+}
+
+func authorizeCard(t *testing.T, card Card, amount int64, currency string) CardAuthorization {
+	store := NewStore(testDB)
+
+	result, err := store.AuthorizeCardTx(context.Background(), AuthorizeCardTxParams{
+		CardID:        card.ID,
+		Amount:        amount,
+		Currency:      currency,
+		Merchant:      "Coffee Shop",
+		SpendingSince: time.Now().UTC().Truncate(24 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, card.ID, result.CardAuthorization.CardID)
+	require.Equal(t, amount, result.CardAuthorization.Amount)
+
+	return result.CardAuthorization
+}
+
+func TestAuthorizeCardTx(t *testing.T) {
+	account := createAccountWithBalance(t, "USD", 1000)
+	card := createRandomCard(t, account, 0)
+
+	authorization := authorizeCard(t, card, 600, "USD")
+	require.Equal(t, CardAuthorizationStatusHeld, authorization.Status)
+	require.Empty(t, authorization.DeclineReason)
+
+	held, err := testQueries.GetHeldAmount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(600), held)
+
+	// the hold leaves 400 available
+	authorization = authorizeCard(t, card, 500, "USD")
+	require.Equal(t, CardAuthorizationStatusDeclined, authorization.Status)
+	require.Equal(t, CardDeclineInsufficientFunds, authorization.DeclineReason)
+
+	authorization = authorizeCard(t, card, 100, "EUR")
+	require.Equal(t, CardAuthorizationStatusDeclined, authorization.Status)
+	require.Equal(t, CardDeclineCurrency, authorization.DeclineReason)
+
+	// holds also limit what can be transferred
+	store := NewStore(testDB)
+	account2 := createAccountWithBalance(t, "USD", 0)
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account.ID,
+		ToAccountID:   account2.ID,
+		Amount:        500,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestAuthorizeCardTxFrozenAndLimit(t *testing.T) {
+	account := createAccountWithBalance(t, "USD", 1000)
+	card := createRandomCard(t, account, 300)
+
+	authorization := authorizeCard(t, card, 200, "USD")
+	require.Equal(t, CardAuthorizationStatusHeld, authorization.Status)
+
+	authorization = authorizeCard(t, card, 200, "USD")
+	require.Equal(t, CardAuthorizationStatusDeclined, authorization.Status)
+	require.Equal(t, CardDeclineSpendingLimit, authorization.DeclineReason)
+
+	card, err := testQueries.UpdateCardStatus(context.Background(), UpdateCardStatusParams{
+		ID:     card.ID,
+		Status: CardStatusFrozen,
+	})
+	require.NoError(t, err)
+
+	authorization = authorizeCard(t, card, 50, "USD")
+	require.Equal(t, CardAuthorizationStatusDeclined, authorization.Status)
+	require.Equal(t, CardDeclineFrozen, authorization.DeclineReason)
+}
+
+func TestIncrementCardFailedCVVAttempts(t *testing.T) {
+	account := createAccountWithBalance(t, "USD", 1000)
+	card := createRandomCard(t, account, 0)
+	require.Zero(t, card.FailedCvvAttempts)
+
+	arg := IncrementCardFailedCVVAttemptsParams{
+		ID:          card.ID,
+		MaxAttempts: 3,
+	}
+
+	card, err := testQueries.IncrementCardFailedCVVAttempts(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), card.FailedCvvAttempts)
+	require.Equal(t, CardStatusActive, card.Status)
+
+	// a valid CVV starts the count over
+	err = testQueries.ResetCardFailedCVVAttempts(context.Background(), card.ID)
+	require.NoError(t, err)
+
+	for i := int32(1); i <= arg.MaxAttempts; i++ {
+		card, err = testQueries.IncrementCardFailedCVVAttempts(context.Background(), arg)
+		require.NoError(t, err)
+		require.Equal(t, i, card.FailedCvvAttempts)
+	}
+	require.Equal(t, CardStatusBlocked, card.Status)
+
+	authorization := authorizeCard(t, card, 50, "USD")
+	require.Equal(t, CardAuthorizationStatusDeclined, authorization.Status)
+	require.Equal(t, CardDeclineBlocked, authorization.DeclineReason)
+
+	// unfreezing the card lets it pay again with a fresh count
+	card, err = testQueries.UpdateCardStatus(context.Background(), UpdateCardStatusParams{
+		ID:     card.ID,
+		Status: CardStatusActive,
+	})
+	require.NoError(t, err)
+	require.Equal(t, CardStatusActive, card.Status)
+	require.Zero(t, card.FailedCvvAttempts)
+}
+
+func TestClearCardAuthorizationTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createAccountWithBalance(t, "USD", 1000)
+	card := createRandomCard(t, account, 0)
+	authorization := authorizeCard(t, card, 600, "USD")
+
+	_, err := store.ClearCardAuthorizationTx(context.Background(), ClearCardAuthorizationTxParams{
+		ID:     authorization.ID,
+		Amount: 700,
+	})
+	require.ErrorIs(t, err, ErrClearingExceedsHold)
+
+	// the merchant settles less than it authorized, e.g. a tip was not added
+	result, err := store.ClearCardAuthorizationTx(context.Background(), ClearCardAuthorizationTxParams{
+		ID:     authorization.ID,
+		Amount: 550,
+	})
+	require.NoError(t, err)
+	require.Equal(t, CardAuthorizationStatusCleared, result.CardAuthorization.Status)
+	require.Equal(t, int64(550), result.CardAuthorization.ClearedAmount)
+	require.True(t, result.CardAuthorization.SettledAt.Valid)
+	require.Equal(t, sql.NullInt64{Int64: result.JournalEntry.ID, Valid: true}, result.CardAuthorization.JournalEntryID)
+	require.Equal(t, JournalKindCard, result.JournalEntry.Kind)
+	require.Equal(t, int64(450), result.Account.Balance)
+
+	held, err := testQueries.GetHeldAmount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Zero(t, held)
+
+	_, err = store.ClearCardAuthorizationTx(context.Background(), ClearCardAuthorizationTxParams{ID: authorization.ID})
+	require.ErrorIs(t, err, ErrCardAuthorizationNotHeld)
+}
+
+func TestReleaseCardAuthorization(t *testing.T) {
+	account := createAccountWithBalance(t, "USD", 1000)
+	card := createRandomCard(t, account, 0)
+	authorization := authorizeCard(t, card, 600, "USD")
+
+	released, err := testQueries.ReleaseCardAuthorization(context.Background(), authorization.ID)
+	require.NoError(t, err)
+	require.Equal(t, CardAuthorizationStatusReleased, released.Status)
+	require.True(t, released.SettledAt.Valid)
+
+	held, err := testQueries.GetHeldAmount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Zero(t, held)
+
+	// released authorizations can't be released again
+	_, err = testQueries.ReleaseCardAuthorization(context.Background(), authorization.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
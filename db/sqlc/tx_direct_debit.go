@@ -0,0 +1,186 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Mandate statuses
+const (
+	MandateStatusActive  = "active"
+	MandateStatusRevoked = "revoked"
+)
+
+// Mandate frequencies, how often a direct debit can be collected
+const (
+	MandateFrequencyDaily   = "daily"
+	MandateFrequencyWeekly  = "weekly"
+	MandateFrequencyMonthly = "monthly"
+	MandateFrequencyYearly  = "yearly"
+)
+
+// Direct debit statuses
+const (
+	DirectDebitStatusCollected = "collected"
+	DirectDebitStatusDisputed  = "disputed"
+)
+
+var (
+	ErrMandateNotActive           = errors.New("mandate is not active")
+	ErrMandateAmountExceeded      = errors.New("amount exceeds the maximum amount of the mandate")
+	ErrMandateTooFrequent         = errors.New("mandate was already collected within its frequency")
+	ErrDirectDebitAlreadyDisputed = errors.New("direct debit was already disputed")
+	ErrDisputeWindowClosed        = errors.New("direct debit can no longer be disputed")
+)
+
+// NextCollectionAt returns when a direct debit can be collected again under the mandate,
+// after one was collected at last
+func (mandate Mandate) NextCollectionAt(last time.Time) time.Time {
+	switch mandate.Frequency {
+	case MandateFrequencyDaily:
+		return last.AddDate(0, 0, 1)
+	case MandateFrequencyWeekly:
+		return last.AddDate(0, 0, 7)
+	case MandateFrequencyMonthly:
+		return last.AddDate(0, 1, 0)
+	default:
+		return last.AddDate(1, 0, 0)
+	}
+}
+
+// CollectDirectDebitTxParams contains the input parameters of the collect direct debit transaction
+type CollectDirectDebitTxParams struct {
+	MandateID   int64  `json:"mandate_id"`
+	Amount      int64  `json:"amount"`
+	Description string `json:"description"`
+}
+
+// CollectDirectDebitTxResult is the result of the collect direct debit transaction
+type CollectDirectDebitTxResult struct {
+	Mandate     Mandate          `json:"mandate"`
+	DirectDebit DirectDebit      `json:"direct_debit"`
+	Transfer    TransferTxResult `json:"transfer"`
+}
+
+// CollectDirectDebitTx pulls an amount from the debtor account of a mandate to its creditor account.
+// The mandate has to be active, the amount within its maximum, and the previous direct debit at least one period
+// of its frequency ago. The mandate row is locked so that concurrent collections are checked one after the other.
+func (store *SQLStore) CollectDirectDebitTx(ctx context.Context, arg CollectDirectDebitTxParams) (CollectDirectDebitTxResult, error) {
+	var result CollectDirectDebitTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Mandate, err = q.GetMandateForUpdate(ctx, arg.MandateID)
+		if err != nil {
+			return err
+		}
+
+		if result.Mandate.Status != MandateStatusActive {
+			return ErrMandateNotActive
+		}
+
+		if arg.Amount > result.Mandate.MaxAmount {
+			return ErrMandateAmountExceeded
+		}
+
+		last, err := q.GetLastDirectDebit(ctx, result.Mandate.ID)
+		if err == nil {
+			if time.Now().Before(result.Mandate.NextCollectionAt(last.CollectedAt)) {
+				return ErrMandateTooFrequent
+			}
+		} else if err != sql.ErrNoRows {
+			return err
+		}
+
+		metadata, err := json.Marshal(map[string]int64{"mandate_id": result.Mandate.ID})
+		if err != nil {
+			return err
+		}
+
+		description := arg.Description
+		if description == "" {
+			description = fmt.Sprintf("direct debit under mandate %d", result.Mandate.ID)
+		}
+
+		result.Transfer, err = transfer(ctx, q, TransferTxParams{
+			FromAccountID: result.Mandate.DebtorAccountID,
+			ToAccountID:   result.Mandate.CreditorAccountID,
+			Amount:        arg.Amount,
+			Description:   description,
+			Metadata:      metadata,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.DirectDebit, err = q.CreateDirectDebit(ctx, CreateDirectDebitParams{
+			MandateID:  result.Mandate.ID,
+			TransferID: result.Transfer.Transfer.ID,
+			Amount:     arg.Amount,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// DisputeDirectDebitTxParams contains the input parameters of the dispute direct debit transaction
+type DisputeDirectDebitTxParams struct {
+	DirectDebitID int64  `json:"direct_debit_id"`
+	Reason        string `json:"reason"`
+	DisputedBy    string `json:"disputed_by"`
+	// Window is how long after it was collected a direct debit can be disputed
+	Window time.Duration `json:"window"`
+}
+
+// DisputeDirectDebitTxResult is the result of the dispute direct debit transaction
+type DisputeDirectDebitTxResult struct {
+	DirectDebit DirectDebit             `json:"direct_debit"`
+	Reversal    ReverseTransferTxResult `json:"reversal"`
+}
+
+// DisputeDirectDebitTx refunds a direct debit to the debtor by reversing its transfer, as long as it is disputed
+// within the window. The refund is debited from the creditor account, so it fails when the creditor can't cover it.
+func (store *SQLStore) DisputeDirectDebitTx(ctx context.Context, arg DisputeDirectDebitTxParams) (DisputeDirectDebitTxResult, error) {
+	var result DisputeDirectDebitTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		directDebit, err := q.GetDirectDebitForUpdate(ctx, arg.DirectDebitID)
+		if err != nil {
+			return err
+		}
+
+		if directDebit.Status != DirectDebitStatusCollected {
+			return ErrDirectDebitAlreadyDisputed
+		}
+
+		if !time.Now().Before(directDebit.CollectedAt.Add(arg.Window)) {
+			return ErrDisputeWindowClosed
+		}
+
+		result.Reversal, err = reverseTransfer(ctx, q, ReverseTransferTxParams{
+			TransferID: directDebit.TransferID,
+			Reason:     arg.Reason,
+			ReversedBy: arg.DisputedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.DirectDebit, err = q.DisputeDirectDebit(ctx, DisputeDirectDebitParams{
+			ID:            directDebit.ID,
+			DisputeReason: arg.Reason,
+			ReversalID: sql.NullInt64{
+				Int64: result.Reversal.Reversal.Transfer.ID,
+				Valid: true,
+			},
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,167 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+// createRandomMandate lets a new creditor account collect from the debtor account for testing
+func createRandomMandate(t *testing.T, debtorAccount Account, maxAmount int64, frequency string) (Mandate, Account) {
+	creditorAccount := createAccountWithBalance(t, debtorAccount.Currency, 0)
+
+	arg := CreateMandateParams{
+		DebtorAccountID:   debtorAccount.ID,
+		CreditorAccountID: creditorAccount.ID,
+		MaxAmount:         maxAmount,
+		Frequency:         frequency,
+		Reference:         util.RandomString(10),
+		GrantedBy:         debtorAccount.Owner,
+	}
+
+	mandate, err := testQueries.CreateMandate(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, mandate.ID)
+	require.Equal(t, arg.DebtorAccountID, mandate.DebtorAccountID)
+	require.Equal(t, arg.CreditorAccountID, mandate.CreditorAccountID)
+	require.Equal(t, arg.MaxAmount, mandate.MaxAmount)
+	require.Equal(t, arg.Frequency, mandate.Frequency)
+	require.Equal(t, MandateStatusActive, mandate.Status)
+	require.False(t, mandate.RevokedAt.Valid)
+
+	return mandate, creditorAccount
+}
+
+func TestCollectDirectDebitTx(t *testing.T) {
+	store := NewStore(testDB)
+	debtorAccount := createAccountWithBalance(t, "USD", 1000)
+	mandate, creditorAccount := createRandomMandate(t, debtorAccount, 300, MandateFrequencyMonthly)
+
+	_, err := store.CollectDirectDebitTx(context.Background(), CollectDirectDebitTxParams{
+		MandateID: mandate.ID,
+		Amount:    400,
+	})
+	require.ErrorIs(t, err, ErrMandateAmountExceeded)
+
+	result, err := store.CollectDirectDebitTx(context.Background(), CollectDirectDebitTxParams{
+		MandateID: mandate.ID,
+		Amount:    250,
+	})
+	require.NoError(t, err)
+	require.Equal(t, mandate.ID, result.DirectDebit.MandateID)
+	require.Equal(t, result.Transfer.Transfer.ID, result.DirectDebit.TransferID)
+	require.Equal(t, int64(250), result.DirectDebit.Amount)
+	require.Equal(t, DirectDebitStatusCollected, result.DirectDebit.Status)
+	require.Equal(t, debtorAccount.ID, result.Transfer.Transfer.FromAccountID)
+	require.Equal(t, creditorAccount.ID, result.Transfer.Transfer.ToAccountID)
+	require.Equal(t, int64(750), result.Transfer.FromAccount.Balance)
+	require.Equal(t, int64(250), result.Transfer.ToAccount.Balance)
+
+	// the mandate is monthly, so it can't be collected again this month
+	_, err = store.CollectDirectDebitTx(context.Background(), CollectDirectDebitTxParams{
+		MandateID: mandate.ID,
+		Amount:    50,
+	})
+	require.ErrorIs(t, err, ErrMandateTooFrequent)
+
+	revoked, err := testQueries.RevokeMandate(context.Background(), mandate.ID)
+	require.NoError(t, err)
+	require.Equal(t, MandateStatusRevoked, revoked.Status)
+	require.True(t, revoked.RevokedAt.Valid)
+
+	_, err = store.CollectDirectDebitTx(context.Background(), CollectDirectDebitTxParams{
+		MandateID: mandate.ID,
+		Amount:    50,
+	})
+	require.ErrorIs(t, err, ErrMandateNotActive)
+}
+
+func TestCollectDirectDebitTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+	debtorAccount := createAccountWithBalance(t, "USD", 100)
+	mandate, _ := createRandomMandate(t, debtorAccount, 300, MandateFrequencyDaily)
+
+	_, err := store.CollectDirectDebitTx(context.Background(), CollectDirectDebitTxParams{
+		MandateID: mandate.ID,
+		Amount:    200,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// the failed collection was rolled back, so it doesn't count against the frequency
+	result, err := store.CollectDirectDebitTx(context.Background(), CollectDirectDebitTxParams{
+		MandateID: mandate.ID,
+		Amount:    100,
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.Transfer.FromAccount.Balance)
+}
+
+func TestDisputeDirectDebitTx(t *testing.T) {
+	store := NewStore(testDB)
+	debtorAccount := createAccountWithBalance(t, "USD", 1000)
+	mandate, creditorAccount := createRandomMandate(t, debtorAccount, 300, MandateFrequencyWeekly)
+
+	collected, err := store.CollectDirectDebitTx(context.Background(), CollectDirectDebitTxParams{
+		MandateID: mandate.ID,
+		Amount:    300,
+	})
+	require.NoError(t, err)
+
+	_, err = store.DisputeDirectDebitTx(context.Background(), DisputeDirectDebitTxParams{
+		DirectDebitID: collected.DirectDebit.ID,
+		Reason:        "not authorized",
+		DisputedBy:    debtorAccount.Owner,
+		Window:        0,
+	})
+	require.ErrorIs(t, err, ErrDisputeWindowClosed)
+
+	result, err := store.DisputeDirectDebitTx(context.Background(), DisputeDirectDebitTxParams{
+		DirectDebitID: collected.DirectDebit.ID,
+		Reason:        "not authorized",
+		DisputedBy:    debtorAccount.Owner,
+		Window:        time.Hour,
+	})
+	require.NoError(t, err)
+	require.Equal(t, DirectDebitStatusDisputed, result.DirectDebit.Status)
+	require.Equal(t, "not authorized", result.DirectDebit.DisputeReason)
+	require.True(t, result.DirectDebit.DisputedAt.Valid)
+	require.Equal(t, result.Reversal.Reversal.Transfer.ID, result.DirectDebit.ReversalID.Int64)
+	require.Equal(t, collected.DirectDebit.TransferID, result.Reversal.TransferReversal.TransferID)
+	require.Equal(t, debtorAccount.Owner, result.Reversal.TransferReversal.ReversedBy)
+
+	// the debtor got the money back from the creditor
+	require.Equal(t, int64(1000), result.Reversal.Reversal.ToAccount.Balance)
+	require.Equal(t, creditorAccount.ID, result.Reversal.Reversal.FromAccount.ID)
+	require.Zero(t, result.Reversal.Reversal.FromAccount.Balance)
+
+	_, err = store.DisputeDirectDebitTx(context.Background(), DisputeDirectDebitTxParams{
+		DirectDebitID: collected.DirectDebit.ID,
+		Reason:        "not authorized",
+		DisputedBy:    debtorAccount.Owner,
+		Window:        time.Hour,
+	})
+	require.ErrorIs(t, err, ErrDirectDebitAlreadyDisputed)
+}
+
+func TestMandateNextCollectionAt(t *testing.T) {
+	last := time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		frequency string
+		next      time.Time
+	}{
+		{MandateFrequencyDaily, time.Date(2026, time.February, 1, 9, 0, 0, 0, time.UTC)},
+		{MandateFrequencyWeekly, time.Date(2026, time.February, 7, 9, 0, 0, 0, time.UTC)},
+		// months are added the way time.AddDate does, so January 31 is followed by March 3
+		{MandateFrequencyMonthly, time.Date(2026, time.March, 3, 9, 0, 0, 0, time.UTC)},
+		{MandateFrequencyYearly, time.Date(2027, time.January, 31, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		mandate := Mandate{Frequency: tc.frequency}
+		require.Equal(t, tc.next, mandate.NextCollectionAt(last), tc.frequency)
+	}
+}
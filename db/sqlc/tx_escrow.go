@@ -0,0 +1,258 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Escrow statuses
+const (
+	EscrowStatusHeld     = "held"
+	EscrowStatusDisputed = "disputed"
+	EscrowStatusReleased = "released"
+	EscrowStatusRefunded = "refunded"
+)
+
+var (
+	ErrEscrowNotHeld           = errors.New("escrow is no longer held")
+	ErrEscrowNotDisputed       = errors.New("escrow is not disputed")
+	ErrEscrowDeadlineNotPassed = errors.New("escrow deadline has not passed yet")
+)
+
+// CreateEscrowTxParams contains the input parameters of the create escrow transaction
+type CreateEscrowTxParams struct {
+	PayerAccountID int64     `json:"payer_account_id"`
+	PayeeAccountID int64     `json:"payee_account_id"`
+	Amount         int64     `json:"amount"`
+	Description    string    `json:"description"`
+	Deadline       time.Time `json:"deadline"`
+	CreatedBy      string    `json:"created_by"`
+}
+
+// CreateEscrowTxResult is the result of the create escrow transaction
+type CreateEscrowTxResult struct {
+	Escrow   Escrow           `json:"escrow"`
+	Account  Account          `json:"account"`
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// CreateEscrowTx opens the escrow account of a new escrow, owned by the bank in the currency of the payer account,
+// and funds it with a transfer of the amount from the payer account. The payee account has to be in the same currency.
+// It returns ErrInsufficientFunds when the payer account doesn't cover the amount.
+func (store *SQLStore) CreateEscrowTx(ctx context.Context, arg CreateEscrowTxParams) (CreateEscrowTxResult, error) {
+	var result CreateEscrowTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		payerAccount, err := q.GetAccount(ctx, arg.PayerAccountID)
+		if err != nil {
+			return err
+		}
+
+		account, err := q.CreateAccount(ctx, CreateAccountParams{
+			Owner:    SystemUsername,
+			Balance:  0,
+			Currency: payerAccount.Currency,
+			Type:     AccountTypeEscrow,
+		})
+		if err != nil {
+			return err
+		}
+
+		description := arg.Description
+		if description == "" {
+			description = fmt.Sprintf("escrow for account %d", arg.PayeeAccountID)
+		}
+
+		result.Transfer, err = transfer(ctx, q, TransferTxParams{
+			FromAccountID: payerAccount.ID,
+			ToAccountID:   account.ID,
+			Amount:        arg.Amount,
+			Description:   description,
+		})
+		if err != nil {
+			return err
+		}
+		result.Account = result.Transfer.ToAccount
+
+		result.Escrow, err = q.CreateEscrow(ctx, CreateEscrowParams{
+			AccountID:         account.ID,
+			PayerAccountID:    payerAccount.ID,
+			PayeeAccountID:    arg.PayeeAccountID,
+			Amount:            arg.Amount,
+			Description:       arg.Description,
+			Deadline:          arg.Deadline,
+			CreatedBy:         arg.CreatedBy,
+			FundingTransferID: result.Transfer.Transfer.ID,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// ReleaseEscrowTxParams contains the input parameters of the release escrow transaction
+type ReleaseEscrowTxParams struct {
+	EscrowID   int64  `json:"escrow_id"`
+	ReleasedBy string `json:"released_by"`
+}
+
+// EscrowTxResult is the result of the transactions settling an escrow
+type EscrowTxResult struct {
+	Escrow   Escrow           `json:"escrow"`
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// ReleaseEscrowTx pays an escrow that is still held out to the payee, on behalf of the payer.
+// It returns ErrEscrowNotHeld once the escrow is disputed or settled.
+func (store *SQLStore) ReleaseEscrowTx(ctx context.Context, arg ReleaseEscrowTxParams) (EscrowTxResult, error) {
+	var result EscrowTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		escrow, err := q.GetEscrowForUpdate(ctx, arg.EscrowID)
+		if err != nil {
+			return err
+		}
+
+		if escrow.Status != EscrowStatusHeld {
+			return ErrEscrowNotHeld
+		}
+
+		result, err = payOutEscrow(ctx, q, payOutEscrowParams{
+			Escrow: escrow,
+			Status: EscrowStatusReleased,
+			SettledBy: sql.NullString{
+				String: arg.ReleasedBy,
+				Valid:  true,
+			},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// ResolveEscrowTxParams contains the input parameters of the resolve escrow transaction
+type ResolveEscrowTxParams struct {
+	EscrowID int64 `json:"escrow_id"`
+	// Status is EscrowStatusReleased to pay the payee, or EscrowStatusRefunded to pay the payer back
+	Status     string `json:"status"`
+	Note       string `json:"note"`
+	ResolvedBy string `json:"resolved_by"`
+}
+
+// ResolveEscrowTx settles a disputed escrow the way the arbiter decided, releasing it to the payee or refunding it
+// to the payer. It returns ErrEscrowNotDisputed when the escrow isn't disputed.
+func (store *SQLStore) ResolveEscrowTx(ctx context.Context, arg ResolveEscrowTxParams) (EscrowTxResult, error) {
+	var result EscrowTxResult
+
+	if arg.Status != EscrowStatusReleased && arg.Status != EscrowStatusRefunded {
+		return result, fmt.Errorf("escrow can't be resolved as %q", arg.Status)
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		escrow, err := q.GetEscrowForUpdate(ctx, arg.EscrowID)
+		if err != nil {
+			return err
+		}
+
+		if escrow.Status != EscrowStatusDisputed {
+			return ErrEscrowNotDisputed
+		}
+
+		result, err = payOutEscrow(ctx, q, payOutEscrowParams{
+			Escrow: escrow,
+			Status: arg.Status,
+			SettledBy: sql.NullString{
+				String: arg.ResolvedBy,
+				Valid:  true,
+			},
+			Note: arg.Note,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// RefundEscrowTx pays an escrow that is still held back to the payer once its deadline has passed.
+// Disputed escrows are left to the arbiter. It returns ErrEscrowNotHeld when the escrow was disputed or settled
+// in the meantime, and ErrEscrowDeadlineNotPassed before its deadline.
+func (store *SQLStore) RefundEscrowTx(ctx context.Context, escrowID int64) (EscrowTxResult, error) {
+	var result EscrowTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		escrow, err := q.GetEscrowForUpdate(ctx, escrowID)
+		if err != nil {
+			return err
+		}
+
+		if escrow.Status != EscrowStatusHeld {
+			return ErrEscrowNotHeld
+		}
+
+		if time.Now().Before(escrow.Deadline) {
+			return ErrEscrowDeadlineNotPassed
+		}
+
+		result, err = payOutEscrow(ctx, q, payOutEscrowParams{
+			Escrow: escrow,
+			Status: EscrowStatusRefunded,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type payOutEscrowParams struct {
+	Escrow    Escrow
+	Status    string
+	SettledBy sql.NullString
+	Note      string
+}
+
+// payOutEscrow empties the escrow account into the payee account when the escrow is released,
+// or into the payer account when it is refunded, and records the outcome.
+// It must be called inside a transaction holding the lock on the escrow row.
+func payOutEscrow(ctx context.Context, q *Queries, arg payOutEscrowParams) (EscrowTxResult, error) {
+	var result EscrowTxResult
+
+	metadata, err := json.Marshal(map[string]int64{"escrow_id": arg.Escrow.ID})
+	if err != nil {
+		return result, err
+	}
+
+	toAccountID := arg.Escrow.PayeeAccountID
+	description := fmt.Sprintf("release of escrow %d", arg.Escrow.ID)
+	if arg.Status == EscrowStatusRefunded {
+		toAccountID = arg.Escrow.PayerAccountID
+		description = fmt.Sprintf("refund of escrow %d", arg.Escrow.ID)
+	}
+
+	result.Transfer, err = transfer(ctx, q, TransferTxParams{
+		FromAccountID: arg.Escrow.AccountID,
+		ToAccountID:   toAccountID,
+		Amount:        arg.Escrow.Amount,
+		Description:   description,
+		Metadata:      metadata,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Escrow, err = q.SettleEscrow(ctx, SettleEscrowParams{
+		ID:     arg.Escrow.ID,
+		Status: arg.Status,
+		SettlementTransferID: sql.NullInt64{
+			Int64: result.Transfer.Transfer.ID,
+			Valid: true,
+		},
+		SettledBy:      arg.SettledBy,
+		ResolutionNote: arg.Note,
+	})
+	return result, err
+}
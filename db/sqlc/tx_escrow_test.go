@@ -0,0 +1,190 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomEscrow funds a new escrow from the payer account to a new payee account for testing
+func createRandomEscrow(t *testing.T, payerAccount Account, amount int64, deadline time.Time) (CreateEscrowTxResult, Account) {
+	store := NewStore(testDB)
+	payeeAccount := createAccountWithBalance(t, payerAccount.Currency, 0)
+
+	arg := CreateEscrowTxParams{
+		PayerAccountID: payerAccount.ID,
+		PayeeAccountID: payeeAccount.ID,
+		Amount:         amount,
+		Description:    "second-hand bike",
+		Deadline:       deadline,
+		CreatedBy:      payerAccount.Owner,
+	}
+
+	result, err := store.CreateEscrowTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, result.Escrow.ID)
+	require.Equal(t, result.Account.ID, result.Escrow.AccountID)
+	require.Equal(t, arg.PayerAccountID, result.Escrow.PayerAccountID)
+	require.Equal(t, arg.PayeeAccountID, result.Escrow.PayeeAccountID)
+	require.Equal(t, arg.Amount, result.Escrow.Amount)
+	require.Equal(t, EscrowStatusHeld, result.Escrow.Status)
+	require.WithinDuration(t, arg.Deadline, result.Escrow.Deadline, time.Second)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Escrow.FundingTransferID)
+	require.False(t, result.Escrow.SettlementTransferID.Valid)
+
+	// the escrow account belongs to the bank and holds the amount
+	require.Equal(t, SystemUsername, result.Account.Owner)
+	require.Equal(t, AccountTypeEscrow, result.Account.Type)
+	require.Equal(t, payerAccount.Currency, result.Account.Currency)
+	require.Equal(t, amount, result.Account.Balance)
+
+	return result, payeeAccount
+}
+
+func TestCreateEscrowTx(t *testing.T) {
+	store := NewStore(testDB)
+	payerAccount := createAccountWithBalance(t, "EUR", 1000)
+
+	result, _ := createRandomEscrow(t, payerAccount, 400, time.Now().Add(time.Hour))
+	require.Equal(t, payerAccount.ID, result.Transfer.Transfer.FromAccountID)
+	require.Equal(t, result.Account.ID, result.Transfer.Transfer.ToAccountID)
+	require.Equal(t, int64(600), result.Transfer.FromAccount.Balance)
+	require.Equal(t, int64(-400), result.Transfer.FromEntry.Amount)
+	require.Equal(t, int64(400), result.Transfer.ToEntry.Amount)
+
+	payeeAccount := createAccountWithBalance(t, "EUR", 0)
+	_, err := store.CreateEscrowTx(context.Background(), CreateEscrowTxParams{
+		PayerAccountID: payerAccount.ID,
+		PayeeAccountID: payeeAccount.ID,
+		Amount:         700,
+		Deadline:       time.Now().Add(time.Hour),
+		CreatedBy:      payerAccount.Owner,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestReleaseEscrowTx(t *testing.T) {
+	store := NewStore(testDB)
+	payerAccount := createAccountWithBalance(t, "EUR", 1000)
+	created, payeeAccount := createRandomEscrow(t, payerAccount, 400, time.Now().Add(time.Hour))
+
+	result, err := store.ReleaseEscrowTx(context.Background(), ReleaseEscrowTxParams{
+		EscrowID:   created.Escrow.ID,
+		ReleasedBy: payerAccount.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, EscrowStatusReleased, result.Escrow.Status)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Escrow.SettlementTransferID.Int64)
+	require.Equal(t, sql.NullString{String: payerAccount.Owner, Valid: true}, result.Escrow.SettledBy)
+	require.True(t, result.Escrow.SettledAt.Valid)
+	require.Equal(t, created.Account.ID, result.Transfer.Transfer.FromAccountID)
+	require.Equal(t, payeeAccount.ID, result.Transfer.Transfer.ToAccountID)
+	require.Zero(t, result.Transfer.FromAccount.Balance)
+	require.Equal(t, int64(400), result.Transfer.ToAccount.Balance)
+
+	_, err = store.ReleaseEscrowTx(context.Background(), ReleaseEscrowTxParams{
+		EscrowID:   created.Escrow.ID,
+		ReleasedBy: payerAccount.Owner,
+	})
+	require.ErrorIs(t, err, ErrEscrowNotHeld)
+}
+
+func TestResolveEscrowTx(t *testing.T) {
+	store := NewStore(testDB)
+	payerAccount := createAccountWithBalance(t, "EUR", 1000)
+	created, payeeAccount := createRandomEscrow(t, payerAccount, 400, time.Now().Add(time.Hour))
+
+	_, err := store.ResolveEscrowTx(context.Background(), ResolveEscrowTxParams{
+		EscrowID:   created.Escrow.ID,
+		Status:     EscrowStatusRefunded,
+		ResolvedBy: payerAccount.Owner,
+	})
+	require.ErrorIs(t, err, ErrEscrowNotDisputed)
+
+	disputed, err := testQueries.DisputeEscrow(context.Background(), DisputeEscrowParams{
+		ID:            created.Escrow.ID,
+		DisputeReason: "bike never delivered",
+		DisputedBy:    sql.NullString{String: payerAccount.Owner, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, EscrowStatusDisputed, disputed.Status)
+	require.True(t, disputed.DisputedAt.Valid)
+
+	// a disputed escrow can neither be disputed again nor released by the payer
+	_, err = testQueries.DisputeEscrow(context.Background(), DisputeEscrowParams{
+		ID:            created.Escrow.ID,
+		DisputeReason: "again",
+		DisputedBy:    sql.NullString{String: payeeAccount.Owner, Valid: true},
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = store.ReleaseEscrowTx(context.Background(), ReleaseEscrowTxParams{
+		EscrowID:   created.Escrow.ID,
+		ReleasedBy: payerAccount.Owner,
+	})
+	require.ErrorIs(t, err, ErrEscrowNotHeld)
+
+	disputes, err := testQueries.ListDisputedEscrows(context.Background(), ListDisputedEscrowsParams{
+		Limit:  1000,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Contains(t, disputes, disputed)
+
+	result, err := store.ResolveEscrowTx(context.Background(), ResolveEscrowTxParams{
+		EscrowID:   created.Escrow.ID,
+		Status:     EscrowStatusRefunded,
+		Note:       "no proof of delivery",
+		ResolvedBy: payerAccount.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, EscrowStatusRefunded, result.Escrow.Status)
+	require.Equal(t, "no proof of delivery", result.Escrow.ResolutionNote)
+	require.Equal(t, payerAccount.ID, result.Transfer.Transfer.ToAccountID)
+	require.Zero(t, result.Transfer.FromAccount.Balance)
+	require.Equal(t, int64(1000), result.Transfer.ToAccount.Balance)
+}
+
+func TestRefundEscrowTx(t *testing.T) {
+	store := NewStore(testDB)
+	payerAccount := createAccountWithBalance(t, "EUR", 1000)
+	pending, _ := createRandomEscrow(t, payerAccount, 100, time.Now().Add(time.Hour))
+	expired, _ := createRandomEscrow(t, payerAccount, 200, time.Now().Add(-time.Minute))
+	disputed, _ := createRandomEscrow(t, payerAccount, 300, time.Now().Add(-time.Minute))
+
+	_, err := testQueries.DisputeEscrow(context.Background(), DisputeEscrowParams{
+		ID:            disputed.Escrow.ID,
+		DisputeReason: "bike never delivered",
+		DisputedBy:    sql.NullString{String: payerAccount.Owner, Valid: true},
+	})
+	require.NoError(t, err)
+
+	escrows, err := testQueries.ListExpiredEscrows(context.Background(), time.Now())
+	require.NoError(t, err)
+
+	ids := make([]int64, 0, len(escrows))
+	for _, escrow := range escrows {
+		ids = append(ids, escrow.ID)
+	}
+	require.Contains(t, ids, expired.Escrow.ID)
+	require.NotContains(t, ids, pending.Escrow.ID)
+	require.NotContains(t, ids, disputed.Escrow.ID)
+
+	_, err = store.RefundEscrowTx(context.Background(), pending.Escrow.ID)
+	require.ErrorIs(t, err, ErrEscrowDeadlineNotPassed)
+
+	_, err = store.RefundEscrowTx(context.Background(), disputed.Escrow.ID)
+	require.ErrorIs(t, err, ErrEscrowNotHeld)
+
+	result, err := store.RefundEscrowTx(context.Background(), expired.Escrow.ID)
+	require.NoError(t, err)
+	require.Equal(t, EscrowStatusRefunded, result.Escrow.Status)
+	require.False(t, result.Escrow.SettledBy.Valid)
+	require.Equal(t, payerAccount.ID, result.Transfer.Transfer.ToAccountID)
+	require.Equal(t, int64(200), result.Transfer.Transfer.Amount)
+	// 100 and 300 are still held
+	require.Equal(t, int64(600), result.Transfer.ToAccount.Balance)
+}
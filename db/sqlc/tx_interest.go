@@ -0,0 +1,142 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SystemUsername owns the internal accounts of the bank
+const SystemUsername = "system"
+
+// Purposes of the internal accounts of the bank
+const (
+	SystemAccountInterestExpense         = "interest_expense"
+	SystemAccountFeeIncome               = "fee_income"
+	SystemAccountOverdraftInterestIncome = "overdraft_interest_income"
+	SystemAccountLoanInterestIncome      = "loan_interest_income"
+	SystemAccountCardSettlement          = "card_settlement"
+	SystemAccountReconciliationSuspense  = "reconciliation_suspense"
+)
+
+// InterestAmountScale is the number of units of interest accruals in a cent:
+// accruals are kept in millionths of a cent so that small daily amounts don't round away
+const InterestAmountScale = 1_000_000
+
+var ErrInterestAlreadyPosted = errors.New("interest is already posted for the period")
+
+// PostInterestTxParams contains the input parameters of the post interest transaction
+type PostInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// PeriodEnd is the last day of the period, interest accrued up to and including it is posted
+	PeriodEnd time.Time `json:"period_end"`
+}
+
+// PostInterestTxResult is the result of the post interest transaction.
+// JournalEntry is only set when at least a cent was credited or debited.
+type PostInterestTxResult struct {
+	InterestPosting InterestPosting `json:"interest_posting"`
+	Account         Account         `json:"account"`
+	JournalEntry    *JournalEntry   `json:"journal_entry,omitempty"`
+}
+
+// PostInterestTx credits the whole cents of interest accrued by an account up to the end of a period
+// and not posted yet, debiting the interest expense account of the bank in the same journal entry.
+// Overdraft interest accrues negative amounts, which are debited to the account instead.
+// Fractions of a cent are carried over to the next period. A period is only posted once:
+// it returns ErrInterestAlreadyPosted when the account already has a posting for the period.
+func (store *SQLStore) PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error) {
+	var result PostInterestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// the lock serializes the postings of the account, which depend on the previous ones
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		result.Account = account
+
+		_, err = q.GetInterestPosting(ctx, GetInterestPostingParams{
+			AccountID: account.ID,
+			PeriodEnd: arg.PeriodEnd,
+		})
+		if err == nil {
+			return ErrInterestAlreadyPosted
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		accrued, err := q.GetAccruedInterest(ctx, GetAccruedInterestParams{
+			AccountID: account.ID,
+			PeriodEnd: arg.PeriodEnd,
+		})
+		if err != nil {
+			return err
+		}
+
+		posted, err := q.GetPostedInterest(ctx, GetPostedInterestParams{
+			AccountID: account.ID,
+			PeriodEnd: arg.PeriodEnd,
+		})
+		if err != nil {
+			return err
+		}
+
+		posting := CreateInterestPostingParams{
+			AccountID: account.ID,
+			PeriodEnd: arg.PeriodEnd,
+			Accrued:   accrued,
+			Amount:    accrued/InterestAmountScale - posted,
+		}
+
+		if posting.Amount != 0 {
+			journal, err := postInterest(ctx, q, account, posting.Amount, arg.PeriodEnd)
+			if err != nil {
+				return err
+			}
+
+			result.JournalEntry = &journal.JournalEntry
+			result.Account = journal.Accounts[account.ID]
+			posting.JournalEntryID = sql.NullInt64{
+				Int64: journal.JournalEntry.ID,
+				Valid: true,
+			}
+		}
+
+		result.InterestPosting, err = q.CreateInterestPosting(ctx, posting)
+		return err
+	})
+
+	return result, err
+}
+
+// postInterest books the interest of an account: a positive amount is credited from the interest expense account
+// of the bank, a negative amount is overdraft interest taken from it into the overdraft interest income account
+func postInterest(ctx context.Context, q *Queries, account Account, amount int64, periodEnd time.Time) (postJournalEntryResult, error) {
+	purpose := SystemAccountInterestExpense
+	description := fmt.Sprintf("interest up to %s", periodEnd.Format(time.DateOnly))
+	if amount < 0 {
+		purpose = SystemAccountOverdraftInterestIncome
+		description = "overdraft " + description
+	}
+
+	systemAccount, err := q.GetSystemAccount(ctx, GetSystemAccountParams{
+		Purpose:  purpose,
+		Currency: account.Currency,
+	})
+	if err != nil {
+		return postJournalEntryResult{}, fmt.Errorf("cannot get %s %s account: %w", account.Currency, purpose, err)
+	}
+
+	return postJournalEntry(ctx, q, postJournalEntryParams{
+		Kind:        JournalKindInterest,
+		Description: description,
+		Postings: []Posting{
+			{AccountID: systemAccount.AccountID, Amount: -amount},
+			{AccountID: account.ID, Amount: amount},
+		},
+	})
+}
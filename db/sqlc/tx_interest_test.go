@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createSavingsAccount opens a random savings account for testing
+func createSavingsAccount(t *testing.T, currency string) Account {
+	user := createRandomUser(t)
+	plan := createRandomInterestPlan(t)
+
+	result, err := NewStore(testDB).CreateSavingsAccountTx(context.Background(), CreateSavingsAccountTxParams{
+		Owner:          user.Username,
+		Currency:       currency,
+		InterestPlanID: plan.ID,
+	})
+	require.NoError(t, err)
+
+	return result.Account
+}
+
+// accrueInterest records an accrual of the given amount in millionths of a cent for testing
+func accrueInterest(t *testing.T, account Account, day time.Time, amount int64) {
+	_, err := testQueries.CreateInterestAccrual(context.Background(), CreateInterestAccrualParams{
+		AccountID:     account.ID,
+		AccrualDate:   day,
+		Balance:       account.Balance,
+		AnnualRateBps: 250,
+		DayCount:      "ACT/365",
+		Amount:        amount,
+	})
+	require.NoError(t, err)
+}
+
+// TestPostInterestTx tests that accrued interest is credited once per period from the interest expense account,
+// with the fractions of a cent carried over to the next period
+func TestPostInterestTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createSavingsAccount(t, "USD")
+
+	expense, err := testQueries.GetSystemAccount(context.Background(), GetSystemAccountParams{
+		Purpose:  SystemAccountInterestExpense,
+		Currency: "USD",
+	})
+	require.NoError(t, err)
+	expenseAccount, err := store.GetAccount(context.Background(), expense.AccountID)
+	require.NoError(t, err)
+	require.Equal(t, AccountTypeInternal, expenseAccount.Type)
+
+	january := time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC)
+	february := time.Date(2026, time.February, 28, 0, 0, 0, 0, time.UTC)
+
+	// 1.5 cents accrued in January, 1 cent is credited
+	accrueInterest(t, account, time.Date(2026, time.January, 30, 0, 0, 0, 0, time.UTC), InterestAmountScale)
+	accrueInterest(t, account, january, InterestAmountScale/2)
+
+	result, err := store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		PeriodEnd: january,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.InterestPosting.Amount)
+	require.Equal(t, int64(3*InterestAmountScale/2), result.InterestPosting.Accrued)
+	require.NotNil(t, result.JournalEntry)
+	require.Equal(t, JournalKindInterest, result.JournalEntry.Kind)
+	require.Equal(t, result.JournalEntry.ID, result.InterestPosting.JournalEntryID.Int64)
+	require.Equal(t, int64(1), result.Account.Balance)
+
+	updatedExpense, err := store.GetAccount(context.Background(), expense.AccountID)
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), updatedExpense.Balance-expenseAccount.Balance)
+
+	// the same period is only posted once
+	_, err = store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		PeriodEnd: january,
+	})
+	require.ErrorIs(t, err, ErrInterestAlreadyPosted)
+
+	// the half cent left over from January makes a cent with February
+	accrueInterest(t, account, february, InterestAmountScale/2)
+
+	result, err = store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		PeriodEnd: february,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.InterestPosting.Amount)
+	require.Equal(t, int64(2), result.Account.Balance)
+}
+
+// TestPostInterestTxBelowOneCent tests that a period accruing less than a cent is posted without a journal entry
+func TestPostInterestTxBelowOneCent(t *testing.T) {
+	store := NewStore(testDB)
+	account := createSavingsAccount(t, "EUR")
+	day := time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	accrueInterest(t, account, day, InterestAmountScale-1)
+
+	result, err := store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		PeriodEnd: day,
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.InterestPosting.Amount)
+	require.False(t, result.InterestPosting.JournalEntryID.Valid)
+	require.Nil(t, result.JournalEntry)
+	require.Zero(t, result.Account.Balance)
+}
+
+// TestPostInterestTxOverdraft tests that overdraft interest is debited to the account for the overdraft interest income account
+func TestPostInterestTxOverdraft(t *testing.T) {
+	store := NewStore(testDB)
+	account := createAccountWithBalance(t, "UAH", 0)
+	day := time.Date(2026, time.April, 30, 0, 0, 0, 0, time.UTC)
+
+	income, err := testQueries.GetSystemAccount(context.Background(), GetSystemAccountParams{
+		Purpose:  SystemAccountOverdraftInterestIncome,
+		Currency: "UAH",
+	})
+	require.NoError(t, err)
+	incomeAccount, err := store.GetAccount(context.Background(), income.AccountID)
+	require.NoError(t, err)
+
+	// 2.5 cents of overdraft interest, 2 cents are debited
+	accrueInterest(t, account, day, -5*InterestAmountScale/2)
+
+	result, err := store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		PeriodEnd: day,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(-2), result.InterestPosting.Amount)
+	require.NotNil(t, result.JournalEntry)
+	require.Equal(t, JournalKindInterest, result.JournalEntry.Kind)
+	require.Equal(t, "overdraft interest up to 2026-04-30", result.JournalEntry.Description)
+	require.Equal(t, int64(-2), result.Account.Balance)
+
+	updatedIncome, err := store.GetAccount(context.Background(), income.AccountID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), updatedIncome.Balance-incomeAccount.Balance)
+}
@@ -0,0 +1,391 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Loan repayment methods
+const (
+	// LoanMethodAnnuity loans are repaid in equal installments of principal and interest
+	LoanMethodAnnuity = "annuity"
+	// LoanMethodLinear loans repay the same principal every month, with decreasing interest
+	LoanMethodLinear = "linear"
+)
+
+// Loan statuses
+const (
+	LoanStatusActive = "active"
+	LoanStatusRepaid = "repaid"
+)
+
+// Loan installment statuses
+const (
+	LoanInstallmentStatusPending = "pending"
+	LoanInstallmentStatusPaid    = "paid"
+	// LoanInstallmentStatusArrears installments couldn't be collected in full on their due date
+	LoanInstallmentStatusArrears = "arrears"
+)
+
+var (
+	ErrInvalidLoanSchedule = errors.New("the installments don't repay the principal of the loan over its term")
+	ErrLoanNotActive       = errors.New("loan is already repaid")
+	ErrLoanInstallmentPaid = errors.New("loan installment is already paid")
+)
+
+// LoanInstallmentParams is an installment of the repayment schedule of a loan
+type LoanInstallmentParams struct {
+	DueDate   time.Time `json:"due_date"`
+	Principal int64     `json:"principal"`
+	Interest  int64     `json:"interest"`
+}
+
+// OriginateLoanTxParams contains the input parameters of the originate loan transaction
+type OriginateLoanTxParams struct {
+	// RepaymentAccountID is the account the loan is disbursed to and the installments are collected from
+	RepaymentAccountID int64  `json:"repayment_account_id"`
+	Principal          int64  `json:"principal"`
+	AnnualRateBps      int32  `json:"annual_rate_bps"`
+	TermMonths         int32  `json:"term_months"`
+	Method             string `json:"method"`
+	OriginatedBy       string `json:"originated_by"`
+	// Installments is the repayment schedule, one installment per month of the term
+	Installments []LoanInstallmentParams `json:"installments"`
+}
+
+// OriginateLoanTxResult is the result of the originate loan transaction
+type OriginateLoanTxResult struct {
+	Loan             Loan              `json:"loan"`
+	Installments     []LoanInstallment `json:"installments"`
+	Account          Account           `json:"account"`
+	RepaymentAccount Account           `json:"repayment_account"`
+	JournalEntry     JournalEntry      `json:"journal_entry"`
+}
+
+// OriginateLoanTx opens a loan account for the owner of the repayment account and disburses the principal
+// from it to the repayment account in a single journal entry, so the loan account holds the principal to repay
+// as a negative balance. It returns sql.ErrNoRows when the repayment account doesn't exist,
+// and ErrInvalidLoanSchedule when the installments don't add up to the principal over the term.
+func (store *SQLStore) OriginateLoanTx(ctx context.Context, arg OriginateLoanTxParams) (OriginateLoanTxResult, error) {
+	var result OriginateLoanTxResult
+
+	if len(arg.Installments) != int(arg.TermMonths) {
+		return result, ErrInvalidLoanSchedule
+	}
+	var principal int64
+	for _, installment := range arg.Installments {
+		principal += installment.Principal
+	}
+	if principal != arg.Principal {
+		return result, ErrInvalidLoanSchedule
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		repaymentAccount, err := q.GetAccount(ctx, arg.RepaymentAccountID)
+		if err != nil {
+			return err
+		}
+
+		account, err := q.CreateAccount(ctx, CreateAccountParams{
+			Owner:    repaymentAccount.Owner,
+			Balance:  0,
+			Currency: repaymentAccount.Currency,
+			Type:     AccountTypeLoan,
+		})
+		if err != nil {
+			return err
+		}
+
+		journal, err := postJournalEntry(ctx, q, postJournalEntryParams{
+			Kind:        JournalKindLoan,
+			Description: fmt.Sprintf("disbursement of loan account %d", account.ID),
+			Postings: []Posting{
+				{AccountID: account.ID, Amount: -arg.Principal},
+				{AccountID: repaymentAccount.ID, Amount: arg.Principal},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.JournalEntry = journal.JournalEntry
+		result.Account = journal.Accounts[account.ID]
+		result.RepaymentAccount = journal.Accounts[repaymentAccount.ID]
+
+		result.Loan, err = q.CreateLoan(ctx, CreateLoanParams{
+			AccountID:          account.ID,
+			RepaymentAccountID: repaymentAccount.ID,
+			Principal:          arg.Principal,
+			AnnualRateBps:      arg.AnnualRateBps,
+			TermMonths:         arg.TermMonths,
+			Method:             arg.Method,
+			OriginatedBy:       arg.OriginatedBy,
+			JournalEntryID:     journal.JournalEntry.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Installments = make([]LoanInstallment, 0, len(arg.Installments))
+		for i, params := range arg.Installments {
+			installment, err := q.CreateLoanInstallment(ctx, CreateLoanInstallmentParams{
+				LoanID:    result.Loan.ID,
+				Number:    int32(i + 1),
+				DueDate:   params.DueDate,
+				Principal: params.Principal,
+				Interest:  params.Interest,
+			})
+			if err != nil {
+				return err
+			}
+			result.Installments = append(result.Installments, installment)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// CollectLoanInstallmentTxParams contains the input parameters of the collect loan installment transaction
+type CollectLoanInstallmentTxParams struct {
+	InstallmentID int64 `json:"installment_id"`
+}
+
+// CollectLoanInstallmentTxResult is the result of the collect loan installment transaction.
+// JournalEntry is only set when something was collected.
+type CollectLoanInstallmentTxResult struct {
+	Installment      LoanInstallment `json:"installment"`
+	Loan             Loan            `json:"loan"`
+	RepaymentAccount Account         `json:"repayment_account"`
+	// Collected is the part of the installment collected by this transaction, in cents
+	Collected    int64         `json:"collected"`
+	JournalEntry *JournalEntry `json:"journal_entry,omitempty"`
+}
+
+// CollectLoanInstallmentTx collects what is left to pay of a due installment from the repayment account of its loan,
+// interest first. The principal is credited to the loan account and the interest to the loan interest income
+// account of the bank. When the balance doesn't cover the installment, the balance is collected and the installment
+// is left in arrears, to be collected again later. The loan is repaid once all its installments are paid.
+func (store *SQLStore) CollectLoanInstallmentTx(ctx context.Context, arg CollectLoanInstallmentTxParams) (CollectLoanInstallmentTxResult, error) {
+	var result CollectLoanInstallmentTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		installment, err := q.GetLoanInstallment(ctx, arg.InstallmentID)
+		if err != nil {
+			return err
+		}
+
+		// the lock serializes the collections and repayments of the loan
+		result.Loan, err = q.GetLoanForUpdate(ctx, installment.LoanID)
+		if err != nil {
+			return err
+		}
+		if result.Loan.Status != LoanStatusActive {
+			return ErrLoanNotActive
+		}
+
+		installment, err = q.GetLoanInstallment(ctx, arg.InstallmentID)
+		if err != nil {
+			return err
+		}
+		if installment.Status == LoanInstallmentStatusPaid {
+			return ErrLoanInstallmentPaid
+		}
+
+		// the lock keeps the balance from changing between the check and the collection
+		result.RepaymentAccount, err = q.GetAccountForUpdate(ctx, result.Loan.RepaymentAccountID)
+		if err != nil {
+			return err
+		}
+
+		available := max(result.RepaymentAccount.Balance, 0)
+		interest := min(installment.Interest-installment.PaidInterest, available)
+		principal := min(installment.Principal-installment.PaidPrincipal, available-interest)
+		result.Collected = interest + principal
+
+		update := UpdateLoanInstallmentParams{
+			ID:            installment.ID,
+			Interest:      installment.Interest,
+			PaidPrincipal: installment.PaidPrincipal + principal,
+			PaidInterest:  installment.PaidInterest + interest,
+			Status:        LoanInstallmentStatusArrears,
+		}
+		if update.PaidPrincipal == installment.Principal && update.PaidInterest == installment.Interest {
+			update.Status = LoanInstallmentStatusPaid
+			update.PaidAt = sql.NullTime{Time: time.Now(), Valid: true}
+		}
+
+		if result.Collected > 0 {
+			journal, err := postLoanRepayment(ctx, q, result.Loan, result.RepaymentAccount, principal, interest,
+				fmt.Sprintf("installment %d of loan %d", installment.Number, result.Loan.ID))
+			if err != nil {
+				return err
+			}
+
+			result.JournalEntry = &journal.JournalEntry
+			result.RepaymentAccount = journal.Accounts[result.RepaymentAccount.ID]
+		}
+
+		result.Installment, err = q.UpdateLoanInstallment(ctx, update)
+		if err != nil {
+			return err
+		}
+
+		if result.Installment.Status != LoanInstallmentStatusPaid {
+			return nil
+		}
+
+		unpaid, err := q.CountUnpaidLoanInstallments(ctx, result.Loan.ID)
+		if err != nil {
+			return err
+		}
+		if unpaid == 0 {
+			result.Loan, err = q.MarkLoanRepaid(ctx, result.Loan.ID)
+		}
+		return err
+	})
+
+	return result, err
+}
+
+// RepayLoanTxParams contains the input parameters of the repay loan transaction
+type RepayLoanTxParams struct {
+	LoanID int64 `json:"loan_id"`
+	// AsOf is the day of the repayment, which sets the interest due for the current installment
+	AsOf time.Time `json:"as_of"`
+}
+
+// RepayLoanTxResult is the result of the repay loan transaction
+type RepayLoanTxResult struct {
+	Loan             Loan              `json:"loan"`
+	Installments     []LoanInstallment `json:"installments"`
+	RepaymentAccount Account           `json:"repayment_account"`
+	// Principal and Interest are the cents collected by the repayment
+	Principal    int64        `json:"principal"`
+	Interest     int64        `json:"interest"`
+	JournalEntry JournalEntry `json:"journal_entry"`
+}
+
+// RepayLoanTx repays a loan early from its repayment account. Installments already due are paid in full,
+// the installment of the current month only bears the interest of the days elapsed since the previous due date,
+// and the later installments are paid without interest. It returns ErrLoanNotActive when the loan is already repaid,
+// and ErrInsufficientFunds when the balance of the repayment account doesn't cover the repayment.
+func (store *SQLStore) RepayLoanTx(ctx context.Context, arg RepayLoanTxParams) (RepayLoanTxResult, error) {
+	var result RepayLoanTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		loan, err := q.GetLoanForUpdate(ctx, arg.LoanID)
+		if err != nil {
+			return err
+		}
+		if loan.Status != LoanStatusActive {
+			return ErrLoanNotActive
+		}
+
+		result.RepaymentAccount, err = q.GetAccountForUpdate(ctx, loan.RepaymentAccountID)
+		if err != nil {
+			return err
+		}
+
+		installments, err := q.ListLoanInstallments(ctx, loan.ID)
+		if err != nil {
+			return err
+		}
+
+		day := arg.AsOf.UTC().Truncate(24 * time.Hour)
+		periodStart := loan.CreatedAt.UTC().Truncate(24 * time.Hour)
+		current := true
+		updates := make([]UpdateLoanInstallmentParams, 0, len(installments))
+
+		for _, installment := range installments {
+			dueDate := installment.DueDate.UTC()
+			if installment.Status == LoanInstallmentStatusPaid {
+				periodStart = dueDate
+				continue
+			}
+
+			interest := installment.Interest
+			if dueDate.After(day) {
+				interest = 0
+				if current {
+					// interest of the days elapsed in the current installment period
+					interest = installment.Interest * int64(day.Sub(periodStart)/(24*time.Hour)) /
+						int64(dueDate.Sub(periodStart)/(24*time.Hour))
+					current = false
+				}
+				interest = max(interest, installment.PaidInterest)
+			}
+			periodStart = dueDate
+
+			result.Principal += installment.Principal - installment.PaidPrincipal
+			result.Interest += interest - installment.PaidInterest
+			updates = append(updates, UpdateLoanInstallmentParams{
+				ID:            installment.ID,
+				Interest:      interest,
+				PaidPrincipal: installment.Principal,
+				PaidInterest:  interest,
+				Status:        LoanInstallmentStatusPaid,
+				PaidAt:        sql.NullTime{Time: time.Now(), Valid: true},
+			})
+		}
+
+		if result.RepaymentAccount.Balance < result.Principal+result.Interest {
+			return ErrInsufficientFunds
+		}
+
+		journal, err := postLoanRepayment(ctx, q, loan, result.RepaymentAccount, result.Principal, result.Interest,
+			fmt.Sprintf("early repayment of loan %d", loan.ID))
+		if err != nil {
+			return err
+		}
+		result.JournalEntry = journal.JournalEntry
+		result.RepaymentAccount = journal.Accounts[result.RepaymentAccount.ID]
+
+		for _, update := range updates {
+			if _, err := q.UpdateLoanInstallment(ctx, update); err != nil {
+				return err
+			}
+		}
+
+		result.Installments, err = q.ListLoanInstallments(ctx, loan.ID)
+		if err != nil {
+			return err
+		}
+
+		result.Loan, err = q.MarkLoanRepaid(ctx, loan.ID)
+		return err
+	})
+
+	return result, err
+}
+
+// postLoanRepayment books a repayment taken from the repayment account of a loan:
+// the principal is credited to the loan account and the interest to the loan interest income account of the bank
+func postLoanRepayment(ctx context.Context, q *Queries, loan Loan, repaymentAccount Account, principal, interest int64, description string) (postJournalEntryResult, error) {
+	postings := []Posting{
+		{AccountID: repaymentAccount.ID, Amount: -(principal + interest)},
+	}
+	if principal > 0 {
+		postings = append(postings, Posting{AccountID: loan.AccountID, Amount: principal})
+	}
+	if interest > 0 {
+		income, err := q.GetSystemAccount(ctx, GetSystemAccountParams{
+			Purpose:  SystemAccountLoanInterestIncome,
+			Currency: repaymentAccount.Currency,
+		})
+		if err != nil {
+			return postJournalEntryResult{}, fmt.Errorf("cannot get %s loan interest income account: %w", repaymentAccount.Currency, err)
+		}
+		postings = append(postings, Posting{AccountID: income.AccountID, Amount: interest})
+	}
+
+	return postJournalEntry(ctx, q, postJournalEntryParams{
+		Kind:        JournalKindLoan,
+		Description: description,
+		Postings:    postings,
+	})
+}
@@ -0,0 +1,201 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// originateLoan lends 300.00 repaid in three monthly installments of 100.00 plus interest, the first due today
+func originateLoan(t *testing.T, repaymentAccount Account) OriginateLoanTxResult {
+	store := NewStore(testDB)
+	banker := createRandomUser(t)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	arg := OriginateLoanTxParams{
+		RepaymentAccountID: repaymentAccount.ID,
+		Principal:          30000,
+		AnnualRateBps:      1200,
+		TermMonths:         3,
+		Method:             LoanMethodLinear,
+		OriginatedBy:       banker.Username,
+		Installments: []LoanInstallmentParams{
+			{DueDate: today, Principal: 10000, Interest: 300},
+			{DueDate: today.AddDate(0, 1, 0), Principal: 10000, Interest: 200},
+			{DueDate: today.AddDate(0, 2, 0), Principal: 10000, Interest: 100},
+		},
+	}
+
+	result, err := store.OriginateLoanTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	return result
+}
+
+func TestOriginateLoanTx(t *testing.T) {
+	repaymentAccount := createAccountWithBalance(t, "USD", 500)
+
+	result := originateLoan(t, repaymentAccount)
+
+	loan := result.Loan
+	require.NotZero(t, loan.ID)
+	require.Equal(t, result.Account.ID, loan.AccountID)
+	require.Equal(t, repaymentAccount.ID, loan.RepaymentAccountID)
+	require.Equal(t, int64(30000), loan.Principal)
+	require.Equal(t, LoanStatusActive, loan.Status)
+	require.Equal(t, result.JournalEntry.ID, loan.JournalEntryID)
+	require.False(t, loan.RepaidAt.Valid)
+
+	require.Equal(t, AccountTypeLoan, result.Account.Type)
+	require.Equal(t, repaymentAccount.Owner, result.Account.Owner)
+	require.Equal(t, repaymentAccount.Currency, result.Account.Currency)
+	require.Equal(t, int64(-30000), result.Account.Balance)
+	require.Equal(t, int64(30500), result.RepaymentAccount.Balance)
+	require.Equal(t, JournalKindLoan, result.JournalEntry.Kind)
+
+	require.Len(t, result.Installments, 3)
+	for i, installment := range result.Installments {
+		require.Equal(t, int32(i+1), installment.Number)
+		require.Equal(t, LoanInstallmentStatusPending, installment.Status)
+		require.Zero(t, installment.PaidPrincipal)
+	}
+
+	installments, err := testQueries.ListLoanInstallments(context.Background(), loan.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.Installments, installments)
+
+	loans, err := testQueries.ListLoans(context.Background(), ListLoansParams{
+		Owner:      repaymentAccount.Owner,
+		PageLimit:  5,
+		PageOffset: 0,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Loan{loan}, loans)
+}
+
+func TestOriginateLoanTxInvalidSchedule(t *testing.T) {
+	store := NewStore(testDB)
+	repaymentAccount := createAccountWithBalance(t, "USD", 0)
+	banker := createRandomUser(t)
+
+	_, err := store.OriginateLoanTx(context.Background(), OriginateLoanTxParams{
+		RepaymentAccountID: repaymentAccount.ID,
+		Principal:          30000,
+		TermMonths:         2,
+		Method:             LoanMethodLinear,
+		OriginatedBy:       banker.Username,
+		Installments: []LoanInstallmentParams{
+			{DueDate: time.Now(), Principal: 10000},
+			{DueDate: time.Now().AddDate(0, 1, 0), Principal: 10000},
+		},
+	})
+	require.ErrorIs(t, err, ErrInvalidLoanSchedule)
+}
+
+// TestCollectLoanInstallmentTx tests that installments are collected interest first up to the balance,
+// and that the rest is collected again once the repayment account is funded
+func TestCollectLoanInstallmentTx(t *testing.T) {
+	store := NewStore(testDB)
+	repaymentAccount := createAccountWithBalance(t, "USD", 0)
+	loan := originateLoan(t, repaymentAccount)
+	installment := loan.Installments[0]
+
+	due, err := testQueries.ListDueLoanInstallments(context.Background(), installment.DueDate)
+	require.NoError(t, err)
+	require.Contains(t, due, ListDueLoanInstallmentsRow{ID: installment.ID, LoanID: loan.Loan.ID})
+
+	// spend most of the disbursement, leaving 50.00 to collect
+	_, err = testQueries.AddAccountBalance(context.Background(), AddAccountBalanceParams{ID: repaymentAccount.ID, Amount: -25000})
+	require.NoError(t, err)
+
+	result, err := store.CollectLoanInstallmentTx(context.Background(), CollectLoanInstallmentTxParams{InstallmentID: installment.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(5000), result.Collected)
+	require.Equal(t, LoanInstallmentStatusArrears, result.Installment.Status)
+	require.Equal(t, int64(300), result.Installment.PaidInterest)
+	require.Equal(t, int64(4700), result.Installment.PaidPrincipal)
+	require.Zero(t, result.RepaymentAccount.Balance)
+	require.NotNil(t, result.JournalEntry)
+
+	loanAccount, err := testQueries.GetAccount(context.Background(), loan.Account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(-30000+4700), loanAccount.Balance)
+
+	// nothing to collect while the account is empty
+	result, err = store.CollectLoanInstallmentTx(context.Background(), CollectLoanInstallmentTxParams{InstallmentID: installment.ID})
+	require.NoError(t, err)
+	require.Zero(t, result.Collected)
+	require.Nil(t, result.JournalEntry)
+	require.Equal(t, LoanInstallmentStatusArrears, result.Installment.Status)
+
+	_, err = testQueries.AddAccountBalance(context.Background(), AddAccountBalanceParams{ID: repaymentAccount.ID, Amount: 10000})
+	require.NoError(t, err)
+
+	result, err = store.CollectLoanInstallmentTx(context.Background(), CollectLoanInstallmentTxParams{InstallmentID: installment.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(5300), result.Collected)
+	require.Equal(t, LoanInstallmentStatusPaid, result.Installment.Status)
+	require.True(t, result.Installment.PaidAt.Valid)
+	require.Equal(t, int64(4700), result.RepaymentAccount.Balance)
+	require.Equal(t, LoanStatusActive, result.Loan.Status)
+
+	_, err = store.CollectLoanInstallmentTx(context.Background(), CollectLoanInstallmentTxParams{InstallmentID: installment.ID})
+	require.ErrorIs(t, err, ErrLoanInstallmentPaid)
+}
+
+// TestRepayLoanTx tests that early repayments only pay the interest of the current installment up to the day
+func TestRepayLoanTx(t *testing.T) {
+	store := NewStore(testDB)
+	repaymentAccount := createAccountWithBalance(t, "USD", 0)
+	loan := originateLoan(t, repaymentAccount)
+
+	income, err := testQueries.GetSystemAccount(context.Background(), GetSystemAccountParams{
+		Purpose:  SystemAccountLoanInterestIncome,
+		Currency: "USD",
+	})
+	require.NoError(t, err)
+	incomeAccount, err := testQueries.GetAccount(context.Background(), income.AccountID)
+	require.NoError(t, err)
+
+	// the first installment is due today and the second is the current one, not a day of it has passed
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	_, err = testQueries.AddAccountBalance(context.Background(), AddAccountBalanceParams{ID: repaymentAccount.ID, Amount: -1000})
+	require.NoError(t, err)
+
+	_, err = store.RepayLoanTx(context.Background(), RepayLoanTxParams{LoanID: loan.Loan.ID, AsOf: today})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	_, err = testQueries.AddAccountBalance(context.Background(), AddAccountBalanceParams{ID: repaymentAccount.ID, Amount: 1000})
+	require.NoError(t, err)
+
+	result, err := store.RepayLoanTx(context.Background(), RepayLoanTxParams{LoanID: loan.Loan.ID, AsOf: today})
+	require.NoError(t, err)
+	require.Equal(t, int64(30000), result.Principal)
+	require.Equal(t, int64(300), result.Interest)
+	require.Zero(t, result.RepaymentAccount.Balance)
+	require.Equal(t, LoanStatusRepaid, result.Loan.Status)
+	require.True(t, result.Loan.RepaidAt.Valid)
+
+	require.Len(t, result.Installments, 3)
+	require.Equal(t, int64(300), result.Installments[0].Interest)
+	require.Zero(t, result.Installments[1].Interest)
+	require.Zero(t, result.Installments[2].Interest)
+	for _, installment := range result.Installments {
+		require.Equal(t, LoanInstallmentStatusPaid, installment.Status)
+		require.Equal(t, installment.Principal, installment.PaidPrincipal)
+	}
+
+	loanAccount, err := testQueries.GetAccount(context.Background(), loan.Account.ID)
+	require.NoError(t, err)
+	require.Zero(t, loanAccount.Balance)
+
+	updatedIncome, err := testQueries.GetAccount(context.Background(), income.AccountID)
+	require.NoError(t, err)
+	require.Equal(t, int64(300), updatedIncome.Balance-incomeAccount.Balance)
+
+	_, err = store.RepayLoanTx(context.Background(), RepayLoanTxParams{LoanID: loan.Loan.ID, AsOf: today})
+	require.ErrorIs(t, err, ErrLoanNotActive)
+}
@@ -0,0 +1,189 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// Roles of organization members
+const (
+	OrganizationRoleOwner     = "owner"
+	OrganizationRoleApprover  = "approver"
+	OrganizationRoleInitiator = "initiator"
+	OrganizationRoleViewer    = "viewer"
+)
+
+var ErrAlreadySigned = errors.New("transfer was already approved by this user")
+
+// organizationRolePermissions maps the roles of organization members to what they can do with its accounts.
+// Approvers act on transfers through their approvals rather than on the accounts themselves.
+var organizationRolePermissions = map[string]string{
+	OrganizationRoleOwner:     AccountPermissionManage,
+	OrganizationRoleApprover:  AccountPermissionView,
+	OrganizationRoleInitiator: AccountPermissionTransfer,
+	OrganizationRoleViewer:    AccountPermissionView,
+}
+
+// AccountPermission returns the permission the member's role grants on the accounts of the organization
+func (member OrganizationMember) AccountPermission() string {
+	return organizationRolePermissions[member.Role]
+}
+
+// CanApprove reports whether the member can approve or reject transfers initiated from the organization's accounts
+func (member OrganizationMember) CanApprove() bool {
+	return member.Role == OrganizationRoleOwner || member.Role == OrganizationRoleApprover
+}
+
+// RequiresApproval reports whether a transfer of amount initiated by an initiator needs approvals under the policy
+func (organization Organization) RequiresApproval(amount int64) bool {
+	return organization.RequiredApprovals > 0 && amount > organization.ApprovalThreshold
+}
+
+// CreateOrganizationTxParams contains the input parameters of the create organization transaction
+type CreateOrganizationTxParams struct {
+	Name              string `json:"name"`
+	RequiredApprovals int32  `json:"required_approvals"`
+	ApprovalThreshold int64  `json:"approval_threshold"`
+	Owner             string `json:"owner"`
+}
+
+// CreateOrganizationTxResult is the result of the create organization transaction
+type CreateOrganizationTxResult struct {
+	Organization Organization       `json:"organization"`
+	Owner        OrganizationMember `json:"owner"`
+}
+
+// CreateOrganizationTx creates an organization with the user creating it as its first owner
+func (store *SQLStore) CreateOrganizationTx(ctx context.Context, arg CreateOrganizationTxParams) (CreateOrganizationTxResult, error) {
+	var result CreateOrganizationTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Organization, err = q.CreateOrganization(ctx, CreateOrganizationParams{
+			Name:              arg.Name,
+			RequiredApprovals: arg.RequiredApprovals,
+			ApprovalThreshold: arg.ApprovalThreshold,
+			CreatedBy:         arg.Owner,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Owner, err = q.AddOrganizationMember(ctx, AddOrganizationMemberParams{
+			OrganizationID: result.Organization.ID,
+			Username:       arg.Owner,
+			Role:           OrganizationRoleOwner,
+			AddedBy:        arg.Owner,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// SignTransferApprovalTxParams contains the input parameters of the sign transfer approval transaction
+type SignTransferApprovalTxParams struct {
+	ID       int64  `json:"id"`
+	Approver string `json:"approver"`
+	Approve  bool   `json:"approve"`
+	// RequiredApprovals is the number of approvals the policy of the organization asks for
+	RequiredApprovals int32 `json:"required_approvals"`
+	// BankReview sends the transfer on to a banker's review once it has all its approvals instead of executing it
+	BankReview bool `json:"bank_review"`
+}
+
+// SignTransferApprovalTxResult is the result of the sign transfer approval transaction.
+// Transfer is only set when the last approval executed the transfer.
+type SignTransferApprovalTxResult struct {
+	TransferApproval TransferApproval  `json:"transfer_approval"`
+	Approvals        int64             `json:"approvals"`
+	Transfer         *TransferTxResult `json:"transfer,omitempty"`
+}
+
+// SignTransferApprovalTx records the approval of a transfer pending on an organization's approvers, or rejects it.
+// Once it has the required approvals, the transfer is executed in the same database transaction,
+// or goes on to a banker's review when it is also above the bank's approval threshold.
+// The approval row is locked so that concurrent approvals are counted one after the other.
+func (store *SQLStore) SignTransferApprovalTx(ctx context.Context, arg SignTransferApprovalTxParams) (SignTransferApprovalTxResult, error) {
+	var result SignTransferApprovalTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		approval, err := q.GetTransferApprovalForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if approval.Status != TransferApprovalStatusPendingOrganization {
+			return ErrTransferApprovalNotPending
+		}
+
+		if approval.Initiator == arg.Approver {
+			return ErrSelfApproval
+		}
+
+		if !arg.Approve {
+			result.TransferApproval, err = q.UpdateTransferApproval(ctx, UpdateTransferApprovalParams{
+				ID:       approval.ID,
+				Status:   TransferApprovalStatusRejected,
+				Reviewer: arg.Approver,
+			})
+			return err
+		}
+
+		signed, err := q.CreateTransferApprovalSignature(ctx, CreateTransferApprovalSignatureParams{
+			TransferApprovalID: approval.ID,
+			Approver:           arg.Approver,
+		})
+		if err != nil {
+			return err
+		}
+		if signed == 0 {
+			return ErrAlreadySigned
+		}
+
+		result.Approvals, err = q.CountTransferApprovalSignatures(ctx, approval.ID)
+		if err != nil {
+			return err
+		}
+
+		result.TransferApproval = approval
+		if result.Approvals < int64(arg.RequiredApprovals) {
+			return nil
+		}
+
+		if arg.BankReview {
+			result.TransferApproval, err = q.UpdateTransferApproval(ctx, UpdateTransferApprovalParams{
+				ID:     approval.ID,
+				Status: TransferApprovalStatusPending,
+			})
+			return err
+		}
+
+		transferResult, err := transfer(ctx, q, TransferTxParams{
+			FromAccountID: approval.FromAccountID,
+			ToAccountID:   approval.ToAccountID,
+			Amount:        approval.Amount,
+			Description:   approval.Description,
+			Reference:     approval.Reference,
+			Metadata:      approval.Metadata,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Transfer = &transferResult
+		result.TransferApproval, err = q.UpdateTransferApproval(ctx, UpdateTransferApprovalParams{
+			ID:       approval.ID,
+			Status:   TransferApprovalStatusApproved,
+			Reviewer: arg.Approver,
+			TransferID: sql.NullInt64{
+				Int64: transferResult.Transfer.ID,
+				Valid: true,
+			},
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+// createRandomOrganization creates an organization owned by a new user for testing
+func createRandomOrganization(t *testing.T, requiredApprovals int32) CreateOrganizationTxResult {
+	store := NewStore(testDB)
+	owner := createRandomUser(t)
+
+	arg := CreateOrganizationTxParams{
+		Name:              util.RandomString(12),
+		RequiredApprovals: requiredApprovals,
+		ApprovalThreshold: 0,
+		Owner:             owner.Username,
+	}
+
+	result, err := store.CreateOrganizationTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.NotZero(t, result.Organization.ID)
+	require.Equal(t, arg.Name, result.Organization.Name)
+	require.Equal(t, arg.RequiredApprovals, result.Organization.RequiredApprovals)
+	require.Equal(t, arg.Owner, result.Organization.CreatedBy)
+
+	require.Equal(t, result.Organization.ID, result.Owner.OrganizationID)
+	require.Equal(t, arg.Owner, result.Owner.Username)
+	require.Equal(t, OrganizationRoleOwner, result.Owner.Role)
+
+	return result
+}
+
+// addRandomOrganizationMember adds a new user to the organization for testing
+func addRandomOrganizationMember(t *testing.T, organization CreateOrganizationTxResult, role string) OrganizationMember {
+	user := createRandomUser(t)
+
+	member, err := testQueries.AddOrganizationMember(context.Background(), AddOrganizationMemberParams{
+		OrganizationID: organization.Organization.ID,
+		Username:       user.Username,
+		Role:           role,
+		AddedBy:        organization.Owner.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, role, member.Role)
+
+	return member
+}
+
+func TestCreateOrganizationTx(t *testing.T) {
+	organization := createRandomOrganization(t, 2)
+	approver := addRandomOrganizationMember(t, organization, OrganizationRoleApprover)
+
+	owners, err := testQueries.CountOrganizationOwners(context.Background(), organization.Organization.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), owners)
+
+	members, err := testQueries.ListOrganizationMembers(context.Background(), organization.Organization.ID)
+	require.NoError(t, err)
+	require.Equal(t, []OrganizationMember{organization.Owner, approver}, members)
+
+	organizations, err := testQueries.ListUserOrganizations(context.Background(), approver.Username)
+	require.NoError(t, err)
+	require.Len(t, organizations, 1)
+	require.Equal(t, organization.Organization.ID, organizations[0].ID)
+	require.Equal(t, OrganizationRoleApprover, organizations[0].Role)
+
+	// organization names are unique
+	_, err = NewStore(testDB).CreateOrganizationTx(context.Background(), CreateOrganizationTxParams{
+		Name:  organization.Organization.Name,
+		Owner: approver.Username,
+	})
+	require.Error(t, err)
+}
+
+// TestSignTransferApprovalTx tests that a transfer is executed once it has the approvals of the policy
+func TestSignTransferApprovalTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	organization := createRandomOrganization(t, 2)
+	initiator := addRandomOrganizationMember(t, organization, OrganizationRoleInitiator)
+	approver := addRandomOrganizationMember(t, organization, OrganizationRoleApprover)
+
+	currency := util.RandomCurrency()
+	fromAccount, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    organization.Owner.Username,
+		Balance:  1000,
+		Currency: currency,
+		Type:     AccountTypeChecking,
+		OrganizationID: sql.NullInt64{
+			Int64: organization.Organization.ID,
+			Valid: true,
+		},
+	})
+	require.NoError(t, err)
+	toAccount := createRandomAccountWithCurrency(t, currency)
+
+	approval, err := testQueries.CreateTransferApproval(context.Background(), CreateTransferApprovalParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        100,
+		Currency:      currency,
+		Metadata:      json.RawMessage("{}"),
+		Initiator:     initiator.Username,
+		Status:        TransferApprovalStatusPendingOrganization,
+	})
+	require.NoError(t, err)
+
+	approvals, err := testQueries.ListOrganizationTransferApprovals(context.Background(), ListOrganizationTransferApprovalsParams{
+		OrganizationID: fromAccount.OrganizationID,
+		Status:         TransferApprovalStatusPendingOrganization,
+		PageLimit:      5,
+		PageOffset:     0,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []TransferApproval{approval}, approvals)
+
+	arg := SignTransferApprovalTxParams{
+		ID:                approval.ID,
+		Approver:          approver.Username,
+		Approve:           true,
+		RequiredApprovals: organization.Organization.RequiredApprovals,
+	}
+
+	// the initiator cannot approve their own transfer
+	_, err = store.SignTransferApprovalTx(context.Background(), SignTransferApprovalTxParams{
+		ID:                approval.ID,
+		Approver:          initiator.Username,
+		Approve:           true,
+		RequiredApprovals: 2,
+	})
+	require.ErrorIs(t, err, ErrSelfApproval)
+
+	result, err := store.SignTransferApprovalTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.Approvals)
+	require.Equal(t, TransferApprovalStatusPendingOrganization, result.TransferApproval.Status)
+	require.Nil(t, result.Transfer)
+
+	// the same approver counts once
+	_, err = store.SignTransferApprovalTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrAlreadySigned)
+
+	arg.Approver = organization.Owner.Username
+	result, err = store.SignTransferApprovalTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.Approvals)
+	require.Equal(t, TransferApprovalStatusApproved, result.TransferApproval.Status)
+	require.NotNil(t, result.Transfer)
+	require.Equal(t, fromAccount.Balance-approval.Amount, result.Transfer.FromAccount.Balance)
+	require.Equal(t, result.Transfer.Transfer.ID, result.TransferApproval.TransferID.Int64)
+
+	// an executed transfer cannot be approved again
+	_, err = store.SignTransferApprovalTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrTransferApprovalNotPending)
+}
@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Payment request statuses
+const (
+	PaymentRequestStatusPending  = "pending"
+	PaymentRequestStatusAccepted = "accepted"
+	PaymentRequestStatusDeclined = "declined"
+	PaymentRequestStatusExpired  = "expired"
+	// accepted payment requests whose payment waits for a banker's approval
+	PaymentRequestStatusPendingApproval = "pending_approval"
+)
+
+var (
+	ErrPaymentRequestNotPending = errors.New("payment request is not pending")
+	ErrPaymentRequestExpired    = errors.New("payment request has expired")
+)
+
+// IsExpired reports whether a pending payment request can no longer be accepted
+func (request PaymentRequest) IsExpired(now time.Time) bool {
+	return request.Status == PaymentRequestStatusPending && !now.Before(request.ExpiresAt)
+}
+
+// AcceptPaymentRequestTxParams contains the input parameters of the accept payment request transaction
+type AcceptPaymentRequestTxParams struct {
+	PaymentRequestID int64 `json:"payment_request_id"`
+	FromAccountID    int64 `json:"from_account_id"`
+}
+
+// AcceptPaymentRequestTxResult is the result of the accept payment request transaction
+type AcceptPaymentRequestTxResult struct {
+	PaymentRequest PaymentRequest   `json:"payment_request"`
+	Transfer       TransferTxResult `json:"transfer"`
+}
+
+// AcceptPaymentRequestTx pays a pending payment request from the payer's account.
+// The request row is locked so that it can only be accepted once, and the transfer
+// and the status change are committed together.
+func (store *SQLStore) AcceptPaymentRequestTx(ctx context.Context, arg AcceptPaymentRequestTxParams) (AcceptPaymentRequestTxResult, error) {
+	var result AcceptPaymentRequestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		request, err := q.GetPaymentRequestForUpdate(ctx, arg.PaymentRequestID)
+		if err != nil {
+			return err
+		}
+
+		if request.Status != PaymentRequestStatusPending {
+			return ErrPaymentRequestNotPending
+		}
+
+		if request.IsExpired(time.Now()) {
+			return ErrPaymentRequestExpired
+		}
+
+		result.Transfer, err = transfer(ctx, q, TransferTxParams{
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   request.ToAccountID,
+			Amount:        request.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.PaymentRequest, err = q.UpdatePaymentRequestStatus(ctx, UpdatePaymentRequestStatusParams{
+			ID:     request.ID,
+			Status: PaymentRequestStatusAccepted,
+			TransferID: sql.NullInt64{
+				Int64: result.Transfer.Transfer.ID,
+				Valid: true,
+			},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// RequestPaymentApprovalTxParams contains the input parameters of the request payment approval transaction
+type RequestPaymentApprovalTxParams struct {
+	PaymentRequestID int64  `json:"payment_request_id"`
+	FromAccountID    int64  `json:"from_account_id"`
+	Initiator        string `json:"initiator"`
+	// FraudReview records that the fraud checks asked for the review
+	FraudReview bool `json:"fraud_review"`
+}
+
+// RequestPaymentApprovalTxResult is the result of the request payment approval transaction
+type RequestPaymentApprovalTxResult struct {
+	PaymentRequest   PaymentRequest   `json:"payment_request"`
+	TransferApproval TransferApproval `json:"transfer_approval"`
+}
+
+// RequestPaymentApprovalTx accepts a pending payment request whose payment needs a banker's approval.
+// The payment is stored as a transfer approval linked to the request, which stays pending approval until
+// the banker's review accepts or declines it.
+func (store *SQLStore) RequestPaymentApprovalTx(ctx context.Context, arg RequestPaymentApprovalTxParams) (RequestPaymentApprovalTxResult, error) {
+	var result RequestPaymentApprovalTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		request, err := q.GetPaymentRequestForUpdate(ctx, arg.PaymentRequestID)
+		if err != nil {
+			return err
+		}
+
+		if request.Status != PaymentRequestStatusPending {
+			return ErrPaymentRequestNotPending
+		}
+
+		if request.IsExpired(time.Now()) {
+			return ErrPaymentRequestExpired
+		}
+
+		result.TransferApproval, err = q.CreateTransferApproval(ctx, CreateTransferApprovalParams{
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   request.ToAccountID,
+			Amount:        request.Amount,
+			Currency:      request.Currency,
+			Metadata:      json.RawMessage(`{}`),
+			Initiator:     arg.Initiator,
+			Status:        TransferApprovalStatusPending,
+			FraudReview:   arg.FraudReview,
+			PaymentRequestID: sql.NullInt64{
+				Int64: request.ID,
+				Valid: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.PaymentRequest, err = q.UpdatePaymentRequestStatus(ctx, UpdatePaymentRequestStatusParams{
+			ID:     request.ID,
+			Status: PaymentRequestStatusPendingApproval,
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestAcceptPaymentRequestTx tests that accepting a payment request transfers money once
+func TestAcceptPaymentRequestTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	request := createRandomPaymentRequest(t)
+	toAccount, err := store.GetAccount(context.Background(), request.ToAccountID)
+	require.NoError(t, err)
+
+	fromAccount, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    request.Payer,
+		Balance:  request.Amount,
+		Currency: request.Currency,
+		Type:     AccountTypeChecking,
+	})
+	require.NoError(t, err)
+
+	arg := AcceptPaymentRequestTxParams{
+		PaymentRequestID: request.ID,
+		FromAccountID:    fromAccount.ID,
+	}
+
+	result, err := store.AcceptPaymentRequestTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, PaymentRequestStatusAccepted, result.PaymentRequest.Status)
+	require.True(t, result.PaymentRequest.TransferID.Valid)
+	require.Equal(t, result.Transfer.Transfer.ID, result.PaymentRequest.TransferID.Int64)
+
+	require.Equal(t, fromAccount.ID, result.Transfer.Transfer.FromAccountID)
+	require.Equal(t, toAccount.ID, result.Transfer.Transfer.ToAccountID)
+	require.Equal(t, request.Amount, result.Transfer.Transfer.Amount)
+	require.Equal(t, int64(0), result.Transfer.FromAccount.Balance)
+	require.Equal(t, toAccount.Balance+request.Amount, result.Transfer.ToAccount.Balance)
+
+	// a request can only be paid once
+	_, err = store.AcceptPaymentRequestTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrPaymentRequestNotPending)
+}
+
+// TestRequestPaymentApprovalTx tests that a payment request held for approval is settled by the banker's review
+func TestRequestPaymentApprovalTx(t *testing.T) {
+	store := NewStore(testDB)
+	banker := createRandomUser(t)
+
+	for _, approve := range []bool{true, false} {
+		request := createRandomPaymentRequest(t)
+
+		fromAccount, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+			Owner:    request.Payer,
+			Balance:  request.Amount,
+			Currency: request.Currency,
+			Type:     AccountTypeChecking,
+		})
+		require.NoError(t, err)
+
+		arg := RequestPaymentApprovalTxParams{
+			PaymentRequestID: request.ID,
+			FromAccountID:    fromAccount.ID,
+			Initiator:        request.Payer,
+		}
+
+		held, err := store.RequestPaymentApprovalTx(context.Background(), arg)
+		require.NoError(t, err)
+		require.Equal(t, PaymentRequestStatusPendingApproval, held.PaymentRequest.Status)
+		require.Equal(t, TransferApprovalStatusPending, held.TransferApproval.Status)
+		require.Equal(t, request.ID, held.TransferApproval.PaymentRequestID.Int64)
+		require.Equal(t, request.Amount, held.TransferApproval.Amount)
+
+		// the request can't be paid again while it waits
+		_, err = store.RequestPaymentApprovalTx(context.Background(), arg)
+		require.ErrorIs(t, err, ErrPaymentRequestNotPending)
+
+		result, err := store.ReviewTransferApprovalTx(context.Background(), ReviewTransferApprovalTxParams{
+			ID:       held.TransferApproval.ID,
+			Reviewer: banker.Username,
+			Approve:  approve,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, result.PaymentRequest)
+
+		if approve {
+			require.Equal(t, PaymentRequestStatusAccepted, result.PaymentRequest.Status)
+			require.Equal(t, result.Transfer.Transfer.ID, result.PaymentRequest.TransferID.Int64)
+		} else {
+			require.Equal(t, PaymentRequestStatusDeclined, result.PaymentRequest.Status)
+			require.False(t, result.PaymentRequest.TransferID.Valid)
+		}
+	}
+}
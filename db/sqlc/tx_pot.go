@@ -0,0 +1,167 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// CreatePotTxParams contains the input parameters of the create pot transaction
+type CreatePotTxParams struct {
+	ParentAccountID int64  `json:"parent_account_id"`
+	Name            string `json:"name"`
+	TargetAmount    int64  `json:"target_amount"`
+	RoundUpTo       int64  `json:"round_up_to"`
+}
+
+// CreatePotTxResult is the result of the create pot transaction
+type CreatePotTxResult struct {
+	Pot     Pot     `json:"pot"`
+	Account Account `json:"account"`
+}
+
+// CreatePotTx opens the pot account of a new pot, owned by the owner of the parent account and in its currency.
+// It returns sql.ErrNoRows when the parent account doesn't exist.
+func (store *SQLStore) CreatePotTx(ctx context.Context, arg CreatePotTxParams) (CreatePotTxResult, error) {
+	var result CreatePotTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		parent, err := q.GetAccount(ctx, arg.ParentAccountID)
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = q.CreateAccount(ctx, CreateAccountParams{
+			Owner:    parent.Owner,
+			Balance:  0,
+			Currency: parent.Currency,
+			Type:     AccountTypePot,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Pot, err = q.CreatePot(ctx, CreatePotParams{
+			AccountID:       result.Account.ID,
+			ParentAccountID: parent.ID,
+			Name:            arg.Name,
+			TargetAmount:    arg.TargetAmount,
+			RoundUpTo:       arg.RoundUpTo,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// MovePotTxParams contains the input parameters of the move pot transaction
+type MovePotTxParams struct {
+	PotID int64 `json:"pot_id"`
+	// Amount is moved from the parent account to the pot when positive, and back to the parent account when negative
+	Amount int64 `json:"amount"`
+}
+
+// MovePotTxResult is the result of the move pot transaction
+type MovePotTxResult struct {
+	Pot           Pot          `json:"pot"`
+	Account       Account      `json:"account"`
+	ParentAccount Account      `json:"parent_account"`
+	JournalEntry  JournalEntry `json:"journal_entry"`
+}
+
+// MovePotTx moves money between a pot and its parent account in a pot journal entry, without a transfer or any fee.
+// Neither account can go below zero: it returns ErrInsufficientFunds when the balance doesn't cover the move.
+func (store *SQLStore) MovePotTx(ctx context.Context, arg MovePotTxParams) (MovePotTxResult, error) {
+	var result MovePotTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Pot, err = q.GetPot(ctx, arg.PotID)
+		if err != nil {
+			return err
+		}
+
+		description := fmt.Sprintf("move to pot %q", result.Pot.Name)
+		if arg.Amount < 0 {
+			description = fmt.Sprintf("move from pot %q", result.Pot.Name)
+		}
+
+		journal, err := postJournalEntry(ctx, q, postJournalEntryParams{
+			Kind:        JournalKindPot,
+			Description: description,
+			Postings: []Posting{
+				{AccountID: result.Pot.ParentAccountID, Amount: -arg.Amount},
+				{AccountID: result.Pot.AccountID, Amount: arg.Amount},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.JournalEntry = journal.JournalEntry
+		result.Account = journal.Accounts[result.Pot.AccountID]
+		result.ParentAccount = journal.Accounts[result.Pot.ParentAccountID]
+
+		if result.Account.Balance < 0 || result.ParentAccount.Balance < 0 {
+			return ErrInsufficientFunds
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// RoundUp is the spare change of a transfer moved to the round-up pot of the account it was sent from
+type RoundUp struct {
+	PotID        int64        `json:"pot_id"`
+	Amount       int64        `json:"amount"`
+	JournalEntry JournalEntry `json:"journal_entry"`
+}
+
+// roundUp moves the spare change of a transfer to the round-up pot of the account it was debited from, if it has one,
+// given the account after the transfer. The spare change is what rounds the amount up to a multiple of the pot's
+// round_up_to, and it is only moved when the balance less the card holds covers it, so that it never draws on the
+// overdraft or on held money. It must be called inside the transaction of the transfer, and returns the account
+// after the move.
+func roundUp(ctx context.Context, q *Queries, transfer Transfer, account Account) (*RoundUp, Account, error) {
+	pot, err := q.GetRoundUpPot(ctx, account.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, account, nil
+		}
+		return nil, account, err
+	}
+
+	spare := (pot.RoundUpTo - transfer.Amount%pot.RoundUpTo) % pot.RoundUpTo
+	if spare == 0 || transfer.ToAccountID == pot.AccountID {
+		return nil, account, nil
+	}
+
+	held, err := q.GetHeldAmount(ctx, account.ID)
+	if err != nil {
+		return nil, account, err
+	}
+
+	if account.Balance-held < spare {
+		return nil, account, nil
+	}
+
+	// the pot account was opened after its parent, so the accounts are still locked in ID order
+	journal, err := postJournalEntry(ctx, q, postJournalEntryParams{
+		Kind:        JournalKindPot,
+		Description: fmt.Sprintf("round-up of transfer %d", transfer.ID),
+		Postings: []Posting{
+			{AccountID: account.ID, Amount: -spare},
+			{AccountID: pot.AccountID, Amount: spare},
+		},
+	})
+	if err != nil {
+		return nil, account, err
+	}
+
+	return &RoundUp{
+		PotID:        pot.ID,
+		Amount:       spare,
+		JournalEntry: journal.JournalEntry,
+	}, journal.Accounts[account.ID], nil
+}
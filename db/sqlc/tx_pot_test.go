@@ -0,0 +1,166 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+// createRandomPot sets up a pot under the parent account for testing
+func createRandomPot(t *testing.T, parent Account, roundUpTo int64) CreatePotTxResult {
+	store := NewStore(testDB)
+
+	arg := CreatePotTxParams{
+		ParentAccountID: parent.ID,
+		Name:            util.RandomString(8),
+		TargetAmount:    util.RandomMoney(),
+		RoundUpTo:       roundUpTo,
+	}
+
+	result, err := store.CreatePotTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, parent.ID, result.Pot.ParentAccountID)
+	require.Equal(t, arg.Name, result.Pot.Name)
+	require.Equal(t, arg.TargetAmount, result.Pot.TargetAmount)
+	require.Equal(t, arg.RoundUpTo, result.Pot.RoundUpTo)
+	require.Equal(t, result.Account.ID, result.Pot.AccountID)
+
+	require.Equal(t, AccountTypePot, result.Account.Type)
+	require.Equal(t, parent.Owner, result.Account.Owner)
+	require.Equal(t, parent.Currency, result.Account.Currency)
+	require.Zero(t, result.Account.Balance)
+
+	return result
+}
+
+func TestCreatePotTx(t *testing.T) {
+	parent := createAccountWithBalance(t, "USD", 0)
+	pot := createRandomPot(t, parent, 0)
+
+	// pot accounts are listed under their parent, not on their own
+	accounts, err := testQueries.ListAccounts(context.Background(), ListAccountsParams{
+		Owner:  parent.Owner,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Account{parent}, accounts)
+
+	pots, err := testQueries.ListPots(context.Background(), parent.ID)
+	require.NoError(t, err)
+	require.Len(t, pots, 1)
+	require.Equal(t, pot.Pot.ID, pots[0].ID)
+}
+
+func TestMovePotTx(t *testing.T) {
+	store := NewStore(testDB)
+	parent := createAccountWithBalance(t, "EUR", 1000)
+	pot := createRandomPot(t, parent, 0)
+
+	result, err := store.MovePotTx(context.Background(), MovePotTxParams{PotID: pot.Pot.ID, Amount: 600})
+	require.NoError(t, err)
+	require.Equal(t, JournalKindPot, result.JournalEntry.Kind)
+	require.Equal(t, int64(400), result.ParentAccount.Balance)
+	require.Equal(t, int64(600), result.Account.Balance)
+
+	result, err = store.MovePotTx(context.Background(), MovePotTxParams{PotID: pot.Pot.ID, Amount: -100})
+	require.NoError(t, err)
+	require.Equal(t, int64(500), result.ParentAccount.Balance)
+	require.Equal(t, int64(500), result.Account.Balance)
+
+	// neither account can go below zero
+	_, err = store.MovePotTx(context.Background(), MovePotTxParams{PotID: pot.Pot.ID, Amount: 501})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+	_, err = store.MovePotTx(context.Background(), MovePotTxParams{PotID: pot.Pot.ID, Amount: -501})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	balances, err := testQueries.ListPotBalances(context.Background(), parent.Owner)
+	require.NoError(t, err)
+	require.Equal(t, []ListPotBalancesRow{{ParentAccountID: parent.ID, Pots: 1, Balance: 500}}, balances)
+}
+
+// TestTransferTxRoundUp tests that the spare change of outgoing transfers is moved to the round-up pot
+// when the balance covers it
+func TestTransferTxRoundUp(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createAccountWithBalance(t, "USD", 1050)
+	account2 := createAccountWithBalance(t, "USD", 0)
+	pot := createRandomPot(t, account1, 100)
+
+	transferTo := func(amount int64) TransferTxResult {
+		result, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := transferTo(230)
+	require.NotNil(t, result.RoundUp)
+	require.Equal(t, pot.Pot.ID, result.RoundUp.PotID)
+	require.Equal(t, int64(70), result.RoundUp.Amount)
+	require.Equal(t, JournalKindPot, result.RoundUp.JournalEntry.Kind)
+	require.Equal(t, int64(750), result.FromAccount.Balance)
+
+	// nothing to round up
+	result = transferTo(200)
+	require.Nil(t, result.RoundUp)
+	require.Equal(t, int64(550), result.FromAccount.Balance)
+
+	// the balance doesn't cover the spare change
+	result = transferTo(520)
+	require.Nil(t, result.RoundUp)
+	require.Equal(t, int64(30), result.FromAccount.Balance)
+
+	potAccount, err := testQueries.GetAccount(context.Background(), pot.Account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(70), potAccount.Balance)
+}
+
+// TestTransferTxRoundUpHeld tests that the spare change is not taken from money held by card authorizations
+func TestTransferTxRoundUpHeld(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createAccountWithBalance(t, "USD", 1000)
+	account2 := createAccountWithBalance(t, "USD", 0)
+	createRandomPot(t, account1, 100)
+
+	authorizeCard(t, createRandomCard(t, account1, 0), 700, "USD")
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        250,
+	})
+	require.NoError(t, err)
+	require.Nil(t, result.RoundUp)
+	require.Equal(t, int64(750), result.FromAccount.Balance)
+}
+
+// TestReverseTransferTxRoundUp tests that reversals don't sweep spare change into the pot of the account they debit
+func TestReverseTransferTxRoundUp(t *testing.T) {
+	store := NewStore(testDB)
+	banker := createRandomUser(t)
+	account1 := createAccountWithBalance(t, "USD", 1000)
+	account2 := createAccountWithBalance(t, "USD", 1000)
+	createRandomPot(t, account2, 100)
+
+	original, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        230,
+	})
+	require.NoError(t, err)
+
+	result, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+		Reason:     "sent to the wrong account",
+		ReversedBy: banker.Username,
+	})
+	require.NoError(t, err)
+	require.Nil(t, result.Reversal.RoundUp)
+	require.Equal(t, int64(1000), result.Reversal.FromAccount.Balance)
+}
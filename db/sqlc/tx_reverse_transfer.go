@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+var ErrTransferAlreadyReversed = errors.New("transfer was already reversed")
+
+// ReverseTransferTxParams contains the input parameters of the reverse transfer transaction
+type ReverseTransferTxParams struct {
+	TransferID int64  `json:"transfer_id"`
+	Reason     string `json:"reason"`
+	ReversedBy string `json:"reversed_by"`
+}
+
+// ReverseTransferTxResult is the result of the reverse transfer transaction
+type ReverseTransferTxResult struct {
+	TransferReversal TransferReversal `json:"transfer_reversal"`
+	Reversal         TransferTxResult `json:"reversal"`
+}
+
+// ReverseTransferTx books a compensating transfer that moves the money of a transfer back to its sender.
+// The ledger is append-only, so this is the only way to undo a transfer. A transfer can be reversed once.
+func (store *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+	var result ReverseTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = reverseTransfer(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// reverseTransfer books the compensating transfer of a transfer within an existing database transaction
+func reverseTransfer(ctx context.Context, q *Queries, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+	var result ReverseTransferTxResult
+
+	original, err := q.GetTransfer(ctx, arg.TransferID)
+	if err != nil {
+		return result, err
+	}
+
+	_, err = q.GetTransferReversal(ctx, original.ID)
+	if err == nil {
+		return result, ErrTransferAlreadyReversed
+	}
+	if err != sql.ErrNoRows {
+		return result, err
+	}
+
+	metadata, err := json.Marshal(map[string]int64{"reversal_of": original.ID})
+	if err != nil {
+		return result, err
+	}
+
+	result.Reversal, err = transfer(ctx, q, TransferTxParams{
+		FromAccountID: original.ToAccountID,
+		ToAccountID:   original.FromAccountID,
+		Amount:        original.Amount,
+		Description:   fmt.Sprintf("reversal of transfer %d", original.ID),
+		Metadata:      metadata,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.TransferReversal, err = q.CreateTransferReversal(ctx, CreateTransferReversalParams{
+		TransferID: original.ID,
+		ReversalID: result.Reversal.Transfer.ID,
+		Reason:     arg.Reason,
+		ReversedBy: arg.ReversedBy,
+	})
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+		// the transfer was reversed concurrently
+		return result, ErrTransferAlreadyReversed
+	}
+	return result, err
+}
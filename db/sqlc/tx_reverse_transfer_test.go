@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestReverseTransferTx tests that a transfer is undone by a compensating transfer, and only once
+func TestReverseTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+	banker := createRandomUser(t)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+
+	original, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	arg := ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+		Reason:     "sent to the wrong account",
+		ReversedBy: banker.Username,
+	}
+
+	result, err := store.ReverseTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	reversal := result.Reversal
+	require.Equal(t, account2.ID, reversal.Transfer.FromAccountID)
+	require.Equal(t, account1.ID, reversal.Transfer.ToAccountID)
+	require.Equal(t, original.Transfer.Amount, reversal.Transfer.Amount)
+	require.JSONEq(t, fmt.Sprintf(`{"reversal_of": %d}`, original.Transfer.ID), string(reversal.Transfer.Metadata))
+
+	// both accounts are back to their balance before the original transfer
+	require.Equal(t, account1.Balance, reversal.ToAccount.Balance)
+	require.Equal(t, account2.Balance, reversal.FromAccount.Balance)
+
+	require.Equal(t, original.Transfer.ID, result.TransferReversal.TransferID)
+	require.Equal(t, reversal.Transfer.ID, result.TransferReversal.ReversalID)
+	require.Equal(t, arg.Reason, result.TransferReversal.Reason)
+	require.Equal(t, arg.ReversedBy, result.TransferReversal.ReversedBy)
+
+	// the original transfer and its entries are left untouched
+	transfer, err := store.GetTransfer(context.Background(), original.Transfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, original.Transfer.Amount, transfer.Amount)
+
+	_, err = store.ReverseTransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrTransferAlreadyReversed)
+}
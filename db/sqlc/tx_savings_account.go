@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+)
+
+// Account types
+const (
+	AccountTypeChecking = "checking"
+	AccountTypeSavings  = "savings"
+	// AccountTypeInternal accounts belong to the bank itself, e.g. the interest expense account
+	AccountTypeInternal = "internal"
+	// AccountTypeLoan accounts hold the principal of a loan left to repay as a negative balance
+	AccountTypeLoan = "loan"
+	// AccountTypePot accounts are sub-accounts setting money aside from a checking or savings account
+	AccountTypePot = "pot"
+	// AccountTypeEscrow accounts belong to the bank and hold the money of an escrow until it is settled
+	AccountTypeEscrow = "escrow"
+)
+
+// CreateSavingsAccountTxParams contains the input parameters of the create savings account transaction
+type CreateSavingsAccountTxParams struct {
+	Owner          string `json:"owner"`
+	Currency       string `json:"currency"`
+	InterestPlanID int64  `json:"interest_plan_id"`
+}
+
+// CreateSavingsAccountTxResult is the result of the create savings account transaction
+type CreateSavingsAccountTxResult struct {
+	Account      Account      `json:"account"`
+	InterestPlan InterestPlan `json:"interest_plan"`
+}
+
+// CreateSavingsAccountTx opens a savings account earning interest on the given plan.
+// It returns sql.ErrNoRows when the interest plan doesn't exist.
+func (store *SQLStore) CreateSavingsAccountTx(ctx context.Context, arg CreateSavingsAccountTxParams) (CreateSavingsAccountTxResult, error) {
+	var result CreateSavingsAccountTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.InterestPlan, err = q.GetInterestPlan(ctx, arg.InterestPlanID)
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = q.CreateAccount(ctx, CreateAccountParams{
+			Owner:    arg.Owner,
+			Balance:  0,
+			Currency: arg.Currency,
+			Type:     AccountTypeSavings,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateAccountInterestPlan(ctx, CreateAccountInterestPlanParams{
+			AccountID:      result.Account.ID,
+			InterestPlanID: result.InterestPlan.ID,
+		})
+		return err
+	})
+
+	return result, err
+}
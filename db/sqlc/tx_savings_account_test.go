@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreateSavingsAccountTx tests that savings accounts are opened on an interest plan
+func TestCreateSavingsAccountTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	checking := createRandomAccount(t)
+	plan := createRandomInterestPlan(t)
+
+	// a savings account can be held next to a checking account in the same currency
+	result, err := store.CreateSavingsAccountTx(context.Background(), CreateSavingsAccountTxParams{
+		Owner:          checking.Owner,
+		Currency:       checking.Currency,
+		InterestPlanID: plan.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountTypeSavings, result.Account.Type)
+	require.Equal(t, checking.Owner, result.Account.Owner)
+	require.Zero(t, result.Account.Balance)
+	require.Equal(t, plan.ID, result.InterestPlan.ID)
+
+	accounts, err := store.ListInterestBearingAccounts(context.Background(), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Contains(t, accounts, ListInterestBearingAccountsRow{
+		AccountID:     result.Account.ID,
+		Currency:      result.Account.Currency,
+		AnnualRateBps: plan.AnnualRateBps,
+		DayCount:      plan.DayCount,
+	})
+
+	// but only one of each type
+	_, err = store.CreateSavingsAccountTx(context.Background(), CreateSavingsAccountTxParams{
+		Owner:          checking.Owner,
+		Currency:       checking.Currency,
+		InterestPlanID: plan.ID,
+	})
+	require.Error(t, err)
+}
+
+// TestCreateSavingsAccountTxUnknownPlan tests that no account is opened on a plan that doesn't exist
+func TestCreateSavingsAccountTxUnknownPlan(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	_, err := store.CreateSavingsAccountTx(context.Background(), CreateSavingsAccountTxParams{
+		Owner:          user.Username,
+		Currency:       "USD",
+		InterestPlanID: -1,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	accounts, err := store.ListAccounts(context.Background(), ListAccountsParams{
+		Owner: user.Username,
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+}
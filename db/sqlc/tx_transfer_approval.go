@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// Transfer approval statuses
+const (
+	TransferApprovalStatusPending  = "pending_approval"
+	TransferApprovalStatusApproved = "approved"
+	TransferApprovalStatusRejected = "rejected"
+	// transfers from organization accounts wait for the organization's approvers before being executed
+	// or going on to a banker's review
+	TransferApprovalStatusPendingOrganization = "pending_organization"
+)
+
+var (
+	ErrTransferApprovalNotPending = errors.New("transfer is not pending approval")
+	ErrSelfApproval               = errors.New("transfer cannot be reviewed by its initiator")
+)
+
+// ReviewTransferApprovalTxParams contains the input parameters of the review transfer approval transaction
+type ReviewTransferApprovalTxParams struct {
+	ID       int64  `json:"id"`
+	Reviewer string `json:"reviewer"`
+	Approve  bool   `json:"approve"`
+}
+
+// ReviewTransferApprovalTxResult is the result of the review transfer approval transaction.
+// Transfer is only set when the transfer was approved, and PaymentRequest when the transfer pays one.
+type ReviewTransferApprovalTxResult struct {
+	TransferApproval TransferApproval  `json:"transfer_approval"`
+	Transfer         *TransferTxResult `json:"transfer,omitempty"`
+	PaymentRequest   *PaymentRequest   `json:"payment_request,omitempty"`
+}
+
+// ReviewTransferApprovalTx approves or rejects a transfer that is pending approval.
+// An approved transfer is executed in the same database transaction as the status change,
+// and the approval row is locked so that a transfer can only be executed once.
+// The payment request the transfer pays, if any, is accepted or declined with it.
+func (store *SQLStore) ReviewTransferApprovalTx(ctx context.Context, arg ReviewTransferApprovalTxParams) (ReviewTransferApprovalTxResult, error) {
+	var result ReviewTransferApprovalTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		approval, err := q.GetTransferApprovalForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if approval.Status != TransferApprovalStatusPending {
+			return ErrTransferApprovalNotPending
+		}
+
+		if approval.Initiator == arg.Reviewer {
+			return ErrSelfApproval
+		}
+
+		update := UpdateTransferApprovalParams{
+			ID:       approval.ID,
+			Status:   TransferApprovalStatusRejected,
+			Reviewer: arg.Reviewer,
+		}
+
+		if arg.Approve {
+			transferResult, err := transfer(ctx, q, TransferTxParams{
+				FromAccountID: approval.FromAccountID,
+				ToAccountID:   approval.ToAccountID,
+				Amount:        approval.Amount,
+				Description:   approval.Description,
+				Reference:     approval.Reference,
+				Metadata:      approval.Metadata,
+			})
+			if err != nil {
+				return err
+			}
+
+			result.Transfer = &transferResult
+			update.Status = TransferApprovalStatusApproved
+			update.TransferID = sql.NullInt64{
+				Int64: transferResult.Transfer.ID,
+				Valid: true,
+			}
+		}
+
+		result.TransferApproval, err = q.UpdateTransferApproval(ctx, update)
+		if err != nil {
+			return err
+		}
+
+		if !approval.PaymentRequestID.Valid {
+			return nil
+		}
+
+		status := PaymentRequestStatusDeclined
+		if arg.Approve {
+			status = PaymentRequestStatusAccepted
+		}
+
+		request, err := q.UpdatePaymentRequestStatus(ctx, UpdatePaymentRequestStatusParams{
+			ID:         approval.PaymentRequestID.Int64,
+			Status:     status,
+			TransferID: update.TransferID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.PaymentRequest = &request
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestReviewTransferApprovalTx tests that an approved transfer is executed exactly once
+func TestReviewTransferApprovalTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	approval := createRandomTransferApproval(t)
+	reviewer := createRandomUser(t)
+
+	fromAccount, err := store.GetAccount(context.Background(), approval.FromAccountID)
+	require.NoError(t, err)
+	toAccount, err := store.GetAccount(context.Background(), approval.ToAccountID)
+	require.NoError(t, err)
+
+	// the initiator cannot approve their own transfer
+	_, err = store.ReviewTransferApprovalTx(context.Background(), ReviewTransferApprovalTxParams{
+		ID:       approval.ID,
+		Reviewer: approval.Initiator,
+		Approve:  true,
+	})
+	require.ErrorIs(t, err, ErrSelfApproval)
+
+	arg := ReviewTransferApprovalTxParams{
+		ID:       approval.ID,
+		Reviewer: reviewer.Username,
+		Approve:  true,
+	}
+
+	result, err := store.ReviewTransferApprovalTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, TransferApprovalStatusApproved, result.TransferApproval.Status)
+	require.Equal(t, reviewer.Username, result.TransferApproval.Reviewer)
+	require.NotZero(t, result.TransferApproval.ReviewedAt)
+	require.NotNil(t, result.Transfer)
+	require.True(t, result.TransferApproval.TransferID.Valid)
+	require.Equal(t, result.Transfer.Transfer.ID, result.TransferApproval.TransferID.Int64)
+
+	require.Equal(t, approval.Amount, result.Transfer.Transfer.Amount)
+	require.Equal(t, approval.Reference, result.Transfer.Transfer.Reference)
+	require.Equal(t, fromAccount.Balance-approval.Amount, result.Transfer.FromAccount.Balance)
+	require.Equal(t, toAccount.Balance+approval.Amount, result.Transfer.ToAccount.Balance)
+
+	// an approved transfer cannot be reviewed again
+	_, err = store.ReviewTransferApprovalTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrTransferApprovalNotPending)
+}
+
+func TestRejectTransferApprovalTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	approval := createRandomTransferApproval(t)
+	reviewer := createRandomUser(t)
+
+	fromAccount, err := store.GetAccount(context.Background(), approval.FromAccountID)
+	require.NoError(t, err)
+
+	result, err := store.ReviewTransferApprovalTx(context.Background(), ReviewTransferApprovalTxParams{
+		ID:       approval.ID,
+		Reviewer: reviewer.Username,
+		Approve:  false,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, TransferApprovalStatusRejected, result.TransferApproval.Status)
+	require.Nil(t, result.Transfer)
+	require.False(t, result.TransferApproval.TransferID.Valid)
+
+	// a rejected transfer doesn't move any money
+	account, err := store.GetAccount(context.Background(), approval.FromAccountID)
+	require.NoError(t, err)
+	require.Equal(t, fromAccount.Balance, account.Balance)
+}
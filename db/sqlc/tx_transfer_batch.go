@@ -0,0 +1,42 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrEmptyTransferBatch = errors.New("transfer batch has no transfers")
+
+// TransferBatchTxParams contains the input parameters of the transfer batch transaction
+type TransferBatchTxParams struct {
+	Transfers []TransferTxParams `json:"transfers"`
+}
+
+// TransferBatchTxResult is the result of the transfer batch transaction
+type TransferBatchTxResult struct {
+	Transfers []TransferTxResult `json:"transfers"`
+}
+
+// TransferBatchTx performs several money transfers within a single database transaction.
+// Transfers are made in the given order, and none of them is made if one fails.
+func (store *SQLStore) TransferBatchTx(ctx context.Context, arg TransferBatchTxParams) (TransferBatchTxResult, error) {
+	var result TransferBatchTxResult
+
+	if len(arg.Transfers) == 0 {
+		return result, ErrEmptyTransferBatch
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		result.Transfers = make([]TransferTxResult, 0, len(arg.Transfers))
+		for _, params := range arg.Transfers {
+			transferResult, err := transfer(ctx, q, params)
+			if err != nil {
+				return err
+			}
+			result.Transfers = append(result.Transfers, transferResult)
+		}
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestTransferBatchTx tests that all the transfers of a batch are made together
+func TestTransferBatchTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+	account3 := createRandomAccountWithCurrency(t, account1.Currency)
+
+	result, err := store.TransferBatchTx(context.Background(), TransferBatchTxParams{
+		Transfers: []TransferTxParams{
+			{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 10},
+			{FromAccountID: account1.ID, ToAccountID: account3.ID, Amount: 5},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Transfers, 2)
+
+	require.Equal(t, account2.ID, result.Transfers[0].Transfer.ToAccountID)
+	require.Equal(t, account3.ID, result.Transfers[1].Transfer.ToAccountID)
+	require.Equal(t, account1.Balance-15, result.Transfers[1].FromAccount.Balance)
+	require.Equal(t, account2.Balance+10, result.Transfers[0].ToAccount.Balance)
+	require.Equal(t, account3.Balance+5, result.Transfers[1].ToAccount.Balance)
+}
+
+// TestTransferBatchTxRollback tests that no transfer of a batch is made when one of them fails
+func TestTransferBatchTxRollback(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+
+	_, err := store.TransferBatchTx(context.Background(), TransferBatchTxParams{
+		Transfers: []TransferTxParams{
+			{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 10, Reference: "batch-1"},
+			{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 10, Reference: "batch-1"},
+		},
+	})
+	require.Error(t, err)
+
+	updated1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updated1.Balance)
+
+	updated2, err := store.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account2.Balance, updated2.Balance)
+
+	_, err = store.TransferBatchTx(context.Background(), TransferBatchTxParams{})
+	require.ErrorIs(t, err, ErrEmptyTransferBatch)
+}
@@ -31,6 +31,7 @@ func createRandomUser(t *testing.T) User {
 	require.Equal(t, arg.HashedPassword, user.HashedPassword)
 	require.Equal(t, arg.FullName, user.FullName)
 	require.Equal(t, arg.Email, user.Email)
+	require.Equal(t, util.DepositorRole, user.Role)
 
 	require.True(t, user.PasswordChangedAt.IsZero())
 	require.NotZero(t, user.CreatedAt)
@@ -81,4 +82,19 @@ func TestCreateUserWithHashedPassword(t *testing.T) {
 	wrongPassword := util.RandomString(6)
 	err = util.CheckPassword(wrongPassword, user.HashedPassword)
 	require.Error(t, err)
-}
\ No newline at end of file
+}
+
+func TestUpdateUserRole(t *testing.T) {
+	user1 := createRandomUser(t)
+
+	arg := UpdateUserRoleParams{
+		Username: user1.Username,
+		Role:     util.BankerRole,
+	}
+
+	user2, err := testQueries.UpdateUserRole(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, user1.Username, user2.Username)
+	require.Equal(t, util.BankerRole, user2.Role)
+	require.Equal(t, user1.HashedPassword, user2.HashedPassword)
+}
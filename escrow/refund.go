@@ -0,0 +1,58 @@
+package escrow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+// Store contains the queries and transactions used to refund expired escrows
+type Store interface {
+	ListExpiredEscrows(ctx context.Context, deadline time.Time) ([]db.Escrow, error)
+	RefundEscrowTx(ctx context.Context, escrowID int64) (db.EscrowTxResult, error)
+}
+
+// Report sums up the refund of the escrows whose deadline passed
+type Report struct {
+	Now time.Time
+	// Refunded is the number of escrows paid back to their payer
+	Refunded int
+	// Skipped is the number of escrows released or disputed in the meantime
+	Skipped int
+	// Amounts holds the cents refunded by currency
+	Amounts map[string]int64
+}
+
+// RefundExpired pays back to their payer the escrows still held whose deadline passed by now.
+// Disputed escrows are left to the arbiter whatever their deadline.
+// Each escrow is refunded in its own database transaction, so running it again is safe.
+func RefundExpired(ctx context.Context, store Store, now time.Time) (Report, error) {
+	report := Report{
+		Now:     now,
+		Amounts: make(map[string]int64),
+	}
+
+	escrows, err := store.ListExpiredEscrows(ctx, now)
+	if err != nil {
+		return report, err
+	}
+
+	for _, escrow := range escrows {
+		result, err := store.RefundEscrowTx(ctx, escrow.ID)
+		if errors.Is(err, db.ErrEscrowNotHeld) {
+			report.Skipped++
+			continue
+		}
+		if err != nil {
+			return report, fmt.Errorf("cannot refund escrow [%d]: %w", escrow.ID, err)
+		}
+
+		report.Refunded++
+		report.Amounts[result.Transfer.ToAccount.Currency] += result.Escrow.Amount
+	}
+
+	return report, nil
+}
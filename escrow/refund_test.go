@@ -0,0 +1,68 @@
+package escrow
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/volskyi-dmytro/st-bank/db/mock"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+func TestRefundExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	now := time.Date(2026, time.June, 15, 9, 30, 0, 0, time.UTC)
+
+	escrows := []db.Escrow{{ID: 1}, {ID: 2}, {ID: 3}}
+	store.EXPECT().ListExpiredEscrows(gomock.Any(), gomock.Eq(now)).Times(1).Return(escrows, nil)
+
+	expectRefund := func(escrowID int64, currency string, amount int64, err error) {
+		store.EXPECT().
+			RefundEscrowTx(gomock.Any(), gomock.Eq(escrowID)).
+			Times(1).
+			Return(db.EscrowTxResult{
+				Escrow: db.Escrow{ID: escrowID, Amount: amount, Status: db.EscrowStatusRefunded},
+				Transfer: db.TransferTxResult{
+					ToAccount: db.Account{Currency: currency},
+				},
+			}, err)
+	}
+
+	expectRefund(1, "USD", 1500, nil)
+	expectRefund(2, "", 0, db.ErrEscrowNotHeld)
+	expectRefund(3, "EUR", 700, nil)
+
+	report, err := RefundExpired(context.Background(), store, now)
+	require.NoError(t, err)
+	require.Equal(t, Report{
+		Now:      now,
+		Refunded: 2,
+		Skipped:  1,
+		Amounts:  map[string]int64{"USD": 1500, "EUR": 700},
+	}, report)
+}
+
+func TestRefundExpiredError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().
+		ListExpiredEscrows(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.Escrow{{ID: 1}, {ID: 2}}, nil)
+	store.EXPECT().
+		RefundEscrowTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.EscrowTxResult{}, sql.ErrConnDone)
+
+	_, err := RefundExpired(context.Background(), store, time.Now())
+	require.EqualError(t, err, "cannot refund escrow [1]: "+sql.ErrConnDone.Error())
+}
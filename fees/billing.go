@@ -0,0 +1,111 @@
+package fees
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+// Store contains the queries and transactions used to bill fees
+type Store interface {
+	ListBillableAccounts(ctx context.Context, periodEnd time.Time) ([]db.ListBillableAccountsRow, error)
+	ChargeFeeTx(ctx context.Context, arg db.ChargeFeeTxParams) (db.ChargeFeeTxResult, error)
+}
+
+// Report sums up the billing of a month
+type Report struct {
+	Period time.Time
+	// Statuses counts the fees billed by status
+	Statuses map[string]int
+	// Skipped is the number of accounts that were already billed for the month
+	Skipped int
+	// Charged and Arrears hold the cents charged and left in arrears by currency
+	Charged map[string]int64
+	Arrears map[string]int64
+}
+
+// Biller charges the monthly maintenance fee of accounts
+type Biller struct {
+	store   Store
+	fee     int64
+	waivers []WaiverRule
+}
+
+// NewBiller creates a biller charging fee cents to every account not waived by one of the rules.
+// The same fee applies in every currency.
+func NewBiller(store Store, fee int64, waivers ...WaiverRule) (*Biller, error) {
+	if fee <= 0 {
+		return nil, fmt.Errorf("invalid maintenance fee %d", fee)
+	}
+
+	return &Biller{
+		store:   store,
+		fee:     fee,
+		waivers: waivers,
+	}, nil
+}
+
+// ChargeMonth bills the maintenance fee of a month to every checking and savings account opened before the month ended.
+// Each account is billed in its own database transaction, and only once per month,
+// so running it again for the same month is safe and only bills the accounts that were missed.
+func (biller *Biller) ChargeMonth(ctx context.Context, month time.Time) (Report, error) {
+	year, m, _ := month.UTC().Date()
+	report := Report{
+		Period:   time.Date(year, m, 1, 0, 0, 0, 0, time.UTC),
+		Statuses: make(map[string]int),
+		Charged:  make(map[string]int64),
+		Arrears:  make(map[string]int64),
+	}
+	periodEnd := report.Period.AddDate(0, 1, 0)
+
+	accounts, err := biller.store.ListBillableAccounts(ctx, periodEnd)
+	if err != nil {
+		return report, err
+	}
+
+	for _, row := range accounts {
+		account := Account{
+			ID:        row.ID,
+			Owner:     row.Owner,
+			OwnerRole: row.OwnerRole,
+			Balance:   row.Balance,
+			Currency:  row.Currency,
+			Type:      row.Type,
+			CreatedAt: row.CreatedAt,
+		}
+
+		result, err := biller.store.ChargeFeeTx(ctx, db.ChargeFeeTxParams{
+			AccountID:    account.ID,
+			Period:       report.Period,
+			Amount:       biller.fee,
+			WaiverReason: biller.waiverReason(account, periodEnd),
+		})
+		if errors.Is(err, db.ErrFeeAlreadyCharged) {
+			report.Skipped++
+			continue
+		}
+		if err != nil {
+			return report, fmt.Errorf("cannot charge fee of account [%d]: %w", account.ID, err)
+		}
+
+		fee := result.AccountFee
+		report.Statuses[fee.Status]++
+		report.Charged[account.Currency] += fee.Charged
+		report.Arrears[account.Currency] += fee.Arrears
+	}
+
+	return report, nil
+}
+
+// waiverReason returns the reason of the first rule waiving the fee of the account, if any
+func (biller *Biller) waiverReason(account Account, periodEnd time.Time) string {
+	for _, rule := range biller.waivers {
+		if reason := rule.Waive(account, periodEnd); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+package fees
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/volskyi-dmytro/st-bank/db/mock"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+func TestNewBiller(t *testing.T) {
+	_, err := NewBiller(nil, 0)
+	require.EqualError(t, err, "invalid maintenance fee 0")
+}
+
+func TestChargeMonth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	period := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC)
+	opened := periodEnd.AddDate(-1, 0, 0)
+
+	accounts := []db.ListBillableAccountsRow{
+		{ID: 1, Owner: "alice", OwnerRole: util.DepositorRole, Balance: 100, Currency: "USD", CreatedAt: opened},
+		{ID: 2, Owner: "bob", OwnerRole: util.DepositorRole, Balance: 10, Currency: "USD", CreatedAt: opened},
+		{ID: 3, Owner: "carol", OwnerRole: util.BankerRole, Balance: 0, Currency: "EUR", CreatedAt: opened},
+		{ID: 4, Owner: "dave", OwnerRole: util.DepositorRole, Balance: 5000, Currency: "EUR", CreatedAt: opened},
+		{ID: 5, Owner: "erin", OwnerRole: util.DepositorRole, Balance: 0, Currency: "UAH", CreatedAt: opened},
+	}
+	store.EXPECT().ListBillableAccounts(gomock.Any(), gomock.Eq(periodEnd)).Times(1).Return(accounts, nil)
+
+	expectCharge := func(accountID int64, waiverReason string, fee db.AccountFee, err error) {
+		arg := db.ChargeFeeTxParams{
+			AccountID:    accountID,
+			Period:       period,
+			Amount:       50,
+			WaiverReason: waiverReason,
+		}
+		store.EXPECT().
+			ChargeFeeTx(gomock.Any(), gomock.Eq(arg)).
+			Times(1).
+			Return(db.ChargeFeeTxResult{AccountFee: fee}, err)
+	}
+
+	expectCharge(1, "", db.AccountFee{Status: db.AccountFeeStatusCharged, Amount: 50, Charged: 50}, nil)
+	expectCharge(2, "", db.AccountFee{Status: db.AccountFeeStatusPartial, Amount: 50, Charged: 10, Arrears: 40}, nil)
+	expectCharge(3, ReasonRole, db.AccountFee{Status: db.AccountFeeStatusWaived, Amount: 50}, nil)
+	expectCharge(4, ReasonMinimumBalance, db.AccountFee{Status: db.AccountFeeStatusWaived, Amount: 50}, nil)
+	expectCharge(5, "", db.AccountFee{}, db.ErrFeeAlreadyCharged)
+
+	biller, err := NewBiller(store, 50, NewRoleWaiver(util.BankerRole), NewMinimumBalanceWaiver(1000))
+	require.NoError(t, err)
+
+	report, err := biller.ChargeMonth(context.Background(), period.AddDate(0, 0, 14))
+	require.NoError(t, err)
+	require.Equal(t, Report{
+		Period: period,
+		Statuses: map[string]int{
+			db.AccountFeeStatusCharged: 1,
+			db.AccountFeeStatusPartial: 1,
+			db.AccountFeeStatusWaived:  2,
+		},
+		Skipped: 1,
+		Charged: map[string]int64{"USD": 60, "EUR": 0},
+		Arrears: map[string]int64{"USD": 40, "EUR": 0},
+	}, report)
+}
+
+func TestChargeMonthError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	accounts := []db.ListBillableAccountsRow{{ID: 1}, {ID: 2}}
+	store.EXPECT().ListBillableAccounts(gomock.Any(), gomock.Any()).Times(1).Return(accounts, nil)
+	store.EXPECT().ChargeFeeTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ChargeFeeTxResult{}, sql.ErrConnDone)
+
+	biller, err := NewBiller(store, 50)
+	require.NoError(t, err)
+
+	_, err = biller.ChargeMonth(context.Background(), time.Now())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+}
@@ -0,0 +1,85 @@
+// Package fees bills the monthly maintenance fee of accounts, unless one of the waiver rules applies.
+package fees
+
+import (
+	"time"
+)
+
+// Reasons reported by the built-in waiver rules
+const (
+	ReasonMinimumBalance = "minimum_balance"
+	ReasonNewAccount     = "new_account"
+	ReasonRole           = "role"
+)
+
+// Account is an account about to be billed
+type Account struct {
+	ID        int64
+	Owner     string
+	OwnerRole string
+	Balance   int64
+	Currency  string
+	Type      string
+	CreatedAt time.Time
+}
+
+// WaiverRule decides whether the fee of an account is waived for a period
+type WaiverRule interface {
+	// Waive returns the reason the fee is waived, or an empty string when the account must pay it
+	Waive(account Account, periodEnd time.Time) string
+}
+
+// MinimumBalanceWaiver waives the fee of accounts holding at least a minimum balance
+type MinimumBalanceWaiver struct {
+	minBalance int64
+}
+
+// NewMinimumBalanceWaiver creates a rule waiving the fee of accounts with a balance of at least minBalance
+func NewMinimumBalanceWaiver(minBalance int64) *MinimumBalanceWaiver {
+	return &MinimumBalanceWaiver{minBalance: minBalance}
+}
+
+func (rule *MinimumBalanceWaiver) Waive(account Account, periodEnd time.Time) string {
+	if account.Balance >= rule.minBalance {
+		return ReasonMinimumBalance
+	}
+	return ""
+}
+
+// AccountAgeWaiver waives the fee of accounts opened recently
+type AccountAgeWaiver struct {
+	age time.Duration
+}
+
+// NewAccountAgeWaiver creates a rule waiving the fee of accounts younger than age at the end of the period
+func NewAccountAgeWaiver(age time.Duration) *AccountAgeWaiver {
+	return &AccountAgeWaiver{age: age}
+}
+
+func (rule *AccountAgeWaiver) Waive(account Account, periodEnd time.Time) string {
+	if periodEnd.Sub(account.CreatedAt) < rule.age {
+		return ReasonNewAccount
+	}
+	return ""
+}
+
+// RoleWaiver waives the fee of accounts whose owner has one of the given roles, e.g. staff accounts
+type RoleWaiver struct {
+	roles map[string]bool
+}
+
+// NewRoleWaiver creates a rule waiving the fee of accounts owned by users with one of roles
+func NewRoleWaiver(roles ...string) *RoleWaiver {
+	rule := &RoleWaiver{roles: make(map[string]bool, len(roles))}
+	for _, role := range roles {
+		rule.roles[role] = true
+	}
+	return rule
+}
+
+func (rule *RoleWaiver) Waive(account Account, periodEnd time.Time) string {
+	if rule.roles[account.OwnerRole] {
+		return ReasonRole
+	}
+	return ""
+}
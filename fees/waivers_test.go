@@ -0,0 +1,34 @@
+package fees
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+func TestMinimumBalanceWaiver(t *testing.T) {
+	rule := NewMinimumBalanceWaiver(1000)
+	periodEnd := time.Now()
+
+	require.Equal(t, ReasonMinimumBalance, rule.Waive(Account{Balance: 1000}, periodEnd))
+	require.Empty(t, rule.Waive(Account{Balance: 999}, periodEnd))
+}
+
+func TestAccountAgeWaiver(t *testing.T) {
+	rule := NewAccountAgeWaiver(90 * 24 * time.Hour)
+	periodEnd := time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	require.Equal(t, ReasonNewAccount, rule.Waive(Account{CreatedAt: periodEnd.AddDate(0, 0, -89)}, periodEnd))
+	require.Empty(t, rule.Waive(Account{CreatedAt: periodEnd.AddDate(0, 0, -90)}, periodEnd))
+}
+
+func TestRoleWaiver(t *testing.T) {
+	rule := NewRoleWaiver(util.BankerRole)
+	periodEnd := time.Now()
+
+	require.Equal(t, ReasonRole, rule.Waive(Account{OwnerRole: util.BankerRole}, periodEnd))
+	require.Empty(t, rule.Waive(Account{OwnerRole: util.DepositorRole}, periodEnd))
+	require.Empty(t, NewRoleWaiver().Waive(Account{OwnerRole: util.BankerRole}, periodEnd))
+}
@@ -0,0 +1,121 @@
+package fraud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Decision is the outcome of a fraud assessment
+type Decision string
+
+const (
+	Allow  Decision = "allow"
+	Review Decision = "review"
+	Block  Decision = "block"
+)
+
+// Default score thresholds for review and block decisions
+const (
+	DefaultReviewScore = 50
+	DefaultBlockScore  = 100
+)
+
+// Transfer contains the details of a transfer that is about to be executed
+type Transfer struct {
+	Username      string
+	FromAccountID int64
+	ToAccountID   int64
+	Amount        int64
+	Currency      string
+	CreatedAt     time.Time
+}
+
+// Result is the outcome of a single rule.
+// A zero score means the rule was not triggered.
+type Result struct {
+	Score      int
+	ReasonCode string
+}
+
+// Rule is a single fraud check evaluated against a transfer
+type Rule interface {
+	Evaluate(ctx context.Context, transfer Transfer) (Result, error)
+}
+
+// Assessment is the combined outcome of all rules of an engine
+type Assessment struct {
+	Score       int
+	Decision    Decision
+	ReasonCodes []string
+}
+
+// ReasonCode returns the reason code of the highest scoring rule that was triggered
+func (assessment Assessment) ReasonCode() string {
+	if len(assessment.ReasonCodes) == 0 {
+		return ""
+	}
+	return assessment.ReasonCodes[0]
+}
+
+// Engine evaluates a set of rules and turns their total score into a decision
+type Engine struct {
+	rules       []Rule
+	reviewScore int
+	blockScore  int
+}
+
+// NewEngine creates a new fraud engine.
+// Transfers scoring at least reviewScore need a review, those scoring at least blockScore are blocked.
+func NewEngine(reviewScore, blockScore int, rules ...Rule) (*Engine, error) {
+	if reviewScore <= 0 || blockScore < reviewScore {
+		return nil, fmt.Errorf("invalid fraud scores: review %d, block %d", reviewScore, blockScore)
+	}
+
+	engine := &Engine{
+		rules:       rules,
+		reviewScore: reviewScore,
+		blockScore:  blockScore,
+	}
+
+	return engine, nil
+}
+
+// Assess evaluates every rule against the transfer
+func (engine *Engine) Assess(ctx context.Context, transfer Transfer) (Assessment, error) {
+	var assessment Assessment
+	var triggered []Result
+
+	for _, rule := range engine.rules {
+		result, err := rule.Evaluate(ctx, transfer)
+		if err != nil {
+			return assessment, err
+		}
+
+		if result.Score > 0 {
+			assessment.Score += result.Score
+			triggered = append(triggered, result)
+		}
+	}
+
+	sort.SliceStable(triggered, func(i, j int) bool {
+		return triggered[i].Score > triggered[j].Score
+	})
+
+	assessment.ReasonCodes = make([]string, 0, len(triggered))
+	for _, result := range triggered {
+		assessment.ReasonCodes = append(assessment.ReasonCodes, result.ReasonCode)
+	}
+
+	switch {
+	case assessment.Score >= engine.blockScore:
+		assessment.Decision = Block
+	case assessment.Score >= engine.reviewScore:
+		assessment.Decision = Review
+	default:
+		assessment.Decision = Allow
+	}
+
+	return assessment, nil
+}
@@ -0,0 +1,120 @@
+package fraud
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+type stubRule struct {
+	result Result
+	err    error
+}
+
+func (rule stubRule) Evaluate(ctx context.Context, transfer Transfer) (Result, error) {
+	return rule.result, rule.err
+}
+
+func randomTransfer() Transfer {
+	return Transfer{
+		Username:      util.RandomOwner(),
+		FromAccountID: util.RandomInt(1, 1000),
+		ToAccountID:   util.RandomInt(1, 1000),
+		Amount:        util.RandomMoney(),
+		Currency:      util.RandomCurrency(),
+		CreatedAt:     time.Now(),
+	}
+}
+
+func TestEngineAssess(t *testing.T) {
+	testCases := []struct {
+		name        string
+		rules       []Rule
+		score       int
+		decision    Decision
+		reasonCodes []string
+	}{
+		{
+			name:        "NoRules",
+			decision:    Allow,
+			reasonCodes: []string{},
+		},
+		{
+			name: "Allow",
+			rules: []Rule{
+				stubRule{},
+				stubRule{result: Result{Score: 40, ReasonCode: "low"}},
+			},
+			score:       40,
+			decision:    Allow,
+			reasonCodes: []string{"low"},
+		},
+		{
+			name: "Review",
+			rules: []Rule{
+				stubRule{result: Result{Score: 60, ReasonCode: "medium"}},
+			},
+			score:       60,
+			decision:    Review,
+			reasonCodes: []string{"medium"},
+		},
+		{
+			name: "BlockOnCombinedScore",
+			rules: []Rule{
+				stubRule{result: Result{Score: 40, ReasonCode: "low"}},
+				stubRule{result: Result{Score: 60, ReasonCode: "medium"}},
+			},
+			score:       100,
+			decision:    Block,
+			reasonCodes: []string{"medium", "low"},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			engine, err := NewEngine(DefaultReviewScore, DefaultBlockScore, tc.rules...)
+			require.NoError(t, err)
+
+			assessment, err := engine.Assess(context.Background(), randomTransfer())
+			require.NoError(t, err)
+
+			require.Equal(t, tc.score, assessment.Score)
+			require.Equal(t, tc.decision, assessment.Decision)
+			require.Equal(t, tc.reasonCodes, assessment.ReasonCodes)
+		})
+	}
+}
+
+func TestEngineAssessRuleError(t *testing.T) {
+	ruleErr := errors.New("rule failed")
+
+	engine, err := NewEngine(DefaultReviewScore, DefaultBlockScore,
+		stubRule{result: Result{Score: 60, ReasonCode: "medium"}},
+		stubRule{err: ruleErr},
+	)
+	require.NoError(t, err)
+
+	_, err = engine.Assess(context.Background(), randomTransfer())
+	require.ErrorIs(t, err, ruleErr)
+}
+
+func TestNewEngineInvalidScores(t *testing.T) {
+	_, err := NewEngine(0, DefaultBlockScore)
+	require.Error(t, err)
+
+	_, err = NewEngine(DefaultBlockScore, DefaultReviewScore)
+	require.Error(t, err)
+}
+
+func TestAssessmentReasonCode(t *testing.T) {
+	require.Empty(t, Assessment{}.ReasonCode())
+
+	assessment := Assessment{ReasonCodes: []string{ReasonVelocity, ReasonPasswordChange}}
+	require.Equal(t, ReasonVelocity, assessment.ReasonCode())
+}
@@ -0,0 +1,168 @@
+package fraud
+
+import (
+	"context"
+	"time"
+
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+// Reason codes reported by the built-in rules
+const (
+	ReasonVelocity                = "velocity_exceeded"
+	ReasonNewRecipientLargeAmount = "new_recipient_large_amount"
+	ReasonAmountDeviation         = "amount_deviation"
+	ReasonPasswordChange          = "first_transfer_after_password_change"
+)
+
+// Scores of the built-in rules
+const (
+	VelocityScore       = 100
+	NewRecipientScore   = 60
+	DeviationScore      = 50
+	PasswordChangeScore = 40
+)
+
+// Store contains the queries used by the built-in rules
+type Store interface {
+	GetUser(ctx context.Context, username string) (db.User, error)
+	CountTransfersSince(ctx context.Context, arg db.CountTransfersSinceParams) (int64, error)
+	CountTransfersToAccount(ctx context.Context, arg db.CountTransfersToAccountParams) (int64, error)
+	GetTransferAmountStats(ctx context.Context, owner string) (db.GetTransferAmountStatsRow, error)
+}
+
+// VelocityRule flags users making too many transfers in a short window
+type VelocityRule struct {
+	store        Store
+	maxTransfers int64
+	window       time.Duration
+}
+
+// NewVelocityRule creates a rule that is triggered once a user has made maxTransfers within window
+func NewVelocityRule(store Store, maxTransfers int64, window time.Duration) *VelocityRule {
+	return &VelocityRule{
+		store:        store,
+		maxTransfers: maxTransfers,
+		window:       window,
+	}
+}
+
+func (rule *VelocityRule) Evaluate(ctx context.Context, transfer Transfer) (Result, error) {
+	count, err := rule.store.CountTransfersSince(ctx, db.CountTransfersSinceParams{
+		Owner: transfer.Username,
+		Since: transfer.CreatedAt.Add(-rule.window),
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	if count < rule.maxTransfers {
+		return Result{}, nil
+	}
+
+	return Result{Score: VelocityScore, ReasonCode: ReasonVelocity}, nil
+}
+
+// NewRecipientRule flags large transfers to an account the user never paid before
+type NewRecipientRule struct {
+	store       Store
+	largeAmount int64
+}
+
+// NewNewRecipientRule creates a rule that is triggered by transfers of at least largeAmount to a new recipient
+func NewNewRecipientRule(store Store, largeAmount int64) *NewRecipientRule {
+	return &NewRecipientRule{
+		store:       store,
+		largeAmount: largeAmount,
+	}
+}
+
+func (rule *NewRecipientRule) Evaluate(ctx context.Context, transfer Transfer) (Result, error) {
+	if transfer.Amount < rule.largeAmount {
+		return Result{}, nil
+	}
+
+	count, err := rule.store.CountTransfersToAccount(ctx, db.CountTransfersToAccountParams{
+		Owner:       transfer.Username,
+		ToAccountID: transfer.ToAccountID,
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	if count > 0 {
+		return Result{}, nil
+	}
+
+	return Result{Score: NewRecipientScore, ReasonCode: ReasonNewRecipientLargeAmount}, nil
+}
+
+// DeviationRule flags transfers far above the user's average transfer amount
+type DeviationRule struct {
+	store      Store
+	multiplier int64
+	minHistory int64
+}
+
+// NewDeviationRule creates a rule that is triggered by transfers above multiplier times the user's average.
+// Users with fewer than minHistory transfers are not checked.
+func NewDeviationRule(store Store, multiplier int64, minHistory int64) *DeviationRule {
+	return &DeviationRule{
+		store:      store,
+		multiplier: multiplier,
+		minHistory: minHistory,
+	}
+}
+
+func (rule *DeviationRule) Evaluate(ctx context.Context, transfer Transfer) (Result, error) {
+	stats, err := rule.store.GetTransferAmountStats(ctx, transfer.Username)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if stats.TransferCount < rule.minHistory || transfer.Amount <= stats.AverageAmount*rule.multiplier {
+		return Result{}, nil
+	}
+
+	return Result{Score: DeviationScore, ReasonCode: ReasonAmountDeviation}, nil
+}
+
+// PasswordChangeRule flags the first transfer made shortly after a password change, relying on users.password_changed_at
+type PasswordChangeRule struct {
+	store  Store
+	window time.Duration
+}
+
+// NewPasswordChangeRule creates a rule that is triggered by the first transfer within window of a password change
+func NewPasswordChangeRule(store Store, window time.Duration) *PasswordChangeRule {
+	return &PasswordChangeRule{
+		store:  store,
+		window: window,
+	}
+}
+
+func (rule *PasswordChangeRule) Evaluate(ctx context.Context, transfer Transfer) (Result, error) {
+	user, err := rule.store.GetUser(ctx, transfer.Username)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// the password was never changed, or it was changed long ago
+	if user.PasswordChangedAt.IsZero() || transfer.CreatedAt.Sub(user.PasswordChangedAt) > rule.window {
+		return Result{}, nil
+	}
+
+	count, err := rule.store.CountTransfersSince(ctx, db.CountTransfersSinceParams{
+		Owner: transfer.Username,
+		Since: user.PasswordChangedAt,
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	if count > 0 {
+		return Result{}, nil
+	}
+
+	return Result{Score: PasswordChangeScore, ReasonCode: ReasonPasswordChange}, nil
+}
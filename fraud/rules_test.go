@@ -0,0 +1,212 @@
+package fraud
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/volskyi-dmytro/st-bank/db/mock"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+func TestVelocityRule(t *testing.T) {
+	transfer := randomTransfer()
+	window := 10 * time.Minute
+
+	testCases := []struct {
+		name   string
+		count  int64
+		result Result
+	}{
+		{
+			name:  "BelowLimit",
+			count: 4,
+		},
+		{
+			name:   "LimitReached",
+			count:  5,
+			result: Result{Score: VelocityScore, ReasonCode: ReasonVelocity},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			arg := db.CountTransfersSinceParams{
+				Owner: transfer.Username,
+				Since: transfer.CreatedAt.Add(-window),
+			}
+			store.EXPECT().CountTransfersSince(gomock.Any(), gomock.Eq(arg)).Times(1).Return(tc.count, nil)
+
+			result, err := NewVelocityRule(store, 5, window).Evaluate(context.Background(), transfer)
+			require.NoError(t, err)
+			require.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func TestNewRecipientRule(t *testing.T) {
+	transfer := randomTransfer()
+	transfer.Amount = 1000
+
+	testCases := []struct {
+		name        string
+		largeAmount int64
+		buildStubs  func(store *mockdb.MockStore)
+		result      Result
+	}{
+		{
+			name:        "SmallAmount",
+			largeAmount: 1001,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountTransfersToAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:        "KnownRecipient",
+			largeAmount: 1000,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountTransfersToAccount(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+			},
+		},
+		{
+			name:        "NewRecipient",
+			largeAmount: 1000,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CountTransfersToAccountParams{
+					Owner:       transfer.Username,
+					ToAccountID: transfer.ToAccountID,
+				}
+				store.EXPECT().CountTransfersToAccount(gomock.Any(), gomock.Eq(arg)).Times(1).Return(int64(0), nil)
+			},
+			result: Result{Score: NewRecipientScore, ReasonCode: ReasonNewRecipientLargeAmount},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			result, err := NewNewRecipientRule(store, tc.largeAmount).Evaluate(context.Background(), transfer)
+			require.NoError(t, err)
+			require.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func TestDeviationRule(t *testing.T) {
+	transfer := randomTransfer()
+	transfer.Amount = 1000
+
+	testCases := []struct {
+		name   string
+		stats  db.GetTransferAmountStatsRow
+		result Result
+	}{
+		{
+			name:  "NotEnoughHistory",
+			stats: db.GetTransferAmountStatsRow{TransferCount: 4, AverageAmount: 10},
+		},
+		{
+			name:  "WithinAverage",
+			stats: db.GetTransferAmountStatsRow{TransferCount: 5, AverageAmount: 100},
+		},
+		{
+			name:   "AboveAverage",
+			stats:  db.GetTransferAmountStatsRow{TransferCount: 5, AverageAmount: 99},
+			result: Result{Score: DeviationScore, ReasonCode: ReasonAmountDeviation},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetTransferAmountStats(gomock.Any(), gomock.Eq(transfer.Username)).Times(1).Return(tc.stats, nil)
+
+			result, err := NewDeviationRule(store, 10, 5).Evaluate(context.Background(), transfer)
+			require.NoError(t, err)
+			require.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func TestPasswordChangeRule(t *testing.T) {
+	transfer := randomTransfer()
+	window := 24 * time.Hour
+
+	testCases := []struct {
+		name       string
+		user       db.User
+		buildStubs func(store *mockdb.MockStore)
+		result     Result
+	}{
+		{
+			name: "NeverChanged",
+			user: db.User{Username: transfer.Username},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountTransfersSince(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name: "ChangedLongAgo",
+			user: db.User{Username: transfer.Username, PasswordChangedAt: transfer.CreatedAt.Add(-2 * window)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountTransfersSince(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name: "AlreadyTransferred",
+			user: db.User{Username: transfer.Username, PasswordChangedAt: transfer.CreatedAt.Add(-time.Hour)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountTransfersSince(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+			},
+		},
+		{
+			name: "FirstTransfer",
+			user: db.User{Username: transfer.Username, PasswordChangedAt: transfer.CreatedAt.Add(-time.Hour)},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CountTransfersSinceParams{
+					Owner: transfer.Username,
+					Since: transfer.CreatedAt.Add(-time.Hour),
+				}
+				store.EXPECT().CountTransfersSince(gomock.Any(), gomock.Eq(arg)).Times(1).Return(int64(0), nil)
+			},
+			result: Result{Score: PasswordChangeScore, ReasonCode: ReasonPasswordChange},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(transfer.Username)).Times(1).Return(tc.user, nil)
+			tc.buildStubs(store)
+
+			result, err := NewPasswordChangeRule(store, window).Evaluate(context.Background(), transfer)
+			require.NoError(t, err)
+			require.Equal(t, tc.result, result)
+		})
+	}
+}
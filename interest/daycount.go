@@ -0,0 +1,83 @@
+// Package interest computes the interest of savings accounts: a daily accrual on the end of day balance,
+// and the monthly posting of the accrued interest to the accounts.
+package interest
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+// DayCount is a day count convention, which sets the fraction of a year a day of interest is worth
+type DayCount string
+
+const (
+	// Actual365 counts the actual days over a 365 day year
+	Actual365 DayCount = "ACT/365"
+	// Actual360 counts the actual days over a 360 day year
+	Actual360 DayCount = "ACT/360"
+	// Thirty360 counts every month as 30 days over a 360 day year (US bond basis)
+	Thirty360 DayCount = "30/360"
+)
+
+// ParseDayCount returns the day count convention with the given name
+func ParseDayCount(name string) (DayCount, error) {
+	switch dayCount := DayCount(name); dayCount {
+	case Actual365, Actual360, Thirty360:
+		return dayCount, nil
+	default:
+		return "", fmt.Errorf("unknown day count convention %q", name)
+	}
+}
+
+// DayFraction returns the fraction of a year that the given day accrues interest for, as days over year days.
+// Under 30/360 a day can count for 0 to 3 days, so that every month adds up to 30 days.
+func (dayCount DayCount) DayFraction(day time.Time) (days int64, yearDays int64) {
+	switch dayCount {
+	case Actual360:
+		return 1, 360
+	case Thirty360:
+		return thirty360Days(day, day.AddDate(0, 0, 1)), 360
+	default:
+		return 1, 365
+	}
+}
+
+// thirty360Days counts the days between two dates under the 30/360 US bond basis
+func thirty360Days(start, end time.Time) int64 {
+	y1, m1, d1 := start.Date()
+	y2, m2, d2 := end.Date()
+
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+
+	return int64(360*(y2-y1) + 30*(int(m2)-int(m1)) + (d2 - d1))
+}
+
+// DailyInterest returns the interest a balance in cents earns over a day, in millionths of a cent.
+// The interest is rounded down, and balances that aren't positive earn nothing.
+func DailyInterest(balance int64, annualRateBps int32, dayCount DayCount, day time.Time) (int64, error) {
+	if balance <= 0 || annualRateBps <= 0 {
+		return 0, nil
+	}
+
+	days, yearDays := dayCount.DayFraction(day)
+
+	// balance * rate / 10000 * days / yearDays, scaled to millionths of a cent
+	amount := big.NewInt(balance)
+	amount.Mul(amount, big.NewInt(int64(annualRateBps)))
+	amount.Mul(amount, big.NewInt(days))
+	amount.Mul(amount, big.NewInt(db.InterestAmountScale))
+	amount.Quo(amount, big.NewInt(10000*yearDays))
+
+	if !amount.IsInt64() {
+		return 0, fmt.Errorf("daily interest of balance %d is out of range", balance)
+	}
+	return amount.Int64(), nil
+}
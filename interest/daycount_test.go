@@ -0,0 +1,99 @@
+package interest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseDayCount(t *testing.T) {
+	for _, name := range []string{"ACT/365", "ACT/360", "30/360"} {
+		dayCount, err := ParseDayCount(name)
+		require.NoError(t, err)
+		require.Equal(t, DayCount(name), dayCount)
+	}
+
+	_, err := ParseDayCount("ACT/ACT")
+	require.EqualError(t, err, `unknown day count convention "ACT/ACT"`)
+}
+
+func TestDayFraction(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dayCount DayCount
+		day      time.Time
+		days     int64
+		yearDays int64
+	}{
+		{name: "Actual365", dayCount: Actual365, day: date(2026, time.March, 31), days: 1, yearDays: 365},
+		{name: "Actual360", dayCount: Actual360, day: date(2026, time.March, 31), days: 1, yearDays: 360},
+		{name: "Thirty360", dayCount: Thirty360, day: date(2026, time.March, 15), days: 1, yearDays: 360},
+		{name: "Thirty360Day31", dayCount: Thirty360, day: date(2026, time.March, 31), days: 1, yearDays: 360},
+		{name: "Thirty360Day30", dayCount: Thirty360, day: date(2026, time.March, 30), days: 0, yearDays: 360},
+		{name: "Thirty360EndOfFebruary", dayCount: Thirty360, day: date(2026, time.February, 28), days: 3, yearDays: 360},
+		{name: "Thirty360LeapDay", dayCount: Thirty360, day: date(2024, time.February, 29), days: 2, yearDays: 360},
+		{name: "Thirty360EndOfYear", dayCount: Thirty360, day: date(2025, time.December, 31), days: 1, yearDays: 360},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			days, yearDays := tc.dayCount.DayFraction(tc.day)
+			require.Equal(t, tc.days, days)
+			require.Equal(t, tc.yearDays, yearDays)
+		})
+	}
+}
+
+func TestThirty360MonthsAreThirtyDays(t *testing.T) {
+	for _, year := range []int{2024, 2025} {
+		for month := time.January; month <= time.December; month++ {
+			var total int64
+			for day := date(year, month, 1); day.Month() == month; day = day.AddDate(0, 0, 1) {
+				days, _ := Thirty360.DayFraction(day)
+				total += days
+			}
+			require.Equal(t, int64(30), total, "%d-%02d", year, month)
+		}
+	}
+}
+
+func TestDailyInterest(t *testing.T) {
+	day := date(2026, time.March, 15)
+
+	testCases := []struct {
+		name          string
+		balance       int64
+		annualRateBps int32
+		dayCount      DayCount
+		interest      int64
+	}{
+		{name: "Actual365", balance: 1_000_000, annualRateBps: 365, dayCount: Actual365, interest: 100_000_000},
+		{name: "Actual360", balance: 1_000_000, annualRateBps: 360, dayCount: Actual360, interest: 100_000_000},
+		{name: "Thirty360", balance: 1_000_000, annualRateBps: 360, dayCount: Thirty360, interest: 100_000_000},
+		{name: "RoundedDown", balance: 1, annualRateBps: 100, dayCount: Actual365, interest: 27},
+		{name: "ZeroBalance", balance: 0, annualRateBps: 250, dayCount: Actual365, interest: 0},
+		{name: "NegativeBalance", balance: -1_000_000, annualRateBps: 250, dayCount: Actual365, interest: 0},
+		{name: "ZeroRate", balance: 1_000_000, annualRateBps: 0, dayCount: Actual365, interest: 0},
+		{name: "LargeBalance", balance: 1 << 40, annualRateBps: 10000, dayCount: Actual360, interest: 3054198966044444},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			interest, err := DailyInterest(tc.balance, tc.annualRateBps, tc.dayCount, day)
+			require.NoError(t, err)
+			require.Equal(t, tc.interest, interest)
+		})
+	}
+
+	_, err := DailyInterest(1<<62, 10000, Actual360, day)
+	require.EqualError(t, err, "daily interest of balance 4611686018427387904 is out of range")
+}
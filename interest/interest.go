@@ -0,0 +1,208 @@
+package interest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+// Store contains the queries and transactions used to accrue and post interest
+type Store interface {
+	ListInterestBearingAccounts(ctx context.Context, dayEnd time.Time) ([]db.ListInterestBearingAccountsRow, error)
+	ListOverdraftAccounts(ctx context.Context, dayEnd time.Time) ([]db.ListOverdraftAccountsRow, error)
+	GetBalanceAsOf(ctx context.Context, arg db.GetBalanceAsOfParams) (int64, error)
+	CreateInterestAccrual(ctx context.Context, arg db.CreateInterestAccrualParams) (int64, error)
+	ListAccruingAccounts(ctx context.Context, arg db.ListAccruingAccountsParams) ([]int64, error)
+	PostInterestTx(ctx context.Context, arg db.PostInterestTxParams) (db.PostInterestTxResult, error)
+}
+
+// AccrualReport sums up the accrual of a day
+type AccrualReport struct {
+	Day time.Time
+	// Accrued is the number of accounts that accrued interest for the day
+	Accrued int
+	// Skipped is the number of accounts that had already accrued interest for the day
+	Skipped int
+}
+
+// OverdraftDayCount is the day count convention of overdraft interest
+const OverdraftDayCount = Actual365
+
+// Accrue records a day of interest for every savings account with an interest plan,
+// computed on the balance of the account at the end of the day.
+// Accounts with an overdraft facility that ended the day below zero accrue overdraft interest instead,
+// as a negative amount at the rate of the facility, even once it is revoked.
+// A day only accrues once per account, so running it again for the same day is safe.
+func Accrue(ctx context.Context, store Store, day time.Time) (AccrualReport, error) {
+	report := AccrualReport{Day: Date(day)}
+	dayEnd := report.Day.AddDate(0, 0, 1)
+
+	accounts, err := store.ListInterestBearingAccounts(ctx, dayEnd)
+	if err != nil {
+		return report, err
+	}
+
+	for _, account := range accounts {
+		dayCount, err := ParseDayCount(account.DayCount)
+		if err != nil {
+			return report, fmt.Errorf("account [%d]: %w", account.AccountID, err)
+		}
+
+		balance, err := balanceAt(ctx, store, account.AccountID, dayEnd)
+		if err != nil {
+			return report, err
+		}
+
+		// an overdrawn account doesn't earn interest
+		if balance < 0 {
+			continue
+		}
+
+		amount, err := DailyInterest(balance, account.AnnualRateBps, dayCount, report.Day)
+		if err != nil {
+			return report, fmt.Errorf("account [%d]: %w", account.AccountID, err)
+		}
+
+		err = recordAccrual(ctx, store, &report, db.CreateInterestAccrualParams{
+			AccountID:     account.AccountID,
+			AccrualDate:   report.Day,
+			Balance:       balance,
+			AnnualRateBps: account.AnnualRateBps,
+			DayCount:      account.DayCount,
+			Amount:        amount,
+		})
+		if err != nil {
+			return report, err
+		}
+	}
+
+	overdrafts, err := store.ListOverdraftAccounts(ctx, dayEnd)
+	if err != nil {
+		return report, err
+	}
+
+	for _, account := range overdrafts {
+		balance, err := balanceAt(ctx, store, account.AccountID, dayEnd)
+		if err != nil {
+			return report, err
+		}
+
+		if balance >= 0 {
+			continue
+		}
+
+		amount, err := DailyInterest(-balance, account.AnnualRateBps, OverdraftDayCount, report.Day)
+		if err != nil {
+			return report, fmt.Errorf("account [%d]: %w", account.AccountID, err)
+		}
+
+		err = recordAccrual(ctx, store, &report, db.CreateInterestAccrualParams{
+			AccountID:     account.AccountID,
+			AccrualDate:   report.Day,
+			Balance:       balance,
+			AnnualRateBps: account.AnnualRateBps,
+			DayCount:      string(OverdraftDayCount),
+			Amount:        -amount,
+		})
+		if err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// balanceAt returns the balance of an account at the given time
+func balanceAt(ctx context.Context, store Store, accountID int64, at time.Time) (int64, error) {
+	balance, err := store.GetBalanceAsOf(ctx, db.GetBalanceAsOfParams{
+		AccountID: accountID,
+		AsOf:      at,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("cannot get balance of account [%d]: %w", accountID, err)
+	}
+	return balance, nil
+}
+
+// recordAccrual creates an accrual and counts it in the report, as skipped when the day had already accrued
+func recordAccrual(ctx context.Context, store Store, report *AccrualReport, arg db.CreateInterestAccrualParams) error {
+	created, err := store.CreateInterestAccrual(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("cannot accrue interest of account [%d]: %w", arg.AccountID, err)
+	}
+
+	if created == 0 {
+		report.Skipped++
+	} else {
+		report.Accrued++
+	}
+	return nil
+}
+
+// PostingReport sums up the posting of a month
+type PostingReport struct {
+	PeriodEnd time.Time
+	// Posted is the number of accounts the interest of the month was posted to
+	Posted int
+	// Skipped is the number of accounts whose interest was already posted for the month
+	Skipped int
+	// Totals holds the cents credited by currency
+	Totals map[string]int64
+	// Charged holds the cents of overdraft interest debited by currency
+	Charged map[string]int64
+}
+
+// PostMonth credits the interest accrued during a month to every account that accrued some,
+// each from the interest expense account of the bank in its currency, and debits the overdraft interest
+// of overdrawn accounts to the overdraft interest income account.
+// A month is only posted once per account, so running it again for the same month is safe.
+func PostMonth(ctx context.Context, store Store, month time.Time) (PostingReport, error) {
+	year, m, _ := month.UTC().Date()
+	periodStart := time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+	report := PostingReport{
+		PeriodEnd: periodStart.AddDate(0, 1, -1),
+		Totals:    make(map[string]int64),
+		Charged:   make(map[string]int64),
+	}
+
+	accountIDs, err := store.ListAccruingAccounts(ctx, db.ListAccruingAccountsParams{
+		PeriodStart: periodStart.AddDate(0, 0, -1),
+		PeriodEnd:   report.PeriodEnd,
+	})
+	if err != nil {
+		return report, err
+	}
+
+	for _, accountID := range accountIDs {
+		result, err := store.PostInterestTx(ctx, db.PostInterestTxParams{
+			AccountID: accountID,
+			PeriodEnd: report.PeriodEnd,
+		})
+		if errors.Is(err, db.ErrInterestAlreadyPosted) {
+			report.Skipped++
+			continue
+		}
+		if err != nil {
+			return report, fmt.Errorf("cannot post interest of account [%d]: %w", accountID, err)
+		}
+
+		report.Posted++
+		amount := result.InterestPosting.Amount
+		if amount > 0 {
+			report.Totals[result.Account.Currency] += amount
+		} else if amount < 0 {
+			report.Charged[result.Account.Currency] -= amount
+		}
+	}
+
+	return report, nil
+}
+
+// Date returns the UTC day of a time, at midnight
+func Date(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
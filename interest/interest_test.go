@@ -0,0 +1,164 @@
+package interest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/volskyi-dmytro/st-bank/db/mock"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+func TestAccrue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	day := date(2026, time.March, 15)
+	dayEnd := date(2026, time.March, 16)
+
+	accounts := []db.ListInterestBearingAccountsRow{
+		{AccountID: 1, Currency: "USD", AnnualRateBps: 365, DayCount: string(Actual365)},
+		{AccountID: 2, Currency: "EUR", AnnualRateBps: 360, DayCount: string(Actual360)},
+	}
+	store.EXPECT().ListInterestBearingAccounts(gomock.Any(), gomock.Eq(dayEnd)).Times(1).Return(accounts, nil)
+
+	store.EXPECT().
+		GetBalanceAsOf(gomock.Any(), gomock.Eq(db.GetBalanceAsOfParams{AccountID: 1, AsOf: dayEnd})).
+		Times(1).
+		Return(int64(1_000_000), nil)
+	store.EXPECT().
+		CreateInterestAccrual(gomock.Any(), gomock.Eq(db.CreateInterestAccrualParams{
+			AccountID:     1,
+			AccrualDate:   day,
+			Balance:       1_000_000,
+			AnnualRateBps: 365,
+			DayCount:      string(Actual365),
+			Amount:        100_000_000,
+		})).
+		Times(1).
+		Return(int64(1), nil)
+
+	// the second account is overdrawn, it accrues overdraft interest instead and already did for the day
+	store.EXPECT().
+		GetBalanceAsOf(gomock.Any(), gomock.Eq(db.GetBalanceAsOfParams{AccountID: 2, AsOf: dayEnd})).
+		Times(2).
+		Return(int64(-500), nil)
+
+	overdrafts := []db.ListOverdraftAccountsRow{
+		{AccountID: 2, AnnualRateBps: 1825},
+		{AccountID: 3, AnnualRateBps: 1825},
+	}
+	store.EXPECT().ListOverdraftAccounts(gomock.Any(), gomock.Eq(dayEnd)).Times(1).Return(overdrafts, nil)
+
+	store.EXPECT().
+		CreateInterestAccrual(gomock.Any(), gomock.Eq(db.CreateInterestAccrualParams{
+			AccountID:     2,
+			AccrualDate:   day,
+			Balance:       -500,
+			AnnualRateBps: 1825,
+			DayCount:      string(Actual365),
+			Amount:        -250_000,
+		})).
+		Times(1).
+		Return(int64(0), nil)
+
+	// the third account isn't overdrawn
+	store.EXPECT().
+		GetBalanceAsOf(gomock.Any(), gomock.Eq(db.GetBalanceAsOfParams{AccountID: 3, AsOf: dayEnd})).
+		Times(1).
+		Return(int64(100), nil)
+
+	report, err := Accrue(context.Background(), store, day.Add(13*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, AccrualReport{Day: day, Accrued: 1, Skipped: 1}, report)
+}
+
+func TestAccrueUnknownDayCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	accounts := []db.ListInterestBearingAccountsRow{
+		{AccountID: 1, Currency: "USD", AnnualRateBps: 365, DayCount: "ACT/ACT"},
+	}
+	store.EXPECT().ListInterestBearingAccounts(gomock.Any(), gomock.Any()).Times(1).Return(accounts, nil)
+	store.EXPECT().CreateInterestAccrual(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := Accrue(context.Background(), store, date(2026, time.March, 15))
+	require.EqualError(t, err, `account [1]: unknown day count convention "ACT/ACT"`)
+}
+
+func TestPostMonth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	periodEnd := date(2024, time.February, 29)
+
+	store.EXPECT().
+		ListAccruingAccounts(gomock.Any(), gomock.Eq(db.ListAccruingAccountsParams{
+			PeriodStart: date(2024, time.January, 31),
+			PeriodEnd:   periodEnd,
+		})).
+		Times(1).
+		Return([]int64{1, 2, 3, 4}, nil)
+
+	store.EXPECT().
+		PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 1, PeriodEnd: periodEnd})).
+		Times(1).
+		Return(db.PostInterestTxResult{
+			InterestPosting: db.InterestPosting{AccountID: 1, PeriodEnd: periodEnd, Amount: 2900},
+			Account:         db.Account{ID: 1, Currency: "USD"},
+			JournalEntry:    &db.JournalEntry{ID: 10, Kind: db.JournalKindInterest},
+		}, nil)
+
+	// less than a cent accrued, nothing is credited
+	store.EXPECT().
+		PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 2, PeriodEnd: periodEnd})).
+		Times(1).
+		Return(db.PostInterestTxResult{
+			InterestPosting: db.InterestPosting{AccountID: 2, PeriodEnd: periodEnd},
+			Account:         db.Account{ID: 2, Currency: "EUR"},
+		}, nil)
+
+	store.EXPECT().
+		PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 3, PeriodEnd: periodEnd})).
+		Times(1).
+		Return(db.PostInterestTxResult{}, db.ErrInterestAlreadyPosted)
+
+	// overdraft interest is debited
+	store.EXPECT().
+		PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 4, PeriodEnd: periodEnd})).
+		Times(1).
+		Return(db.PostInterestTxResult{
+			InterestPosting: db.InterestPosting{AccountID: 4, PeriodEnd: periodEnd, Amount: -150},
+			Account:         db.Account{ID: 4, Currency: "EUR"},
+			JournalEntry:    &db.JournalEntry{ID: 11, Kind: db.JournalKindInterest},
+		}, nil)
+
+	report, err := PostMonth(context.Background(), store, date(2024, time.February, 10))
+	require.NoError(t, err)
+	require.Equal(t, PostingReport{
+		PeriodEnd: periodEnd,
+		Posted:    3,
+		Skipped:   1,
+		Totals:    map[string]int64{"USD": 2900},
+		Charged:   map[string]int64{"EUR": 150},
+	}, report)
+}
+
+func TestPostMonthError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListAccruingAccounts(gomock.Any(), gomock.Any()).Times(1).Return([]int64{1, 2}, nil)
+	store.EXPECT().PostInterestTx(gomock.Any(), gomock.Any()).Times(1).Return(db.PostInterestTxResult{}, sql.ErrConnDone)
+
+	_, err := PostMonth(context.Background(), store, date(2026, time.March, 1))
+	require.ErrorIs(t, err, sql.ErrConnDone)
+}
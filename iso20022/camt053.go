@@ -0,0 +1,259 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/volskyi-dmytro/st-bank/statement"
+)
+
+// Camt053Namespace is the XML namespace of camt.053.001.08 documents
+const Camt053Namespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.08"
+
+// ContentType is the MIME type of ISO 20022 documents
+const ContentType = "application/xml"
+
+// Bank transaction codes of statement entries, issued by st-bank
+const (
+	transactionCodeTransfer   = "TRANSFER"
+	transactionCodeAdjustment = "ADJUSTMENT"
+)
+
+type camt053Document struct {
+	XMLName   xml.Name         `xml:"urn:iso:std:iso:20022:tech:xsd:camt.053.001.08 Document"`
+	Statement camt053Statement `xml:"BkToCstmrStmt"`
+}
+
+type camt053Statement struct {
+	GroupHeader camt053GroupHeader    `xml:"GrpHdr"`
+	Statement   camt053AccountSummary `xml:"Stmt"`
+}
+
+type camt053GroupHeader struct {
+	MessageID string `xml:"MsgId"`
+	CreatedAt string `xml:"CreDtTm"`
+}
+
+type camt053AccountSummary struct {
+	ID        string           `xml:"Id"`
+	CreatedAt string           `xml:"CreDtTm"`
+	Period    camt053Period    `xml:"FrToDt"`
+	Account   camt053Account   `xml:"Acct"`
+	Balances  []camt053Balance `xml:"Bal"`
+	Summary   camt053Summary   `xml:"TxsSummry"`
+	Entries   []camt053Entry   `xml:"Ntry"`
+}
+
+type camt053Period struct {
+	From string `xml:"FrDtTm"`
+	To   string `xml:"ToDtTm"`
+}
+
+type camt053CashAccount struct {
+	Other string `xml:"Id>Othr>Id"`
+}
+
+type camt053Account struct {
+	Other    string `xml:"Id>Othr>Id"`
+	Currency string `xml:"Ccy"`
+	Owner    string `xml:"Ownr>Nm"`
+}
+
+type camt053Amount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+type camt053Balance struct {
+	Type                 string        `xml:"Tp>CdOrPrtry>Cd"`
+	Amount               camt053Amount `xml:"Amt"`
+	CreditDebitIndicator string        `xml:"CdtDbtInd"`
+	Date                 string        `xml:"Dt>Dt"`
+}
+
+type camt053Summary struct {
+	Credits camt053Totals `xml:"TtlCdtNtries"`
+	Debits  camt053Totals `xml:"TtlDbtNtries"`
+}
+
+type camt053Totals struct {
+	Count string `xml:"NbOfNtries"`
+	Sum   string `xml:"Sum"`
+}
+
+type camt053Entry struct {
+	Reference            string                 `xml:"NtryRef"`
+	Amount               camt053Amount          `xml:"Amt"`
+	CreditDebitIndicator string                 `xml:"CdtDbtInd"`
+	Status               string                 `xml:"Sts>Cd"`
+	BookingDate          string                 `xml:"BookgDt>DtTm"`
+	ValueDate            string                 `xml:"ValDt>DtTm"`
+	ServicerReference    string                 `xml:"AcctSvcrRef"`
+	TransactionCode      camt053TransactionCode `xml:"BkTxCd>Prtry"`
+	Details              *camt053Details        `xml:"NtryDtls>TxDtls"`
+	AdditionalInfo       string                 `xml:"AddtlNtryInf,omitempty"`
+}
+
+type camt053TransactionCode struct {
+	Code   string `xml:"Cd"`
+	Issuer string `xml:"Issr"`
+}
+
+type camt053Details struct {
+	References camt053References  `xml:"Refs"`
+	Parties    camt053Parties     `xml:"RltdPties"`
+	Remittance *camt053Remittance `xml:"RmtInf"`
+}
+
+type camt053Remittance struct {
+	Unstructured string `xml:"Ustrd"`
+}
+
+type camt053References struct {
+	EndToEndID    string `xml:"EndToEndId,omitempty"`
+	TransactionID string `xml:"TxId"`
+}
+
+type camt053Parties struct {
+	Debtor          *camt053Party       `xml:"Dbtr"`
+	DebtorAccount   *camt053CashAccount `xml:"DbtrAcct"`
+	Creditor        *camt053Party       `xml:"Cdtr"`
+	CreditorAccount *camt053CashAccount `xml:"CdtrAcct"`
+}
+
+type camt053Party struct {
+	Name string `xml:"Pty>Nm"`
+}
+
+// WriteCamt053 writes the statement as a camt.053.001.08 bank to customer statement.
+// Every entry is booked, and the counterparty of transfers is given as the related party.
+func WriteCamt053(w io.Writer, s statement.Statement) error {
+	accountID := strconv.FormatInt(s.AccountID, 10)
+
+	summary := camt053AccountSummary{
+		ID:        fmt.Sprintf("%d-%s-%s", s.AccountID, s.From.Format("20060102"), s.LastDay().Format("20060102")),
+		CreatedAt: isoDateTime(s.GeneratedAt),
+		Period: camt053Period{
+			From: isoDateTime(s.From),
+			To:   isoDateTime(s.LastDay()),
+		},
+		Account: camt053Account{
+			Other:    accountID,
+			Currency: s.Currency,
+			Owner:    s.Owner,
+		},
+		Balances: []camt053Balance{
+			newCamt053Balance("OPBD", s.OpeningBalance, s.Currency, s.From),
+			newCamt053Balance("CLBD", s.ClosingBalance, s.Currency, s.LastDay()),
+		},
+		Entries: make([]camt053Entry, 0, len(s.Lines)),
+	}
+
+	var credits, debits, creditSum, debitSum int64
+	for _, line := range s.Lines {
+		amount, indicator := creditDebit(line.Amount)
+		if line.Amount < 0 {
+			debits++
+			debitSum += amount
+		} else {
+			credits++
+			creditSum += amount
+		}
+
+		entry := camt053Entry{
+			Reference:            strconv.FormatInt(line.EntryID, 10),
+			Amount:               camt053Amount{Value: formatAmount(amount), Currency: s.Currency},
+			CreditDebitIndicator: indicator,
+			Status:               "BOOK",
+			BookingDate:          isoDateTime(line.PostedAt),
+			ValueDate:            isoDateTime(line.PostedAt),
+			ServicerReference:    strconv.FormatInt(line.EntryID, 10),
+			TransactionCode: camt053TransactionCode{
+				Code:   transactionCodeTransfer,
+				Issuer: statement.BankID,
+			},
+		}
+
+		if line.TransferID == 0 {
+			entry.TransactionCode.Code = transactionCodeAdjustment
+			entry.AdditionalInfo = line.Counterparty()
+		} else {
+			details := &camt053Details{
+				References: camt053References{
+					EndToEndID:    line.Reference,
+					TransactionID: strconv.FormatInt(line.TransferID, 10),
+				},
+			}
+			if line.Description != "" {
+				details.Remittance = &camt053Remittance{Unstructured: line.Description}
+			}
+
+			party := &camt053Party{Name: line.CounterpartyOwner}
+			account := &camt053CashAccount{Other: strconv.FormatInt(line.CounterpartyAccountID, 10)}
+			if line.Amount < 0 {
+				details.Parties.Creditor = party
+				details.Parties.CreditorAccount = account
+			} else {
+				details.Parties.Debtor = party
+				details.Parties.DebtorAccount = account
+			}
+			entry.Details = details
+		}
+
+		summary.Entries = append(summary.Entries, entry)
+	}
+
+	summary.Summary = camt053Summary{
+		Credits: camt053Totals{Count: strconv.FormatInt(credits, 10), Sum: formatAmount(creditSum)},
+		Debits:  camt053Totals{Count: strconv.FormatInt(debits, 10), Sum: formatAmount(debitSum)},
+	}
+
+	document := camt053Document{
+		Statement: camt053Statement{
+			GroupHeader: camt053GroupHeader{
+				MessageID: fmt.Sprintf("%d-%s", s.AccountID, s.GeneratedAt.UTC().Format("20060102150405")),
+				CreatedAt: isoDateTime(s.GeneratedAt),
+			},
+			Statement: summary,
+		},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func newCamt053Balance(balanceType string, balance int64, currency string, date time.Time) camt053Balance {
+	amount, indicator := creditDebit(balance)
+	return camt053Balance{
+		Type:                 balanceType,
+		Amount:               camt053Amount{Value: formatAmount(amount), Currency: currency},
+		CreditDebitIndicator: indicator,
+		Date:                 date.UTC().Format("2006-01-02"),
+	}
+}
+
+// creditDebit splits a signed amount into its absolute value and ISO 20022 credit/debit code
+func creditDebit(amount int64) (int64, string) {
+	if amount < 0 {
+		return -amount, "DBIT"
+	}
+	return amount, "CRDT"
+}
+
+// isoDateTime formats a time as an ISO date time in UTC
+func isoDateTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
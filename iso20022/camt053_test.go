@@ -0,0 +1,129 @@
+package iso20022
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+	"github.com/volskyi-dmytro/st-bank/statement"
+)
+
+func testStatement() statement.Statement {
+	from := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+	account := db.Account{
+		ID:       42,
+		Owner:    "alice",
+		Currency: "USD",
+	}
+
+	entries := []db.ListStatementEntriesRow{
+		{
+			ID:                    1,
+			Amount:                2500,
+			CreatedAt:             from.Add(24 * time.Hour),
+			TransferID:            sql.NullInt64{Int64: 7, Valid: true},
+			CounterpartyAccountID: 12,
+			CounterpartyOwner:     "bob",
+			Description:           "rent share (June)",
+			Reference:             "INV-1",
+		},
+		{
+			ID:                    2,
+			Amount:                -1005,
+			CreatedAt:             from.Add(48 * time.Hour),
+			TransferID:            sql.NullInt64{Int64: 8, Valid: true},
+			CounterpartyAccountID: 13,
+			CounterpartyOwner:     "carol",
+		},
+		{
+			ID:        3,
+			Amount:    5,
+			CreatedAt: from.Add(72 * time.Hour),
+		},
+	}
+
+	return statement.New(account, from, from.AddDate(0, 1, 0), 10000, entries)
+}
+
+func TestWriteCamt053(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteCamt053(&buf, testStatement())
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(buf.String(), xml.Header))
+
+	err = Camt053Schema.Validate(buf.Bytes())
+	require.NoError(t, err)
+
+	var document camt053Document
+	err = xml.Unmarshal(buf.Bytes(), &document)
+	require.NoError(t, err)
+	require.Equal(t, Camt053Namespace, document.XMLName.Space)
+
+	stmt := document.Statement.Statement
+	require.Equal(t, "42-20260601-20260630", stmt.ID)
+	require.Equal(t, "2026-06-01T00:00:00Z", stmt.Period.From)
+	require.Equal(t, "2026-06-30T23:59:59Z", stmt.Period.To)
+	require.Equal(t, camt053Account{Other: "42", Currency: "USD", Owner: "alice"}, stmt.Account)
+
+	require.Len(t, stmt.Balances, 2)
+	require.Equal(t, camt053Balance{
+		Type:                 "OPBD",
+		Amount:               camt053Amount{Value: "100.00", Currency: "USD"},
+		CreditDebitIndicator: "CRDT",
+		Date:                 "2026-06-01",
+	}, stmt.Balances[0])
+	require.Equal(t, "CLBD", stmt.Balances[1].Type)
+	require.Equal(t, "115.00", stmt.Balances[1].Amount.Value)
+	require.Equal(t, "2026-06-30", stmt.Balances[1].Date)
+
+	require.Equal(t, camt053Totals{Count: "2", Sum: "25.05"}, stmt.Summary.Credits)
+	require.Equal(t, camt053Totals{Count: "1", Sum: "10.05"}, stmt.Summary.Debits)
+
+	require.Len(t, stmt.Entries, 3)
+
+	credit := stmt.Entries[0]
+	require.Equal(t, "25.00", credit.Amount.Value)
+	require.Equal(t, "CRDT", credit.CreditDebitIndicator)
+	require.Equal(t, "BOOK", credit.Status)
+	require.Equal(t, "2026-06-02T00:00:00Z", credit.BookingDate)
+	require.Equal(t, camt053TransactionCode{Code: "TRANSFER", Issuer: statement.BankID}, credit.TransactionCode)
+	require.Equal(t, "INV-1", credit.Details.References.EndToEndID)
+	require.Equal(t, "7", credit.Details.References.TransactionID)
+	require.Equal(t, "bob", credit.Details.Parties.Debtor.Name)
+	require.Equal(t, "12", credit.Details.Parties.DebtorAccount.Other)
+	require.Nil(t, credit.Details.Parties.Creditor)
+	require.Nil(t, credit.Details.Parties.CreditorAccount)
+	require.Equal(t, "rent share (June)", credit.Details.Remittance.Unstructured)
+
+	debit := stmt.Entries[1]
+	require.Equal(t, "10.05", debit.Amount.Value)
+	require.Equal(t, "DBIT", debit.CreditDebitIndicator)
+	require.Equal(t, "carol", debit.Details.Parties.Creditor.Name)
+	require.Equal(t, "13", debit.Details.Parties.CreditorAccount.Other)
+	require.Nil(t, debit.Details.Parties.Debtor)
+	require.Nil(t, debit.Details.Remittance)
+	require.Empty(t, debit.Details.References.EndToEndID)
+	require.NotContains(t, buf.String(), "<Pty></Pty>")
+	require.NotContains(t, buf.String(), "<RmtInf></RmtInf>")
+
+	adjustment := stmt.Entries[2]
+	require.Equal(t, "ADJUSTMENT", adjustment.TransactionCode.Code)
+	require.Nil(t, adjustment.Details)
+	require.Equal(t, "ledger adjustment", adjustment.AdditionalInfo)
+}
+
+func TestWriteCamt053NegativeBalance(t *testing.T) {
+	s := testStatement()
+	s.OpeningBalance = -250
+
+	var buf bytes.Buffer
+	err := WriteCamt053(&buf, s)
+	require.NoError(t, err)
+	require.NoError(t, Camt053Schema.Validate(buf.Bytes()))
+	require.Contains(t, buf.String(), "<Amt Ccy=\"USD\">2.50</Amt>\n        <CdtDbtInd>DBIT</CdtDbtInd>")
+}
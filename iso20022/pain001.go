@@ -0,0 +1,303 @@
+package iso20022
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+// Pain001Namespace is the XML namespace of pain.001.001.09 documents
+const Pain001Namespace = "urn:iso:std:iso:20022:tech:xsd:pain.001.001.09"
+
+const (
+	// notProvided is the conventional end-to-end identification of payments without a reference
+	notProvided = "NOTPROVIDED"
+	// maxDescriptionLength is the longest transfer description, matching the transfer API
+	maxDescriptionLength = 140
+)
+
+// CreditTransfer is a single credit transfer of a payment initiation
+type CreditTransfer struct {
+	PaymentInformationID   string
+	RequestedExecutionDate time.Time
+	// EndToEndID is the reference of the transfer, empty when the initiating party didn't provide one
+	EndToEndID    string
+	FromAccountID int64
+	ToAccountID   int64
+	// Amount is in cents of Currency
+	Amount      int64
+	Currency    string
+	Description string
+}
+
+// PaymentInitiation is a parsed pain.001 customer credit transfer initiation
+type PaymentInitiation struct {
+	MessageID       string
+	CreatedAt       time.Time
+	InitiatingParty string
+	Transfers       []CreditTransfer
+}
+
+type pain001Document struct {
+	XMLName    xml.Name `xml:"urn:iso:std:iso:20022:tech:xsd:pain.001.001.09 Document"`
+	Initiation struct {
+		GroupHeader struct {
+			MessageID       string `xml:"MsgId"`
+			CreatedAt       string `xml:"CreDtTm"`
+			NumberOfTxs     string `xml:"NbOfTxs"`
+			ControlSum      string `xml:"CtrlSum"`
+			InitiatingParty string `xml:"InitgPty>Nm"`
+		} `xml:"GrpHdr"`
+		PaymentInformation []pain001PaymentInformation `xml:"PmtInf"`
+	} `xml:"CstmrCdtTrfInitn"`
+}
+
+type pain001PaymentInformation struct {
+	ID                     string         `xml:"PmtInfId"`
+	Method                 string         `xml:"PmtMtd"`
+	NumberOfTxs            string         `xml:"NbOfTxs"`
+	ControlSum             string         `xml:"CtrlSum"`
+	RequestedExecutionDate pain001Date    `xml:"ReqdExctnDt"`
+	DebtorAccount          pain001Account `xml:"DbtrAcct"`
+	Transactions           []struct {
+		EndToEndID string `xml:"PmtId>EndToEndId"`
+		Amount     struct {
+			Value    string `xml:",chardata"`
+			Currency string `xml:"Ccy,attr"`
+		} `xml:"Amt>InstdAmt"`
+		CreditorAccount *pain001Account `xml:"CdtrAcct"`
+		Remittance      []string        `xml:"RmtInf>Ustrd"`
+	} `xml:"CdtTrfTxInf"`
+}
+
+type pain001Date struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+type pain001Account struct {
+	IBAN  string `xml:"Id>IBAN"`
+	Other string `xml:"Id>Othr>Id"`
+}
+
+// ParsePain001 validates a pain.001.001.09 document against the bundled schema and reads its credit transfers.
+// Accounts are identified by their st-bank account number in Othr/Id, IBANs aren't supported.
+// The number of transactions and control sums of the document must match its transfers.
+func ParsePain001(data []byte) (PaymentInitiation, error) {
+	var initiation PaymentInitiation
+
+	if err := Pain001Schema.Validate(data); err != nil {
+		return initiation, err
+	}
+
+	var document pain001Document
+	if err := xml.Unmarshal(data, &document); err != nil {
+		return initiation, err
+	}
+
+	header := document.Initiation.GroupHeader
+	initiation.MessageID = header.MessageID
+	initiation.InitiatingParty = header.InitiatingParty
+
+	var err error
+	initiation.CreatedAt, err = parseDateTime(header.CreatedAt)
+	if err != nil {
+		return initiation, err
+	}
+
+	var total int64
+	references := make(map[string]bool)
+	for _, payment := range document.Initiation.PaymentInformation {
+		transfers, err := parsePaymentInformation(payment)
+		if err != nil {
+			return initiation, fmt.Errorf("payment information %s: %w", payment.ID, err)
+		}
+
+		for _, transfer := range transfers {
+			if transfer.EndToEndID != "" {
+				key := fmt.Sprintf("%d/%s", transfer.FromAccountID, transfer.EndToEndID)
+				if references[key] {
+					return initiation, fmt.Errorf("end-to-end id %s is used twice for account %d", transfer.EndToEndID, transfer.FromAccountID)
+				}
+				references[key] = true
+			}
+			total += transfer.Amount
+		}
+		initiation.Transfers = append(initiation.Transfers, transfers...)
+	}
+
+	if err := checkControls(header.NumberOfTxs, header.ControlSum, len(initiation.Transfers), total); err != nil {
+		return initiation, fmt.Errorf("group header: %w", err)
+	}
+
+	return initiation, nil
+}
+
+func parsePaymentInformation(payment pain001PaymentInformation) ([]CreditTransfer, error) {
+	if payment.Method != "TRF" {
+		return nil, fmt.Errorf("payment method %s is not supported, only credit transfers (TRF) are", payment.Method)
+	}
+
+	executionDate, err := parseRequestedExecutionDate(payment.RequestedExecutionDate)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAccountID, err := parseAccount(payment.DebtorAccount)
+	if err != nil {
+		return nil, fmt.Errorf("debtor account: %w", err)
+	}
+
+	var total int64
+	transfers := make([]CreditTransfer, 0, len(payment.Transactions))
+	for _, transaction := range payment.Transactions {
+		if transaction.CreditorAccount == nil {
+			return nil, fmt.Errorf("transaction %s: creditor account is required", transaction.EndToEndID)
+		}
+
+		toAccountID, err := parseAccount(*transaction.CreditorAccount)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: creditor account: %w", transaction.EndToEndID, err)
+		}
+
+		amount, err := parseAmount(transaction.Amount.Value)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: %w", transaction.EndToEndID, err)
+		}
+		if amount == 0 {
+			return nil, fmt.Errorf("transaction %s: amount must be positive", transaction.EndToEndID)
+		}
+
+		description := strings.Join(transaction.Remittance, " ")
+		if utf8.RuneCountInString(description) > maxDescriptionLength {
+			return nil, fmt.Errorf("transaction %s: remittance information is longer than %d characters",
+				transaction.EndToEndID, maxDescriptionLength)
+		}
+
+		transfer := CreditTransfer{
+			PaymentInformationID:   payment.ID,
+			RequestedExecutionDate: executionDate,
+			EndToEndID:             transaction.EndToEndID,
+			FromAccountID:          fromAccountID,
+			ToAccountID:            toAccountID,
+			Amount:                 amount,
+			Currency:               transaction.Amount.Currency,
+			Description:            description,
+		}
+		if transfer.EndToEndID == notProvided {
+			transfer.EndToEndID = ""
+		}
+
+		total += amount
+		transfers = append(transfers, transfer)
+	}
+
+	if payment.NumberOfTxs == "" && payment.ControlSum == "" {
+		return transfers, nil
+	}
+	return transfers, checkControls(payment.NumberOfTxs, payment.ControlSum, len(transfers), total)
+}
+
+// checkControls compares the optional number of transactions and control sum of a group with its transfers
+func checkControls(numberOfTxs, controlSum string, count int, total int64) error {
+	if numberOfTxs != "" {
+		n, err := strconv.Atoi(numberOfTxs)
+		if err != nil || n != count {
+			return fmt.Errorf("number of transactions is %s but there are %d", numberOfTxs, count)
+		}
+	}
+
+	if controlSum != "" {
+		sum, err := parseAmount(controlSum)
+		if err != nil {
+			return fmt.Errorf("control sum: %w", err)
+		}
+		if sum != total {
+			return fmt.Errorf("control sum is %s but the transfers add up to %s", controlSum, formatAmount(total))
+		}
+	}
+
+	return nil
+}
+
+func parseAccount(account pain001Account) (int64, error) {
+	if account.IBAN != "" {
+		return 0, errors.New("IBAN accounts are not supported, use Othr/Id with the account number")
+	}
+
+	id, err := strconv.ParseInt(account.Other, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%q is not an account number", account.Other)
+	}
+	return id, nil
+}
+
+func parseRequestedExecutionDate(date pain001Date) (time.Time, error) {
+	if date.Date != "" {
+		return time.Parse("2006-01-02", strings.TrimSpace(date.Date))
+	}
+	return parseDateTime(date.DateTime)
+}
+
+// parseDateTime parses an ISO date time, which is in UTC when it has no time zone
+func parseDateTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Parse("2006-01-02T15:04:05.999999999", value)
+	}
+	return t, nil
+}
+
+// parseAmount converts a decimal amount to cents, e.g. 12.3 to 1230
+func parseAmount(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	integer, fraction, _ := strings.Cut(value, ".")
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > 2 {
+		return 0, fmt.Errorf("amount %s has more than 2 decimals", value)
+	}
+
+	cents, err := strconv.ParseInt(integer+(fraction + "00")[:2], 10, 64)
+	if err != nil || cents < 0 {
+		return 0, fmt.Errorf("amount %s is out of range", value)
+	}
+	return cents, nil
+}
+
+// formatAmount formats an amount in cents as a decimal number with two decimals
+func formatAmount(amount int64) string {
+	return fmt.Sprintf("%d.%02d", amount/100, amount%100)
+}
+
+// Batch returns the parameters of the transfers of the payment initiation.
+// The message and payment information ids are kept in the metadata of every transfer.
+func (initiation PaymentInitiation) Batch() ([]db.TransferTxParams, error) {
+	batch := make([]db.TransferTxParams, 0, len(initiation.Transfers))
+	for _, transfer := range initiation.Transfers {
+		metadata, err := json.Marshal(map[string]string{
+			"pain001_msg_id":     initiation.MessageID,
+			"pain001_pmt_inf_id": transfer.PaymentInformationID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		batch = append(batch, db.TransferTxParams{
+			FromAccountID: transfer.FromAccountID,
+			ToAccountID:   transfer.ToAccountID,
+			Amount:        transfer.Amount,
+			Description:   transfer.Description,
+			Reference:     transfer.EndToEndID,
+			Metadata:      metadata,
+		})
+	}
+	return batch, nil
+}
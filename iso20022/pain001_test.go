@@ -0,0 +1,189 @@
+package iso20022
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+func TestParsePain001(t *testing.T) {
+	initiation, err := ParsePain001([]byte(readTestPain001(t)))
+	require.NoError(t, err)
+
+	require.Equal(t, "PAYROLL-2026-06", initiation.MessageID)
+	require.Equal(t, "alice", initiation.InitiatingParty)
+	require.Equal(t, time.Date(2026, time.June, 30, 9, 15, 0, 0, time.UTC), initiation.CreatedAt)
+
+	require.Equal(t, []CreditTransfer{
+		{
+			PaymentInformationID:   "SALARIES",
+			RequestedExecutionDate: time.Date(2026, time.June, 30, 0, 0, 0, 0, time.UTC),
+			EndToEndID:             "SAL-0001",
+			FromAccountID:          42,
+			ToAccountID:            12,
+			Amount:                 2500,
+			Currency:               "USD",
+			Description:            "June salary",
+		},
+		{
+			PaymentInformationID:   "SALARIES",
+			RequestedExecutionDate: time.Date(2026, time.June, 30, 0, 0, 0, 0, time.UTC),
+			FromAccountID:          42,
+			ToAccountID:            13,
+			Amount:                 1530,
+			Currency:               "USD",
+		},
+		{
+			PaymentInformationID:   "EXPENSES",
+			RequestedExecutionDate: time.Date(2026, time.June, 30, 12, 0, 0, 0, time.UTC),
+			EndToEndID:             "EXP-0001",
+			FromAccountID:          43,
+			ToAccountID:            12,
+			Amount:                 500,
+			Currency:               "USD",
+			Description:            "taxi to the airport",
+		},
+	}, initiation.Transfers)
+}
+
+func TestParsePain001Errors(t *testing.T) {
+	document := readTestPain001(t)
+
+	testCases := []struct {
+		name string
+		old  string
+		new  string
+		err  string
+	}{
+		{
+			name: "InvalidSchema",
+			old:  "<PmtMtd>TRF</PmtMtd>",
+			new:  "<PmtMtd>DD</PmtMtd>",
+			err:  "/Document/CstmrCdtTrfInitn/PmtInf[1]/PmtMtd",
+		},
+		{
+			name: "Cheque",
+			old:  "<PmtMtd>TRF</PmtMtd>",
+			new:  "<PmtMtd>CHK</PmtMtd>",
+			err:  "payment information SALARIES: payment method CHK is not supported, only credit transfers (TRF) are",
+		},
+		{
+			name: "IBAN",
+			old:  "<Othr>\n            <Id>42</Id>\n          </Othr>",
+			new:  "<IBAN>DE89370400440532013000</IBAN>",
+			err:  "payment information SALARIES: debtor account: IBAN accounts are not supported, use Othr/Id with the account number",
+		},
+		{
+			name: "InvalidAccountNumber",
+			old:  "<Id>42</Id>",
+			new:  "<Id>ACC-42</Id>",
+			err:  `payment information SALARIES: debtor account: "ACC-42" is not an account number`,
+		},
+		{
+			name: "MissingCreditorAccount",
+			old:  "<CdtrAcct>\n          <Id>\n            <Othr>\n              <Id>13</Id>\n            </Othr>\n          </Id>\n        </CdtrAcct>",
+			err:  "payment information SALARIES: transaction NOTPROVIDED: creditor account is required",
+		},
+		{
+			name: "TooManyDecimals",
+			old:  ">25.00<",
+			new:  ">25.001<",
+			err:  "payment information SALARIES: transaction SAL-0001: amount 25.001 has more than 2 decimals",
+		},
+		{
+			name: "ZeroAmount",
+			old:  ">25.00<",
+			new:  ">0.00<",
+			err:  "payment information SALARIES: transaction SAL-0001: amount must be positive",
+		},
+		{
+			name: "PaymentControlSum",
+			old:  "<CtrlSum>40.3</CtrlSum>",
+			new:  "<CtrlSum>40.4</CtrlSum>",
+			err:  "payment information SALARIES: control sum is 40.4 but the transfers add up to 40.30",
+		},
+		{
+			name: "GroupNumberOfTxs",
+			old:  "<NbOfTxs>3</NbOfTxs>",
+			new:  "<NbOfTxs>4</NbOfTxs>",
+			err:  "group header: number of transactions is 4 but there are 3",
+		},
+		{
+			name: "DuplicateEndToEndID",
+			old:  "<EndToEndId>NOTPROVIDED</EndToEndId>",
+			new:  "<EndToEndId>SAL-0001</EndToEndId>",
+			err:  "end-to-end id SAL-0001 is used twice for account 42",
+		},
+		{
+			name: "RemittanceTooLong",
+			old:  "<Ustrd>to the airport</Ustrd>",
+			new:  "<Ustrd>" + strings.Repeat("x", 140) + "</Ustrd>",
+			err:  "payment information EXPENSES: transaction EXP-0001: remittance information is longer than 140 characters",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Contains(t, document, tc.old)
+			data := strings.Replace(document, tc.old, tc.new, 1)
+
+			_, err := ParsePain001([]byte(data))
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestPaymentInitiationBatch(t *testing.T) {
+	initiation, err := ParsePain001([]byte(readTestPain001(t)))
+	require.NoError(t, err)
+
+	batch, err := initiation.Batch()
+	require.NoError(t, err)
+	require.Len(t, batch, 3)
+
+	require.Equal(t, db.TransferTxParams{
+		FromAccountID: 42,
+		ToAccountID:   12,
+		Amount:        2500,
+		Description:   "June salary",
+		Reference:     "SAL-0001",
+		Metadata:      batch[0].Metadata,
+	}, batch[0])
+	require.JSONEq(t, `{"pain001_msg_id": "PAYROLL-2026-06", "pain001_pmt_inf_id": "SALARIES"}`, string(batch[0].Metadata))
+
+	require.Empty(t, batch[1].Reference)
+	require.JSONEq(t, `{"pain001_msg_id": "PAYROLL-2026-06", "pain001_pmt_inf_id": "EXPENSES"}`, string(batch[2].Metadata))
+}
+
+func TestParseAmount(t *testing.T) {
+	testCases := []struct {
+		value  string
+		amount int64
+		err    bool
+	}{
+		{value: "12.34", amount: 1234},
+		{value: "12.3", amount: 1230},
+		{value: "12", amount: 1200},
+		{value: "12.340000", amount: 1234},
+		{value: ".5", amount: 50},
+		{value: "0.01", amount: 1},
+		{value: "12.345", err: true},
+		{value: "-1", err: true},
+		{value: "99999999999999999999", err: true},
+	}
+
+	for _, tc := range testCases {
+		amount, err := parseAmount(tc.value)
+		if tc.err {
+			require.Error(t, err, tc.value)
+			continue
+		}
+		require.NoError(t, err, tc.value)
+		require.Equal(t, tc.amount, amount, tc.value)
+	}
+}
@@ -0,0 +1,660 @@
+// Package iso20022 reads and writes the ISO 20022 messages supported by st-bank:
+// camt.053 bank to customer statements and pain.001 customer credit transfer initiations.
+//
+// Messages are checked against the XML schemas bundled in the schema directory.
+// Those are subsets of the official schemas: they keep the names, order, occurrences and facets
+// of the elements st-bank uses and leave out the rest, so documents using them are rejected.
+package iso20022
+
+import (
+	"bytes"
+	"embed"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+//go:embed schema/*.xsd
+var schemaFiles embed.FS
+
+var (
+	// Pain001Schema is the bundled pain.001.001.09 schema
+	Pain001Schema = mustLoadSchema("schema/pain.001.001.09.xsd")
+	// Camt053Schema is the bundled camt.053.001.08 schema
+	Camt053Schema = mustLoadSchema("schema/camt.053.001.08.xsd")
+)
+
+// unbounded is the maximum occurrence of an element without an upper limit
+const unbounded = -1
+
+// Schema is a parsed XML schema.
+// Only the constructs used by the bundled schemas are supported: named complex types with a sequence,
+// a choice or simple content with attributes, and named simple types restricting a built-in type.
+type Schema struct {
+	namespace    string
+	elements     map[string]string
+	complexTypes map[string]complexType
+	simpleTypes  map[string]simpleType
+}
+
+type particle struct {
+	name      string
+	typeName  string
+	minOccurs int
+	maxOccurs int
+}
+
+type attribute struct {
+	name     string
+	typeName string
+	required bool
+}
+
+type complexType struct {
+	choice   bool
+	elements []particle
+	// simpleContent is the type of the text of elements with simple content, empty for element-only content
+	simpleContent string
+	attributes    []attribute
+}
+
+type simpleType struct {
+	base           string
+	patterns       []*regexp.Regexp
+	enumeration    []string
+	minLength      *int
+	maxLength      *int
+	totalDigits    *int
+	fractionDigits *int
+	minInclusive   *big.Rat
+}
+
+type xsdSchema struct {
+	TargetNamespace string           `xml:"targetNamespace,attr"`
+	Elements        []xsdElement     `xml:"element"`
+	ComplexTypes    []xsdComplexType `xml:"complexType"`
+	SimpleTypes     []xsdSimpleType  `xml:"simpleType"`
+}
+
+type xsdElement struct {
+	Name      string `xml:"name,attr"`
+	Type      string `xml:"type,attr"`
+	MinOccurs string `xml:"minOccurs,attr"`
+	MaxOccurs string `xml:"maxOccurs,attr"`
+}
+
+type xsdGroup struct {
+	Elements []xsdElement `xml:"element"`
+}
+
+type xsdAttribute struct {
+	Name string `xml:"name,attr"`
+	Type string `xml:"type,attr"`
+	Use  string `xml:"use,attr"`
+}
+
+type xsdComplexType struct {
+	Name          string    `xml:"name,attr"`
+	Sequence      *xsdGroup `xml:"sequence"`
+	Choice        *xsdGroup `xml:"choice"`
+	SimpleContent *struct {
+		Extension struct {
+			Base       string         `xml:"base,attr"`
+			Attributes []xsdAttribute `xml:"attribute"`
+		} `xml:"extension"`
+	} `xml:"simpleContent"`
+}
+
+type xsdFacet struct {
+	Value string `xml:"value,attr"`
+}
+
+type xsdSimpleType struct {
+	Name        string `xml:"name,attr"`
+	Restriction struct {
+		Base           string     `xml:"base,attr"`
+		Patterns       []xsdFacet `xml:"pattern"`
+		Enumeration    []xsdFacet `xml:"enumeration"`
+		MinLength      *xsdFacet  `xml:"minLength"`
+		MaxLength      *xsdFacet  `xml:"maxLength"`
+		TotalDigits    *xsdFacet  `xml:"totalDigits"`
+		FractionDigits *xsdFacet  `xml:"fractionDigits"`
+		MinInclusive   *xsdFacet  `xml:"minInclusive"`
+	} `xml:"restriction"`
+}
+
+func mustLoadSchema(name string) *Schema {
+	data, err := schemaFiles.ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+
+	schema, err := ParseSchema(data)
+	if err != nil {
+		panic(fmt.Sprintf("cannot parse %s: %v", name, err))
+	}
+	return schema
+}
+
+// ParseSchema parses an XML schema document
+func ParseSchema(data []byte) (*Schema, error) {
+	var document xsdSchema
+	if err := xml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	schema := &Schema{
+		namespace:    document.TargetNamespace,
+		elements:     make(map[string]string),
+		complexTypes: make(map[string]complexType),
+		simpleTypes:  make(map[string]simpleType),
+	}
+
+	for _, element := range document.Elements {
+		schema.elements[element.Name] = element.Type
+	}
+
+	for _, xsdType := range document.ComplexTypes {
+		var t complexType
+		var group *xsdGroup
+
+		switch {
+		case xsdType.Sequence != nil:
+			group = xsdType.Sequence
+		case xsdType.Choice != nil:
+			group = xsdType.Choice
+			t.choice = true
+		case xsdType.SimpleContent != nil:
+			t.simpleContent = xsdType.SimpleContent.Extension.Base
+			for _, attr := range xsdType.SimpleContent.Extension.Attributes {
+				t.attributes = append(t.attributes, attribute{
+					name:     attr.Name,
+					typeName: attr.Type,
+					required: attr.Use == "required",
+				})
+			}
+		default:
+			return nil, fmt.Errorf("complex type %s has no supported content model", xsdType.Name)
+		}
+
+		if group != nil {
+			for _, element := range group.Elements {
+				p, err := newParticle(element)
+				if err != nil {
+					return nil, fmt.Errorf("complex type %s: %w", xsdType.Name, err)
+				}
+				t.elements = append(t.elements, p)
+			}
+		}
+
+		schema.complexTypes[xsdType.Name] = t
+	}
+
+	for _, xsdType := range document.SimpleTypes {
+		t, err := newSimpleType(xsdType)
+		if err != nil {
+			return nil, fmt.Errorf("simple type %s: %w", xsdType.Name, err)
+		}
+		schema.simpleTypes[xsdType.Name] = t
+	}
+
+	return schema, schema.checkReferences()
+}
+
+func newParticle(element xsdElement) (particle, error) {
+	p := particle{
+		name:      element.Name,
+		typeName:  element.Type,
+		minOccurs: 1,
+		maxOccurs: 1,
+	}
+
+	var err error
+	if element.MinOccurs != "" {
+		if p.minOccurs, err = strconv.Atoi(element.MinOccurs); err != nil {
+			return p, fmt.Errorf("element %s: invalid minOccurs: %w", element.Name, err)
+		}
+	}
+
+	switch element.MaxOccurs {
+	case "":
+	case "unbounded":
+		p.maxOccurs = unbounded
+	default:
+		if p.maxOccurs, err = strconv.Atoi(element.MaxOccurs); err != nil {
+			return p, fmt.Errorf("element %s: invalid maxOccurs: %w", element.Name, err)
+		}
+	}
+
+	return p, nil
+}
+
+func newSimpleType(xsdType xsdSimpleType) (simpleType, error) {
+	restriction := xsdType.Restriction
+	t := simpleType{base: restriction.Base}
+
+	for _, pattern := range restriction.Patterns {
+		// XML schema patterns always match the whole value
+		re, err := regexp.Compile("^(?:" + pattern.Value + ")$")
+		if err != nil {
+			return t, err
+		}
+		t.patterns = append(t.patterns, re)
+	}
+
+	for _, value := range restriction.Enumeration {
+		t.enumeration = append(t.enumeration, value.Value)
+	}
+
+	facets := []struct {
+		facet *xsdFacet
+		value **int
+	}{
+		{restriction.MinLength, &t.minLength},
+		{restriction.MaxLength, &t.maxLength},
+		{restriction.TotalDigits, &t.totalDigits},
+		{restriction.FractionDigits, &t.fractionDigits},
+	}
+	for _, f := range facets {
+		if f.facet == nil {
+			continue
+		}
+		n, err := strconv.Atoi(f.facet.Value)
+		if err != nil {
+			return t, err
+		}
+		*f.value = &n
+	}
+
+	if restriction.MinInclusive != nil {
+		minimum, ok := new(big.Rat).SetString(restriction.MinInclusive.Value)
+		if !ok {
+			return t, fmt.Errorf("invalid minInclusive %q", restriction.MinInclusive.Value)
+		}
+		t.minInclusive = minimum
+	}
+
+	return t, nil
+}
+
+// checkReferences makes sure that every type used in the schema is defined
+func (schema *Schema) checkReferences() error {
+	for name, typeName := range schema.elements {
+		if !schema.hasType(typeName) {
+			return fmt.Errorf("element %s has undefined type %s", name, typeName)
+		}
+	}
+
+	for name, t := range schema.complexTypes {
+		for _, p := range t.elements {
+			if !schema.hasType(p.typeName) {
+				return fmt.Errorf("complex type %s: element %s has undefined type %s", name, p.name, p.typeName)
+			}
+		}
+		if t.simpleContent != "" && !schema.hasSimpleType(t.simpleContent) {
+			return fmt.Errorf("complex type %s extends undefined type %s", name, t.simpleContent)
+		}
+		for _, attr := range t.attributes {
+			if !schema.hasSimpleType(attr.typeName) {
+				return fmt.Errorf("complex type %s: attribute %s has undefined type %s", name, attr.name, attr.typeName)
+			}
+		}
+	}
+
+	for name, t := range schema.simpleTypes {
+		if !schema.hasSimpleType(t.base) {
+			return fmt.Errorf("simple type %s restricts undefined type %s", name, t.base)
+		}
+	}
+
+	return nil
+}
+
+func (schema *Schema) hasType(name string) bool {
+	_, ok := schema.complexTypes[name]
+	return ok || schema.hasSimpleType(name)
+}
+
+func (schema *Schema) hasSimpleType(name string) bool {
+	if _, ok := builtinTypes[name]; ok {
+		return true
+	}
+	_, ok := schema.simpleTypes[name]
+	return ok
+}
+
+// ValidationError describes the first part of a document that doesn't conform to the schema
+type ValidationError struct {
+	// Path locates the element, e.g. /Document/CstmrCdtTrfInitn/PmtInf[1]/PmtInfId
+	Path    string
+	Message string
+}
+
+func (err *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", err.Path, err.Message)
+}
+
+// node is an element of the document being validated
+type node struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []*node
+	text     strings.Builder
+}
+
+// Validate checks that the document conforms to the schema.
+// It returns a *ValidationError when it doesn't, or the error of the XML parser when it isn't well-formed.
+func (schema *Schema) Validate(document []byte) error {
+	root, err := parseDocument(document)
+	if err != nil {
+		return err
+	}
+
+	path := "/" + root.name.Local
+	if root.name.Space != schema.namespace {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("namespace must be %q", schema.namespace)}
+	}
+
+	typeName, ok := schema.elements[root.name.Local]
+	if !ok {
+		return &ValidationError{Path: path, Message: "unexpected root element"}
+	}
+
+	return schema.validateElement(root, typeName, path)
+}
+
+// parseDocument reads the element tree of an XML document
+func parseDocument(document []byte) (*node, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(document))
+
+	var root *node
+	var stack []*node
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			n := &node{name: token.Name, attrs: token.Attr}
+			switch {
+			case len(stack) > 0:
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			case root != nil:
+				return nil, fmt.Errorf("document has more than one root element")
+			default:
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(token)
+			}
+		case xml.Directive:
+			return nil, fmt.Errorf("xml directives are not allowed")
+		}
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("document has no root element")
+	}
+	return root, nil
+}
+
+func (schema *Schema) validateElement(n *node, typeName string, path string) error {
+	attrs := documentAttrs(n.attrs)
+
+	t, ok := schema.complexTypes[typeName]
+	if !ok {
+		if len(attrs) > 0 {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("unexpected attribute %s", attrs[0].Name.Local)}
+		}
+		if len(n.children) > 0 {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("unexpected element %s", n.children[0].name.Local)}
+		}
+		return schema.validateValue(n.text.String(), typeName, path)
+	}
+
+	if t.simpleContent != "" {
+		if len(n.children) > 0 {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("unexpected element %s", n.children[0].name.Local)}
+		}
+		if err := schema.validateAttributes(attrs, t.attributes, path); err != nil {
+			return err
+		}
+		return schema.validateValue(n.text.String(), t.simpleContent, path)
+	}
+
+	if len(attrs) > 0 {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("unexpected attribute %s", attrs[0].Name.Local)}
+	}
+	if strings.TrimSpace(n.text.String()) != "" {
+		return &ValidationError{Path: path, Message: "unexpected text"}
+	}
+
+	for _, child := range n.children {
+		if child.name.Space != schema.namespace {
+			return &ValidationError{
+				Path:    path + "/" + child.name.Local,
+				Message: fmt.Sprintf("namespace must be %q", schema.namespace),
+			}
+		}
+	}
+
+	if t.choice {
+		return schema.validateChoice(n, t, path)
+	}
+	return schema.validateSequence(n, t, path)
+}
+
+func (schema *Schema) validateSequence(n *node, t complexType, path string) error {
+	i := 0
+	for _, p := range t.elements {
+		count := 0
+		for i < len(n.children) && n.children[i].name.Local == p.name && (p.maxOccurs == unbounded || count < p.maxOccurs) {
+			count++
+			childPath := path + "/" + p.name
+			if p.maxOccurs != 1 {
+				childPath += fmt.Sprintf("[%d]", count)
+			}
+			if err := schema.validateElement(n.children[i], p.typeName, childPath); err != nil {
+				return err
+			}
+			i++
+		}
+
+		if count < p.minOccurs {
+			if i < len(n.children) {
+				return &ValidationError{
+					Path:    path,
+					Message: fmt.Sprintf("unexpected element %s, expected %s", n.children[i].name.Local, p.name),
+				}
+			}
+			return &ValidationError{Path: path, Message: fmt.Sprintf("missing element %s", p.name)}
+		}
+	}
+
+	if i < len(n.children) {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("unexpected element %s", n.children[i].name.Local)}
+	}
+	return nil
+}
+
+func (schema *Schema) validateChoice(n *node, t complexType, path string) error {
+	if len(n.children) != 1 {
+		names := make([]string, len(t.elements))
+		for i, p := range t.elements {
+			names[i] = p.name
+		}
+		return &ValidationError{Path: path, Message: fmt.Sprintf("must contain exactly one of %s", strings.Join(names, ", "))}
+	}
+
+	child := n.children[0]
+	for _, p := range t.elements {
+		if p.name == child.name.Local {
+			return schema.validateElement(child, p.typeName, path+"/"+p.name)
+		}
+	}
+	return &ValidationError{Path: path, Message: fmt.Sprintf("unexpected element %s", child.name.Local)}
+}
+
+func (schema *Schema) validateAttributes(attrs []xml.Attr, allowed []attribute, path string) error {
+	values := make(map[string]string, len(attrs))
+	for _, attr := range attrs {
+		values[attr.Name.Local] = attr.Value
+	}
+
+	for _, a := range allowed {
+		value, ok := values[a.name]
+		if !ok {
+			if a.required {
+				return &ValidationError{Path: path, Message: fmt.Sprintf("missing attribute %s", a.name)}
+			}
+			continue
+		}
+		if err := schema.validateValue(value, a.typeName, path+"/@"+a.name); err != nil {
+			return err
+		}
+		delete(values, a.name)
+	}
+
+	for name := range values {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("unexpected attribute %s", name)}
+	}
+	return nil
+}
+
+// documentAttrs leaves out the namespace declarations of an element
+func documentAttrs(attrs []xml.Attr) []xml.Attr {
+	var result []xml.Attr
+	for _, attr := range attrs {
+		if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+			continue
+		}
+		result = append(result, attr)
+	}
+	return result
+}
+
+// validateValue checks a text value against a simple type and the types it restricts
+func (schema *Schema) validateValue(value string, typeName string, path string) error {
+	if check, ok := builtinTypes[typeName]; ok {
+		if typeName != "xs:string" {
+			value = strings.TrimSpace(value)
+		}
+		if !check(value) {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("%q is not a valid %s", value, strings.TrimPrefix(typeName, "xs:"))}
+		}
+		return nil
+	}
+
+	t := schema.simpleTypes[typeName]
+	if err := schema.validateValue(value, t.base, path); err != nil {
+		return err
+	}
+	if t.base != "xs:string" {
+		value = strings.TrimSpace(value)
+	}
+
+	invalid := func(format string, args ...any) error {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("%q is not a valid %s: ", value, typeName) + fmt.Sprintf(format, args...)}
+	}
+
+	for _, pattern := range t.patterns {
+		if !pattern.MatchString(value) {
+			return invalid("must match %s", strings.TrimSuffix(strings.TrimPrefix(pattern.String(), "^(?:"), ")$"))
+		}
+	}
+
+	if len(t.enumeration) > 0 && !contains(t.enumeration, value) {
+		return invalid("must be one of %s", strings.Join(t.enumeration, ", "))
+	}
+
+	length := utf8.RuneCountInString(value)
+	if t.minLength != nil && length < *t.minLength {
+		return invalid("must be at least %d characters long", *t.minLength)
+	}
+	if t.maxLength != nil && length > *t.maxLength {
+		return invalid("must be at most %d characters long", *t.maxLength)
+	}
+
+	if t.totalDigits != nil || t.fractionDigits != nil {
+		total, fraction := decimalDigits(value)
+		if t.totalDigits != nil && total > *t.totalDigits {
+			return invalid("must have at most %d digits", *t.totalDigits)
+		}
+		if t.fractionDigits != nil && fraction > *t.fractionDigits {
+			return invalid("must have at most %d fraction digits", *t.fractionDigits)
+		}
+	}
+
+	if t.minInclusive != nil {
+		number, _ := new(big.Rat).SetString(value)
+		if number.Cmp(t.minInclusive) < 0 {
+			return invalid("must be at least %s", t.minInclusive.FloatString(0))
+		}
+	}
+
+	return nil
+}
+
+var decimalPattern = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)$`)
+
+// builtinTypes checks the lexical form of the built-in XML schema types used by the bundled schemas
+var builtinTypes = map[string]func(string) bool{
+	"xs:string": func(string) bool {
+		return true
+	},
+	"xs:decimal": func(value string) bool {
+		return decimalPattern.MatchString(value)
+	},
+	"xs:boolean": func(value string) bool {
+		return contains([]string{"true", "false", "1", "0"}, value)
+	},
+	"xs:date": func(value string) bool {
+		return parsesAs(value, "2006-01-02", "2006-01-02Z07:00")
+	},
+	"xs:dateTime": func(value string) bool {
+		return parsesAs(value, "2006-01-02T15:04:05.999999999", "2006-01-02T15:04:05.999999999Z07:00")
+	},
+}
+
+func parsesAs(value string, layouts ...string) bool {
+	for _, layout := range layouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// decimalDigits counts the significant digits of a decimal number and those after the decimal point
+func decimalDigits(value string) (total int, fraction int) {
+	value = strings.TrimLeft(value, "+-")
+	integer, frac, _ := strings.Cut(value, ".")
+	integer = strings.TrimLeft(integer, "0")
+	frac = strings.TrimRight(frac, "0")
+	return len(integer) + len(frac), len(frac)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package iso20022
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readTestPain001(t *testing.T) string {
+	data, err := os.ReadFile("testdata/pain001.xml")
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestValidate(t *testing.T) {
+	document := readTestPain001(t)
+
+	testCases := []struct {
+		name    string
+		old     string
+		new     string
+		path    string
+		message string
+	}{
+		{
+			name: "OK",
+		},
+		{
+			name:    "MissingElement",
+			old:     "<MsgId>PAYROLL-2026-06</MsgId>",
+			path:    "/Document/CstmrCdtTrfInitn/GrpHdr",
+			message: "unexpected element CreDtTm, expected MsgId",
+		},
+		{
+			name:    "UnexpectedElement",
+			old:     "<PmtMtd>TRF</PmtMtd>",
+			new:     "<PmtMtd>TRF</PmtMtd><Purp>SALA</Purp>",
+			path:    "/Document/CstmrCdtTrfInitn/PmtInf[1]",
+			message: "unexpected element Purp, expected ReqdExctnDt",
+		},
+		{
+			name:    "WrongOrder",
+			old:     "<NbOfTxs>3</NbOfTxs>\n      <CtrlSum>45.30</CtrlSum>",
+			new:     "<CtrlSum>45.30</CtrlSum>\n      <NbOfTxs>3</NbOfTxs>",
+			path:    "/Document/CstmrCdtTrfInitn/GrpHdr",
+			message: "unexpected element CtrlSum, expected NbOfTxs",
+		},
+		{
+			name:    "Enumeration",
+			old:     "<PmtMtd>TRF</PmtMtd>",
+			new:     "<PmtMtd>DD</PmtMtd>",
+			path:    "/Document/CstmrCdtTrfInitn/PmtInf[1]/PmtMtd",
+			message: "must be one of CHK, TRF, TRA",
+		},
+		{
+			name:    "Pattern",
+			old:     `Ccy="USD">25.00`,
+			new:     `Ccy="usd">25.00`,
+			path:    "/Document/CstmrCdtTrfInitn/PmtInf[1]/CdtTrfTxInf[1]/Amt/InstdAmt/@Ccy",
+			message: "must match [A-Z]{3,3}",
+		},
+		{
+			name:    "MaxLength",
+			old:     "<MsgId>PAYROLL-2026-06</MsgId>",
+			new:     "<MsgId>" + strings.Repeat("X", 36) + "</MsgId>",
+			path:    "/Document/CstmrCdtTrfInitn/GrpHdr/MsgId",
+			message: "must be at most 35 characters long",
+		},
+		{
+			name:    "FractionDigits",
+			old:     ">25.00<",
+			new:     ">25.000001<",
+			path:    "/Document/CstmrCdtTrfInitn/PmtInf[1]/CdtTrfTxInf[1]/Amt/InstdAmt",
+			message: "must have at most 5 fraction digits",
+		},
+		{
+			name:    "MinInclusive",
+			old:     ">25.00<",
+			new:     ">-25.00<",
+			path:    "/Document/CstmrCdtTrfInitn/PmtInf[1]/CdtTrfTxInf[1]/Amt/InstdAmt",
+			message: "must be at least 0",
+		},
+		{
+			name:    "InvalidDate",
+			old:     "<Dt>2026-06-30</Dt>",
+			new:     "<Dt>30.06.2026</Dt>",
+			path:    "/Document/CstmrCdtTrfInitn/PmtInf[1]/ReqdExctnDt/Dt",
+			message: `"30.06.2026" is not a valid date`,
+		},
+		{
+			name:    "MissingElementAtEnd",
+			old:     "<InitgPty>\n        <Nm>alice</Nm>\n      </InitgPty>",
+			path:    "/Document/CstmrCdtTrfInitn/GrpHdr",
+			message: "missing element InitgPty",
+		},
+		{
+			name:    "EmptyChoice",
+			old:     "<Dt>2026-06-30</Dt>",
+			path:    "/Document/CstmrCdtTrfInitn/PmtInf[1]/ReqdExctnDt",
+			message: "must contain exactly one of Dt, DtTm",
+		},
+		{
+			name:    "MissingAttribute",
+			old:     `<InstdAmt Ccy="USD">25.00`,
+			new:     `<InstdAmt>25.00`,
+			path:    "/Document/CstmrCdtTrfInitn/PmtInf[1]/CdtTrfTxInf[1]/Amt/InstdAmt",
+			message: "missing attribute Ccy",
+		},
+		{
+			name:    "UnexpectedAttribute",
+			old:     "<MsgId>",
+			new:     `<MsgId lang="en">`,
+			path:    "/Document/CstmrCdtTrfInitn/GrpHdr/MsgId",
+			message: "unexpected attribute lang",
+		},
+		{
+			name:    "WrongNamespace",
+			old:     "pain.001.001.09",
+			new:     "pain.001.001.03",
+			path:    "/Document",
+			message: `namespace must be "urn:iso:std:iso:20022:tech:xsd:pain.001.001.09"`,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			data := document
+			if tc.old != "" {
+				require.Contains(t, data, tc.old)
+				data = strings.Replace(data, tc.old, tc.new, 1)
+			}
+
+			err := Pain001Schema.Validate([]byte(data))
+			if tc.path == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Equal(t, tc.path, validationErr.Path)
+			require.Contains(t, validationErr.Message, tc.message)
+		})
+	}
+}
+
+func TestValidateMalformed(t *testing.T) {
+	err := Pain001Schema.Validate([]byte("<Document>"))
+	require.Error(t, err)
+
+	var validationErr *ValidationError
+	require.False(t, errors.As(err, &validationErr))
+
+	err = Pain001Schema.Validate([]byte(`<!DOCTYPE Document [<!ENTITY x "y">]><Document/>`))
+	require.Error(t, err)
+}
+
+func TestParseSchemaUndefinedType(t *testing.T) {
+	_, err := ParseSchema([]byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:element name="Document" type="Missing"/>
+</xs:schema>`))
+	require.EqualError(t, err, "element Document has undefined type Missing")
+}
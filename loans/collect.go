@@ -0,0 +1,67 @@
+package loans
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+// Store contains the queries and transactions used to collect loan installments
+type Store interface {
+	ListDueLoanInstallments(ctx context.Context, day time.Time) ([]db.ListDueLoanInstallmentsRow, error)
+	CollectLoanInstallmentTx(ctx context.Context, arg db.CollectLoanInstallmentTxParams) (db.CollectLoanInstallmentTxResult, error)
+}
+
+// Report sums up the collection of the installments due on a day
+type Report struct {
+	Day time.Time
+	// Paid is the number of installments paid in full
+	Paid int
+	// Arrears is the number of installments the repayment account didn't cover
+	Arrears int
+	// Skipped is the number of installments paid or repaid in the meantime
+	Skipped int
+	// Collected holds the cents collected by currency
+	Collected map[string]int64
+}
+
+// CollectDue collects every unpaid installment of an active loan that is due on or before the day,
+// including the installments left in arrears by previous collections.
+// Each installment is collected in its own database transaction, so running it again for the same day is safe.
+func CollectDue(ctx context.Context, store Store, day time.Time) (Report, error) {
+	year, month, d := day.UTC().Date()
+	report := Report{
+		Day:       time.Date(year, month, d, 0, 0, 0, 0, time.UTC),
+		Collected: make(map[string]int64),
+	}
+
+	installments, err := store.ListDueLoanInstallments(ctx, report.Day)
+	if err != nil {
+		return report, err
+	}
+
+	for _, installment := range installments {
+		result, err := store.CollectLoanInstallmentTx(ctx, db.CollectLoanInstallmentTxParams{
+			InstallmentID: installment.ID,
+		})
+		if errors.Is(err, db.ErrLoanInstallmentPaid) || errors.Is(err, db.ErrLoanNotActive) {
+			report.Skipped++
+			continue
+		}
+		if err != nil {
+			return report, fmt.Errorf("cannot collect installment [%d] of loan [%d]: %w", installment.ID, installment.LoanID, err)
+		}
+
+		if result.Installment.Status == db.LoanInstallmentStatusPaid {
+			report.Paid++
+		} else {
+			report.Arrears++
+		}
+		report.Collected[result.RepaymentAccount.Currency] += result.Collected
+	}
+
+	return report, nil
+}
@@ -0,0 +1,75 @@
+package loans
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/volskyi-dmytro/st-bank/db/mock"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+func TestCollectDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	day := date(2026, time.June, 15)
+
+	installments := []db.ListDueLoanInstallmentsRow{
+		{ID: 1, LoanID: 10},
+		{ID: 2, LoanID: 11},
+		{ID: 3, LoanID: 12},
+		{ID: 4, LoanID: 13},
+	}
+	store.EXPECT().ListDueLoanInstallments(gomock.Any(), gomock.Eq(day)).Times(1).Return(installments, nil)
+
+	expectCollect := func(installmentID int64, status string, currency string, collected int64, err error) {
+		store.EXPECT().
+			CollectLoanInstallmentTx(gomock.Any(), gomock.Eq(db.CollectLoanInstallmentTxParams{InstallmentID: installmentID})).
+			Times(1).
+			Return(db.CollectLoanInstallmentTxResult{
+				Installment:      db.LoanInstallment{ID: installmentID, Status: status},
+				RepaymentAccount: db.Account{Currency: currency},
+				Collected:        collected,
+			}, err)
+	}
+
+	expectCollect(1, db.LoanInstallmentStatusPaid, "USD", 1500, nil)
+	expectCollect(2, db.LoanInstallmentStatusArrears, "USD", 200, nil)
+	expectCollect(3, db.LoanInstallmentStatusArrears, "EUR", 0, nil)
+	expectCollect(4, "", "", 0, db.ErrLoanInstallmentPaid)
+
+	report, err := CollectDue(context.Background(), store, day.Add(13*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, Report{
+		Day:       day,
+		Paid:      1,
+		Arrears:   2,
+		Skipped:   1,
+		Collected: map[string]int64{"USD": 1700, "EUR": 0},
+	}, report)
+}
+
+func TestCollectDueError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	day := date(2026, time.June, 15)
+
+	store.EXPECT().
+		ListDueLoanInstallments(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.ListDueLoanInstallmentsRow{{ID: 1, LoanID: 10}, {ID: 2, LoanID: 11}}, nil)
+	store.EXPECT().
+		CollectLoanInstallmentTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.CollectLoanInstallmentTxResult{}, sql.ErrConnDone)
+
+	_, err := CollectDue(context.Background(), store, day)
+	require.EqualError(t, err, "cannot collect installment [1] of loan [10]: "+sql.ErrConnDone.Error())
+}
@@ -0,0 +1,129 @@
+// Package loans computes the amortization schedules of loans, and collects their installments on their due dates.
+package loans
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+// Method is the repayment method of a loan, which sets how the installments split into principal and interest
+type Method string
+
+const (
+	// Annuity loans are repaid in equal monthly installments of principal and interest
+	Annuity Method = db.LoanMethodAnnuity
+	// Linear loans repay the same principal every month, so the installments decrease with the interest
+	Linear Method = db.LoanMethodLinear
+)
+
+// ParseMethod returns the repayment method with the given name
+func ParseMethod(name string) (Method, error) {
+	switch method := Method(name); method {
+	case Annuity, Linear:
+		return method, nil
+	default:
+		return "", fmt.Errorf("unknown repayment method %q", name)
+	}
+}
+
+// Installment is a monthly installment of an amortization schedule, in cents
+type Installment struct {
+	Number    int32
+	DueDate   time.Time
+	Principal int64
+	Interest  int64
+}
+
+// Schedule returns the amortization schedule of a loan starting on the given day, with one installment a month.
+// The interest of a month is the outstanding principal times a twelfth of the annual rate, rounded to the cent.
+// The last installment repays whatever principal is left, so the principals always add up to the loan.
+func Schedule(principal int64, annualRateBps int32, termMonths int32, method Method, start time.Time) ([]Installment, error) {
+	if principal <= 0 {
+		return nil, fmt.Errorf("invalid principal %d", principal)
+	}
+	if termMonths <= 0 {
+		return nil, fmt.Errorf("invalid term of %d months", termMonths)
+	}
+	if annualRateBps < 0 {
+		return nil, fmt.Errorf("invalid annual rate of %d bps", annualRateBps)
+	}
+
+	var payment int64
+	switch method {
+	case Annuity:
+		payment = annuityPayment(principal, annualRateBps, termMonths)
+	case Linear:
+		payment = principal / int64(termMonths)
+	default:
+		return nil, fmt.Errorf("unknown repayment method %q", method)
+	}
+
+	installments := make([]Installment, 0, termMonths)
+	outstanding := principal
+	for number := int32(1); number <= termMonths; number++ {
+		interest := monthlyInterest(outstanding, annualRateBps)
+
+		repaid := payment
+		if method == Annuity {
+			repaid = max(payment-interest, 0)
+		}
+		if number == termMonths || repaid > outstanding {
+			repaid = outstanding
+		}
+		outstanding -= repaid
+
+		installments = append(installments, Installment{
+			Number:    number,
+			DueDate:   addMonths(start, int(number)),
+			Principal: repaid,
+			Interest:  interest,
+		})
+	}
+
+	return installments, nil
+}
+
+// annuityPayment returns the constant monthly payment repaying the principal with its interest over the term,
+// principal * r / (1 - (1 + r)^-n) with r the monthly rate, rounded to the cent
+func annuityPayment(principal int64, annualRateBps int32, termMonths int32) int64 {
+	if annualRateBps == 0 {
+		return (principal + int64(termMonths) - 1) / int64(termMonths)
+	}
+
+	const precision = 256
+	rate := new(big.Float).SetPrec(precision).SetInt64(int64(annualRateBps))
+	rate.Quo(rate, big.NewFloat(120000))
+
+	// (1 + r)^n
+	growth := new(big.Float).SetPrec(precision).SetInt64(1)
+	base := new(big.Float).SetPrec(precision).Add(growth, rate)
+	for range termMonths {
+		growth.Mul(growth, base)
+	}
+
+	// principal * r * (1 + r)^n / ((1 + r)^n - 1)
+	payment := new(big.Float).SetPrec(precision).SetInt64(principal)
+	payment.Mul(payment, rate)
+	payment.Mul(payment, growth)
+	payment.Quo(payment, growth.Sub(growth, big.NewFloat(1)))
+	payment.Add(payment, big.NewFloat(0.5))
+
+	cents, _ := payment.Int64()
+	return cents
+}
+
+// monthlyInterest returns a month of interest on the outstanding principal, rounded half up to the cent
+func monthlyInterest(outstanding int64, annualRateBps int32) int64 {
+	return (outstanding*int64(annualRateBps) + 60000) / 120000
+}
+
+// addMonths adds months to a day, moving to the last day of the month when the day doesn't exist in it
+func addMonths(day time.Time, months int) time.Time {
+	year, month, d := day.UTC().Date()
+	first := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(d, lastDay)-1)
+}
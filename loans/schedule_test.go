@@ -0,0 +1,118 @@
+package loans
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volskyi-dmytro/st-bank/util"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseMethod(t *testing.T) {
+	for _, name := range []string{"annuity", "linear"} {
+		method, err := ParseMethod(name)
+		require.NoError(t, err)
+		require.Equal(t, Method(name), method)
+	}
+
+	_, err := ParseMethod("balloon")
+	require.EqualError(t, err, `unknown repayment method "balloon"`)
+}
+
+func TestScheduleAnnuity(t *testing.T) {
+	// 1,200.00 at 12% a year over 12 months is repaid in installments of 106.62
+	installments, err := Schedule(120000, 1200, 12, Annuity, date(2026, time.January, 15))
+	require.NoError(t, err)
+	require.Len(t, installments, 12)
+
+	require.Equal(t, Installment{Number: 1, DueDate: date(2026, time.February, 15), Principal: 9462, Interest: 1200}, installments[0])
+	require.Equal(t, Installment{Number: 2, DueDate: date(2026, time.March, 15), Principal: 9557, Interest: 1105}, installments[1])
+
+	var principal int64
+	for i, installment := range installments {
+		require.Equal(t, int32(i+1), installment.Number)
+		principal += installment.Principal
+		if i < len(installments)-1 {
+			require.Equal(t, int64(10662), installment.Principal+installment.Interest)
+		}
+	}
+	require.Equal(t, int64(120000), principal)
+
+	last := installments[11]
+	require.Equal(t, date(2027, time.January, 15), last.DueDate)
+	require.InDelta(t, 10662, last.Principal+last.Interest, 12)
+}
+
+func TestScheduleLinear(t *testing.T) {
+	installments, err := Schedule(100000, 600, 3, Linear, date(2026, time.January, 10))
+	require.NoError(t, err)
+	require.Equal(t, []Installment{
+		{Number: 1, DueDate: date(2026, time.February, 10), Principal: 33333, Interest: 500},
+		{Number: 2, DueDate: date(2026, time.March, 10), Principal: 33333, Interest: 333},
+		{Number: 3, DueDate: date(2026, time.April, 10), Principal: 33334, Interest: 167},
+	}, installments)
+}
+
+func TestScheduleWithoutInterest(t *testing.T) {
+	for _, method := range []Method{Annuity, Linear} {
+		installments, err := Schedule(1000, 0, 3, method, date(2026, time.January, 10))
+		require.NoError(t, err)
+
+		var principal int64
+		for _, installment := range installments {
+			require.Zero(t, installment.Interest)
+			principal += installment.Principal
+		}
+		require.Equal(t, int64(1000), principal)
+	}
+}
+
+func TestScheduleRepaysPrincipal(t *testing.T) {
+	for range 20 {
+		principal := util.RandomInt(1, 10000000)
+		rate := int32(util.RandomInt(0, 3000))
+		term := int32(util.RandomInt(1, 360))
+
+		for _, method := range []Method{Annuity, Linear} {
+			installments, err := Schedule(principal, rate, term, method, date(2026, time.January, 31))
+			require.NoError(t, err)
+			require.Len(t, installments, int(term))
+
+			var repaid int64
+			for _, installment := range installments {
+				require.GreaterOrEqual(t, installment.Principal, int64(0))
+				require.GreaterOrEqual(t, installment.Interest, int64(0))
+				repaid += installment.Principal
+			}
+			require.Equal(t, principal, repaid)
+		}
+	}
+}
+
+func TestScheduleEndOfMonth(t *testing.T) {
+	installments, err := Schedule(3000, 0, 3, Linear, date(2026, time.January, 31))
+	require.NoError(t, err)
+	require.Equal(t, date(2026, time.February, 28), installments[0].DueDate)
+	require.Equal(t, date(2026, time.March, 31), installments[1].DueDate)
+	require.Equal(t, date(2026, time.April, 30), installments[2].DueDate)
+}
+
+func TestScheduleInvalid(t *testing.T) {
+	start := date(2026, time.January, 1)
+
+	_, err := Schedule(0, 500, 12, Annuity, start)
+	require.EqualError(t, err, "invalid principal 0")
+
+	_, err = Schedule(1000, 500, 0, Annuity, start)
+	require.EqualError(t, err, "invalid term of 0 months")
+
+	_, err = Schedule(1000, -1, 12, Annuity, start)
+	require.EqualError(t, err, "invalid annual rate of -1 bps")
+
+	_, err = Schedule(1000, 500, 12, Method("balloon"), start)
+	require.EqualError(t, err, `unknown repayment method "balloon"`)
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
+	"os"
 
 	_ "github.com/lib/pq"
 
@@ -23,6 +25,16 @@ func main() {
 	}
 
 	store := db.NewStore(conn)
+
+	// e.g. "main reconcile -correct -reason ..." runs a maintenance command and exits
+	if len(os.Args) > 1 {
+		err = runCommand(context.Background(), config, store, os.Args[1], os.Args[2:])
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	server, err := api.NewServer(config, store)
 	if err != nil {
 		log.Fatal("cannot create server:", err)
@@ -0,0 +1,134 @@
+package spending
+
+import (
+	"sort"
+	"time"
+
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+const monthFormat = "2006-01"
+
+// Line is the spending in a category and currency over a month, against the budget of the category
+type Line struct {
+	// CategoryID is 0, and Category empty, for transfers neither the sender nor a rule categorized
+	CategoryID int64
+	Category   string
+	Currency   string
+	// Budget is 0 when the category has no budget in the currency
+	Budget    int64
+	Spent     int64
+	Transfers int64
+}
+
+// HasBudget reports whether the line has a budget to compare the spending with
+func (line Line) HasBudget() bool {
+	return line.Budget > 0
+}
+
+// Remaining is what is left of the budget, negative when the spending went over it
+func (line Line) Remaining() int64 {
+	return line.Budget - line.Spent
+}
+
+// Month sums up the spending of a calendar month
+type Month struct {
+	// Month is the first day of the month, in UTC
+	Month time.Time
+	Lines []Line
+}
+
+// FirstOfMonth returns the start of the month of t, in UTC
+func FirstOfMonth(t time.Time) time.Time {
+	year, month, _ := t.UTC().Date()
+	return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Summarize compares the monthly spending with the budgets, for every month from the month of from
+// up to the month before to. Every budget gets a line each month, even when nothing was spent in its category.
+// Lines follow the order of the categories, with the uncategorized spending last, then the currency.
+func Summarize(from, to time.Time, categories []db.SpendingCategory, budgets []db.CategoryBudget, spending []db.ListMonthlySpendingRow) []Month {
+	order := make(map[int64]int, len(categories))
+	names := make(map[int64]string, len(categories))
+	for i, category := range categories {
+		order[category.ID] = i
+		names[category.ID] = category.Name
+	}
+
+	type lineKey struct {
+		month      string
+		categoryID int64
+		currency   string
+	}
+
+	lines := make(map[lineKey]*Line)
+	var months []Month
+	for month := FirstOfMonth(from); month.Before(to); month = month.AddDate(0, 1, 0) {
+		months = append(months, Month{Month: month})
+
+		for _, budget := range budgets {
+			key := lineKey{month.Format(monthFormat), budget.CategoryID, budget.Currency}
+			lines[key] = &Line{
+				CategoryID: budget.CategoryID,
+				Category:   names[budget.CategoryID],
+				Currency:   budget.Currency,
+				Budget:     budget.Amount,
+			}
+		}
+	}
+
+	for _, row := range spending {
+		key := lineKey{row.Month.Format(monthFormat), row.CategoryID.Int64, row.Currency}
+		line, ok := lines[key]
+		if !ok {
+			line = &Line{
+				CategoryID: row.CategoryID.Int64,
+				Category:   names[row.CategoryID.Int64],
+				Currency:   row.Currency,
+			}
+			lines[key] = line
+		}
+		line.Spent += row.Spent
+		line.Transfers += row.TransferCount
+	}
+
+	byMonth := make(map[string]int, len(months))
+	for i, month := range months {
+		byMonth[month.Month.Format(monthFormat)] = i
+	}
+
+	for key, line := range lines {
+		// the query and the months cover the same period, but a row outside of it has no month to go to
+		i, ok := byMonth[key.month]
+		if !ok {
+			continue
+		}
+		months[i].Lines = append(months[i].Lines, *line)
+	}
+
+	// the uncategorized spending, and categories missing from the list, sort after the known categories
+	rank := func(line Line) int {
+		if i, ok := order[line.CategoryID]; ok {
+			return i
+		}
+		if line.CategoryID != 0 {
+			return len(categories)
+		}
+		return len(categories) + 1
+	}
+
+	for _, month := range months {
+		sort.Slice(month.Lines, func(i, j int) bool {
+			a, b := month.Lines[i], month.Lines[j]
+			if rank(a) != rank(b) {
+				return rank(a) < rank(b)
+			}
+			if a.CategoryID != b.CategoryID {
+				return a.CategoryID < b.CategoryID
+			}
+			return a.Currency < b.Currency
+		})
+	}
+
+	return months
+}
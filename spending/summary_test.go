@@ -0,0 +1,69 @@
+package spending
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+func TestSummarize(t *testing.T) {
+	january := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	february := january.AddDate(0, 1, 0)
+	march := february.AddDate(0, 1, 0)
+
+	categories := []db.SpendingCategory{
+		{ID: 7, Name: "groceries"},
+		{ID: 3, Name: "rent"},
+	}
+	budgets := []db.CategoryBudget{
+		{CategoryID: 3, Currency: "EUR", Amount: 1000},
+		{CategoryID: 7, Currency: "EUR", Amount: 300},
+	}
+	spending := []db.ListMonthlySpendingRow{
+		{Month: january, CategoryID: sql.NullInt64{Int64: 3, Valid: true}, Currency: "EUR", TransferCount: 1, Spent: 1000},
+		{Month: january, CategoryID: sql.NullInt64{Int64: 7, Valid: true}, Currency: "EUR", TransferCount: 4, Spent: 350},
+		{Month: january, CategoryID: sql.NullInt64{Int64: 7, Valid: true}, Currency: "USD", TransferCount: 1, Spent: 20},
+		{Month: january, Currency: "EUR", TransferCount: 2, Spent: 45},
+		{Month: february, CategoryID: sql.NullInt64{Int64: 7, Valid: true}, Currency: "EUR", TransferCount: 2, Spent: 120},
+	}
+
+	months := Summarize(january.AddDate(0, 0, 14), march, categories, budgets, spending)
+	require.Len(t, months, 2)
+
+	require.Equal(t, january, months[0].Month)
+	require.Equal(t, []Line{
+		{CategoryID: 7, Category: "groceries", Currency: "EUR", Budget: 300, Spent: 350, Transfers: 4},
+		{CategoryID: 7, Category: "groceries", Currency: "USD", Spent: 20, Transfers: 1},
+		{CategoryID: 3, Category: "rent", Currency: "EUR", Budget: 1000, Spent: 1000, Transfers: 1},
+		{Currency: "EUR", Spent: 45, Transfers: 2},
+	}, months[0].Lines)
+	require.Equal(t, int64(-50), months[0].Lines[0].Remaining())
+	require.False(t, months[0].Lines[1].HasBudget())
+
+	// the rent budget shows up even though nothing was paid in February
+	require.Equal(t, february, months[1].Month)
+	require.Equal(t, []Line{
+		{CategoryID: 7, Category: "groceries", Currency: "EUR", Budget: 300, Spent: 120, Transfers: 2},
+		{CategoryID: 3, Category: "rent", Currency: "EUR", Budget: 1000},
+	}, months[1].Lines)
+	require.Equal(t, int64(1000), months[1].Lines[1].Remaining())
+}
+
+func TestSummarizeWithoutSpending(t *testing.T) {
+	from := time.Date(2026, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+	months := Summarize(from, from.AddDate(0, 1, 0), nil, nil, nil)
+	require.Len(t, months, 1)
+	require.Equal(t, from, months[0].Month)
+	require.Empty(t, months[0].Lines)
+}
+
+func TestFirstOfMonth(t *testing.T) {
+	kyiv := time.FixedZone("EET", 2*60*60)
+	// still December in UTC
+	t1 := time.Date(2026, time.January, 1, 1, 0, 0, 0, kyiv)
+	require.Equal(t, time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC), FirstOfMonth(t1))
+}
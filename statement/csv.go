@@ -0,0 +1,68 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const dateFormat = "2006-01-02"
+
+var csvHeader = []string{
+	"date",
+	"entry_id",
+	"transfer_id",
+	"counterparty_account_id",
+	"counterparty",
+	"description",
+	"reference",
+	"amount",
+	"balance",
+}
+
+// WriteCSV writes the statement as CSV, with the opening and closing balance as the first and last rows
+func WriteCSV(w io.Writer, statement Statement) error {
+	writer := csv.NewWriter(w)
+
+	records := make([][]string, 0, len(statement.Lines)+3)
+	records = append(records, csvHeader)
+	records = append(records, []string{
+		statement.From.Format(dateFormat), "", "", "", "", "Opening balance", "", "",
+		formatAmount(statement.OpeningBalance),
+	})
+
+	for _, line := range statement.Lines {
+		record := []string{
+			line.PostedAt.UTC().Format(dateFormat),
+			strconv.FormatInt(line.EntryID, 10),
+			"",
+			"",
+			csvText(line.Counterparty()),
+			csvText(line.Description),
+			csvText(line.Reference),
+			formatAmount(line.Amount),
+			formatAmount(line.Balance),
+		}
+		if line.TransferID != 0 {
+			record[2] = strconv.FormatInt(line.TransferID, 10)
+			record[3] = strconv.FormatInt(line.CounterpartyAccountID, 10)
+		}
+		records = append(records, record)
+	}
+
+	records = append(records, []string{
+		statement.LastDay().Format(dateFormat), "", "", "", "", "Closing balance", "", "",
+		formatAmount(statement.ClosingBalance),
+	})
+
+	return writer.WriteAll(records)
+}
+
+// csvText keeps spreadsheets from evaluating free text that looks like a formula
+func csvText(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
@@ -0,0 +1,29 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteCSV(&buf, testStatement())
+	require.NoError(t, err)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 6)
+
+	require.Equal(t, csvHeader, records[0])
+	require.Equal(t, []string{"2026-06-01", "", "", "", "", "Opening balance", "", "", "100.00"}, records[1])
+	require.Equal(t, []string{"2026-06-02", "1", "7", "12", "bob, account 12", "rent share (June)", "INV-1", "25.00", "125.00"}, records[2])
+	require.Equal(t, []string{"2026-06-04", "3", "", "", "ledger adjustment", "", "", "0.05", "115.00"}, records[4])
+	require.Equal(t, []string{"2026-06-30", "", "", "", "", "Closing balance", "", "", "115.00"}, records[5])
+
+	// free text is never evaluated as a formula
+	require.Equal(t, `'=HYPERLINK("x")`, records[3][5])
+	require.Equal(t, "-10.05", records[3][7])
+}
@@ -0,0 +1,230 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MT940 field rules, from the SWIFT MT940 customer statement message specification
+const (
+	// mt940MaxMessageLength is the maximum length of the text block of a SWIFT message
+	mt940MaxMessageLength = 2000
+	// mt940ReferenceLength is the length of the transaction reference in :20: and the references in :61:
+	mt940ReferenceLength = 16
+	// mt940AccountLength is the length of the account identification in :25:
+	mt940AccountLength = 35
+	// mt940AmountLength is the length of an amount, including the decimal comma
+	mt940AmountLength = 15
+	// mt940DetailsLength is the length of the supplementary details of :61:
+	mt940DetailsLength = 34
+	// mt940InfoLineLength and mt940InfoLines limit the information to account owner in :86:
+	mt940InfoLineLength = 65
+	mt940InfoLines      = 6
+	mt940DateFormat     = "060102"
+)
+
+// mt940Charset is the SWIFT x character set, the only characters allowed in MT940 fields
+const mt940Charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789/-?:().,'+ "
+
+// Transaction type identification codes of :61:
+const (
+	mt940TransferCode   = "NTRF"
+	mt940AdjustmentCode = "NMSC"
+)
+
+// WriteMT940 writes the statement as SWIFT MT940 customer statement messages, one field per line.
+// Entries are split over several messages when they don't fit in the 2000 characters of a single message:
+// the messages are numbered in :28C: and carry intermediate balances in :60M: and :62M:.
+// Text is reduced to the SWIFT character set and truncated to the length of its field.
+func WriteMT940(w io.Writer, statement Statement) error {
+	header := []string{
+		":20:" + mt940Text(fmt.Sprintf("%s%d", statement.From.Format(mt940DateFormat), statement.AccountID), mt940ReferenceLength),
+		":25:" + mt940Text(fmt.Sprintf("%s/%d", BankID, statement.AccountID), mt940AccountLength),
+	}
+
+	var messages [][]string
+	sequence := 1
+	balance := statement.OpeningBalance
+	balanceDate := statement.From
+
+	opening, err := mt940Balance(":60F:", balance, balanceDate, statement.Currency)
+	if err != nil {
+		return err
+	}
+	message := append(append([]string{}, header...), ":28C:1/"+strconv.Itoa(sequence), opening)
+
+	for _, line := range statement.Lines {
+		entry, err := mt940Entry(line)
+		if err != nil {
+			return err
+		}
+
+		// keep room for the closing balance of the message and its end
+		closing, err := mt940Balance(":62M:", balance, balanceDate, statement.Currency)
+		if err != nil {
+			return err
+		}
+		if mt940Length(message)+mt940Length(entry)+mt940Length([]string{closing, "-"}) > mt940MaxMessageLength {
+			messages = append(messages, append(message, closing))
+
+			sequence++
+			intermediate, err := mt940Balance(":60M:", balance, balanceDate, statement.Currency)
+			if err != nil {
+				return err
+			}
+			message = append(append([]string{}, header...), ":28C:1/"+strconv.Itoa(sequence), intermediate)
+		}
+
+		message = append(message, entry...)
+		balance = line.Balance
+		balanceDate = line.PostedAt
+	}
+
+	closing, err := mt940Balance(":62F:", statement.ClosingBalance, statement.LastDay(), statement.Currency)
+	if err != nil {
+		return err
+	}
+	messages = append(messages, append(message, closing))
+
+	var builder strings.Builder
+	for _, message := range messages {
+		for _, field := range message {
+			builder.WriteString(field)
+			builder.WriteString("\r\n")
+		}
+		builder.WriteString("-\r\n")
+	}
+
+	_, err = io.WriteString(w, builder.String())
+	return err
+}
+
+// mt940Entry formats the :61: statement line and :86: information of an entry
+func mt940Entry(line Line) ([]string, error) {
+	amount, err := mt940Amount(line.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	mark := "C"
+	if line.Amount < 0 {
+		mark = "D"
+	}
+
+	code := mt940TransferCode
+	if line.TransferID == 0 {
+		code = mt940AdjustmentCode
+	}
+
+	// the reference is followed by // and the bank reference, so it can't contain // or start or end with /
+	reference := mt940Text(line.Reference, mt940ReferenceLength)
+	for strings.Contains(reference, "//") {
+		reference = strings.ReplaceAll(reference, "//", "/")
+	}
+	reference = strings.Trim(reference, "/")
+	if reference == "" {
+		reference = "NONREF"
+	}
+
+	postedAt := line.PostedAt.UTC()
+	statementLine := fmt.Sprintf(":61:%s%s%s%s%s%s//%s",
+		postedAt.Format(mt940DateFormat), postedAt.Format("0102"), mark, amount, code, reference,
+		mt940Text(strconv.FormatInt(line.EntryID, 10), mt940ReferenceLength))
+
+	fields := []string{statementLine, mt940Text(line.Counterparty(), mt940DetailsLength)}
+
+	info := line.Counterparty()
+	if line.Description != "" {
+		info += " " + line.Description
+	}
+	infoLines := mt940Wrap(mt940Text(info, mt940InfoLines*mt940InfoLineLength), mt940InfoLineLength)
+	if len(infoLines) > mt940InfoLines {
+		infoLines = infoLines[:mt940InfoLines]
+	}
+	infoLines[0] = ":86:" + infoLines[0]
+
+	return append(fields, infoLines...), nil
+}
+
+// mt940Balance formats a balance field such as :60F:C260601USD100,00
+func mt940Balance(tag string, balance int64, date time.Time, currency string) (string, error) {
+	amount, err := mt940Amount(balance)
+	if err != nil {
+		return "", err
+	}
+
+	mark := "C"
+	if balance < 0 {
+		mark = "D"
+	}
+
+	return tag + mark + date.UTC().Format(mt940DateFormat) + currency + amount, nil
+}
+
+// mt940Amount formats the absolute value of an amount in cents with a decimal comma, e.g. -1234 as 12,34
+func mt940Amount(amount int64) (string, error) {
+	if amount < 0 {
+		amount = -amount
+	}
+
+	formatted := strings.Replace(formatAmount(amount), ".", ",", 1)
+	if len(formatted) > mt940AmountLength {
+		return "", fmt.Errorf("amount %s is too long for MT940", formatted)
+	}
+	return formatted, nil
+}
+
+// mt940Text replaces the characters outside of the SWIFT character set and truncates text to n characters.
+// Text can't start with ':' or '-', which would be read as a new field or the end of the message.
+func mt940Text(text string, n int) string {
+	var builder strings.Builder
+	for _, r := range text {
+		switch {
+		case unicode.IsSpace(r):
+			builder.WriteRune(' ')
+		case strings.ContainsRune(mt940Charset, r):
+			builder.WriteRune(r)
+		default:
+			builder.WriteRune('?')
+		}
+	}
+
+	// line breaks would start a new field, so all whitespace becomes single spaces
+	text = strings.Join(strings.Fields(builder.String()), " ")
+	return mt940LineStart(truncate(text, n))
+}
+
+// mt940Wrap splits text into lines of at most n characters, preferably between words
+func mt940Wrap(text string, n int) []string {
+	var lines []string
+	for len(text) > n {
+		cut := strings.LastIndexByte(text[:n+1], ' ')
+		if cut <= 0 {
+			cut = n
+		}
+		lines = append(lines, mt940LineStart(strings.TrimSpace(text[:cut])))
+		text = strings.TrimSpace(text[cut:])
+	}
+	return append(lines, mt940LineStart(text))
+}
+
+// mt940LineStart keeps a line from starting with ':' or '-'
+func mt940LineStart(line string) string {
+	if strings.HasPrefix(line, ":") || strings.HasPrefix(line, "-") {
+		return "." + line[1:]
+	}
+	return line
+}
+
+// mt940Length returns the length of fields written one per line
+func mt940Length(fields []string) int {
+	length := 0
+	for _, field := range fields {
+		length += len(field) + len("\r\n")
+	}
+	return length
+}
@@ -0,0 +1,100 @@
+package statement
+
+import (
+	"bytes"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// requireGolden compares data with a golden file, or rewrites the file when the tests run with -update
+func requireGolden(t *testing.T, name string, data []byte) {
+	path := filepath.Join("testdata", name)
+	if *update {
+		err := os.WriteFile(path, data, 0o644)
+		require.NoError(t, err)
+	}
+
+	golden, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(golden), string(data))
+}
+
+func TestWriteMT940(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteMT940(&buf, testStatement())
+	require.NoError(t, err)
+
+	requireGolden(t, "statement.mt940", buf.Bytes())
+}
+
+func TestWriteMT940MultipleMessages(t *testing.T) {
+	account := db.Account{
+		ID:       42,
+		Owner:    "alice",
+		Currency: "EUR",
+	}
+
+	entries := make([]db.ListStatementEntriesRow, 30)
+	for i := range entries {
+		entries[i] = db.ListStatementEntriesRow{
+			ID:                    int64(100 + i),
+			Amount:                int64((i%2*2 - 1) * (1000 + i)),
+			CreatedAt:             testFrom.Add(time.Duration(i) * 12 * time.Hour),
+			TransferID:            sql.NullInt64{Int64: int64(200 + i), Valid: true},
+			CounterpartyAccountID: 12,
+			CounterpartyOwner:     "bob",
+			Description:           fmt.Sprintf("invoice %d for consulting services rendered in June", i+1),
+			Reference:             fmt.Sprintf("INV-%d", i+1),
+		}
+	}
+
+	var buf bytes.Buffer
+	err := WriteMT940(&buf, New(account, testFrom, testTo, 50000, entries))
+	require.NoError(t, err)
+
+	requireGolden(t, "statement_multiple.mt940", buf.Bytes())
+
+	messages := strings.Split(strings.TrimSuffix(buf.String(), "-\r\n"), "-\r\n")
+	require.Greater(t, len(messages), 1)
+	for i, message := range messages {
+		require.LessOrEqual(t, len(message), mt940MaxMessageLength)
+		require.Contains(t, message, fmt.Sprintf(":28C:1/%d\r\n", i+1))
+	}
+	require.Contains(t, messages[1], ":60M:")
+	require.Contains(t, messages[0], ":62M:")
+}
+
+func TestWriteMT940AmountTooLong(t *testing.T) {
+	statement := New(db.Account{ID: 1, Currency: "USD"}, testFrom, testTo, 1_000_000_000_000_000, nil)
+
+	var buf bytes.Buffer
+	err := WriteMT940(&buf, statement)
+	require.EqualError(t, err, "amount 10000000000000,00 is too long for MT940")
+}
+
+func TestMT940Text(t *testing.T) {
+	require.Equal(t, "Zahlung f?r M?ller", mt940Text("Zahlung für Müller", 35))
+	require.Equal(t, "?HYPERLINK(?x?)", mt940Text(`=HYPERLINK("x")`, 35))
+	require.Equal(t, "line one line two", mt940Text("line one\r\n\tline two ", 35))
+	require.Equal(t, ".61:fake field", mt940Text(":61:fake field", 35))
+	require.Equal(t, ". end of message", mt940Text("- end of message", 35))
+	require.Equal(t, "abcd", mt940Text("abcdef", 4))
+}
+
+func TestMT940Wrap(t *testing.T) {
+	require.Equal(t, []string{"short"}, mt940Wrap("short", 10))
+	require.Equal(t, []string{"rent share", "for June"}, mt940Wrap("rent share for June", 10))
+	require.Equal(t, []string{"abcdefghij", "klm"}, mt940Wrap("abcdefghijklm", 10))
+	require.Equal(t, []string{"abc", ".def"}, mt940Wrap("abc -def", 4))
+}
@@ -0,0 +1,152 @@
+package statement
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+)
+
+// BankID identifies the bank in OFX statements
+const BankID = "STBANK"
+
+const (
+	ofxHeader     = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>` + "\n" + `<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n"
+	ofxDateFormat = "20060102150405.000"
+	// ofxNameLength is the maximum length of a transaction name in OFX
+	ofxNameLength = 32
+)
+
+type ofxDocument struct {
+	XMLName xml.Name   `xml:"OFX"`
+	SignOn  ofxSignOn  `xml:"SIGNONMSGSRSV1>SONRS"`
+	Bank    ofxStmtTrn `xml:"BANKMSGSRSV1>STMTTRNRS"`
+}
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxSignOn struct {
+	Status   ofxStatus `xml:"STATUS"`
+	DTServer string    `xml:"DTSERVER"`
+	Language string    `xml:"LANGUAGE"`
+}
+
+type ofxStmtTrn struct {
+	TrnUID string    `xml:"TRNUID"`
+	Status ofxStatus `xml:"STATUS"`
+	StmtRs ofxStmtRs `xml:"STMTRS"`
+}
+
+type ofxStmtRs struct {
+	CurDef    string         `xml:"CURDEF"`
+	Account   ofxBankAccount `xml:"BANKACCTFROM"`
+	TranList  ofxTranList    `xml:"BANKTRANLIST"`
+	LedgerBal ofxBalance     `xml:"LEDGERBAL"`
+	AvailBal  ofxBalance     `xml:"AVAILBAL"`
+}
+
+type ofxBankAccount struct {
+	BankID   string `xml:"BANKID"`
+	AcctID   string `xml:"ACCTID"`
+	AcctType string `xml:"ACCTTYPE"`
+}
+
+type ofxTranList struct {
+	DTStart      string           `xml:"DTSTART"`
+	DTEnd        string           `xml:"DTEND"`
+	Transactions []ofxTransaction `xml:"STMTTRN"`
+}
+
+type ofxTransaction struct {
+	TrnType  string `xml:"TRNTYPE"`
+	DTPosted string `xml:"DTPOSTED"`
+	TrnAmt   string `xml:"TRNAMT"`
+	FITID    string `xml:"FITID"`
+	Name     string `xml:"NAME,omitempty"`
+	Memo     string `xml:"MEMO,omitempty"`
+}
+
+type ofxBalance struct {
+	BalAmt string `xml:"BALAMT"`
+	DTAsOf string `xml:"DTASOF"`
+}
+
+// WriteOFX writes the statement as an OFX 2.2 bank statement response
+func WriteOFX(w io.Writer, statement Statement) error {
+	closing := ofxBalance{
+		BalAmt: formatAmount(statement.ClosingBalance),
+		DTAsOf: ofxDate(statement.LastDay()),
+	}
+
+	document := ofxDocument{
+		SignOn: ofxSignOn{
+			Status:   ofxStatus{Code: 0, Severity: "INFO"},
+			DTServer: ofxDate(statement.GeneratedAt),
+			Language: "ENG",
+		},
+		Bank: ofxStmtTrn{
+			TrnUID: "0",
+			Status: ofxStatus{Code: 0, Severity: "INFO"},
+			StmtRs: ofxStmtRs{
+				CurDef: statement.Currency,
+				Account: ofxBankAccount{
+					BankID:   BankID,
+					AcctID:   strconv.FormatInt(statement.AccountID, 10),
+					AcctType: "CHECKING",
+				},
+				TranList: ofxTranList{
+					DTStart:      ofxDate(statement.From),
+					DTEnd:        ofxDate(statement.To),
+					Transactions: make([]ofxTransaction, 0, len(statement.Lines)),
+				},
+				LedgerBal: closing,
+				AvailBal:  closing,
+			},
+		},
+	}
+
+	for _, line := range statement.Lines {
+		transaction := ofxTransaction{
+			TrnType:  "CREDIT",
+			DTPosted: ofxDate(line.PostedAt),
+			TrnAmt:   formatAmount(line.Amount),
+			FITID:    strconv.FormatInt(line.EntryID, 10),
+			Name:     truncate(line.Counterparty(), ofxNameLength),
+			Memo:     line.Description,
+		}
+		if line.Amount < 0 {
+			transaction.TrnType = "DEBIT"
+		}
+		document.Bank.StmtRs.TranList.Transactions = append(document.Bank.StmtRs.TranList.Transactions, transaction)
+	}
+
+	if _, err := io.WriteString(w, ofxHeader); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ofxDate formats a time in the OFX datetime format, always in UTC
+func ofxDate(t time.Time) string {
+	return t.UTC().Format(ofxDateFormat) + "[0:GMT]"
+}
+
+// truncate shortens text to at most n characters
+func truncate(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return string(runes[:n])
+}
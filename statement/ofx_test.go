@@ -0,0 +1,50 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteOFX(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteOFX(&buf, testStatement())
+	require.NoError(t, err)
+
+	data := buf.String()
+	require.True(t, strings.HasPrefix(data, `<?xml version="1.0"`))
+	require.Contains(t, data, `<?OFX OFXHEADER="200" VERSION="220"`)
+
+	var document ofxDocument
+	err = xml.Unmarshal(buf.Bytes(), &document)
+	require.NoError(t, err)
+
+	stmt := document.Bank.StmtRs
+	require.Equal(t, "USD", stmt.CurDef)
+	require.Equal(t, BankID, stmt.Account.BankID)
+	require.Equal(t, "42", stmt.Account.AcctID)
+	require.Equal(t, "20260601000000.000[0:GMT]", stmt.TranList.DTStart)
+	require.Equal(t, "20260701000000.000[0:GMT]", stmt.TranList.DTEnd)
+	require.Equal(t, "115.00", stmt.LedgerBal.BalAmt)
+
+	require.Len(t, stmt.TranList.Transactions, 3)
+	require.Equal(t, ofxTransaction{
+		TrnType:  "CREDIT",
+		DTPosted: "20260602000000.000[0:GMT]",
+		TrnAmt:   "25.00",
+		FITID:    "1",
+		Name:     "bob, account 12",
+		Memo:     "rent share (June)",
+	}, stmt.TranList.Transactions[0])
+	require.Equal(t, "DEBIT", stmt.TranList.Transactions[1].TrnType)
+	require.Equal(t, "-10.05", stmt.TranList.Transactions[1].TrnAmt)
+}
+
+func TestTruncate(t *testing.T) {
+	require.Equal(t, "abc", truncate("abc", 5))
+	require.Equal(t, "ab", truncate("abc", 2))
+	require.Equal(t, "жи", truncate("жир", 2))
+}
@@ -0,0 +1,175 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The PDF is laid out on A4 pages in Courier, so that columns line up without measuring text
+const (
+	pdfPageWidth    = 595
+	pdfPageHeight   = 842
+	pdfMargin       = 40
+	pdfFontSize     = 8
+	pdfLineHeight   = 11
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLineHeight
+)
+
+// Column widths of the entries table, in characters
+const (
+	pdfDateWidth         = 10
+	pdfCounterpartyWidth = 26
+	pdfDescriptionWidth  = 30
+	pdfAmountWidth       = 14
+)
+
+// pdfLine is a line of text on a page
+type pdfLine struct {
+	text string
+	bold bool
+}
+
+// WritePDF writes the statement as a PDF document.
+// The document is generated without external tools: it only uses the standard Courier fonts,
+// so text outside of printable ASCII is replaced.
+func WritePDF(w io.Writer, statement Statement) error {
+	lines := []pdfLine{
+		{text: fmt.Sprintf("Account statement - account %d", statement.AccountID), bold: true},
+		{text: fmt.Sprintf("Holder: %s", statement.Owner)},
+		{text: fmt.Sprintf("Currency: %s", statement.Currency)},
+		{text: fmt.Sprintf("Period: %s to %s", statement.From.Format(dateFormat), statement.LastDay().Format(dateFormat))},
+		{text: fmt.Sprintf("Generated: %s", statement.GeneratedAt.UTC().Format("2006-01-02 15:04:05 UTC"))},
+		{},
+		{text: fmt.Sprintf("Opening balance: %s %s", formatAmount(statement.OpeningBalance), statement.Currency), bold: true},
+		{},
+		{text: pdfRow("Date", "Counterparty", "Description", "Amount", "Balance"), bold: true},
+	}
+
+	for _, line := range statement.Lines {
+		lines = append(lines, pdfLine{text: pdfRow(
+			line.PostedAt.UTC().Format(dateFormat),
+			line.Counterparty(),
+			line.Description,
+			formatAmount(line.Amount),
+			formatAmount(line.Balance),
+		)})
+	}
+
+	lines = append(lines,
+		pdfLine{},
+		pdfLine{text: fmt.Sprintf("Closing balance: %s %s", formatAmount(statement.ClosingBalance), statement.Currency), bold: true},
+	)
+
+	var pages [][]pdfLine
+	for len(lines) > 0 {
+		n := min(len(lines), pdfLinesPerPage-1)
+		pages = append(pages, lines[:n])
+		lines = lines[n:]
+	}
+
+	return writePDFDocument(w, pages)
+}
+
+// pdfRow formats a row of the entries table in fixed-width columns
+func pdfRow(date, counterparty, description, amount, balance string) string {
+	return fmt.Sprintf("%-*s %-*s %-*s %*s %*s",
+		pdfDateWidth, truncate(date, pdfDateWidth),
+		pdfCounterpartyWidth, truncate(counterparty, pdfCounterpartyWidth),
+		pdfDescriptionWidth, truncate(description, pdfDescriptionWidth),
+		pdfAmountWidth, amount,
+		pdfAmountWidth, balance,
+	)
+}
+
+// writePDFDocument writes a PDF 1.4 document with one page per list of lines and a page number footer
+func writePDFDocument(w io.Writer, pages [][]pdfLine) error {
+	var buf bytes.Buffer
+	var offsets []int
+
+	// objects are numbered from 1 in the order they are written
+	beginObject := func() {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n", len(offsets))
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1: catalog, 2: page tree, 3 and 4: fonts, then a page and its content stream for every page
+	const firstPageObject = 5
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObject+2*i)
+	}
+
+	beginObject()
+	buf.WriteString("<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+
+	beginObject()
+	fmt.Fprintf(&buf, "<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", strings.Join(kids, " "), len(pages))
+
+	beginObject()
+	buf.WriteString("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>\nendobj\n")
+
+	beginObject()
+	buf.WriteString("<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>\nendobj\n")
+
+	for i, lines := range pages {
+		var content bytes.Buffer
+		content.WriteString("BT\n")
+		fmt.Fprintf(&content, "%d TL\n", pdfLineHeight)
+		fmt.Fprintf(&content, "%d %d Td\n", pdfMargin, pdfPageHeight-pdfMargin)
+		for _, line := range lines {
+			font := "F1"
+			if line.bold {
+				font = "F2"
+			}
+			fmt.Fprintf(&content, "/%s %d Tf (%s) Tj T*\n", font, pdfFontSize, pdfEscape(line.text))
+		}
+		content.WriteString("ET\n")
+
+		content.WriteString("BT\n")
+		fmt.Fprintf(&content, "/F1 %d Tf %d %d Td (%s) Tj\n", pdfFontSize, pdfMargin, pdfMargin/2,
+			pdfEscape(fmt.Sprintf("Page %d of %d", i+1, len(pages))))
+		content.WriteString("ET\n")
+
+		beginObject()
+		fmt.Fprintf(&buf, "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] ", pdfPageWidth, pdfPageHeight)
+		buf.WriteString("/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> ")
+		fmt.Fprintf(&buf, "/Contents %d 0 R >>\nendobj\n", firstPageObject+2*i+1)
+
+		beginObject()
+		fmt.Fprintf(&buf, "<< /Length %d >>\nstream\n", content.Len())
+		buf.Write(content.Bytes())
+		buf.WriteString("endstream\nendobj\n")
+	}
+
+	xrefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n", len(offsets)+1)
+	buf.WriteString("0000000000 65535 f \n")
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xrefOffset)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// pdfEscape escapes text for a PDF string literal and replaces characters the standard fonts can't show
+func pdfEscape(text string) string {
+	var builder strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			builder.WriteRune('\\')
+			builder.WriteRune(r)
+		case r < ' ' || r > '~':
+			builder.WriteRune('?')
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,81 @@
+package statement
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+// requireValidPDF checks the structure of a PDF document: every object must be where the cross-reference table says
+func requireValidPDF(t *testing.T, data []byte) {
+	require.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+
+	match := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(data)
+	require.NotNil(t, match)
+	xrefOffset, err := strconv.Atoi(string(match[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(data[xrefOffset:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(data[xrefOffset:], -1)
+	require.NotEmpty(t, entries)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(string(entry[1]))
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(data[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))))
+	}
+
+	for _, stream := range regexp.MustCompile(`(?s)<< /Length (\d+) >>\nstream\n(.*?)endstream`).FindAllSubmatch(data, -1) {
+		length, err := strconv.Atoi(string(stream[1]))
+		require.NoError(t, err)
+		require.Len(t, stream[2], length)
+	}
+}
+
+func TestWritePDF(t *testing.T) {
+	var buf bytes.Buffer
+	err := WritePDF(&buf, testStatement())
+	require.NoError(t, err)
+
+	data := buf.Bytes()
+	requireValidPDF(t, data)
+	require.Contains(t, string(data), "/Count 1")
+	require.Contains(t, string(data), "Opening balance: 100.00 USD")
+	require.Contains(t, string(data), "Closing balance: 115.00 USD")
+	require.Contains(t, string(data), `rent share \(June\)`)
+}
+
+func TestWritePDFPages(t *testing.T) {
+	entries := make([]db.ListStatementEntriesRow, 200)
+	for i := range entries {
+		entries[i] = db.ListStatementEntriesRow{
+			ID:                    int64(i + 1),
+			Amount:                100,
+			CreatedAt:             testFrom,
+			TransferID:            sql.NullInt64{Int64: int64(i + 1), Valid: true},
+			CounterpartyAccountID: 2,
+			CounterpartyOwner:     "Łukasz",
+		}
+	}
+
+	var buf bytes.Buffer
+	err := WritePDF(&buf, New(db.Account{ID: 1, Currency: "EUR"}, testFrom, testTo, 0, entries))
+	require.NoError(t, err)
+
+	data := buf.Bytes()
+	requireValidPDF(t, data)
+	require.Contains(t, string(data), "/Count 4")
+	require.Contains(t, string(data), "Page 4 of 4")
+	require.Contains(t, string(data), "?ukasz, account 2")
+}
+
+func TestPDFEscape(t *testing.T) {
+	require.Equal(t, `a\(b\)c\\d`, pdfEscape(`a(b)c\d`))
+	require.Equal(t, "caf? ?", pdfEscape("café \n"))
+}
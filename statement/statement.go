@@ -0,0 +1,150 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+// Format is a file format a statement can be rendered in
+type Format string
+
+const (
+	CSV   Format = "csv"
+	PDF   Format = "pdf"
+	OFX   Format = "ofx"
+	MT940 Format = "mt940"
+)
+
+// ContentType returns the MIME type of the format
+func (format Format) ContentType() string {
+	switch format {
+	case PDF:
+		return "application/pdf"
+	case OFX:
+		return "application/x-ofx"
+	case MT940:
+		return "text/plain"
+	default:
+		return "text/csv"
+	}
+}
+
+// Extension returns the file name extension of the format
+func (format Format) Extension() string {
+	if format == MT940 {
+		return "sta"
+	}
+	return string(format)
+}
+
+// Line is a single entry of a statement
+type Line struct {
+	EntryID  int64
+	PostedAt time.Time
+	Amount   int64
+	// Balance is the balance of the account after the entry
+	Balance int64
+	// TransferID and the counterparty are empty for entries that aren't part of a transfer, e.g. corrections
+	TransferID            int64
+	CounterpartyAccountID int64
+	CounterpartyOwner     string
+	Description           string
+	Reference             string
+}
+
+// Counterparty describes the other side of the line
+func (line Line) Counterparty() string {
+	if line.TransferID == 0 {
+		return "ledger adjustment"
+	}
+	return fmt.Sprintf("%s, account %d", line.CounterpartyOwner, line.CounterpartyAccountID)
+}
+
+// Statement lists the entries of an account over a period, between its opening and closing balance
+type Statement struct {
+	AccountID int64
+	Owner     string
+	Currency  string
+	// From is the start of the period, To is its exclusive end
+	From           time.Time
+	To             time.Time
+	OpeningBalance int64
+	ClosingBalance int64
+	Lines          []Line
+	GeneratedAt    time.Time
+}
+
+// New creates a statement of an account from its opening balance and the entries of the period,
+// which must be ordered by the time they were created
+func New(account db.Account, from, to time.Time, openingBalance int64, entries []db.ListStatementEntriesRow) Statement {
+	statement := Statement{
+		AccountID:      account.ID,
+		Owner:          account.Owner,
+		Currency:       account.Currency,
+		From:           from,
+		To:             to,
+		OpeningBalance: openingBalance,
+		Lines:          make([]Line, 0, len(entries)),
+		GeneratedAt:    time.Now().UTC(),
+	}
+
+	balance := openingBalance
+	for _, entry := range entries {
+		balance += entry.Amount
+		statement.Lines = append(statement.Lines, Line{
+			EntryID:               entry.ID,
+			PostedAt:              entry.CreatedAt,
+			Amount:                entry.Amount,
+			Balance:               balance,
+			TransferID:            entry.TransferID.Int64,
+			CounterpartyAccountID: entry.CounterpartyAccountID,
+			CounterpartyOwner:     entry.CounterpartyOwner,
+			Description:           entry.Description,
+			Reference:             entry.Reference,
+		})
+	}
+	statement.ClosingBalance = balance
+
+	return statement
+}
+
+// LastDay returns the last day included in the statement
+func (statement Statement) LastDay() time.Time {
+	return statement.To.Add(-time.Nanosecond)
+}
+
+// Render writes the statement to w in the given format
+func Render(w io.Writer, format Format, statement Statement) error {
+	switch format {
+	case CSV:
+		return WriteCSV(w, statement)
+	case PDF:
+		return WritePDF(w, statement)
+	case OFX:
+		return WriteOFX(w, statement)
+	case MT940:
+		return WriteMT940(w, statement)
+	default:
+		return fmt.Errorf("unsupported statement format %q", format)
+	}
+}
+
+// formatAmount formats an amount in cents as a decimal number, e.g. -1234 as -12.34
+func formatAmount(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	cents := strconv.FormatInt(amount%100, 10)
+	if len(cents) < 2 {
+		cents = "0" + cents
+	}
+
+	return sign + strconv.FormatInt(amount/100, 10) + "." + cents
+}
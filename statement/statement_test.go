@@ -0,0 +1,111 @@
+package statement
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	db "github.com/volskyi-dmytro/st-bank/db/sqlc"
+)
+
+var (
+	testFrom = time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+	testTo   = time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// testStatement creates a statement with a credit, a debit and a correction entry
+func testStatement() Statement {
+	account := db.Account{
+		ID:       42,
+		Owner:    "alice",
+		Currency: "USD",
+	}
+
+	entries := []db.ListStatementEntriesRow{
+		{
+			ID:                    1,
+			Amount:                2500,
+			CreatedAt:             testFrom.Add(24 * time.Hour),
+			TransferID:            sql.NullInt64{Int64: 7, Valid: true},
+			CounterpartyAccountID: 12,
+			CounterpartyOwner:     "bob",
+			Description:           "rent share (June)",
+			Reference:             "INV-1",
+		},
+		{
+			ID:                    2,
+			Amount:                -1005,
+			CreatedAt:             testFrom.Add(48 * time.Hour),
+			TransferID:            sql.NullInt64{Int64: 8, Valid: true},
+			CounterpartyAccountID: 13,
+			CounterpartyOwner:     "carol",
+			Description:           "=HYPERLINK(\"x\")",
+		},
+		{
+			ID:        3,
+			Amount:    5,
+			CreatedAt: testFrom.Add(72 * time.Hour),
+		},
+	}
+
+	return New(account, testFrom, testTo, 10000, entries)
+}
+
+func TestNew(t *testing.T) {
+	statement := testStatement()
+
+	require.Equal(t, int64(42), statement.AccountID)
+	require.Equal(t, "alice", statement.Owner)
+	require.Equal(t, int64(10000), statement.OpeningBalance)
+	require.Equal(t, int64(11500), statement.ClosingBalance)
+
+	require.Len(t, statement.Lines, 3)
+	require.Equal(t, int64(12500), statement.Lines[0].Balance)
+	require.Equal(t, int64(11495), statement.Lines[1].Balance)
+	require.Equal(t, int64(11500), statement.Lines[2].Balance)
+
+	require.Equal(t, "bob, account 12", statement.Lines[0].Counterparty())
+	require.Equal(t, "ledger adjustment", statement.Lines[2].Counterparty())
+
+	require.Equal(t, time.Date(2026, time.June, 30, 0, 0, 0, 0, time.UTC), statement.LastDay().Truncate(24*time.Hour))
+}
+
+func TestNewWithoutEntries(t *testing.T) {
+	statement := New(db.Account{ID: 1}, testFrom, testTo, 300, nil)
+	require.Empty(t, statement.Lines)
+	require.Equal(t, int64(300), statement.ClosingBalance)
+}
+
+func TestFormatAmount(t *testing.T) {
+	testCases := map[int64]string{
+		0:       "0.00",
+		5:       "0.05",
+		-5:      "-0.05",
+		1234:    "12.34",
+		-1234:   "-12.34",
+		100000:  "1000.00",
+		-100001: "-1000.01",
+	}
+
+	for amount, expected := range testCases {
+		require.Equal(t, expected, formatAmount(amount))
+	}
+}
+
+func TestRender(t *testing.T) {
+	statement := testStatement()
+
+	for _, format := range []Format{CSV, PDF, OFX} {
+		var buf bytes.Buffer
+		err := Render(&buf, format, statement)
+		require.NoError(t, err)
+		require.NotZero(t, buf.Len())
+		require.NotEmpty(t, format.ContentType())
+	}
+
+	var buf bytes.Buffer
+	err := Render(&buf, Format("xls"), statement)
+	require.Error(t, err)
+}
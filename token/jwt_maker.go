@@ -22,11 +22,17 @@ func NewJWTMaker(secretKey string) (Maker, error) {
 	return &JWTMaker{secretKey}, nil
 }
 
-func (maker *JWTMaker) CreateToken(username string, duration time.Duration) (string, error) {
-	payload, err := NewPayload(username, duration)
+func (maker *JWTMaker) CreateToken(username string, role string, duration time.Duration) (string, error) {
+	return maker.CreateOrganizationToken(username, role, 0, time.Now().Add(duration))
+}
+
+func (maker *JWTMaker) CreateOrganizationToken(username string, role string, organizationID int64, expiredAt time.Time) (string, error) {
+	payload, err := NewPayload(username, role, time.Until(expiredAt))
 	if err != nil {
 		return "", err
 	}
+	payload.OrganizationID = organizationID
+	payload.ExpiredAt = expiredAt
 
 	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
 	return jwtToken.SignedString([]byte(maker.secretKey))
@@ -14,12 +14,13 @@ func TestJWTMaker(t *testing.T) {
 	require.NoError(t, err)
 
 	username := util.RandomOwner()
+	role := util.BankerRole
 	duration := time.Minute
 
 	issuedAt := time.Now()
 	expiredAt := issuedAt.Add(duration)
 
-	token, err := maker.CreateToken(username, duration)
+	token, err := maker.CreateToken(username, role, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 
@@ -29,15 +30,43 @@ func TestJWTMaker(t *testing.T) {
 
 	require.NotZero(t, payload.ID)
 	require.Equal(t, username, payload.Username)
+	require.Equal(t, role, payload.Role)
 	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
 	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
 }
 
+func TestJWTOrganizationToken(t *testing.T) {
+	maker, err := NewJWTMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	username := util.RandomOwner()
+	organizationID := util.RandomInt(1, 1000)
+
+	expiredAt := time.Now().Add(time.Minute)
+
+	token, err := maker.CreateOrganizationToken(username, util.DepositorRole, organizationID, expiredAt)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, username, payload.Username)
+	require.Equal(t, organizationID, payload.OrganizationID)
+	require.True(t, expiredAt.Equal(payload.ExpiredAt))
+
+	// tokens created without an organization act for the user's own accounts
+	token, err = maker.CreateToken(username, util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Zero(t, payload.OrganizationID)
+}
+
 func TestExpiredJWTToken(t *testing.T) {
 	maker, err := NewJWTMaker(util.RandomString(32))
 	require.NoError(t, err)
 
-	token, err := maker.CreateToken(util.RandomOwner(), -time.Minute)
+	token, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, -time.Minute)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 
@@ -48,7 +77,7 @@ func TestExpiredJWTToken(t *testing.T) {
 }
 
 func TestInvalidJWTTokenAlgNone(t *testing.T) {
-	payload, err := NewPayload(util.RandomOwner(), time.Minute)
+	payload, err := NewPayload(util.RandomOwner(), util.DepositorRole, time.Minute)
 	require.NoError(t, err)
 
 	jwtToken := jwt.NewWithClaims(jwt.SigningMethodNone, payload)
@@ -65,7 +94,7 @@ func TestInvalidJWTTokenAlgNone(t *testing.T) {
 }
 
 func TestInvalidJWTTokenInvalidSigningMethod(t *testing.T) {
-	payload, err := NewPayload(util.RandomOwner(), time.Minute)
+	payload, err := NewPayload(util.RandomOwner(), util.DepositorRole, time.Minute)
 	require.NoError(t, err)
 
 	jwtToken := jwt.NewWithClaims(jwt.SigningMethodRS256, payload)
@@ -108,7 +137,7 @@ func TestJWTTokenWithDifferentSigningKey(t *testing.T) {
 	username := util.RandomOwner()
 	duration := time.Minute
 
-	token, err := maker1.CreateToken(username, duration)
+	token, err := maker1.CreateToken(username, util.DepositorRole, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 
@@ -125,7 +154,7 @@ func TestJWTTokenExpiredPayload(t *testing.T) {
 	username := util.RandomOwner()
 	duration := time.Millisecond * 100
 
-	token, err := maker.CreateToken(username, duration)
+	token, err := maker.CreateToken(username, util.DepositorRole, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 
@@ -142,7 +171,7 @@ func TestJWTTokenEdgeCases(t *testing.T) {
 		maker, err := NewJWTMaker(util.RandomString(32))
 		require.NoError(t, err)
 
-		token, err := maker.CreateToken("", time.Minute)
+		token, err := maker.CreateToken("", util.DepositorRole, time.Minute)
 		require.NoError(t, err)
 		require.NotEmpty(t, token)
 
@@ -155,7 +184,7 @@ func TestJWTTokenEdgeCases(t *testing.T) {
 		maker, err := NewJWTMaker(util.RandomString(32))
 		require.NoError(t, err)
 
-		token, err := maker.CreateToken(util.RandomOwner(), 0)
+		token, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, 0)
 		require.NoError(t, err)
 		require.NotEmpty(t, token)
 
@@ -172,7 +201,7 @@ func TestJWTTokenEdgeCases(t *testing.T) {
 		username := util.RandomOwner()
 		duration := time.Hour * 24 * 365 // 1 year
 
-		token, err := maker.CreateToken(username, duration)
+		token, err := maker.CreateToken(username, util.DepositorRole, duration)
 		require.NoError(t, err)
 		require.NotEmpty(t, token)
 
@@ -190,7 +219,7 @@ func TestJWTTokenEdgeCases(t *testing.T) {
 		require.NotNil(t, maker)
 
 		username := util.RandomOwner()
-		token, err := maker.CreateToken(username, time.Minute)
+		token, err := maker.CreateToken(username, util.DepositorRole, time.Minute)
 		require.NoError(t, err)
 
 		payload, err := maker.VerifyToken(token)
@@ -206,7 +235,7 @@ func TestJWTTokenValidationOrder(t *testing.T) {
 	username := util.RandomOwner()
 	duration := time.Minute
 
-	token, err := maker.CreateToken(username, duration)
+	token, err := maker.CreateToken(username, util.DepositorRole, duration)
 	require.NoError(t, err)
 
 	// Verify token multiple times
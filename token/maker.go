@@ -4,7 +4,11 @@ import "time"
 
 // Maker is an interface for managing tokens
 type Maker interface {
-	CreateToken(username string, duration time.Duration) (string, error)
+	CreateToken(username string, role string, duration time.Duration) (string, error)
+
+	// CreateOrganizationToken creates a token for a user acting for one of their organizations,
+	// expiring at expiredAt so that switching context doesn't extend the session it is switched from
+	CreateOrganizationToken(username string, role string, organizationID int64, expiredAt time.Time) (string, error)
 	
 	VerifyToken(token string) (*Payload, error)
 }
\ No newline at end of file
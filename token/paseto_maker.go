@@ -26,11 +26,17 @@ func NewPasetoMaker(symmetricKey string) (Maker, error) {
 	return maker, nil
 }
 
-func (maker *PasetoMaker) CreateToken(username string, duration time.Duration) (string, error) {
-	payload, err := NewPayload(username, duration)
+func (maker *PasetoMaker) CreateToken(username string, role string, duration time.Duration) (string, error) {
+	return maker.CreateOrganizationToken(username, role, 0, time.Now().Add(duration))
+}
+
+func (maker *PasetoMaker) CreateOrganizationToken(username string, role string, organizationID int64, expiredAt time.Time) (string, error) {
+	payload, err := NewPayload(username, role, time.Until(expiredAt))
 	if err != nil {
 		return "", err
 	}
+	payload.OrganizationID = organizationID
+	payload.ExpiredAt = expiredAt
 
 	return maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
 }
@@ -14,12 +14,13 @@ func TestPasetoMaker(t *testing.T) {
 	require.NoError(t, err)
 
 	username := util.RandomOwner()
+	role := util.BankerRole
 	duration := time.Minute
 
 	issuedAt := time.Now()
 	expiredAt := issuedAt.Add(duration)
 
-	token, err := maker.CreateToken(username, duration)
+	token, err := maker.CreateToken(username, role, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 
@@ -29,15 +30,43 @@ func TestPasetoMaker(t *testing.T) {
 
 	require.NotZero(t, payload.ID)
 	require.Equal(t, username, payload.Username)
+	require.Equal(t, role, payload.Role)
 	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
 	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
 }
 
+func TestPasetoOrganizationToken(t *testing.T) {
+	maker, err := NewPasetoMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	username := util.RandomOwner()
+	organizationID := util.RandomInt(1, 1000)
+
+	expiredAt := time.Now().Add(time.Minute)
+
+	token, err := maker.CreateOrganizationToken(username, util.DepositorRole, organizationID, expiredAt)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, username, payload.Username)
+	require.Equal(t, organizationID, payload.OrganizationID)
+	require.True(t, expiredAt.Equal(payload.ExpiredAt))
+
+	// tokens created without an organization act for the user's own accounts
+	token, err = maker.CreateToken(username, util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Zero(t, payload.OrganizationID)
+}
+
 func TestExpiredPasetoToken(t *testing.T) {
 	maker, err := NewPasetoMaker(util.RandomString(32))
 	require.NoError(t, err)
 
-	token, err := maker.CreateToken(util.RandomOwner(), -time.Minute)
+	token, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, -time.Minute)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 
@@ -111,7 +140,7 @@ func TestPasetoTokenWithDifferentKey(t *testing.T) {
 	username := util.RandomOwner()
 	duration := time.Minute
 
-	token, err := maker1.CreateToken(username, duration)
+	token, err := maker1.CreateToken(username, util.DepositorRole, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 
@@ -128,7 +157,7 @@ func TestPasetoTokenExpiredPayload(t *testing.T) {
 	username := util.RandomOwner()
 	duration := time.Millisecond * 100
 
-	token, err := maker.CreateToken(username, duration)
+	token, err := maker.CreateToken(username, util.DepositorRole, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 
@@ -145,7 +174,7 @@ func TestPasetoTokenEdgeCases(t *testing.T) {
 		maker, err := NewPasetoMaker(util.RandomString(32))
 		require.NoError(t, err)
 
-		token, err := maker.CreateToken("", time.Minute)
+		token, err := maker.CreateToken("", util.DepositorRole, time.Minute)
 		require.NoError(t, err)
 		require.NotEmpty(t, token)
 
@@ -158,7 +187,7 @@ func TestPasetoTokenEdgeCases(t *testing.T) {
 		maker, err := NewPasetoMaker(util.RandomString(32))
 		require.NoError(t, err)
 
-		token, err := maker.CreateToken(util.RandomOwner(), 0)
+		token, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, 0)
 		require.NoError(t, err)
 		require.NotEmpty(t, token)
 
@@ -175,7 +204,7 @@ func TestPasetoTokenEdgeCases(t *testing.T) {
 		username := util.RandomOwner()
 		duration := time.Hour * 24 * 365 // 1 year
 
-		token, err := maker.CreateToken(username, duration)
+		token, err := maker.CreateToken(username, util.DepositorRole, duration)
 		require.NoError(t, err)
 		require.NotEmpty(t, token)
 
@@ -193,7 +222,7 @@ func TestPasetoTokenEdgeCases(t *testing.T) {
 		require.NotNil(t, maker)
 
 		username := util.RandomOwner()
-		token, err := maker.CreateToken(username, time.Minute)
+		token, err := maker.CreateToken(username, util.DepositorRole, time.Minute)
 		require.NoError(t, err)
 
 		payload, err := maker.VerifyToken(token)
@@ -209,7 +238,7 @@ func TestPasetoTokenValidationOrder(t *testing.T) {
 	username := util.RandomOwner()
 	duration := time.Minute
 
-	token, err := maker.CreateToken(username, duration)
+	token, err := maker.CreateToken(username, util.DepositorRole, duration)
 	require.NoError(t, err)
 
 	// Verify token multiple times
@@ -227,7 +256,7 @@ func TestPasetoTokenFormat(t *testing.T) {
 	username := util.RandomOwner()
 	duration := time.Minute
 
-	token, err := maker.CreateToken(username, duration)
+	token, err := maker.CreateToken(username, util.DepositorRole, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 
@@ -247,7 +276,7 @@ func TestPasetoTokenSecurity(t *testing.T) {
 		// Generate multiple tokens for the same user
 		tokens := make([]string, 10)
 		for i := 0; i < 10; i++ {
-			token, err := maker.CreateToken(username, duration)
+			token, err := maker.CreateToken(username, util.DepositorRole, duration)
 			require.NoError(t, err)
 			tokens[i] = token
 		}
@@ -273,7 +302,7 @@ func TestPasetoTokenSecurity(t *testing.T) {
 		duration := time.Minute
 
 		// Create token with first maker
-		token, err := makers[0].CreateToken(username, duration)
+		token, err := makers[0].CreateToken(username, util.DepositorRole, duration)
 		require.NoError(t, err)
 
 		// Try to verify with other makers - should all fail
@@ -303,7 +332,7 @@ func TestPasetoTokenStress(t *testing.T) {
 		username := util.RandomOwner()
 		usernames[i] = username
 
-		token, err := maker.CreateToken(username, time.Hour)
+		token, err := maker.CreateToken(username, util.DepositorRole, time.Hour)
 		require.NoError(t, err)
 		tokens[i] = token
 	}
@@ -15,11 +15,14 @@ var (
 type Payload struct {
 	ID uuid.UUID `json:"id"`
 	Username string `json:"username"`
+	Role string `json:"role"`
+	// OrganizationID is the organization the user acts for, 0 for their own accounts
+	OrganizationID int64 `json:"organization_id,omitempty"`
 	IssuedAt time.Time `json:"issued_at"`
 	ExpiredAt time.Time `json:"expired_at"`
 }
 
-func NewPayload(username string, duration time.Duration) (*Payload, error) {
+func NewPayload(username string, role string, duration time.Duration) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
@@ -28,6 +31,7 @@ func NewPayload(username string, duration time.Duration) (*Payload, error) {
 	payload := &Payload {
 		ID: tokenID,
 		Username: username,
+		Role: role,
 		IssuedAt: time.Now(),
 		ExpiredAt: time.Now().Add(duration),
 	}
@@ -12,6 +12,21 @@ type Config struct {
 	TokenSymmetricKey string `mapstructure:"TOKEN_SYMMETRIC_KEY"`
 	AccessTokenDuration time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
 	TokenType        string `mapstructure:"TOKEN_TYPE"`
+	PaymentRequestDuration time.Duration `mapstructure:"PAYMENT_REQUEST_DURATION"`
+	BeneficiaryCoolingOffPeriod time.Duration `mapstructure:"BENEFICIARY_COOLING_OFF_PERIOD"`
+	BeneficiaryCoolingOffLimit int64 `mapstructure:"BENEFICIARY_COOLING_OFF_LIMIT"`
+	TransferApprovalThreshold int64 `mapstructure:"TRANSFER_APPROVAL_THRESHOLD"`
+	FraudCheckEnabled bool `mapstructure:"FRAUD_CHECK_ENABLED"`
+	FraudVelocityLimit int64 `mapstructure:"FRAUD_VELOCITY_LIMIT"`
+	FraudVelocityWindow time.Duration `mapstructure:"FRAUD_VELOCITY_WINDOW"`
+	FraudLargeAmount int64 `mapstructure:"FRAUD_LARGE_AMOUNT"`
+	AuditLogEnabled bool `mapstructure:"AUDIT_LOG_ENABLED"`
+	MaintenanceFee int64 `mapstructure:"MAINTENANCE_FEE"`
+	MaintenanceFeeWaiverBalance int64 `mapstructure:"MAINTENANCE_FEE_WAIVER_BALANCE"`
+	MaintenanceFeeWaiverAccountAge time.Duration `mapstructure:"MAINTENANCE_FEE_WAIVER_ACCOUNT_AGE"`
+	MaintenanceFeeWaiverRoles string `mapstructure:"MAINTENANCE_FEE_WAIVER_ROLES"`
+	CardMerchantKey string `mapstructure:"CARD_MERCHANT_KEY"`
+	DirectDebitDisputeWindow time.Duration `mapstructure:"DIRECT_DEBIT_DISPUTE_WINDOW"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
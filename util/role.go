@@ -0,0 +1,16 @@
+package util
+
+// User roles
+const (
+	DepositorRole = "depositor"
+	BankerRole    = "banker"
+)
+
+// IsSupportedRole returns true if the role is supported
+func IsSupportedRole(role string) bool {
+	switch role {
+	case DepositorRole, BankerRole:
+		return true
+	}
+	return false
+}